@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/config/configuration"
+	_ "giftcard-engine/utils/indraframework"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler interface {
+	Configuration(c *gin.Context)
+}
+
+type adminHandler struct {
+	configurations func() configuration.Configurations
+}
+
+// Configuration godoc
+// @Summary running configuration
+// @Description settings of the running service with their secrets masked, and the keys of the reloadable ones
+// @ID admin-configuration
+// @Accept  json
+// @Produce  json
+// @tags Admin
+// @Param Authorization header string true "Bearer admin token"
+// @Success 200 {object} dto.ConfigurationDTO
+// @Failure 401 {object} indraframework.IndraException
+// @Failure 404 "the admin token is not configured"
+// @Router /admin/configuration [get]
+func (h *adminHandler) Configuration(c *gin.Context) {
+	jsonSuccess(c, dto.ConfigurationDTO{
+		Settings:   h.configurations().Masked(),
+		Reloadable: configuration.ReloadableKeys(),
+	})
+}
+
+// NewAdminHandler returns the handler of the admin endpoints, which reads the running configurations on every
+// request
+func NewAdminHandler(configurations func() configuration.Configurations) AdminHandler {
+	return &adminHandler{configurations: configurations}
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/utils"
+	_ "giftcard-engine/utils/indraframework"
+	"giftcard-engine/utils/parser"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type BatchHandler interface {
+	FindPage(c *gin.Context)
+	FindCards(c *gin.Context)
+	ExtendExpiry(c *gin.Context)
+	Export(c *gin.Context)
+	Void(c *gin.Context)
+}
+
+type batchHandler struct {
+	service core.BatchService
+}
+
+// Batch FindPage godoc
+// @Summary Batch paging
+// @Description get list of gift card batches in paging object
+// @ID batch-find-page
+// @Accept  json
+// @tags Batch
+// @Produce  json
+// @Param size path number true "page size"
+// @Param number path number true "page number"
+// @Param createdBy query string false "filter by the creator of the batch"
+// @Success 200 {object} dto.BatchPageDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Router /v1/batch/page/{size}/{number} [get]
+func (h *batchHandler) FindPage(c *gin.Context) {
+	number, err := parser.ParseNumber(c.Param("number"))
+	if err != nil {
+		jsonBadRequest(c, &dto.BatchPageDTO{}, err)
+		return
+	}
+	var size uint
+	size, err = parser.ParseNumber(c.Param("size"))
+	if err != nil {
+		jsonBadRequest(c, &dto.BatchPageDTO{}, err)
+		return
+	}
+	size = utils.MinUint(size, 50)
+	if number == 0 {
+		number += 1
+	}
+	number = number - 1
+	batchesPage := h.service.FindPage(c.Request.Context(), size, number, c.Query("createdBy"))
+	jsonSuccess(c, batchesPage)
+}
+
+// FindCards godoc
+// @Summary batch gift cards
+// @Description get list of the gift cards issued in a batch
+// @ID batch-find-cards
+// @Accept  json
+// @Produce  json
+// @tags Batch
+// @Param id path int true "batch id"
+// @Success 200 {object} dto.GiftCardsListDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 404 {object} indraframework.IndraException
+// @Failure 500 {object} indraframework.IndraException
+// @Router /v1/batch/cards/{id} [get]
+func (h *batchHandler) FindCards(c *gin.Context) {
+	id, err := parser.ParseNumber(c.Param("id"))
+	if err != nil {
+		jsonBadRequest(c, &dto.GiftCardsListDTO{}, err)
+		return
+	}
+
+	cards, err := h.service.FindCards(c.Request.Context(), id)
+	if err == common.BatchNotFound {
+		jsonNotFound(c, &dto.GiftCardsListDTO{}, err)
+	} else if err != nil {
+		jsonInternalServerError(c, &dto.GiftCardsListDTO{}, err)
+	} else {
+		jsonSuccess(c, cards)
+	}
+}
+
+// ExtendExpiry godoc
+// @Summary extend batch expire date
+// @Description changes the expire date of all of the unused gift cards of a batch
+// @ID batch-extend-expiry
+// @Accept  json
+// @Produce  json
+// @tags Batch
+// @Param id path int true "batch id"
+// @Param extendBatchExpiryDTO body dto.ExtendBatchExpiryDTO true "new expire date"
+// @Success 200 {object} dto.BatchOperationDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 404 {object} indraframework.IndraException
+// @Failure 500 {object} indraframework.IndraException
+// @Router /v1/batch/extend-expiry/{id} [put]
+func (h *batchHandler) ExtendExpiry(c *gin.Context) {
+	id, err := parser.ParseNumber(c.Param("id"))
+	if err != nil {
+		jsonBadRequest(c, &dto.BatchOperationDTO{}, err)
+		return
+	}
+	var extendDTO dto.ExtendBatchExpiryDTO
+	if success := tryActions(c,
+		func() (error error, data dto.Dto) { return c.BindJSON(&extendDTO), &dto.BatchOperationDTO{} },
+		func() (error error, data dto.Dto) { return extendDTO.Validate(), &dto.BatchOperationDTO{} }); !success {
+		return
+	}
+
+	result, err := h.service.ExtendExpiry(c.Request.Context(), id, &extendDTO)
+	if err == common.BatchNotFound {
+		jsonNotFound(c, &dto.BatchOperationDTO{}, err)
+	} else if err == common.ExpireDateIsBeforeCurrent {
+		jsonBadRequest(c, &dto.BatchOperationDTO{}, err)
+	} else if err != nil {
+		jsonInternalServerError(c, &dto.BatchOperationDTO{}, err)
+	} else {
+		jsonSuccess(c, result)
+	}
+}
+
+// Export godoc
+// @Summary export batch
+// @Description download the gift cards of a batch as a csv file, without their secret codes
+// @ID batch-export
+// @Produce  text/csv
+// @tags Batch
+// @Param id path int true "batch id"
+// @Success 200 {string} string "csv file"
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 404 {object} indraframework.IndraException
+// @Failure 500 {object} indraframework.IndraException
+// @Router /v1/batch/export/{id} [get]
+func (h *batchHandler) Export(c *gin.Context) {
+	id, err := parser.ParseNumber(c.Param("id"))
+	if err != nil {
+		jsonBadRequest(c, &dto.GiftCardsListDTO{}, err)
+		return
+	}
+
+	cards, err := h.service.FindCards(c.Request.Context(), id)
+	if err == common.BatchNotFound {
+		jsonNotFound(c, &dto.GiftCardsListDTO{}, err)
+		return
+	} else if err != nil {
+		jsonInternalServerError(c, &dto.GiftCardsListDTO{}, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=batch-%d.csv", id))
+	c.Header("Content-Type", "text/csv")
+	c.Status(http.StatusOK)
+	writer := csv.NewWriter(c.Writer)
+	// the file is shared outside of the service, so the secret codes which redeem the cards are left out
+	_ = writer.Write([]string{"id", "public_code", "amount", "expire_date", "campaign_id", "uun", "is_valid"})
+	for _, card := range cards.Cards {
+		_ = writer.Write([]string{
+			strconv.Itoa(card.ID),
+			card.PublicCode,
+			strconv.Itoa(int(card.Amount)),
+			card.ExpireDate,
+			strconv.Itoa(int(card.CampaignId)),
+			card.UUN,
+			strconv.FormatBool(card.IsValid),
+		})
+	}
+	writer.Flush()
+}
+
+// Void godoc
+// @Summary void batch
+// @Description voids all of the unused gift cards of a batch at once
+// @ID batch-void
+// @Accept  json
+// @Produce  json
+// @tags Batch
+// @Param id path int true "batch id"
+// @Success 200 {object} dto.BatchOperationDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 404 {object} indraframework.IndraException
+// @Failure 500 {object} indraframework.IndraException
+// @Router /v1/batch/void/{id} [post]
+func (h *batchHandler) Void(c *gin.Context) {
+	id, err := parser.ParseNumber(c.Param("id"))
+	if err != nil {
+		jsonBadRequest(c, &dto.BatchOperationDTO{}, err)
+		return
+	}
+
+	result, err := h.service.Void(c.Request.Context(), id)
+	if err == common.BatchNotFound {
+		jsonNotFound(c, &dto.BatchOperationDTO{}, err)
+	} else if err != nil {
+		jsonInternalServerError(c, &dto.BatchOperationDTO{}, err)
+	} else {
+		jsonSuccess(c, result)
+	}
+}
+
+func NewBatchHandler(service core.BatchService) BatchHandler {
+	return &batchHandler{service: service}
+}
@@ -0,0 +1,327 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"giftcard-engine/application/api"
+	"giftcard-engine/application/api/handlers"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/timeout"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type fakeBatchService struct {
+	strategy          int
+	findPageCall      int
+	findPageCreatedBy string
+	findByIDCall      int
+	findCardsCall     int
+	extendExpiryCall  int
+	voidCall          int
+}
+
+func (s *fakeBatchService) FindPage(ctx context.Context, size, page uint, createdBy string) dto.BatchPageDTO {
+	s.findPageCall++
+	s.findPageCreatedBy = createdBy
+	return dto.BatchPageDTO{
+		Size:       int(size),
+		Page:       int(page),
+		Batches:    []dto.BatchDTO{{ID: 1, CreatedBy: createdBy, Count: 2, TotalAmount: 4000}},
+		TotalItems: 1,
+	}
+}
+
+func (s *fakeBatchService) FindByID(ctx context.Context, id uint) (*dto.BatchDTO, error) {
+	s.findByIDCall++
+	if s.strategy == notFound {
+		return nil, common.BatchNotFound
+	}
+	return &dto.BatchDTO{ID: int(id)}, nil
+}
+
+func (s *fakeBatchService) FindCards(ctx context.Context, id uint) (*dto.GiftCardsListDTO, error) {
+	s.findCardsCall++
+	if s.strategy == notFound {
+		return nil, common.BatchNotFound
+	}
+	if s.strategy == internalError {
+		return nil, fakeError
+	}
+	return &dto.GiftCardsListDTO{
+		BatchId: id,
+		Cards: []dto.GiftCardDTO{
+			{ID: 1, PublicCode: "123456789012", SecretCode: "1234567890123456", Amount: 2000, BatchId: id},
+			{ID: 2, PublicCode: "123456789013", SecretCode: "1234567890123457", Amount: 2000, BatchId: id},
+		},
+	}, nil
+}
+
+func (s *fakeBatchService) ExtendExpiry(ctx context.Context, id uint,
+	expiry *dto.ExtendBatchExpiryDTO) (*dto.BatchOperationDTO, error) {
+	s.extendExpiryCall++
+	if s.strategy == notFound {
+		return nil, common.BatchNotFound
+	}
+	if s.strategy == internalError {
+		return nil, fakeError
+	}
+	if s.strategy == invalidOperation {
+		return nil, common.ExpireDateIsBeforeCurrent
+	}
+	return &dto.BatchOperationDTO{BatchId: int(id), AffectedCards: 2}, nil
+}
+
+func (s *fakeBatchService) Void(ctx context.Context, id uint) (*dto.BatchOperationDTO, error) {
+	s.voidCall++
+	if s.strategy == notFound {
+		return nil, common.BatchNotFound
+	}
+	if s.strategy == internalError {
+		return nil, fakeError
+	}
+	return &dto.BatchOperationDTO{BatchId: int(id), AffectedCards: 2}, nil
+}
+
+func newFakeBatchService(strategy int) *fakeBatchService {
+	return &fakeBatchService{
+		strategy: strategy,
+	}
+}
+
+var batchBaseUrl = "/v1/batch"
+
+func createBatchTestObjects(strategy int) (*fakeBatchService, *httptest.ResponseRecorder, *gin.Engine) {
+	w := httptest.NewRecorder()
+	fakeBatchService := newFakeBatchService(strategy)
+	handler := handlers.NewGiftCardHandler(newFakeValidGiftCardService(strategy))
+	campaignHandler := handlers.NewCampaignHandler(newFakeCampaignService(strategy))
+	batchHandler := handlers.NewBatchHandler(fakeBatchService)
+	searchHandler := handlers.NewSearchHandler(newFakeSearchService(strategy))
+	reportHandler := handlers.NewReportHandler(newFakeReportService(strategy))
+	webhookHandler := handlers.NewWebhookHandler(newFakeWebhookService(strategy))
+	router := api.CreateRoute(handler, campaignHandler, batchHandler, searchHandler, reportHandler,
+		webhookHandler, timeout.NewDeadlines(configuration.TimeoutConfiguration{}))
+	return fakeBatchService, w, router
+}
+
+func TestBatchFindPage(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", batchBaseUrl+"/page/10/1?createdBy=milawd", nil)
+		fakeService, w, router := createBatchTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.BatchPageDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 1, response.TotalItems)
+		assert.Equal(t, "milawd", fakeService.findPageCreatedBy)
+		assert.Equal(t, 1, fakeService.findPageCall, "findPage should be called just once")
+	})
+
+	te.Run("with invalid size", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", batchBaseUrl+"/page/s/1", nil)
+		fakeService, w, router := createBatchTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 0, fakeService.findPageCall, "findPage should not be called")
+	})
+}
+
+func TestBatchFindCards(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", batchBaseUrl+"/cards/4", nil)
+		fakeService, w, router := createBatchTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.GiftCardsListDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, uint(4), response.BatchId)
+		assert.Equal(t, 2, len(response.Cards))
+		assert.Equal(t, 1, fakeService.findCardsCall, "findCards should be called just once")
+	})
+
+	te.Run("with not found strategy", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", batchBaseUrl+"/cards/4", nil)
+		fakeService, w, router := createBatchTestObjects(notFound)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+		assert.Equal(t, 1, fakeService.findCardsCall, "findCards should be called just once")
+	})
+
+	te.Run("with internal error strategy", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", batchBaseUrl+"/cards/4", nil)
+		fakeService, w, router := createBatchTestObjects(internalError)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.Equal(t, 1, fakeService.findCardsCall, "findCards should be called just once")
+	})
+}
+
+func TestBatchExtendExpiry(te *testing.T) {
+	te.Parallel()
+	date := time.Now().Add(time.Hour * 25).Format("2006-01-02")
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("PUT", batchBaseUrl+"/extend-expiry/4",
+			createJsonReader(dto.ExtendBatchExpiryDTO{ExpireDate: date}))
+		fakeService, w, router := createBatchTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.BatchOperationDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 4, response.BatchId)
+		assert.Equal(t, 1, fakeService.extendExpiryCall, "extendExpiry should be called just once")
+	})
+
+	te.Run("with past expire date", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("PUT", batchBaseUrl+"/extend-expiry/4",
+			createJsonReader(dto.ExtendBatchExpiryDTO{ExpireDate: "2012-01-01"}))
+		fakeService, w, router := createBatchTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 0, fakeService.extendExpiryCall, "extendExpiry should not be called")
+	})
+
+	te.Run("with not found strategy", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("PUT", batchBaseUrl+"/extend-expiry/4",
+			createJsonReader(dto.ExtendBatchExpiryDTO{ExpireDate: date}))
+		fakeService, w, router := createBatchTestObjects(notFound)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+		assert.Equal(t, 1, fakeService.extendExpiryCall, "extendExpiry should be called just once")
+	})
+
+	te.Run("with an expire date before the current one", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("PUT", batchBaseUrl+"/extend-expiry/4",
+			createJsonReader(dto.ExtendBatchExpiryDTO{ExpireDate: date}))
+		fakeService, w, router := createBatchTestObjects(invalidOperation)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 1, fakeService.extendExpiryCall, "extendExpiry should be called just once")
+	})
+}
+
+func TestBatchExport(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", batchBaseUrl+"/export/4", nil)
+		fakeService, w, router := createBatchTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		records, err := csv.NewReader(w.Body).ReadAll()
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+		assert.Equal(t, 3, len(records), "header and two cards")
+		assert.Equal(t, "123456789012", records[1][1])
+		assert.NotContains(t, records[0], "secret_code")
+		assert.NotContains(t, records[1], "1234567890123456")
+		assert.Equal(t, 1, fakeService.findCardsCall, "findCards should be called just once")
+	})
+
+	te.Run("with not found strategy", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", batchBaseUrl+"/export/4", nil)
+		_, w, router := createBatchTestObjects(notFound)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+	})
+	te.Run("with internal error strategy", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", batchBaseUrl+"/export/4", nil)
+		_, w, router := createBatchTestObjects(internalError)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.NotEqual(t, "text/csv", w.Header().Get("Content-Type"))
+	})
+}
+
+func TestBatchVoid(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("POST", batchBaseUrl+"/void/4", nil)
+		fakeService, w, router := createBatchTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.BatchOperationDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 2, response.AffectedCards)
+		assert.Equal(t, 1, fakeService.voidCall, "void should be called just once")
+	})
+
+	te.Run("with internal error strategy", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("POST", batchBaseUrl+"/void/4", nil)
+		fakeService, w, router := createBatchTestObjects(internalError)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.Equal(t, 1, fakeService.voidCall, "void should be called just once")
+	})
+
+	te.Run("with invalid id", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("POST", batchBaseUrl+"/void/invalid", nil)
+		fakeService, w, router := createBatchTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 0, fakeService.voidCall, "void should not be called")
+	})
+}
+
+func TestNewBatchHandler(te *testing.T) {
+	te.Parallel()
+	handler := handlers.NewBatchHandler(newFakeBatchService(found))
+	assert.NotEmpty(te, handler)
+}
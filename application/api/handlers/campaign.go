@@ -12,9 +12,12 @@ import (
 
 type CampaignHandler interface {
 	FindPage(c *gin.Context)
+	FindCursorPage(c *gin.Context)
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
 	Create(c *gin.Context)
+	Stats(c *gin.Context)
+	Summary(c *gin.Context)
 }
 
 type campaignHandler struct {
@@ -51,7 +54,42 @@ func (h *campaignHandler) FindPage(c *gin.Context) {
 		number += 1
 	}
 	number = number - 1
-	campaignsPage := h.service.FindPage(size, number, c.Query("search"))
+	campaignsPage := h.service.FindPage(c.Request.Context(), size, number, c.Query("search"))
+	jsonSuccess(c, campaignsPage)
+}
+
+// Campaign FindCursorPage godoc
+// @Summary Campaign keyset paging
+// @Description get list of campaigns after the given cursor. use next_cursor of the response to get the next page
+// @ID campaign-find-cursor-page
+// @Accept  json
+// @tags Campaign
+// @Produce  json
+// @Param size path number true "page size"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param skipCount query boolean false "do not calculate total items"
+// @Param search query string false "search by title"
+// @Success 200 {object} dto.CampaignCursorPageDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Router /v1/campaign/cursor/{size} [get]
+func (h *campaignHandler) FindCursorPage(c *gin.Context) {
+	size, err := parser.ParseNumber(c.Param("size"))
+	if err != nil {
+		jsonBadRequest(c, &dto.CampaignCursorPageDTO{}, err)
+		return
+	}
+	size = utils.MaxUint(utils.MinUint(size, 50), 1)
+	withCount, err := parseWithCount(c)
+	if err != nil {
+		jsonBadRequest(c, &dto.CampaignCursorPageDTO{}, err)
+		return
+	}
+	campaignsPage, err := h.service.FindCursorPage(c.Request.Context(), size, c.Query("cursor"), c.Query("search"),
+		withCount)
+	if err != nil {
+		jsonBadRequest(c, &dto.CampaignCursorPageDTO{}, err)
+		return
+	}
 	jsonSuccess(c, campaignsPage)
 }
 
@@ -75,7 +113,7 @@ func (h *campaignHandler) Create(c *gin.Context) {
 		return
 	}
 
-	campaign, err := h.service.Create(campaignDTO)
+	campaign, err := h.service.Create(c.Request.Context(), campaignDTO)
 	if err == common.DuplicatedCampaignTitle {
 		jsonBadRequest(c, &dto.CampaignDTO{}, err)
 	} else if err != nil {
@@ -106,7 +144,7 @@ func (h *campaignHandler) Update(c *gin.Context) {
 		return
 	}
 
-	campaign, err := h.service.Update(campaignDTO)
+	campaign, err := h.service.Update(c.Request.Context(), campaignDTO)
 
 	if err == common.CampaignNotFound {
 		jsonNotFound(c, &dto.CampaignDTO{}, err)
@@ -139,7 +177,7 @@ func (h *campaignHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.service.Delete(id)
+	err = h.service.Delete(c.Request.Context(), id)
 
 	if err == common.CampaignNotFound {
 		jsonNotFound(c, &dto.DeleteMessageDTO{}, err)
@@ -156,6 +194,54 @@ func (h *campaignHandler) Delete(c *gin.Context) {
 	})
 }
 
+// Stats godoc
+// @Summary campaign statistics
+// @Description counts and amounts of the issued, redeemed, expired, voided, deleted and outstanding cards of a campaign
+// @ID campaign-stats
+// @Accept  json
+// @tags Campaign
+// @Produce  json
+// @Param id path int true "campaign's id"
+// @Success 200 {object} dto.CampaignStatsDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 404 {object} indraframework.IndraException
+// @Router /v1/campaign/{id}/stats [get]
+func (h *campaignHandler) Stats(c *gin.Context) {
+	id, err := parser.ParseNumber(c.Param("id"))
+	if err != nil {
+		jsonBadRequest(c, &dto.CampaignStatsDTO{}, err)
+		return
+	}
+
+	stats, err := h.service.Stats(c.Request.Context(), id)
+	if err == common.CampaignNotFound {
+		jsonNotFound(c, &dto.CampaignStatsDTO{}, err)
+	} else if err != nil {
+		jsonInternalServerError(c, &dto.CampaignStatsDTO{}, err)
+	} else {
+		jsonSuccess(c, stats)
+	}
+}
+
+// Summary godoc
+// @Summary statistics summary
+// @Description counts and amounts of the issued, redeemed, expired, voided, deleted and outstanding cards of all campaigns
+// @ID campaign-stats-summary
+// @Accept  json
+// @tags Campaign
+// @Produce  json
+// @Success 200 {object} dto.CampaignStatsDTO
+// @Failure 500 {object} indraframework.IndraException
+// @Router /v1/campaign/stats [get]
+func (h *campaignHandler) Summary(c *gin.Context) {
+	stats, err := h.service.Summary(c.Request.Context())
+	if err != nil {
+		jsonInternalServerError(c, &dto.CampaignStatsDTO{}, err)
+		return
+	}
+	jsonSuccess(c, stats)
+}
+
 func NewCampaignHandler(service core.CampaignService) CampaignHandler {
 	return &campaignHandler{service: service}
 }
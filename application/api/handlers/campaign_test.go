@@ -1,11 +1,14 @@
 package handlers_test
 
 import (
+	"context"
 	"encoding/json"
 	"giftcard-engine/application/api"
 	"giftcard-engine/application/api/handlers"
 	"giftcard-engine/core/common"
 	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/timeout"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"net/http"
@@ -17,9 +20,12 @@ type fakeCampaignService struct {
 	strategy       int
 	findPageCall   int
 	findPageSearch string
+	findCursorCall int
 	createCall     int
 	updateCall     int
 	deleteCall     int
+	statsCall      int
+	summaryCall    int
 }
 
 var fakeCampaign = dto.CampaignDTO{
@@ -28,7 +34,7 @@ var fakeCampaign = dto.CampaignDTO{
 	Error: nil,
 }
 
-func (s *fakeCampaignService) FindPage(size, page uint, search string) dto.CampaignPageDTO {
+func (s *fakeCampaignService) FindPage(ctx context.Context, size, page uint, search string) dto.CampaignPageDTO {
 	s.findPageCall++
 	s.findPageSearch = search
 	return dto.CampaignPageDTO{
@@ -42,7 +48,17 @@ func (s *fakeCampaignService) FindPage(size, page uint, search string) dto.Campa
 	}
 }
 
-func (s *fakeCampaignService) Create(campaign dto.CreateCampaignDTO) (dto.CampaignDTO, error) {
+func (s *fakeCampaignService) FindCursorPage(ctx context.Context, size uint, cursor string, search string,
+	withCount bool) (dto.CampaignCursorPageDTO, error) {
+	s.findCursorCall++
+	s.findPageSearch = search
+	if cursor == "invalid" {
+		return dto.CampaignCursorPageDTO{}, common.InvalidCursor
+	}
+	return dto.NewCampaignCursorPageDTO([]dto.CampaignDTO{fakeCampaign}, int(size), "", 1), nil
+}
+
+func (s *fakeCampaignService) Create(ctx context.Context, campaign dto.CreateCampaignDTO) (dto.CampaignDTO, error) {
 	s.createCall++
 	if s.strategy == internalError {
 		return dto.CampaignDTO{}, fakeError
@@ -53,7 +69,7 @@ func (s *fakeCampaignService) Create(campaign dto.CreateCampaignDTO) (dto.Campai
 	return fakeCampaign, nil
 }
 
-func (s *fakeCampaignService) Update(campaign dto.UpdateCampaignDto) (dto.CampaignDTO, error) {
+func (s *fakeCampaignService) Update(ctx context.Context, campaign dto.UpdateCampaignDto) (dto.CampaignDTO, error) {
 	s.updateCall++
 	if s.strategy == internalError {
 		return dto.CampaignDTO{}, fakeError
@@ -67,7 +83,7 @@ func (s *fakeCampaignService) Update(campaign dto.UpdateCampaignDto) (dto.Campai
 	return fakeCampaign, nil
 }
 
-func (s *fakeCampaignService) Delete(id uint) error {
+func (s *fakeCampaignService) Delete(ctx context.Context, id uint) error {
 	s.deleteCall++
 	if s.strategy == internalError {
 		return fakeError
@@ -81,6 +97,25 @@ func (s *fakeCampaignService) Delete(id uint) error {
 	return nil
 }
 
+func (s *fakeCampaignService) Stats(ctx context.Context, id uint) (*dto.CampaignStatsDTO, error) {
+	s.statsCall++
+	if s.strategy == notFound {
+		return nil, common.CampaignNotFound
+	}
+	if s.strategy == internalError {
+		return nil, fakeError
+	}
+	return &dto.CampaignStatsDTO{CampaignId: int(id), Issued: dto.CardsAggregateDTO{Count: 2, Amount: 4000}}, nil
+}
+
+func (s *fakeCampaignService) Summary(ctx context.Context) (*dto.CampaignStatsDTO, error) {
+	s.summaryCall++
+	if s.strategy == internalError {
+		return nil, fakeError
+	}
+	return &dto.CampaignStatsDTO{Issued: dto.CardsAggregateDTO{Count: 5, Amount: 9000}}, nil
+}
+
 func newFakeCampaignService(strategy int) *fakeCampaignService {
 	return &fakeCampaignService{
 		strategy: strategy,
@@ -95,7 +130,12 @@ func createCampaignTestObjects(strategy int) (*fakeCampaignService, *httptest.Re
 	fakeCampaignService := newFakeCampaignService(strategy)
 	handler := handlers.NewGiftCardHandler(fakeService)
 	campaignHandler := handlers.NewCampaignHandler(fakeCampaignService)
-	router := api.CreateRoute(handler, campaignHandler)
+	batchHandler := handlers.NewBatchHandler(newFakeBatchService(strategy))
+	searchHandler := handlers.NewSearchHandler(newFakeSearchService(strategy))
+	reportHandler := handlers.NewReportHandler(newFakeReportService(strategy))
+	webhookHandler := handlers.NewWebhookHandler(newFakeWebhookService(strategy))
+	router := api.CreateRoute(handler, campaignHandler, batchHandler, searchHandler, reportHandler,
+		webhookHandler, timeout.NewDeadlines(configuration.TimeoutConfiguration{}))
 	return fakeCampaignService, w, router
 }
 
@@ -368,8 +408,117 @@ func TestCampaignDelete(te *testing.T) {
 	})
 }
 
+func TestCampaignFindCursorPage(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", campaignBaseUrl+"/cursor/5?search=milawd", nil)
+		fakeService, w, router := createCampaignTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.CampaignCursorPageDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 1, len(response.Campaigns))
+		assert.Equal(t, 1, *response.TotalItems)
+		assert.Equal(t, "milawd", fakeService.findPageSearch)
+		assert.Equal(t, 1, fakeService.findCursorCall, "findCursorPage should be called just once")
+	})
+
+	te.Run("with invalid cursor", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", campaignBaseUrl+"/cursor/5?cursor=invalid", nil)
+		_, w, router := createCampaignTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+
+	te.Run("with invalid size", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", campaignBaseUrl+"/cursor/s", nil)
+		fakeService, w, router := createCampaignTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 0, fakeService.findCursorCall, "findCursorPage should not be called")
+	})
+}
+
 func TestNewCampaignHandler(te *testing.T) {
 	te.Parallel()
 	handler := handlers.NewCampaignHandler(newFakeCampaignService(found))
 	assert.NotEmpty(te, handler)
 }
+
+func TestCampaignStats(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", campaignBaseUrl+"/12/stats", nil)
+		fakeService, w, router := createCampaignTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.CampaignStatsDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 12, response.CampaignId)
+		assert.Equal(t, int64(4000), response.Issued.Amount)
+		assert.Equal(t, 1, fakeService.statsCall, "stats should be called just once")
+	})
+
+	te.Run("with invalid id", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", campaignBaseUrl+"/x/stats", nil)
+		fakeService, w, router := createCampaignTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 0, fakeService.statsCall, "stats should not be called")
+	})
+
+	te.Run("with not found strategy", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", campaignBaseUrl+"/12/stats", nil)
+		_, w, router := createCampaignTestObjects(notFound)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+	})
+}
+
+func TestCampaignSummary(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", campaignBaseUrl+"/stats", nil)
+		fakeService, w, router := createCampaignTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.CampaignStatsDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, int64(5), response.Issued.Count)
+		assert.Equal(t, 1, fakeService.summaryCall, "summary should be called just once")
+	})
+
+	te.Run("with internal error strategy", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", campaignBaseUrl+"/stats", nil)
+		_, w, router := createCampaignTestObjects(internalError)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+	})
+}
@@ -1,17 +1,21 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"giftcard-engine/core/dto"
 	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/infrastructure/requestid"
 	"giftcard-engine/utils/indraframework"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
 )
 
 func tryActions(c GinContext, actions ...func() (error error, dto dto.Dto)) (Success bool) {
 	for _, action := range actions {
 		if err, data := action(); err != nil {
-			logger.Error(err.Error())
+			logger.WithRequestId(c.GetString(requestid.Key)).Error(err.Error())
 			jsonBadRequest(c, data, err)
 			return false
 		}
@@ -20,22 +24,50 @@ func tryActions(c GinContext, actions ...func() (error error, dto dto.Dto)) (Suc
 }
 
 func jsonError(c GinContext, data dto.Dto, err *indraframework.IndraException) {
+	err.RequestId = c.GetString(requestid.Key)
 	data.SetError(err)
 	c.JSON(err.ErrorCode, data)
 }
 
 func jsonBadRequest(c GinContext, data dto.Dto, err error) {
+	if timedOut(err) {
+		jsonTimeout(c, data, err)
+		return
+	}
 	jsonError(c, data, indraframework.BadRequestException(err.Error(), "bad request"))
 }
 
 func jsonNotFound(c GinContext, data dto.Dto, err error) {
+	if timedOut(err) {
+		jsonTimeout(c, data, err)
+		return
+	}
 	jsonError(c, data, indraframework.NotFoundException(err.Error(), "not found"))
 }
 
 func jsonInternalServerError(c GinContext, data dto.Dto, err error) {
+	if timedOut(err) {
+		jsonTimeout(c, data, err)
+		return
+	}
 	jsonError(c, data, indraframework.InternalServerException(err.Error(), "not found"))
 }
 
+func jsonServiceUnavailable(c GinContext, data dto.Dto, err error) {
+	jsonError(c, data, indraframework.NewIndraException(err.Error(), "service unavailable",
+		http.StatusServiceUnavailable))
+}
+
+// jsonTimeout answers the requests whose context is done before their work is finished
+func jsonTimeout(c GinContext, data dto.Dto, err error) {
+	jsonError(c, data, indraframework.NewIndraException(err.Error(), "request timeout",
+		http.StatusGatewayTimeout))
+}
+
+func timedOut(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
+
 func jsonSuccess(c GinContext, value interface{}) {
 	c.JSON(http.StatusOK, value)
 }
@@ -43,3 +75,13 @@ func jsonSuccess(c GinContext, value interface{}) {
 func success(c GinContext) {
 	c.JSON(http.StatusOK, gin.H{"message": "completed"})
 }
+
+// parseWithCount reads the skipCount query param of the cursor paging endpoints
+func parseWithCount(c *gin.Context) (bool, error) {
+	skipCountQuery := c.Query("skipCount")
+	if skipCountQuery == "" {
+		return true, nil
+	}
+	skipCount, err := strconv.ParseBool(skipCountQuery)
+	return !skipCount, err
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"errors"
 	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/requestid"
 	"giftcard-engine/utils/indraframework"
 	"net/http"
 	"testing"
@@ -12,6 +13,8 @@ type fakeGinContext struct {
 	data       string
 	jsonCalled int
 	status     int
+	obj        interface{}
+	requestId  string
 }
 
 func (c *fakeGinContext) JSON(code int, obj interface{}) {
@@ -21,6 +24,14 @@ func (c *fakeGinContext) JSON(code int, obj interface{}) {
 	}
 	c.status = code
 	c.data = "some data"
+	c.obj = obj
+}
+
+func (c *fakeGinContext) GetString(key string) string {
+	if key == requestid.Key {
+		return c.requestId
+	}
+	return ""
 }
 
 func createFakeContext() *fakeGinContext {
@@ -138,6 +149,17 @@ func TestJsonError(t *testing.T) {
 	}
 }
 
+func TestJsonErrorReturnsTheRequestId(t *testing.T) {
+	t.Parallel()
+	context := createFakeContext()
+	context.requestId = "order-42"
+	jsonNotFound(context, &dto.GiftCardDTO{}, errors.New("some error"))
+	body := context.obj.(*dto.GiftCardDTO)
+	if body.Error == nil || body.Error.RequestId != "order-42" {
+		t.Errorf("The error should carry the request id got %v", body.Error)
+	}
+}
+
 func TestJsonSuccess(t *testing.T) {
 	t.Parallel()
 	context := createFakeContext()
@@ -18,6 +18,7 @@ import (
 type GiftCardHandler interface {
 	FindByID(c *gin.Context)
 	FindPage(c *gin.Context)
+	FindCursorPage(c *gin.Context)
 	Store(c *gin.Context)
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
@@ -56,7 +57,7 @@ func (h *cardHandler) FindByID(c *gin.Context) {
 		jsonBadRequest(c, &dto.GiftCardDTO{}, err)
 		return
 	}
-	giftCard, err := h.service.FindByID(id)
+	giftCard, err := h.service.FindByID(c.Request.Context(), id)
 
 	if err == common.GiftCardNotFound {
 		jsonNotFound(c, &dto.GiftCardDTO{}, err)
@@ -79,7 +80,7 @@ func (h *cardHandler) FindByID(c *gin.Context) {
 // @Router /v1/gift-card/find-by-public-key/{key} [get]
 func (h *cardHandler) FindByPublicKey(c *gin.Context) {
 	key := strings.ToUpper(c.Param("key"))
-	giftCard, err := h.service.FindByPublicKey(key)
+	giftCard, err := h.service.FindByPublicKey(c.Request.Context(), key)
 
 	if err == common.GiftCardNotFound {
 		jsonNotFound(c, &dto.GiftCardStatusDTO{}, err)
@@ -109,7 +110,7 @@ func (h *cardHandler) Store(c *gin.Context) {
 		return
 	}
 
-	giftCard, err := h.service.Store(&giftCardDTO)
+	giftCard, err := h.service.Store(c.Request.Context(), &giftCardDTO)
 	if err == common.InvalidCampaign {
 		jsonBadRequest(c, &dto.GiftCardDTO{}, err)
 	} else if err != nil {
@@ -140,7 +141,7 @@ func (h *cardHandler) Update(c *gin.Context) {
 		return
 	}
 
-	giftCard, err := h.service.Update(&giftCardDTO)
+	giftCard, err := h.service.Update(c.Request.Context(), &giftCardDTO)
 
 	if err == common.GiftCardNotFound {
 		jsonNotFound(c, &dto.GiftCardDTO{}, err)
@@ -173,7 +174,7 @@ func (h *cardHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.service.Delete(id)
+	err = h.service.Delete(c.Request.Context(), id)
 
 	if err == common.GiftCardNotFound {
 		jsonNotFound(c, &dto.DeleteMessageDTO{}, err)
@@ -208,7 +209,7 @@ func (h *cardHandler) CreateMany(c *gin.Context) {
 		func() (error error, data dto.Dto) { return createGiftCards.Validate(), &dto.GiftCardsListDTO{} }); !success {
 		return
 	}
-	cards, _ := h.service.CreateMany(&createGiftCards)
+	cards, _ := h.service.CreateMany(c.Request.Context(), &createGiftCards)
 	jsonSuccess(c, cards)
 }
 
@@ -231,7 +232,7 @@ func (h *cardHandler) CreateSameMany(c *gin.Context) {
 		func() (error error, data dto.Dto) { return createGiftCards.Validate(), &dto.GiftCardsListDTO{} }); !success {
 		return
 	}
-	cards, _ := h.service.CreateSameMany(&createGiftCards)
+	cards, _ := h.service.CreateSameMany(c.Request.Context(), &createGiftCards)
 	jsonSuccess(c, cards)
 }
 
@@ -245,6 +246,7 @@ func (h *cardHandler) CreateSameMany(c *gin.Context) {
 // @Param validateGiftCardsDto body dto.ValidateGiftCardsDto true "bulk validate dto"
 // @Success 200 {object} dto.GiftCardStatusListDTO
 // @Failure 400 {object} indraframework.IndraException
+// @Failure 504 {object} indraframework.IndraException
 // @Router /v1/gift-card/validate-gift-cards [post]
 func (h *cardHandler) ValidateGiftCards(c *gin.Context) {
 	var validateGiftCardsDto dto.ValidateGiftCardsDto
@@ -258,7 +260,11 @@ func (h *cardHandler) ValidateGiftCards(c *gin.Context) {
 		}); !success {
 		return
 	}
-	cardsStatus := h.service.ValidateGiftCards(&validateGiftCardsDto)
+	cardsStatus, err := h.service.ValidateGiftCards(c.Request.Context(), &validateGiftCardsDto)
+	if err != nil {
+		jsonInternalServerError(c, &dto.GiftCardStatusListDTO{}, err)
+		return
+	}
 	jsonSuccess(c, cardsStatus)
 }
 
@@ -286,8 +292,8 @@ func (h *cardHandler) ApproveGiftCards(c *gin.Context) {
 		}); !success {
 		return
 	}
-	approveGiftCards, err := h.service.ApproveGiftCards(&approveGiftCardsDto)
-	if err == common.GiftCardIsTaken {
+	approveGiftCards, err := h.service.ApproveGiftCards(c.Request.Context(), &approveGiftCardsDto)
+	if err == common.GiftCardIsTaken || err == common.GiftCardIsVoided {
 		jsonBadRequest(c, &dto.GiftCardStatusListDTO{}, err)
 		return
 	}
@@ -311,11 +317,21 @@ func (h *cardHandler) ApproveGiftCards(c *gin.Context) {
 // @tags Gift Card
 // @Param size path integer true "page size"
 // @Param number path integer true "page number"
-// @Param campaignId query integer false "campaign id"
 // @Param search query string false "search in public key"
+// @Param uun query string false "exact uun"
+// @Param status query integer false "status of gift card"
+// @Param amountFrom query integer false "minimum amount"
+// @Param amountTo query integer false "maximum amount"
+// @Param campaignId query []integer false "campaign ids, repeatable or comma separated" collectionFormat(multi)
+// @Param campaignTitle query string false "search in campaign title"
 // @Param isValid query boolean false "is valid gift card"
 // @Param expireDateFrom query string false "expire date from"
 // @Param expireDateTo query string false "expire date to"
+// @Param createdFrom query string false "created date from"
+// @Param createdTo query string false "created date to"
+// @Param redeemedFrom query string false "redeemed date from"
+// @Param redeemedTo query string false "redeemed date to"
+// @Param sort query string false "comma separated columns, prefix with - for descending. id, amount, status, expire_date, created_at, redeemed_at"
 // @Success 200 {object} dto.GiftCardsPageDTO
 // @Failure 400 {object} indraframework.IndraException
 // @Router /v1/gift-card/page/{size}/{number} [get]
@@ -336,53 +352,146 @@ func (h *cardHandler) FindPage(c *gin.Context) {
 		number += 1
 	}
 	number = number - 1
-	var campaignId *int
-	campaignIdQuery := c.Query("campaignId")
-	if campaignIdQuery != "" {
-		id, err := strconv.Atoi(campaignIdQuery)
-		if err != nil {
-			jsonBadRequest(c, &dto.GiftCardsPageDTO{}, common.InvalidCampaignQueryParam)
-			return
+	filter, err := parseGiftCardFilter(c)
+	if err != nil {
+		jsonBadRequest(c, &dto.GiftCardsPageDTO{}, err)
+		return
+	}
+
+	cardsPage := h.service.FindPage(c.Request.Context(), size, number, filter)
+	jsonSuccess(c, cardsPage)
+}
+
+// FindCursorPage godoc
+// @Summary gift cards keyset paging
+// @Description get list of gift cards after the given cursor. use next_cursor of the response to get the next page
+// @ID find-cursor-page
+// @Accept  json
+// @Produce  json
+// @tags Gift Card
+// @Param size path integer true "page size"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param skipCount query boolean false "do not calculate total items"
+// @Param search query string false "search in public key"
+// @Param uun query string false "exact uun"
+// @Param status query integer false "status of gift card"
+// @Param amountFrom query integer false "minimum amount"
+// @Param amountTo query integer false "maximum amount"
+// @Param campaignId query []integer false "campaign ids, repeatable or comma separated" collectionFormat(multi)
+// @Param campaignTitle query string false "search in campaign title"
+// @Param isValid query boolean false "is valid gift card"
+// @Param expireDateFrom query string false "expire date from"
+// @Param expireDateTo query string false "expire date to"
+// @Param createdFrom query string false "created date from"
+// @Param createdTo query string false "created date to"
+// @Param redeemedFrom query string false "redeemed date from"
+// @Param redeemedTo query string false "redeemed date to"
+// @Success 200 {object} dto.GiftCardsCursorPageDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Router /v1/gift-card/cursor/{size} [get]
+func (h *cardHandler) FindCursorPage(c *gin.Context) {
+	size, err := parser.ParseNumber(c.Param("size"))
+	if err != nil {
+		jsonBadRequest(c, &dto.GiftCardsCursorPageDTO{}, err)
+		return
+	}
+	size = utils.MaxUint(utils.MinUint(size, 50), 1)
+	withCount, err := parseWithCount(c)
+	if err != nil {
+		jsonBadRequest(c, &dto.GiftCardsCursorPageDTO{}, err)
+		return
+	}
+	filter, err := parseGiftCardFilter(c)
+	if err != nil {
+		jsonBadRequest(c, &dto.GiftCardsCursorPageDTO{}, err)
+		return
+	}
+
+	cardsPage, err := h.service.FindCursorPage(c.Request.Context(), size, c.Query("cursor"), filter, withCount)
+	if err != nil {
+		jsonBadRequest(c, &dto.GiftCardsCursorPageDTO{}, err)
+		return
+	}
+	jsonSuccess(c, cardsPage)
+}
+
+// parseGiftCardFilter reads the listing criteria from the query string
+func parseGiftCardFilter(c *gin.Context) (core.GiftCardFilter, error) {
+	filter := core.GiftCardFilter{
+		Search:        c.Query("search"),
+		UUN:           c.Query("uun"),
+		CampaignTitle: c.Query("campaignTitle"),
+	}
+
+	for _, value := range c.QueryArray("campaignId") {
+		for _, item := range strings.Split(value, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(item), 10, 32)
+			if err != nil {
+				return filter, common.InvalidCampaignQueryParam
+			}
+			filter.CampaignIds = append(filter.CampaignIds, uint(id))
 		}
-		campaignId = &id
 	}
-	search := c.Query("search")
 
-	var isValid *bool
-	isValidQuery := c.Query("isValid")
-	if isValidQuery != "" {
-		b, err := strconv.ParseBool(isValidQuery)
+	if statusQuery := c.Query("status"); statusQuery != "" {
+		status, err := strconv.Atoi(statusQuery)
 		if err != nil {
-			jsonBadRequest(c, &dto.GiftCardsPageDTO{}, err)
-			return
+			return filter, common.InvalidStatusQueryParam
 		}
-		isValid = &b
+		filter.Status = &status
 	}
 
-	var expireDateFrom *time.Time
-	expireDateFromQuery := c.Query("expireDateFrom")
-	if expireDateFromQuery != "" {
-		d, err := date.DefaultToTime(expireDateFromQuery)
+	var err error
+	if filter.AmountFrom, err = parseAmountQuery(c, "amountFrom"); err != nil {
+		return filter, err
+	}
+	if filter.AmountTo, err = parseAmountQuery(c, "amountTo"); err != nil {
+		return filter, err
+	}
+
+	if isValidQuery := c.Query("isValid"); isValidQuery != "" {
+		b, err := strconv.ParseBool(isValidQuery)
 		if err != nil {
-			jsonBadRequest(c, &dto.GiftCardsPageDTO{}, err)
-			return
+			return filter, err
 		}
-		expireDateFrom = &d
+		filter.IsValid = &b
 	}
 
-	var expireDateTo *time.Time
-	expireDateToQuery := c.Query("expireDateTo")
-	if expireDateToQuery != "" {
-		d, err := date.DefaultToTime(expireDateToQuery)
+	dates := map[string]**time.Time{
+		"expireDateFrom": &filter.ExpireDateFrom,
+		"expireDateTo":   &filter.ExpireDateTo,
+		"createdFrom":    &filter.CreatedFrom,
+		"createdTo":      &filter.CreatedTo,
+		"redeemedFrom":   &filter.RedeemedFrom,
+		"redeemedTo":     &filter.RedeemedTo,
+	}
+	for key, field := range dates {
+		value := c.Query(key)
+		if value == "" {
+			continue
+		}
+		d, err := date.DefaultToTime(value)
 		if err != nil {
-			jsonBadRequest(c, &dto.GiftCardsPageDTO{}, err)
-			return
+			return filter, err
 		}
-		expireDateTo = &d
+		*field = &d
 	}
 
-	cardsPage := h.service.FindPage(size, number, search, campaignId, isValid, expireDateFrom, expireDateTo)
-	jsonSuccess(c, cardsPage)
+	filter.Sort, err = core.ParseGiftCardSort(c.Query("sort"))
+	return filter, err
+}
+
+func parseAmountQuery(c *gin.Context, key string) (*int32, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	amount, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, common.InvalidAmountQueryParam
+	}
+	result := int32(amount)
+	return &result, nil
 }
 
 // ValidateGiftCard godoc
@@ -394,10 +503,15 @@ func (h *cardHandler) FindPage(c *gin.Context) {
 // @tags Gift Card
 // @Param secret path string true "gift card secret"
 // @Success 200 {object} dto.GiftCardStatusDTO
+// @Failure 504 {object} indraframework.IndraException
 // @Router /v1/gift-card/validate-gift-card/{secret} [get]
 func (h *cardHandler) ValidateGiftCard(c *gin.Context) {
 	secret := c.Param("secret")
-	status := h.service.ValidateGiftCard(secret)
+	status, err := h.service.ValidateGiftCard(c.Request.Context(), secret)
+	if err != nil {
+		jsonInternalServerError(c, &dto.GiftCardStatusDTO{}, err)
+		return
+	}
 	jsonSuccess(c, status)
 }
 
@@ -419,13 +533,13 @@ func (h *cardHandler) ApproveGiftCard(c *gin.Context) {
 	secret := c.Param("secret")
 	uun := c.Param("uun")
 
-	card, err := h.service.ApproveGiftCard(uun, secret)
+	card, err := h.service.ApproveGiftCard(c.Request.Context(), uun, secret)
 	if err == common.GiftCardNotFound {
 		jsonNotFound(c, &dto.GiftCardStatusDTO{}, err)
 		return
 	}
 
-	if err == common.GiftCardIsTaken {
+	if err == common.GiftCardIsTaken || err == common.GiftCardIsVoided {
 		jsonBadRequest(c, &dto.GiftCardStatusDTO{}, err)
 		return
 	}
@@ -451,7 +565,7 @@ func (h *cardHandler) ApproveGiftCard(c *gin.Context) {
 func (h *cardHandler) FindByUUN(c *gin.Context) {
 	uun := c.Param("uun")
 
-	giftCards, err := h.service.FindByUUN(uun)
+	giftCards, err := h.service.FindByUUN(c.Request.Context(), uun)
 	if err == common.NoGiftCardFoundForUser {
 		jsonNotFound(c, &dto.GiftCardsListDTO{}, err)
 		return
@@ -461,15 +575,15 @@ func (h *cardHandler) FindByUUN(c *gin.Context) {
 }
 
 // HealthCheck godoc
-// @Summary test Endpoint
-// @Description get 200 response
-// @Accept  json
+// @Summary readiness probe of the old clients
+// @Description the same as /health/ready, which should be used instead
 // @Produce  json
 // @tags Public
-// @Success 200
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
 // @Router /v1/gift-card/health [get]
 func (h *cardHandler) HealthCheck(c *gin.Context) {
-	c.JSON(200, health.GetHealth())
+	serveProbe(c, health.Readiness)
 }
 
 // Info godoc
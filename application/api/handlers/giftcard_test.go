@@ -2,12 +2,16 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"giftcard-engine/application/api"
 	"giftcard-engine/application/api/handlers"
+	"giftcard-engine/core"
 	"giftcard-engine/core/common"
 	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/timeout"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"io"
@@ -20,6 +24,8 @@ import (
 type fakeValidGiftCardService struct {
 	strategy              int
 	findPageCall          int
+	findCursorPageCall    int
+	findCursorWithCount   bool
 	findByIDCall          int
 	storeCall             int
 	updateCall            int
@@ -32,6 +38,7 @@ type fakeValidGiftCardService struct {
 	approveGiftCardsCall  int
 	validateGiftCardCall  int
 	approveGiftCardCall   int
+	findPageFilter        core.GiftCardFilter
 }
 
 const (
@@ -44,9 +51,10 @@ const (
 
 var fakeError = errors.New("some error")
 
-func (s *fakeValidGiftCardService) FindPage(size, page uint, search string, campaignId *int,
-	isValid *bool, expireDateFrom *time.Time, expireDateTo *time.Time) dto.GiftCardsPageDTO {
+func (s *fakeValidGiftCardService) FindPage(ctx context.Context, size, page uint,
+	filter core.GiftCardFilter) dto.GiftCardsPageDTO {
 	s.findPageCall++
+	s.findPageFilter = filter
 	return dto.GiftCardsPageDTO{
 		Size:       int(size),
 		Page:       int(page),
@@ -54,7 +62,19 @@ func (s *fakeValidGiftCardService) FindPage(size, page uint, search string, camp
 		TotalItems: 0,
 	}
 }
-func (s *fakeValidGiftCardService) FindByID(id uint) (*dto.GiftCardDTO, error) {
+func (s *fakeValidGiftCardService) FindCursorPage(ctx context.Context, size uint, cursor string,
+	filter core.GiftCardFilter, withCount bool) (*dto.GiftCardsCursorPageDTO, error) {
+	s.findCursorPageCall++
+	if len(filter.Sort) > 0 {
+		return nil, common.SortIsNotSupportedByCursor
+	}
+	s.findCursorWithCount = withCount
+	if cursor == "invalid" {
+		return nil, common.InvalidCursor
+	}
+	return dto.NewGiftCardsCursorPageDTO(nil, int(size), "next", 0), nil
+}
+func (s *fakeValidGiftCardService) FindByID(ctx context.Context, id uint) (*dto.GiftCardDTO, error) {
 	s.findByIDCall++
 	if s.strategy == notFound {
 		return nil, common.GiftCardNotFound
@@ -63,14 +83,14 @@ func (s *fakeValidGiftCardService) FindByID(id uint) (*dto.GiftCardDTO, error) {
 		ID: int(id),
 	}, nil
 }
-func (s *fakeValidGiftCardService) Store(card *dto.CreateGiftCardDTO) (*dto.GiftCardDTO, error) {
+func (s *fakeValidGiftCardService) Store(ctx context.Context, card *dto.CreateGiftCardDTO) (*dto.GiftCardDTO, error) {
 	s.storeCall++
 	if s.strategy == internalError {
 		return nil, fakeError
 	}
 	return &dto.GiftCardDTO{}, nil
 }
-func (s *fakeValidGiftCardService) Update(card *dto.UpdateGiftCardDto) (*dto.GiftCardDTO, error) {
+func (s *fakeValidGiftCardService) Update(ctx context.Context, card *dto.UpdateGiftCardDto) (*dto.GiftCardDTO, error) {
 	s.updateCall++
 	if s.strategy == notFound {
 		return nil, common.GiftCardNotFound
@@ -85,7 +105,7 @@ func (s *fakeValidGiftCardService) Update(card *dto.UpdateGiftCardDto) (*dto.Gif
 	}
 	return &dto.GiftCardDTO{}, nil
 }
-func (s *fakeValidGiftCardService) Delete(id uint) error {
+func (s *fakeValidGiftCardService) Delete(ctx context.Context, id uint) error {
 	s.deleteCall++
 
 	if s.strategy == invalidOperation {
@@ -96,21 +116,23 @@ func (s *fakeValidGiftCardService) Delete(id uint) error {
 	}
 	return nil
 }
-func (s *fakeValidGiftCardService) CreateMany(cards *dto.BulkCreateGiftCardsDTO) (*dto.GiftCardsListDTO, error) {
+func (s *fakeValidGiftCardService) CreateMany(ctx context.Context,
+	cards *dto.BulkCreateGiftCardsDTO) (*dto.GiftCardsListDTO, error) {
 	s.createManyCall++
 	return &dto.GiftCardsListDTO{
 		Cards: []dto.GiftCardDTO{},
 		Error: nil,
 	}, nil
 }
-func (s *fakeValidGiftCardService) CreateSameMany(cards *dto.BulkCreateSameGiftCardsDTO) (*dto.GiftCardsListDTO, error) {
+func (s *fakeValidGiftCardService) CreateSameMany(ctx context.Context,
+	cards *dto.BulkCreateSameGiftCardsDTO) (*dto.GiftCardsListDTO, error) {
 	s.createSameManyCall++
 	return &dto.GiftCardsListDTO{
 		Cards: []dto.GiftCardDTO{},
 		Error: nil,
 	}, nil
 }
-func (s *fakeValidGiftCardService) FindByPublicKey(key string) (*dto.GiftCardStatusDTO, error) {
+func (s *fakeValidGiftCardService) FindByPublicKey(ctx context.Context, key string) (*dto.GiftCardStatusDTO, error) {
 	s.findByPublicKeyCall++
 	if s.strategy == notFound {
 		return nil, common.GiftCardNotFound
@@ -118,7 +140,7 @@ func (s *fakeValidGiftCardService) FindByPublicKey(key string) (*dto.GiftCardSta
 	return &dto.GiftCardStatusDTO{}, nil
 }
 
-func (s *fakeValidGiftCardService) FindByUUN(uun string) (*dto.GiftCardsListDTO, error) {
+func (s *fakeValidGiftCardService) FindByUUN(ctx context.Context, uun string) (*dto.GiftCardsListDTO, error) {
 	s.findByUUNCall++
 	if s.strategy == notFound {
 		return nil, common.NoGiftCardFoundForUser
@@ -129,14 +151,19 @@ func (s *fakeValidGiftCardService) FindByUUN(uun string) (*dto.GiftCardsListDTO,
 		Error: nil,
 	}, nil
 }
-func (s *fakeValidGiftCardService) ValidateGiftCards(cards *dto.ValidateGiftCardsDto) *dto.GiftCardStatusListDTO {
+func (s *fakeValidGiftCardService) ValidateGiftCards(ctx context.Context,
+	cards *dto.ValidateGiftCardsDto) (*dto.GiftCardStatusListDTO, error) {
 	s.validateGiftCardsCall++
+	if s.strategy == internalError {
+		return nil, context.DeadlineExceeded
+	}
 	return &dto.GiftCardStatusListDTO{
 		Cards: []dto.GiftCardStatusDTO{},
 		Error: nil,
-	}
+	}, nil
 }
-func (s *fakeValidGiftCardService) ApproveGiftCards(cards *dto.ApproveGiftCardsDTO) (*dto.GiftCardStatusListDTO, error) {
+func (s *fakeValidGiftCardService) ApproveGiftCards(ctx context.Context,
+	cards *dto.ApproveGiftCardsDTO) (*dto.GiftCardStatusListDTO, error) {
 	s.approveGiftCardsCall++
 
 	if s.strategy == internalError {
@@ -153,11 +180,16 @@ func (s *fakeValidGiftCardService) ApproveGiftCards(cards *dto.ApproveGiftCardsD
 		Error: nil,
 	}, nil
 }
-func (s *fakeValidGiftCardService) ValidateGiftCard(giftCardSecret string) dto.GiftCardStatusDTO {
+func (s *fakeValidGiftCardService) ValidateGiftCard(ctx context.Context,
+	giftCardSecret string) (dto.GiftCardStatusDTO, error) {
 	s.validateGiftCardCall++
-	return dto.GiftCardStatusDTO{}
+	if s.strategy == internalError {
+		return dto.GiftCardStatusDTO{}, context.DeadlineExceeded
+	}
+	return dto.GiftCardStatusDTO{}, nil
 }
-func (s *fakeValidGiftCardService) ApproveGiftCard(uun, giftCardSecret string) (dto.GiftCardStatusDTO, error) {
+func (s *fakeValidGiftCardService) ApproveGiftCard(ctx context.Context,
+	uun, giftCardSecret string) (dto.GiftCardStatusDTO, error) {
 	s.approveGiftCardCall++
 
 	if s.strategy == notFound {
@@ -196,7 +228,12 @@ func createTestObjects(strategy int) (*fakeValidGiftCardService, *httptest.Respo
 	fakeCampaignService := newFakeCampaignService(strategy)
 	handler := handlers.NewGiftCardHandler(fakeService)
 	campaignHandler := handlers.NewCampaignHandler(fakeCampaignService)
-	router := api.CreateRoute(handler, campaignHandler)
+	batchHandler := handlers.NewBatchHandler(newFakeBatchService(strategy))
+	searchHandler := handlers.NewSearchHandler(newFakeSearchService(strategy))
+	reportHandler := handlers.NewReportHandler(newFakeReportService(strategy))
+	webhookHandler := handlers.NewWebhookHandler(newFakeWebhookService(strategy))
+	router := api.CreateRoute(handler, campaignHandler, batchHandler, searchHandler, reportHandler,
+		webhookHandler, timeout.NewDeadlines(configuration.TimeoutConfiguration{}))
 	return fakeService, w, router
 }
 
@@ -649,6 +686,120 @@ func TestFindPage(te *testing.T) {
 		assert.Equal(t, 400, w.Code)
 		assert.Equal(t, 0, fakeService.findPageCall, "findPage should not be called")
 	})
+
+	te.Run("with filters and sort", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/page/10/1?uun=milad&status=1&amountFrom=100&amountTo=500"+
+			"&campaignId=1,2&campaignId=3&campaignTitle=yalda&createdFrom=2020-01-01&redeemedTo=2020-02-01"+
+			"&sort=-amount,created_at", nil)
+		fakeService, w, router := createTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		filter := fakeService.findPageFilter
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "milad", filter.UUN)
+		assert.Equal(t, 1, *filter.Status)
+		assert.Equal(t, int32(100), *filter.AmountFrom)
+		assert.Equal(t, int32(500), *filter.AmountTo)
+		assert.Equal(t, []uint{1, 2, 3}, filter.CampaignIds)
+		assert.Equal(t, "yalda", filter.CampaignTitle)
+		assert.Equal(t, 2020, filter.CreatedFrom.Year())
+		assert.Equal(t, time.February, filter.RedeemedTo.Month())
+		assert.Nil(t, filter.ExpireDateFrom)
+		assert.Equal(t, []core.SortField{{Column: "amount", Descending: true}, {Column: "created_at"}}, filter.Sort)
+	})
+
+	te.Run("with invalid filters", func(t *testing.T) {
+		t.Parallel()
+		for _, query := range []string{"status=x", "amountFrom=1.5", "sort=secret", "campaignId=1,x", "createdTo=x"} {
+			req, _ := http.NewRequest("GET", baseUrl+"/page/10/1?"+query, nil)
+			fakeService, w, router := createTestObjects(found)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, 400, w.Code, query)
+			assert.Equal(t, 0, fakeService.findPageCall, "findPage should not be called")
+		}
+	})
+}
+
+func TestFindCursorPage(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid service", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/cursor/10?cursor=abc&campaignId=2", nil)
+		fakeService, w, router := createTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.GiftCardsCursorPageDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err, "valid response object")
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "next", response.NextCursor)
+		assert.Equal(t, 10, response.Size)
+		assert.True(t, fakeService.findCursorWithCount)
+		assert.Equal(t, 1, fakeService.findCursorPageCall, "findCursorPage should be called just once")
+	})
+
+	te.Run("with skip count", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/cursor/100?skipCount=true", nil)
+		fakeService, w, router := createTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.GiftCardsCursorPageDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err, "valid response object")
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 50, response.Size)
+		assert.False(t, fakeService.findCursorWithCount)
+	})
+
+	te.Run("with invalid cursor", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/cursor/10?cursor=invalid", nil)
+		fakeService, w, router := createTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 1, fakeService.findCursorPageCall, "findCursorPage should be called just once")
+	})
+
+	te.Run("with invalid campaign id", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/cursor/10?campaignId=invalid", nil)
+		fakeService, w, router := createTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 0, fakeService.findCursorPageCall, "findCursorPage should not be called")
+	})
+
+	te.Run("with invalid skip count", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/cursor/10?skipCount=maybe", nil)
+		fakeService, w, router := createTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 0, fakeService.findCursorPageCall, "findCursorPage should not be called")
+	})
+
+	te.Run("with sort", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/cursor/10?sort=amount", nil)
+		_, w, router := createTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
 }
 
 func TestValidateGiftCard(te *testing.T) {
@@ -666,6 +817,20 @@ func TestValidateGiftCard(te *testing.T) {
 		assert.Equal(t, 200, w.Code)
 		assert.Equal(t, 1, fakeService.validateGiftCardCall, "validateGiftCard should be called just once")
 	})
+
+	te.Run("with timed out request", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/validate-gift-card/secret", nil)
+		_, w, router := createTestObjects(internalError)
+
+		router.ServeHTTP(w, req)
+		var response dto.GiftCardStatusDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err, "valid response object")
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.NotEmpty(t, response.Error)
+	})
 }
 
 func TestApproveGiftCard(te *testing.T) {
@@ -749,7 +914,12 @@ func TestFindByUUN(te *testing.T) {
 func TestCreateRoute(te *testing.T) {
 	te.Parallel()
 	route := api.CreateRoute(handlers.NewGiftCardHandler(newFakeValidGiftCardService(found)),
-		handlers.NewCampaignHandler(newFakeCampaignService(found)))
+		handlers.NewCampaignHandler(newFakeCampaignService(found)),
+		handlers.NewBatchHandler(newFakeBatchService(found)),
+		handlers.NewSearchHandler(newFakeSearchService(found)),
+		handlers.NewReportHandler(newFakeReportService(found)),
+		handlers.NewWebhookHandler(newFakeWebhookService(found)),
+		timeout.NewDeadlines(configuration.TimeoutConfiguration{Default: time.Minute}))
 	assert.NotEmpty(te, route)
 }
 
@@ -2,4 +2,5 @@ package handlers
 
 type GinContext interface {
 	JSON(code int, obj interface{})
+	GetString(key string) string
 }
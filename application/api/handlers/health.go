@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"giftcard-engine/infrastructure/health"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler interface {
+	Live(c *gin.Context)
+	Ready(c *gin.Context)
+	Startup(c *gin.Context)
+}
+
+type healthHandler struct{}
+
+// Live godoc
+// @Summary liveness probe
+// @Description runs the liveness checks, which fail when the process should be restarted
+// @ID health-live
+// @Produce  json
+// @tags Public
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /health/live [get]
+func (h *healthHandler) Live(c *gin.Context) {
+	serveProbe(c, health.Liveness)
+}
+
+// Ready godoc
+// @Summary readiness probe
+// @Description runs the readiness checks, which fail when the service should not receive requests for now, like
+// @Description when a critical dependency is down or the service is shutting down
+// @ID health-ready
+// @Produce  json
+// @tags Public
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /health/ready [get]
+func (h *healthHandler) Ready(c *gin.Context) {
+	serveProbe(c, health.Readiness)
+}
+
+// Startup godoc
+// @Summary startup probe
+// @Description runs the startup checks, which fail until the service has started
+// @ID health-startup
+// @Produce  json
+// @tags Public
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /health/startup [get]
+func (h *healthHandler) Startup(c *gin.Context) {
+	serveProbe(c, health.Startup)
+}
+
+// serveProbe answers the report of the probe, with 503 when it is unhealthy
+func serveProbe(c *gin.Context, probe health.Probe) {
+	health.Handler(probe).ServeHTTP(c.Writer, c.Request)
+}
+
+func NewHealthHandler() HealthHandler {
+	return &healthHandler{}
+}
@@ -0,0 +1,29 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"giftcard-engine/infrastructure/health"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthProbes(te *testing.T) {
+	te.Parallel()
+	for _, url := range []string{"/health/live", "/health/ready", "/health/startup", "/v1/gift-card/health"} {
+		url := url
+		te.Run(url, func(t *testing.T) {
+			t.Parallel()
+			_, w, router := createTestObjects(found)
+
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+			var report health.Report
+			err := json.NewDecoder(w.Body).Decode(&report)
+
+			assert.Empty(t, err)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, health.Healthy, report.Status)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dto"
+	_ "giftcard-engine/utils/indraframework"
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"net/http"
+	"strconv"
+)
+
+type ReportHandler interface {
+	GiftCardSeries(c *gin.Context)
+	Liability(c *gin.Context)
+}
+
+type reportHandler struct {
+	service core.ReportService
+}
+
+// GiftCardSeries godoc
+// @Summary gift card time series
+// @Description issued and redeemed count and value of gift cards per day, week or month of a date range
+// @ID report-gift-card-series
+// @Accept  json
+// @Produce  json
+// @Produce  text/csv
+// @tags Report
+// @Param from query string true "first day of the report. 2006-01-02"
+// @Param to query string true "last day of the report. 2006-01-02"
+// @Param interval query string false "day, week or month. default is day"
+// @Param timezone query string false "IANA timezone of the periods like Asia/Tehran. default is UTC"
+// @Param campaignId query integer false "campaign id"
+// @Param format query string false "json or csv. default is json"
+// @Success 200 {object} dto.ReportDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 500 {object} indraframework.IndraException
+// @Router /v1/report/gift-card [get]
+func (h *reportHandler) GiftCardSeries(c *gin.Context) {
+	request := dto.ReportRequestDTO{
+		From:     c.Query("from"),
+		To:       c.Query("to"),
+		Interval: c.DefaultQuery("interval", dto.DailyReport),
+		Timezone: c.DefaultQuery("timezone", "UTC"),
+	}
+	campaignId, format, err := parseReportQuery(c)
+	if err != nil {
+		jsonBadRequest(c, &dto.ReportDTO{}, err)
+		return
+	}
+	request.CampaignId = campaignId
+
+	report, err := h.service.GiftCardSeries(c.Request.Context(), request)
+	if !reportSucceeded(c, &dto.ReportDTO{}, err) {
+		return
+	}
+	if format == "json" {
+		jsonSuccess(c, report)
+		return
+	}
+
+	records := [][]string{{"period_start", "issued_count", "issued_amount", "redeemed_count", "redeemed_amount"}}
+	for _, point := range report.Points {
+		records = append(records, []string{
+			point.PeriodStart,
+			strconv.FormatInt(point.IssuedCount, 10),
+			strconv.FormatInt(point.IssuedAmount, 10),
+			strconv.FormatInt(point.RedeemedCount, 10),
+			strconv.FormatInt(point.RedeemedAmount, 10),
+		})
+	}
+	csvSuccess(c, fmt.Sprintf("gift-cards-%s-%s-%s.csv", report.Interval, report.From, report.To), records)
+}
+
+// Liability godoc
+// @Summary outstanding liability
+// @Description outstanding count and value of the unredeemed and unexpired gift cards of every campaign at the end of a date
+// @ID report-liability
+// @Accept  json
+// @Produce  json
+// @Produce  text/csv
+// @tags Report
+// @Param date query string true "the as of date. 2006-01-02"
+// @Param timezone query string false "IANA timezone of the date like Asia/Tehran. default is UTC"
+// @Param campaignId query integer false "campaign id"
+// @Param format query string false "json or csv. default is json"
+// @Success 200 {object} dto.LiabilityDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 500 {object} indraframework.IndraException
+// @Router /v1/report/liability [get]
+func (h *reportHandler) Liability(c *gin.Context) {
+	request := dto.LiabilityRequestDTO{
+		AsOf:     c.Query("date"),
+		Timezone: c.DefaultQuery("timezone", "UTC"),
+	}
+	campaignId, format, err := parseReportQuery(c)
+	if err != nil {
+		jsonBadRequest(c, &dto.LiabilityDTO{}, err)
+		return
+	}
+	request.CampaignId = campaignId
+
+	report, err := h.service.Liability(c.Request.Context(), request)
+	if !reportSucceeded(c, &dto.LiabilityDTO{}, err) {
+		return
+	}
+	if format == "json" {
+		jsonSuccess(c, report)
+		return
+	}
+
+	records := [][]string{{"campaign_id", "campaign_title", "outstanding_count", "outstanding_amount"}}
+	for _, campaign := range report.Campaigns {
+		records = append(records, []string{
+			strconv.Itoa(campaign.CampaignId),
+			campaign.CampaignTitle,
+			strconv.FormatInt(campaign.OutstandingCount, 10),
+			strconv.FormatInt(campaign.OutstandingAmount, 10),
+		})
+	}
+	csvSuccess(c, fmt.Sprintf("liability-%s.csv", report.AsOf), records)
+}
+
+// parseReportQuery reads the optional campaign and the output format shared by the reports
+func parseReportQuery(c *gin.Context) (*uint, string, error) {
+	var campaignId *uint
+	if campaignIdQuery := c.Query("campaignId"); campaignIdQuery != "" {
+		id, err := strconv.ParseUint(campaignIdQuery, 10, 32)
+		if err != nil {
+			return nil, "", common.InvalidCampaignQueryParam
+		}
+		value := uint(id)
+		campaignId = &value
+	}
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		return nil, "", common.InvalidReportFormat
+	}
+	return campaignId, format, nil
+}
+
+// reportSucceeded writes the error response of a report. invalid requests are bad requests
+func reportSucceeded(c *gin.Context, data dto.Dto, err error) bool {
+	if _, invalid := err.(validation.Errors); invalid || err == common.InvalidTimezone ||
+		err == common.InvalidReportRange || err == common.ReportRangeIsTooLong {
+		jsonBadRequest(c, data, err)
+		return false
+	} else if err != nil {
+		jsonInternalServerError(c, data, err)
+		return false
+	}
+	return true
+}
+
+func csvSuccess(c *gin.Context, fileName string, records [][]string) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+	c.Header("Content-Type", "text/csv")
+	c.Status(http.StatusOK)
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.WriteAll(records)
+}
+
+func NewReportHandler(service core.ReportService) ReportHandler {
+	return &reportHandler{service: service}
+}
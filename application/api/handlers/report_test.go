@@ -0,0 +1,243 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"giftcard-engine/application/api"
+	"giftcard-engine/application/api/handlers"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/timeout"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeReportService struct {
+	strategy           int
+	giftCardSeriesCall int
+	request            dto.ReportRequestDTO
+	liabilityCall      int
+	liabilityRequest   dto.LiabilityRequestDTO
+}
+
+func (s *fakeReportService) GiftCardSeries(ctx context.Context, request dto.ReportRequestDTO) (*dto.ReportDTO, error) {
+	s.giftCardSeriesCall++
+	s.request = request
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	switch s.strategy {
+	case invalidOperation:
+		return nil, common.ReportRangeIsTooLong
+	case internalError:
+		return nil, errors.New("connection lost")
+	}
+	return &dto.ReportDTO{
+		Interval: request.Interval,
+		Timezone: request.Timezone,
+		From:     request.From,
+		To:       request.To,
+		Points: []dto.ReportPointDTO{
+			{PeriodStart: request.From, IssuedCount: 2, IssuedAmount: 2000, RedeemedCount: 1, RedeemedAmount: 1000},
+		},
+	}, nil
+}
+
+func (s *fakeReportService) Liability(ctx context.Context, request dto.LiabilityRequestDTO) (*dto.LiabilityDTO, error) {
+	s.liabilityCall++
+	s.liabilityRequest = request
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	if s.strategy == internalError {
+		return nil, errors.New("connection lost")
+	}
+	return &dto.LiabilityDTO{
+		AsOf:     request.AsOf,
+		Timezone: request.Timezone,
+		Campaigns: []dto.CampaignLiabilityDTO{
+			{CampaignId: 1, CampaignTitle: "yalda", OutstandingCount: 2, OutstandingAmount: 2000},
+		},
+		OutstandingCount:  2,
+		OutstandingAmount: 2000,
+	}, nil
+}
+
+func newFakeReportService(strategy int) *fakeReportService {
+	return &fakeReportService{
+		strategy: strategy,
+	}
+}
+
+func createReportTestObjects(strategy int) (*fakeReportService, *httptest.ResponseRecorder, *gin.Engine) {
+	w := httptest.NewRecorder()
+	fakeReportService := newFakeReportService(strategy)
+	handler := handlers.NewGiftCardHandler(newFakeValidGiftCardService(strategy))
+	campaignHandler := handlers.NewCampaignHandler(newFakeCampaignService(strategy))
+	batchHandler := handlers.NewBatchHandler(newFakeBatchService(strategy))
+	searchHandler := handlers.NewSearchHandler(newFakeSearchService(strategy))
+	reportHandler := handlers.NewReportHandler(fakeReportService)
+	webhookHandler := handlers.NewWebhookHandler(newFakeWebhookService(strategy))
+	router := api.CreateRoute(handler, campaignHandler, batchHandler, searchHandler, reportHandler,
+		webhookHandler, timeout.NewDeadlines(configuration.TimeoutConfiguration{}))
+	return fakeReportService, w, router
+}
+
+var reportUrl = "/v1/report/gift-card"
+
+func TestGiftCardSeries(te *testing.T) {
+	te.Parallel()
+	te.Run("with json format", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", reportUrl+"?from=2020-01-01&to=2020-01-31&campaignId=3", nil)
+		fakeService, w, router := createReportTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.ReportDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 1, len(response.Points))
+		assert.Equal(t, dto.DailyReport, fakeService.request.Interval)
+		assert.Equal(t, "UTC", fakeService.request.Timezone)
+		assert.Equal(t, uint(3), *fakeService.request.CampaignId)
+		assert.Equal(t, 1, fakeService.giftCardSeriesCall, "giftCardSeries should be called just once")
+	})
+
+	te.Run("with csv format", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET",
+			reportUrl+"?from=2020-01-01&to=2020-03-31&interval=month&timezone=Asia/Tehran&format=csv", nil)
+		fakeService, w, router := createReportTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		records, err := csv.NewReader(w.Body).ReadAll()
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "gift-cards-month-2020-01-01-2020-03-31.csv")
+		assert.Equal(t, [][]string{
+			{"period_start", "issued_count", "issued_amount", "redeemed_count", "redeemed_amount"},
+			{"2020-01-01", "2", "2000", "1", "1000"},
+		}, records)
+		assert.Equal(t, "Asia/Tehran", fakeService.request.Timezone)
+	})
+
+	te.Run("with invalid format", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", reportUrl+"?from=2020-01-01&to=2020-01-31&format=xml", nil)
+		fakeService, w, router := createReportTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 0, fakeService.giftCardSeriesCall, "giftCardSeries should not be called")
+	})
+
+	te.Run("with invalid campaign", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", reportUrl+"?from=2020-01-01&to=2020-01-31&campaignId=x", nil)
+		fakeService, w, router := createReportTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 0, fakeService.giftCardSeriesCall, "giftCardSeries should not be called")
+	})
+
+	te.Run("without range", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", reportUrl, nil)
+		_, w, router := createReportTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+
+	te.Run("with too long range", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", reportUrl+"?from=2000-01-01&to=2020-01-31", nil)
+		_, w, router := createReportTestObjects(invalidOperation)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+
+	te.Run("with internal error", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", reportUrl+"?from=2020-01-01&to=2020-01-31", nil)
+		_, w, router := createReportTestObjects(internalError)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+	})
+}
+
+func TestLiability(te *testing.T) {
+	te.Parallel()
+	te.Run("with json format", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", "/v1/report/liability?date=2020-01-31&campaignId=1", nil)
+		fakeService, w, router := createReportTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.LiabilityDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, int64(2000), response.OutstandingAmount)
+		assert.Equal(t, "2020-01-31", fakeService.liabilityRequest.AsOf)
+		assert.Equal(t, "UTC", fakeService.liabilityRequest.Timezone)
+		assert.Equal(t, uint(1), *fakeService.liabilityRequest.CampaignId)
+		assert.Equal(t, 1, fakeService.liabilityCall, "liability should be called just once")
+	})
+
+	te.Run("with csv format", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", "/v1/report/liability?date=2020-01-31&format=csv", nil)
+		_, w, router := createReportTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		records, err := csv.NewReader(w.Body).ReadAll()
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "liability-2020-01-31.csv")
+		assert.Equal(t, [][]string{
+			{"campaign_id", "campaign_title", "outstanding_count", "outstanding_amount"},
+			{"1", "yalda", "2", "2000"},
+		}, records)
+	})
+
+	te.Run("without date", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", "/v1/report/liability", nil)
+		_, w, router := createReportTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+
+	te.Run("with internal error", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", "/v1/report/liability?date=2020-01-31", nil)
+		_, w, router := createReportTestObjects(internalError)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+	})
+}
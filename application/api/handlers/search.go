@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/utils"
+	_ "giftcard-engine/utils/indraframework"
+	"giftcard-engine/utils/parser"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler interface {
+	SearchGiftCards(c *gin.Context)
+}
+
+type searchHandler struct {
+	service core.SearchService
+}
+
+// SearchGiftCards godoc
+// @Summary gift card search
+// @Description fuzzy search of gift cards by the start of the public code or uun, campaign title and amount
+// @ID search-gift-cards
+// @Accept  json
+// @Produce  json
+// @tags Gift Card
+// @Param size path integer true "page size"
+// @Param number path integer true "page number"
+// @Param q query string true "search query"
+// @Success 200 {object} dto.GiftCardsPageDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 503 {object} indraframework.IndraException
+// @Router /v1/gift-card/search/{size}/{number} [get]
+func (h *searchHandler) SearchGiftCards(c *gin.Context) {
+	number, err := parser.ParseNumber(c.Param("number"))
+	if err != nil {
+		jsonBadRequest(c, &dto.GiftCardsPageDTO{}, err)
+		return
+	}
+	var size uint
+	size, err = parser.ParseNumber(c.Param("size"))
+	if err != nil {
+		jsonBadRequest(c, &dto.GiftCardsPageDTO{}, err)
+		return
+	}
+	size = utils.MinUint(size, 50)
+	if number == 0 {
+		number += 1
+	}
+	number = number - 1
+
+	cardsPage, err := h.service.SearchGiftCards(c.Request.Context(), c.Query("q"), size, number)
+	if err == common.EmptySearchQuery {
+		jsonBadRequest(c, &dto.GiftCardsPageDTO{}, err)
+	} else if err != nil {
+		jsonServiceUnavailable(c, &dto.GiftCardsPageDTO{}, err)
+	} else {
+		jsonSuccess(c, cardsPage)
+	}
+}
+
+func NewSearchHandler(service core.SearchService) SearchHandler {
+	return &searchHandler{service: service}
+}
@@ -0,0 +1,113 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"giftcard-engine/application/api"
+	"giftcard-engine/application/api/handlers"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/timeout"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeSearchService struct {
+	strategy           int
+	searchGiftCardCall int
+	searchQuery        string
+	reindexCall        int
+}
+
+func (s *fakeSearchService) SearchGiftCards(ctx context.Context, query string,
+	size, page uint) (*dto.GiftCardsPageDTO, error) {
+	s.searchGiftCardCall++
+	s.searchQuery = query
+	if query == "" {
+		return nil, common.EmptySearchQuery
+	}
+	if s.strategy == internalError {
+		return nil, common.SearchIsNotAvailable
+	}
+	return dto.NewGiftCardsPageDTO([]dto.GiftCardDTO{{ID: 1}}, int(size), int(page), 1), nil
+}
+
+func (s *fakeSearchService) Reindex(ctx context.Context, chunkSize uint) (int, error) {
+	s.reindexCall++
+	return 0, nil
+}
+
+func newFakeSearchService(strategy int) *fakeSearchService {
+	return &fakeSearchService{
+		strategy: strategy,
+	}
+}
+
+func createSearchTestObjects(strategy int) (*fakeSearchService, *httptest.ResponseRecorder, *gin.Engine) {
+	w := httptest.NewRecorder()
+	fakeSearchService := newFakeSearchService(strategy)
+	handler := handlers.NewGiftCardHandler(newFakeValidGiftCardService(strategy))
+	campaignHandler := handlers.NewCampaignHandler(newFakeCampaignService(strategy))
+	batchHandler := handlers.NewBatchHandler(newFakeBatchService(strategy))
+	searchHandler := handlers.NewSearchHandler(fakeSearchService)
+	reportHandler := handlers.NewReportHandler(newFakeReportService(strategy))
+	webhookHandler := handlers.NewWebhookHandler(newFakeWebhookService(strategy))
+	router := api.CreateRoute(handler, campaignHandler, batchHandler, searchHandler, reportHandler,
+		webhookHandler, timeout.NewDeadlines(configuration.TimeoutConfiguration{}))
+	return fakeSearchService, w, router
+}
+
+func TestSearchGiftCards(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/search/10/2?q=abc", nil)
+		fakeService, w, router := createSearchTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.GiftCardsPageDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 2, response.Page)
+		assert.Equal(t, 1, len(response.GiftCards))
+		assert.Equal(t, "abc", fakeService.searchQuery)
+		assert.Equal(t, 1, fakeService.searchGiftCardCall, "searchGiftCards should be called just once")
+	})
+
+	te.Run("without query", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/search/10/1", nil)
+		_, w, router := createSearchTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+
+	te.Run("with invalid size", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/search/x/1?q=abc", nil)
+		fakeService, w, router := createSearchTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Equal(t, 0, fakeService.searchGiftCardCall, "searchGiftCards should not be called")
+	})
+
+	te.Run("with unavailable search", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("GET", baseUrl+"/search/10/1?q=abc", nil)
+		_, w, router := createSearchTestObjects(internalError)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 503, w.Code)
+	})
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/utils"
+	_ "giftcard-engine/utils/indraframework"
+	"giftcard-engine/utils/parser"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler interface {
+	FindAll(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	FindDeadLetters(c *gin.Context)
+	Redeliver(c *gin.Context)
+}
+
+type webhookHandler struct {
+	service core.WebhookService
+}
+
+// FindAll godoc
+// @Summary webhook subscriptions
+// @Description get every webhook subscription. the secrets are not returned
+// @ID webhook-find-all
+// @Accept  json
+// @Produce  json
+// @tags Webhook
+// @Success 200 {object} dto.WebhookListDTO
+// @Failure 500 {object} indraframework.IndraException
+// @Router /v1/webhook [get]
+func (h *webhookHandler) FindAll(c *gin.Context) {
+	webhooks, err := h.service.FindAll(c.Request.Context())
+	if err != nil {
+		jsonInternalServerError(c, &dto.WebhookListDTO{}, err)
+		return
+	}
+	jsonSuccess(c, webhooks)
+}
+
+// Create godoc
+// @Summary subscribe a webhook
+// @Description subscribes an url to the events of the gift cards, which are posted to it as json signed with
+// @Description the secret in the X-Gift-Card-Signature header. the events are gift_card.issued,
+// @Description gift_card.approved, gift_card.rolled_back, gift_card.updated, gift_card.voided,
+// @Description gift_card.expired and gift_card.deleted
+// @ID webhook-create
+// @Accept  json
+// @Produce  json
+// @tags Webhook
+// @Param webhookDTO body dto.CreateWebhookDTO true "Create webhook dto"
+// @Success 200 {object} dto.WebhookDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 500 {object} indraframework.IndraException
+// @Router /v1/webhook [post]
+func (h *webhookHandler) Create(c *gin.Context) {
+	var webhookDTO dto.CreateWebhookDTO
+	if success := tryActions(c,
+		func() (error error, data dto.Dto) { return c.BindJSON(&webhookDTO), &dto.WebhookDTO{} },
+		func() (error error, data dto.Dto) { return webhookDTO.Validate(), &dto.WebhookDTO{} }); !success {
+		return
+	}
+
+	webhook, err := h.service.Create(c.Request.Context(), webhookDTO)
+	if err != nil {
+		jsonInternalServerError(c, &dto.WebhookDTO{}, err)
+		return
+	}
+	jsonSuccess(c, webhook)
+}
+
+// Update godoc
+// @Summary updates a webhook
+// @Description changes the url and the events of a webhook subscription. the secret is kept when it is empty
+// @ID webhook-update
+// @Accept  json
+// @Produce  json
+// @tags Webhook
+// @Param webhookDTO body dto.UpdateWebhookDTO true "Update webhook dto"
+// @Success 200 {object} dto.WebhookDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 404 {object} indraframework.IndraException
+// @Failure 500 {object} indraframework.IndraException
+// @Router /v1/webhook [put]
+func (h *webhookHandler) Update(c *gin.Context) {
+	var webhookDTO dto.UpdateWebhookDTO
+	if success := tryActions(c,
+		func() (error error, data dto.Dto) { return c.BindJSON(&webhookDTO), &dto.WebhookDTO{} },
+		func() (error error, data dto.Dto) { return webhookDTO.Validate(), &dto.WebhookDTO{} }); !success {
+		return
+	}
+
+	webhook, err := h.service.Update(c.Request.Context(), webhookDTO)
+	if err == common.WebhookNotFound {
+		jsonNotFound(c, &dto.WebhookDTO{}, err)
+	} else if err != nil {
+		jsonInternalServerError(c, &dto.WebhookDTO{}, err)
+	} else {
+		jsonSuccess(c, webhook)
+	}
+}
+
+// Delete godoc
+// @Summary deletes a webhook
+// @Description deletes a webhook subscription. its pending deliveries become dead letters
+// @ID webhook-delete
+// @Accept  json
+// @Produce  json
+// @tags Webhook
+// @Param id path int true "webhook's id"
+// @Success 200 {object} dto.DeleteMessageDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 404 {object} indraframework.IndraException
+// @Router /v1/webhook/{id} [delete]
+func (h *webhookHandler) Delete(c *gin.Context) {
+	id, err := parser.ParseNumber(c.Param("id"))
+	if err != nil {
+		jsonBadRequest(c, &dto.DeleteMessageDTO{}, err)
+		return
+	}
+
+	err = h.service.Delete(c.Request.Context(), id)
+	if err == common.WebhookNotFound {
+		jsonNotFound(c, &dto.DeleteMessageDTO{}, err)
+		return
+	}
+	if err != nil {
+		jsonInternalServerError(c, &dto.DeleteMessageDTO{}, err)
+		return
+	}
+	jsonSuccess(c, &dto.DeleteMessageDTO{
+		ID:      int(id),
+		Message: "Webhook Deleted!",
+		Error:   nil,
+	})
+}
+
+// FindDeadLetters godoc
+// @Summary dead webhook deliveries
+// @Description get the webhook deliveries which ran out of attempts in paging object, newest first
+// @ID webhook-dead-letters
+// @Accept  json
+// @Produce  json
+// @tags Webhook
+// @Param size path number true "page size"
+// @Param number path number true "page number"
+// @Success 200 {object} dto.WebhookDeliveryPageDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Router /v1/webhook/dead-letter/{size}/{number} [get]
+func (h *webhookHandler) FindDeadLetters(c *gin.Context) {
+	number, err := parser.ParseNumber(c.Param("number"))
+	if err != nil {
+		jsonBadRequest(c, &dto.WebhookDeliveryPageDTO{}, err)
+		return
+	}
+	var size uint
+	size, err = parser.ParseNumber(c.Param("size"))
+	if err != nil {
+		jsonBadRequest(c, &dto.WebhookDeliveryPageDTO{}, err)
+		return
+	}
+	size = utils.MinUint(size, 50)
+	if number == 0 {
+		number += 1
+	}
+	number = number - 1
+	jsonSuccess(c, h.service.FindDeadLetters(c.Request.Context(), size, number))
+}
+
+// Redeliver godoc
+// @Summary redeliver a webhook
+// @Description makes a dead webhook delivery pending again, so it is sent with all of its attempts
+// @ID webhook-redeliver
+// @Accept  json
+// @Produce  json
+// @tags Webhook
+// @Param id path int true "delivery id"
+// @Success 200 {object} dto.WebhookDeliveryDTO
+// @Failure 400 {object} indraframework.IndraException
+// @Failure 404 {object} indraframework.IndraException
+// @Failure 500 {object} indraframework.IndraException
+// @Router /v1/webhook/redeliver/{id} [post]
+func (h *webhookHandler) Redeliver(c *gin.Context) {
+	id, err := parser.ParseNumber(c.Param("id"))
+	if err != nil {
+		jsonBadRequest(c, &dto.WebhookDeliveryDTO{}, err)
+		return
+	}
+
+	delivery, err := h.service.Redeliver(c.Request.Context(), id)
+	if err == common.WebhookDeliveryNotFound {
+		jsonNotFound(c, &dto.WebhookDeliveryDTO{}, err)
+	} else if err == common.WebhookDeliveryIsNotDead {
+		jsonBadRequest(c, &dto.WebhookDeliveryDTO{}, err)
+	} else if err != nil {
+		jsonInternalServerError(c, &dto.WebhookDeliveryDTO{}, err)
+	} else {
+		jsonSuccess(c, delivery)
+	}
+}
+
+func NewWebhookHandler(service core.WebhookService) WebhookHandler {
+	return &webhookHandler{service: service}
+}
@@ -0,0 +1,278 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"giftcard-engine/application/api"
+	"giftcard-engine/application/api/handlers"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/timeout"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type fakeWebhookService struct {
+	strategy            int
+	findAllCall         int
+	createCall          int
+	updateCall          int
+	deleteCall          int
+	findDeadLettersCall int
+	redeliverCall       int
+}
+
+func (s *fakeWebhookService) Publish(ctx context.Context, event dto.WebhookEventDTO) error {
+	return nil
+}
+
+func (s *fakeWebhookService) PublishExpired(ctx context.Context, from, to time.Time) (int, error) {
+	return 0, nil
+}
+
+func (s *fakeWebhookService) FindAll(ctx context.Context) (*dto.WebhookListDTO, error) {
+	s.findAllCall++
+	if s.strategy == internalError {
+		return nil, fakeError
+	}
+	return &dto.WebhookListDTO{Webhooks: []dto.WebhookDTO{
+		{ID: 1, Url: "http://crm/hooks", Events: []string{dbmodel.CardApprovedEvent}},
+	}}, nil
+}
+
+func (s *fakeWebhookService) Create(ctx context.Context, webhook dto.CreateWebhookDTO) (*dto.WebhookDTO, error) {
+	s.createCall++
+	if s.strategy == internalError {
+		return nil, fakeError
+	}
+	return &dto.WebhookDTO{ID: 1, Url: webhook.Url, Events: webhook.Events}, nil
+}
+
+func (s *fakeWebhookService) Update(ctx context.Context, webhook dto.UpdateWebhookDTO) (*dto.WebhookDTO, error) {
+	s.updateCall++
+	if s.strategy == notFound {
+		return nil, common.WebhookNotFound
+	}
+	return &dto.WebhookDTO{ID: webhook.ID, Url: webhook.Url, Events: webhook.Events}, nil
+}
+
+func (s *fakeWebhookService) Delete(ctx context.Context, id uint) error {
+	s.deleteCall++
+	if s.strategy == notFound {
+		return common.WebhookNotFound
+	}
+	return nil
+}
+
+func (s *fakeWebhookService) FindDeadLetters(ctx context.Context, size, page uint) dto.WebhookDeliveryPageDTO {
+	s.findDeadLettersCall++
+	return dto.NewWebhookDeliveryPageDTO([]dto.WebhookDeliveryDTO{
+		{ID: 3, SubscriptionId: 1, Event: dbmodel.CardIssuedEvent, Status: "dead", Attempts: 8,
+			Payload: json.RawMessage(`{"event":"gift_card.issued"}`)},
+	}, int(size), int(page), 1)
+}
+
+func (s *fakeWebhookService) Redeliver(ctx context.Context, id uint) (*dto.WebhookDeliveryDTO, error) {
+	s.redeliverCall++
+	switch s.strategy {
+	case notFound:
+		return nil, common.WebhookDeliveryNotFound
+	case invalidOperation:
+		return nil, common.WebhookDeliveryIsNotDead
+	}
+	return &dto.WebhookDeliveryDTO{ID: int(id), Status: "pending", Payload: json.RawMessage(`{}`)}, nil
+}
+
+func newFakeWebhookService(strategy int) *fakeWebhookService {
+	return &fakeWebhookService{
+		strategy: strategy,
+	}
+}
+
+func createWebhookTestObjects(strategy int) (*fakeWebhookService, *httptest.ResponseRecorder, *gin.Engine) {
+	w := httptest.NewRecorder()
+	fakeWebhookService := newFakeWebhookService(strategy)
+	handler := handlers.NewGiftCardHandler(newFakeValidGiftCardService(strategy))
+	campaignHandler := handlers.NewCampaignHandler(newFakeCampaignService(strategy))
+	batchHandler := handlers.NewBatchHandler(newFakeBatchService(strategy))
+	searchHandler := handlers.NewSearchHandler(newFakeSearchService(strategy))
+	reportHandler := handlers.NewReportHandler(newFakeReportService(strategy))
+	webhookHandler := handlers.NewWebhookHandler(fakeWebhookService)
+	router := api.CreateRoute(handler, campaignHandler, batchHandler, searchHandler, reportHandler,
+		webhookHandler, timeout.NewDeadlines(configuration.TimeoutConfiguration{}))
+	return fakeWebhookService, w, router
+}
+
+var webhookBaseUrl = "/v1/webhook"
+
+func TestWebhookFindAll(t *testing.T) {
+	t.Parallel()
+	req, _ := http.NewRequest("GET", webhookBaseUrl+"/", nil)
+	fakeService, w, router := createWebhookTestObjects(found)
+
+	router.ServeHTTP(w, req)
+	var response dto.WebhookListDTO
+	err := json.NewDecoder(w.Body).Decode(&response)
+
+	assert.Empty(t, err)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "http://crm/hooks", response.Webhooks[0].Url)
+	assert.Equal(t, 1, fakeService.findAllCall, "findAll should be called just once")
+}
+
+func TestWebhookCreate(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("POST", webhookBaseUrl+"/", createJsonReader(dto.CreateWebhookDTO{
+			Url:    "https://crm.example.com/hooks/gift-cards",
+			Events: []string{dbmodel.CardApprovedEvent, dbmodel.CardRolledBackEvent},
+			Secret: "a-long-enough-secret",
+		}))
+		fakeService, w, router := createWebhookTestObjects(found)
+
+		router.ServeHTTP(w, req)
+		var response dto.WebhookDTO
+		err := json.NewDecoder(w.Body).Decode(&response)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, response.Error)
+		assert.Equal(t, []string{dbmodel.CardApprovedEvent, dbmodel.CardRolledBackEvent}, response.Events)
+		assert.Equal(t, 1, fakeService.createCall, "create should be called just once")
+	})
+
+	for name, webhook := range map[string]dto.CreateWebhookDTO{
+		"with unknown event": {Url: "http://crm/hooks", Events: []string{"gift_card.lost"},
+			Secret: "a-long-enough-secret"},
+		"with no events": {Url: "http://crm/hooks", Secret: "a-long-enough-secret"},
+		"with ftp url": {Url: "ftp://crm/hooks", Events: []string{dbmodel.CardIssuedEvent},
+			Secret: "a-long-enough-secret"},
+		"with short secret": {Url: "http://crm/hooks", Events: []string{dbmodel.CardIssuedEvent},
+			Secret: "short"},
+	} {
+		webhook := webhook
+		te.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req, _ := http.NewRequest("POST", webhookBaseUrl+"/", createJsonReader(webhook))
+			fakeService, w, router := createWebhookTestObjects(found)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, 400, w.Code)
+			assert.Equal(t, 0, fakeService.createCall, "create should not be called")
+		})
+	}
+
+	te.Run("with internal error", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("POST", webhookBaseUrl+"/", createJsonReader(dto.CreateWebhookDTO{
+			Url:    "http://crm/hooks",
+			Events: []string{dbmodel.CardIssuedEvent},
+			Secret: "a-long-enough-secret",
+		}))
+		_, w, router := createWebhookTestObjects(internalError)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+	})
+}
+
+func TestWebhookUpdate(te *testing.T) {
+	te.Parallel()
+	update := dto.UpdateWebhookDTO{ID: 1, Url: "http://crm/hooks", Events: []string{dbmodel.CardExpiredEvent}}
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("PUT", webhookBaseUrl+"/", createJsonReader(update))
+		fakeService, w, router := createWebhookTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 1, fakeService.updateCall, "update should be called just once")
+	})
+
+	te.Run("with not found webhook", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("PUT", webhookBaseUrl+"/", createJsonReader(update))
+		_, w, router := createWebhookTestObjects(notFound)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+	})
+}
+
+func TestWebhookDelete(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("DELETE", webhookBaseUrl+"/1", nil)
+		fakeService, w, router := createWebhookTestObjects(found)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 1, fakeService.deleteCall, "delete should be called just once")
+	})
+
+	te.Run("with not found webhook", func(t *testing.T) {
+		t.Parallel()
+		req, _ := http.NewRequest("DELETE", webhookBaseUrl+"/1", nil)
+		_, w, router := createWebhookTestObjects(notFound)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+	})
+}
+
+func TestWebhookFindDeadLetters(t *testing.T) {
+	t.Parallel()
+	req, _ := http.NewRequest("GET", webhookBaseUrl+"/dead-letter/10/1", nil)
+	fakeService, w, router := createWebhookTestObjects(found)
+
+	router.ServeHTTP(w, req)
+	var response dto.WebhookDeliveryPageDTO
+	err := json.NewDecoder(w.Body).Decode(&response)
+
+	assert.Empty(t, err)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, 1, response.Page)
+	assert.Equal(t, 1, response.TotalItems)
+	assert.JSONEq(t, `{"event":"gift_card.issued"}`, string(response.Deliveries[0].Payload))
+	assert.Equal(t, 1, fakeService.findDeadLettersCall, "findDeadLetters should be called just once")
+}
+
+func TestWebhookRedeliver(te *testing.T) {
+	te.Parallel()
+	tests := []struct {
+		name     string
+		strategy int
+		code     int
+	}{
+		{"with valid behavior", found, 200},
+		{"with not found delivery", notFound, 404},
+		{"with pending delivery", invalidOperation, 400},
+	}
+	for _, test := range tests {
+		test := test
+		te.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			req, _ := http.NewRequest("POST", webhookBaseUrl+"/redeliver/3", nil)
+			fakeService, w, router := createWebhookTestObjects(test.strategy)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.code, w.Code)
+			assert.Equal(t, 1, fakeService.redeliverCall, "redeliver should be called just once")
+		})
+	}
+}
@@ -2,13 +2,23 @@ package api
 
 import (
 	"giftcard-engine/application/api/handlers"
+	"giftcard-engine/infrastructure/metrics"
+	"giftcard-engine/infrastructure/requestid"
+	"giftcard-engine/infrastructure/timeout"
+	"giftcard-engine/infrastructure/tracing"
 	"github.com/gin-gonic/gin"
-	_ "github.com/jinzhu/gorm/dialects/mssql"
 	"net/http"
 )
 
-func CreateRoute(cardHandler handlers.GiftCardHandler, campaignHandler handlers.CampaignHandler) *gin.Engine {
+func CreateRoute(cardHandler handlers.GiftCardHandler, campaignHandler handlers.CampaignHandler,
+	batchHandler handlers.BatchHandler, searchHandler handlers.SearchHandler,
+	reportHandler handlers.ReportHandler, webhookHandler handlers.WebhookHandler,
+	deadlines *timeout.Deadlines) *gin.Engine {
 	route := gin.Default()
+	route.Use(requestid.Middleware())
+	route.Use(tracing.Middleware())
+	route.Use(metrics.Middleware())
+	route.Use(timeout.Middleware(deadlines))
 	giftCardV1 := route.Group("v1/gift-card")
 	{
 		giftCardV1.GET("/find/:id", cardHandler.FindByID)
@@ -16,6 +26,8 @@ func CreateRoute(cardHandler handlers.GiftCardHandler, campaignHandler handlers.
 		giftCardV1.DELETE("/:id", cardHandler.Delete)
 		giftCardV1.PUT("/", cardHandler.Update)
 		giftCardV1.GET("/page/:size/:number", cardHandler.FindPage)
+		giftCardV1.GET("/cursor/:size", cardHandler.FindCursorPage)
+		giftCardV1.GET("/search/:size/:number", searchHandler.SearchGiftCards)
 		giftCardV1.POST("/create-same-many", cardHandler.CreateSameMany)
 		giftCardV1.POST("/create-many", cardHandler.CreateMany)
 		giftCardV1.GET("/find-by-public-key/:key", cardHandler.FindByPublicKey)
@@ -36,12 +48,45 @@ func CreateRoute(cardHandler handlers.GiftCardHandler, campaignHandler handlers.
 		campaignV1.PUT("/", campaignHandler.Update)
 		campaignV1.DELETE("/:id", campaignHandler.Delete)
 		campaignV1.GET("/page/:size/:number", campaignHandler.FindPage)
+		campaignV1.GET("/cursor/:size", campaignHandler.FindCursorPage)
+		campaignV1.GET("/stats", campaignHandler.Summary)
+		campaignV1.GET("/:id/stats", campaignHandler.Stats)
+	}
+
+	batchV1 := route.Group("v1/batch")
+	{
+		batchV1.GET("/page/:size/:number", batchHandler.FindPage)
+		batchV1.GET("/cards/:id", batchHandler.FindCards)
+		batchV1.PUT("/extend-expiry/:id", batchHandler.ExtendExpiry)
+		batchV1.GET("/export/:id", batchHandler.Export)
+		batchV1.POST("/void/:id", batchHandler.Void)
+	}
+
+	reportV1 := route.Group("v1/report")
+	{
+		reportV1.GET("/gift-card", reportHandler.GiftCardSeries)
+		reportV1.GET("/liability", reportHandler.Liability)
+	}
+
+	webhookV1 := route.Group("v1/webhook")
+	{
+		webhookV1.GET("/", webhookHandler.FindAll)
+		webhookV1.POST("/", webhookHandler.Create)
+		webhookV1.PUT("/", webhookHandler.Update)
+		webhookV1.DELETE("/:id", webhookHandler.Delete)
+		webhookV1.GET("/dead-letter/:size/:number", webhookHandler.FindDeadLetters)
+		webhookV1.POST("/redeliver/:id", webhookHandler.Redeliver)
 	}
 
 	swaggerRedirectHandler := func(c *gin.Context) {
 		c.Redirect(http.StatusMovedPermanently, "/swagger/index.html")
 	}
 	route.GET("/swagger", swaggerRedirectHandler)
+	route.GET("/metrics", gin.WrapH(metrics.Handler()))
+	healthHandler := handlers.NewHealthHandler()
+	route.GET("/health/live", healthHandler.Live)
+	route.GET("/health/ready", healthHandler.Ready)
+	route.GET("/health/startup", healthHandler.Startup)
 	route.GET("/swagg", swaggerRedirectHandler)
 
 	return route
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"context"
+	"giftcard-engine/application/rpc/giftcardpb"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type campaignServer struct {
+	giftcardpb.UnimplementedCampaignServiceServer
+	service core.CampaignService
+}
+
+// NewCampaignServer returns the grpc service of the campaigns which serves them with the service of the REST api
+func NewCampaignServer(service core.CampaignService) giftcardpb.CampaignServiceServer {
+	return &campaignServer{service: service}
+}
+
+func (s *campaignServer) FindPage(ctx context.Context,
+	request *giftcardpb.FindCampaignPageRequest) (*giftcardpb.CampaignPage, error) {
+	size, number := page(request.GetSize(), request.GetPage())
+	campaigns := s.service.FindPage(ctx, size, number, request.GetSearch())
+	return &giftcardpb.CampaignPage{
+		Size:       int32(campaigns.Size),
+		Page:       int32(campaigns.Page),
+		Campaigns:  toCampaigns(campaigns.Campaigns),
+		TotalItems: int64(campaigns.TotalItems),
+	}, nil
+}
+
+func (s *campaignServer) FindCursorPage(ctx context.Context,
+	request *giftcardpb.FindCampaignCursorPageRequest) (*giftcardpb.CampaignCursorPage, error) {
+	campaigns, err := s.service.FindCursorPage(ctx, cursorPageSize(request.GetSize()), request.GetCursor(),
+		request.GetSearch(), !request.GetSkipCount())
+	if err != nil {
+		return nil, Error(err)
+	}
+	return &giftcardpb.CampaignCursorPage{
+		Size:       int32(campaigns.Size),
+		Campaigns:  toCampaigns(campaigns.Campaigns),
+		NextCursor: campaigns.NextCursor,
+		TotalItems: totalItems(campaigns.TotalItems),
+	}, nil
+}
+
+func (s *campaignServer) Create(ctx context.Context,
+	request *giftcardpb.CreateCampaignRequest) (*giftcardpb.Campaign, error) {
+	create := dto.CreateCampaignDTO{Title: request.GetTitle()}
+	if err := create.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+	campaign, err := s.service.Create(ctx, create)
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toCampaign(campaign), nil
+}
+
+func (s *campaignServer) Update(ctx context.Context,
+	request *giftcardpb.UpdateCampaignRequest) (*giftcardpb.Campaign, error) {
+	update := dto.UpdateCampaignDto{ID: int(request.GetId()), Title: request.GetTitle()}
+	if err := update.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+	campaign, err := s.service.Update(ctx, update)
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toCampaign(campaign), nil
+}
+
+func (s *campaignServer) Delete(ctx context.Context, request *giftcardpb.DeleteCampaignRequest) (*emptypb.Empty,
+	error) {
+	if err := s.service.Delete(ctx, uint(request.GetId())); err != nil {
+		return nil, Error(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *campaignServer) Stats(ctx context.Context,
+	request *giftcardpb.CampaignStatsRequest) (*giftcardpb.CampaignStats, error) {
+	stats, err := s.service.Stats(ctx, uint(request.GetId()))
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toCampaignStats(*stats), nil
+}
+
+func (s *campaignServer) Summary(ctx context.Context, _ *emptypb.Empty) (*giftcardpb.CampaignStats, error) {
+	stats, err := s.service.Summary(ctx)
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toCampaignStats(*stats), nil
+}
@@ -0,0 +1,81 @@
+package rpc_test
+
+import (
+	"context"
+	"giftcard-engine/application/rpc/giftcardpb"
+	"giftcard-engine/infrastructure/config/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"testing"
+)
+
+func TestCampaignServer(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		cards, campaigns := createClientsForTest(t, configuration.TimeoutConfiguration{})
+		campaign, _ := issueCardsForTest(t, cards, campaigns, 2)
+		_, err := campaigns.Create(context.Background(), &giftcardpb.CreateCampaignRequest{Title: "nowruz"})
+		require.Nil(t, err)
+
+		updated, err := campaigns.Update(context.Background(),
+			&giftcardpb.UpdateCampaignRequest{Id: campaign.Id, Title: "shab yalda"})
+		require.Nil(t, err)
+		page, err := campaigns.FindPage(context.Background(),
+			&giftcardpb.FindCampaignPageRequest{Size: 10, Page: 1, Search: "yalda"})
+		require.Nil(t, err)
+		cursorPage, err := campaigns.FindCursorPage(context.Background(),
+			&giftcardpb.FindCampaignCursorPageRequest{Size: 1, SkipCount: true})
+		require.Nil(t, err)
+		stats, err := campaigns.Stats(context.Background(), &giftcardpb.CampaignStatsRequest{Id: uint32(campaign.Id)})
+		require.Nil(t, err)
+		summary, err := campaigns.Summary(context.Background(), &emptypb.Empty{})
+		require.Nil(t, err)
+
+		assert.Equal(t, "shab yalda", updated.Title)
+		require.Equal(t, 1, len(page.Campaigns))
+		assert.Equal(t, campaign.Id, page.Campaigns[0].Id)
+		assert.Equal(t, int64(1), page.TotalItems)
+		assert.Equal(t, 1, len(cursorPage.Campaigns))
+		assert.NotEmpty(t, cursorPage.NextCursor)
+		assert.Nil(t, cursorPage.TotalItems)
+		assert.Equal(t, campaign.Id, stats.CampaignId)
+		assert.Equal(t, int64(2), stats.Issued.Count)
+		assert.Equal(t, int64(10000), stats.Outstanding.Amount)
+		assert.Equal(t, int64(2), summary.Issued.Count)
+	})
+
+	te.Run("with delete", func(t *testing.T) {
+		t.Parallel()
+		_, campaigns := createClientsForTest(t, configuration.TimeoutConfiguration{})
+		campaign, err := campaigns.Create(context.Background(), &giftcardpb.CreateCampaignRequest{Title: "yalda"})
+		require.Nil(t, err)
+
+		_, err = campaigns.Delete(context.Background(), &giftcardpb.DeleteCampaignRequest{Id: uint32(campaign.Id)})
+		assert.Nil(t, err)
+		_, err = campaigns.Delete(context.Background(), &giftcardpb.DeleteCampaignRequest{Id: uint32(campaign.Id)})
+		assertCode(t, codes.NotFound, err)
+		_, err = campaigns.Stats(context.Background(), &giftcardpb.CampaignStatsRequest{Id: uint32(campaign.Id)})
+		assertCode(t, codes.NotFound, err)
+	})
+
+	te.Run("with invalid requests", func(t *testing.T) {
+		t.Parallel()
+		_, campaigns := createClientsForTest(t, configuration.TimeoutConfiguration{})
+		_, err := campaigns.Create(context.Background(), &giftcardpb.CreateCampaignRequest{Title: "yalda"})
+		require.Nil(t, err)
+
+		_, duplicated := campaigns.Create(context.Background(), &giftcardpb.CreateCampaignRequest{Title: "yalda"})
+		_, empty := campaigns.Create(context.Background(), &giftcardpb.CreateCampaignRequest{})
+		_, missing := campaigns.Update(context.Background(), &giftcardpb.UpdateCampaignRequest{Id: 12, Title: "nowruz"})
+		_, cursor := campaigns.FindCursorPage(context.Background(),
+			&giftcardpb.FindCampaignCursorPageRequest{Size: 10, Cursor: "yalda"})
+
+		assertCode(t, codes.AlreadyExists, duplicated)
+		assertCode(t, codes.InvalidArgument, empty)
+		assertCode(t, codes.NotFound, missing)
+		assertCode(t, codes.InvalidArgument, cursor)
+	})
+}
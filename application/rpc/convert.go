@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"giftcard-engine/application/rpc/giftcardpb"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/utils/date"
+	"time"
+)
+
+func toGiftCard(card dto.GiftCardDTO) *giftcardpb.GiftCard {
+	return &giftcardpb.GiftCard{
+		Id:            int64(card.ID),
+		PublicCode:    card.PublicCode,
+		SecretCode:    card.SecretCode,
+		Uun:           card.UUN,
+		ExpireDate:    card.ExpireDate,
+		Amount:        card.Amount,
+		IsValid:       card.IsValid,
+		CampaignId:    uint32(card.CampaignId),
+		CampaignTitle: card.CampaignTitle,
+		BatchId:       uint32(card.BatchId),
+		RedeemedAt:    card.RedeemedAt,
+	}
+}
+
+func toGiftCards(cards []dto.GiftCardDTO) []*giftcardpb.GiftCard {
+	result := make([]*giftcardpb.GiftCard, 0, len(cards))
+	for _, card := range cards {
+		result = append(result, toGiftCard(card))
+	}
+	return result
+}
+
+func toGiftCardList(list dto.GiftCardsListDTO) *giftcardpb.GiftCardList {
+	return &giftcardpb.GiftCardList{GiftCards: toGiftCards(list.Cards), BatchId: uint32(list.BatchId)}
+}
+
+func toGiftCardStatus(card dto.GiftCardStatusDTO) *giftcardpb.GiftCardStatus {
+	return &giftcardpb.GiftCardStatus{
+		Id:         int64(card.Id),
+		IsValid:    card.IsValid,
+		Amount:     card.Amount,
+		SecretKey:  card.SecretKey,
+		PublicKey:  card.PublicKey,
+		Uun:        card.UUN,
+		ExpireDate: card.ExpireDate,
+	}
+}
+
+func toGiftCardStatusList(list dto.GiftCardStatusListDTO) *giftcardpb.GiftCardStatusList {
+	statuses := make([]*giftcardpb.GiftCardStatus, 0, len(list.Cards))
+	for _, card := range list.Cards {
+		statuses = append(statuses, toGiftCardStatus(card))
+	}
+	return &giftcardpb.GiftCardStatusList{GiftCardsStatuses: statuses}
+}
+
+func toCampaign(campaign dto.CampaignDTO) *giftcardpb.Campaign {
+	return &giftcardpb.Campaign{Id: int64(campaign.ID), Title: campaign.Title}
+}
+
+func toCampaigns(campaigns []dto.CampaignDTO) []*giftcardpb.Campaign {
+	result := make([]*giftcardpb.Campaign, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		result = append(result, toCampaign(campaign))
+	}
+	return result
+}
+
+func toCampaignStats(stats dto.CampaignStatsDTO) *giftcardpb.CampaignStats {
+	aggregate := func(value dto.CardsAggregateDTO) *giftcardpb.CardsAggregate {
+		return &giftcardpb.CardsAggregate{Count: value.Count, Amount: value.Amount}
+	}
+	return &giftcardpb.CampaignStats{
+		CampaignId:             int64(stats.CampaignId),
+		Issued:                 aggregate(stats.Issued),
+		Redeemed:               aggregate(stats.Redeemed),
+		Expired:                aggregate(stats.Expired),
+		Voided:                 aggregate(stats.Voided),
+		Deleted:                aggregate(stats.Deleted),
+		Outstanding:            aggregate(stats.Outstanding),
+		RedemptionRate:         stats.RedemptionRate,
+		AverageSecondsToRedeem: stats.AverageSecondsToRedeem,
+	}
+}
+
+// totalItems returns the total items of a cursor page, which is nil when the count is skipped
+func totalItems(total *int) *int64 {
+	if total == nil {
+		return nil
+	}
+	result := int64(*total)
+	return &result
+}
+
+func fromStoreRequest(request *giftcardpb.StoreGiftCardRequest) dto.CreateGiftCardDTO {
+	return dto.CreateGiftCardDTO{
+		ExpireDate: request.GetExpireDate(),
+		Amount:     request.GetAmount(),
+		CampaignId: uint(request.GetCampaignId()),
+	}
+}
+
+// fromFilter returns the listing criteria of the filter, which is nil when the cards are not filtered
+func fromFilter(filter *giftcardpb.GiftCardFilter) (core.GiftCardFilter, error) {
+	result := core.GiftCardFilter{
+		Search:        filter.GetSearch(),
+		UUN:           filter.GetUun(),
+		CampaignTitle: filter.GetCampaignTitle(),
+	}
+	if filter == nil {
+		return result, nil
+	}
+	for _, id := range filter.CampaignIds {
+		result.CampaignIds = append(result.CampaignIds, uint(id))
+	}
+	if filter.Status != nil {
+		status := int(*filter.Status)
+		result.Status = &status
+	}
+	result.AmountFrom, result.AmountTo, result.IsValid = filter.AmountFrom, filter.AmountTo, filter.IsValid
+
+	for _, item := range []struct {
+		value string
+		field **time.Time
+	}{
+		{filter.ExpireDateFrom, &result.ExpireDateFrom},
+		{filter.ExpireDateTo, &result.ExpireDateTo},
+		{filter.CreatedFrom, &result.CreatedFrom},
+		{filter.CreatedTo, &result.CreatedTo},
+		{filter.RedeemedFrom, &result.RedeemedFrom},
+		{filter.RedeemedTo, &result.RedeemedTo},
+	} {
+		if item.value == "" {
+			continue
+		}
+		d, err := date.DefaultToTime(item.value)
+		if err != nil {
+			return result, err
+		}
+		*item.field = &d
+	}
+
+	var err error
+	result.Sort, err = core.ParseGiftCardSort(filter.Sort)
+	return result, err
+}
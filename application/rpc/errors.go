@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes are the status codes of the errors of the services. the other errors are internal
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{common.GiftCardNotFound, codes.NotFound},
+	{common.CampaignNotFound, codes.NotFound},
+	{common.NoGiftCardFoundForUser, codes.NotFound},
+	{common.BatchNotFound, codes.NotFound},
+	{common.WebhookNotFound, codes.NotFound},
+	{common.WebhookDeliveryNotFound, codes.NotFound},
+	{common.DuplicatedCampaignTitle, codes.AlreadyExists},
+	{common.GiftCardIsTaken, codes.FailedPrecondition},
+	{common.GiftCardIsNotValid, codes.FailedPrecondition},
+	{common.GiftCardIsVoided, codes.FailedPrecondition},
+	{common.WebhookDeliveryIsNotDead, codes.FailedPrecondition},
+	{common.ExpireDateIsNotInValidRange, codes.InvalidArgument},
+	{common.ExpireDateIsBeforeCurrent, codes.InvalidArgument},
+	{common.InvalidCampaign, codes.InvalidArgument},
+	{common.InvalidCampaignQueryParam, codes.InvalidArgument},
+	{common.InvalidIsValidQueryParam, codes.InvalidArgument},
+	{common.InvalidCursor, codes.InvalidArgument},
+	{common.InvalidStatusQueryParam, codes.InvalidArgument},
+	{common.InvalidAmountQueryParam, codes.InvalidArgument},
+	{common.InvalidSortQueryParam, codes.InvalidArgument},
+	{common.SortIsNotSupportedByCursor, codes.InvalidArgument},
+	{common.EmptySearchQuery, codes.InvalidArgument},
+	{common.InvalidTimezone, codes.InvalidArgument},
+	{common.InvalidReportRange, codes.InvalidArgument},
+	{common.ReportRangeIsTooLong, codes.InvalidArgument},
+	{common.InvalidReportFormat, codes.InvalidArgument},
+	{common.SearchIsNotAvailable, codes.Unavailable},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+	{context.Canceled, codes.Canceled},
+}
+
+// Error returns the error of a service as a status with the code of the error, so the clients can tell the
+// requests which should not be sent again from the failures of the service
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	for _, known := range errorCodes {
+		if errors.Is(err, known.err) {
+			return status.Error(known.code, err.Error())
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// invalidArgument returns the error of a request which is not valid
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// partialError returns the error of a bulk create which may have issued some of the cards. the issued cards and
+// their batch are the details of the status, like the rest api answers them, unless no batch was opened
+func partialError(err error, cards *dto.GiftCardsListDTO) error {
+	converted := Error(err)
+	if cards == nil || cards.BatchId == 0 {
+		return converted
+	}
+	detailed, detailErr := status.Convert(converted).WithDetails(toGiftCardList(*cards))
+	if detailErr != nil {
+		return converted
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,42 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"giftcard-engine/application/rpc"
+	"giftcard-engine/core/common"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{common.GiftCardNotFound, codes.NotFound},
+		{common.NoGiftCardFoundForUser, codes.NotFound},
+		{common.DuplicatedCampaignTitle, codes.AlreadyExists},
+		{common.GiftCardIsTaken, codes.FailedPrecondition},
+		{common.GiftCardIsVoided, codes.FailedPrecondition},
+		{common.InvalidCampaign, codes.InvalidArgument},
+		{fmt.Errorf("reading the page: %w", common.InvalidCursor), codes.InvalidArgument},
+		{common.SearchIsNotAvailable, codes.Unavailable},
+		{fmt.Errorf("finding the card: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{context.Canceled, codes.Canceled},
+		{errors.New("the database is gone"), codes.Internal},
+	}
+	for _, test := range tests {
+		err := rpc.Error(test.err)
+
+		assert.Equal(t, test.code, status.Code(err), test.err.Error())
+		assert.Equal(t, test.err.Error(), status.Convert(err).Message())
+	}
+	denied := status.Error(codes.PermissionDenied, "denied")
+	assert.Equal(t, denied, rpc.Error(denied), "a status should be kept")
+	assert.Nil(t, rpc.Error(nil))
+}
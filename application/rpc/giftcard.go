@@ -0,0 +1,218 @@
+package rpc
+
+import (
+	"context"
+	"giftcard-engine/application/rpc/giftcardpb"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/utils"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"strings"
+)
+
+// maxPageSize is the most items of a page, like the pages of the REST api
+const maxPageSize = 50
+
+type giftCardServer struct {
+	giftcardpb.UnimplementedGiftCardServiceServer
+	service core.GiftCardService
+}
+
+// NewGiftCardServer returns the grpc service of the gift cards which serves them with the service of the REST api
+func NewGiftCardServer(service core.GiftCardService) giftcardpb.GiftCardServiceServer {
+	return &giftCardServer{service: service}
+}
+
+func (s *giftCardServer) FindById(ctx context.Context,
+	request *giftcardpb.FindGiftCardRequest) (*giftcardpb.GiftCard, error) {
+	card, err := s.service.FindByID(ctx, uint(request.GetId()))
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toGiftCard(*card), nil
+}
+
+func (s *giftCardServer) FindByPublicKey(ctx context.Context,
+	request *giftcardpb.FindByPublicKeyRequest) (*giftcardpb.GiftCardStatus, error) {
+	card, err := s.service.FindByPublicKey(ctx, strings.ToUpper(request.GetPublicKey()))
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toGiftCardStatus(*card), nil
+}
+
+func (s *giftCardServer) FindByUun(ctx context.Context,
+	request *giftcardpb.FindByUunRequest) (*giftcardpb.GiftCardList, error) {
+	cards, err := s.service.FindByUUN(ctx, request.GetUun())
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toGiftCardList(*cards), nil
+}
+
+func (s *giftCardServer) FindPage(ctx context.Context,
+	request *giftcardpb.FindGiftCardPageRequest) (*giftcardpb.GiftCardPage, error) {
+	filter, err := fromFilter(request.GetFilter())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	size, number := page(request.GetSize(), request.GetPage())
+	cards := s.service.FindPage(ctx, size, number, filter)
+	return &giftcardpb.GiftCardPage{
+		Size:       int32(cards.Size),
+		Page:       int32(cards.Page),
+		GiftCards:  toGiftCards(cards.GiftCards),
+		TotalItems: int64(cards.TotalItems),
+	}, nil
+}
+
+func (s *giftCardServer) FindCursorPage(ctx context.Context,
+	request *giftcardpb.FindGiftCardCursorPageRequest) (*giftcardpb.GiftCardCursorPage, error) {
+	filter, err := fromFilter(request.GetFilter())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	cards, err := s.service.FindCursorPage(ctx, cursorPageSize(request.GetSize()), request.GetCursor(), filter,
+		!request.GetSkipCount())
+	if err != nil {
+		return nil, Error(err)
+	}
+	return &giftcardpb.GiftCardCursorPage{
+		Size:       int32(cards.Size),
+		GiftCards:  toGiftCards(cards.GiftCards),
+		NextCursor: cards.NextCursor,
+		TotalItems: totalItems(cards.TotalItems),
+	}, nil
+}
+
+func (s *giftCardServer) Store(ctx context.Context,
+	request *giftcardpb.StoreGiftCardRequest) (*giftcardpb.GiftCard, error) {
+	create := fromStoreRequest(request)
+	if err := create.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+	card, err := s.service.Store(ctx, &create)
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toGiftCard(*card), nil
+}
+
+func (s *giftCardServer) Update(ctx context.Context,
+	request *giftcardpb.UpdateGiftCardRequest) (*giftcardpb.GiftCard, error) {
+	update := dto.UpdateGiftCardDto{
+		ID:         int(request.GetId()),
+		ExpireDate: request.GetExpireDate(),
+		Amount:     request.GetAmount(),
+	}
+	if err := update.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+	card, err := s.service.Update(ctx, &update)
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toGiftCard(*card), nil
+}
+
+func (s *giftCardServer) Delete(ctx context.Context, request *giftcardpb.DeleteGiftCardRequest) (*emptypb.Empty,
+	error) {
+	if err := s.service.Delete(ctx, uint(request.GetId())); err != nil {
+		return nil, Error(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *giftCardServer) CreateMany(ctx context.Context,
+	request *giftcardpb.CreateManyRequest) (*giftcardpb.GiftCardList, error) {
+	create := dto.BulkCreateGiftCardsDTO{
+		GiftCards: make([]dto.CreateGiftCardDTO, 0, len(request.GetGiftCards())),
+		CreatedBy: request.GetCreatedBy(),
+	}
+	for _, card := range request.GetGiftCards() {
+		create.GiftCards = append(create.GiftCards, fromStoreRequest(card))
+	}
+	if err := create.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+	cards, err := s.service.CreateMany(ctx, &create)
+	if err != nil {
+		return nil, partialError(err, cards)
+	}
+	return toGiftCardList(*cards), nil
+}
+
+func (s *giftCardServer) CreateSameMany(ctx context.Context,
+	request *giftcardpb.CreateSameManyRequest) (*giftcardpb.GiftCardList, error) {
+	create := dto.BulkCreateSameGiftCardsDTO{
+		ExpireDate: request.GetExpireDate(),
+		Amount:     request.GetAmount(),
+		Count:      int(request.GetCount()),
+		CampaignId: uint(request.GetCampaignId()),
+		CreatedBy:  request.GetCreatedBy(),
+	}
+	if err := create.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+	cards, err := s.service.CreateSameMany(ctx, &create)
+	if err != nil {
+		return nil, partialError(err, cards)
+	}
+	return toGiftCardList(*cards), nil
+}
+
+func (s *giftCardServer) ValidateGiftCard(ctx context.Context,
+	request *giftcardpb.ValidateGiftCardRequest) (*giftcardpb.GiftCardStatus, error) {
+	card, err := s.service.ValidateGiftCard(ctx, request.GetSecret())
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toGiftCardStatus(card), nil
+}
+
+func (s *giftCardServer) ValidateGiftCards(ctx context.Context,
+	request *giftcardpb.ValidateGiftCardsRequest) (*giftcardpb.GiftCardStatusList, error) {
+	validate := dto.ValidateGiftCardsDto{GiftCardsSecret: request.GetGiftCardsSecret()}
+	if err := validate.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+	cards, err := s.service.ValidateGiftCards(ctx, &validate)
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toGiftCardStatusList(*cards), nil
+}
+
+func (s *giftCardServer) ApproveGiftCard(ctx context.Context,
+	request *giftcardpb.ApproveGiftCardRequest) (*giftcardpb.GiftCardStatus, error) {
+	card, err := s.service.ApproveGiftCard(ctx, request.GetUun(), request.GetSecret())
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toGiftCardStatus(card), nil
+}
+
+func (s *giftCardServer) ApproveGiftCards(ctx context.Context,
+	request *giftcardpb.ApproveGiftCardsRequest) (*giftcardpb.GiftCardStatusList, error) {
+	approve := dto.ApproveGiftCardsDTO{UUN: request.GetUun(), GiftCardsSecret: request.GetGiftCardsSecret()}
+	if err := approve.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+	cards, err := s.service.ApproveGiftCards(ctx, &approve)
+	if err != nil {
+		return nil, Error(err)
+	}
+	return toGiftCardStatusList(*cards), nil
+}
+
+// page returns the size and the index of the requested page, whose number starts from 1
+func page(size, number uint32) (uint, uint) {
+	if number == 0 {
+		number = 1
+	}
+	return utils.MinUint(uint(size), maxPageSize), uint(number - 1)
+}
+
+func cursorPageSize(size uint32) uint {
+	return utils.MaxUint(utils.MinUint(uint(size), maxPageSize), 1)
+}
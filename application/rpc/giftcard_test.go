@@ -0,0 +1,194 @@
+package rpc_test
+
+import (
+	"context"
+	"giftcard-engine/application/rpc/giftcardpb"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+	"time"
+)
+
+// issueCardsForTest creates a campaign and issues the same cards for it
+func issueCardsForTest(t *testing.T, cards giftcardpb.GiftCardServiceClient,
+	campaigns giftcardpb.CampaignServiceClient, count int32) (*giftcardpb.Campaign, *giftcardpb.GiftCardList) {
+	campaign, err := campaigns.Create(context.Background(), &giftcardpb.CreateCampaignRequest{Title: "yalda"})
+	require.Nil(t, err)
+	issued, err := cards.CreateSameMany(context.Background(), &giftcardpb.CreateSameManyRequest{
+		ExpireDate: time.Now().AddDate(0, 1, 0).Format("2006-01-02"),
+		Amount:     5000,
+		Count:      count,
+		CampaignId: uint32(campaign.Id),
+		CreatedBy:  "checkout",
+	})
+	require.Nil(t, err)
+	return campaign, issued
+}
+
+func TestGiftCardServer(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		cards, campaigns := createClientsForTest(t, configuration.TimeoutConfiguration{})
+		campaign, issued := issueCardsForTest(t, cards, campaigns, 2)
+		secrets := []string{issued.GiftCards[0].SecretCode, issued.GiftCards[1].SecretCode}
+
+		validated, err := cards.ValidateGiftCards(context.Background(),
+			&giftcardpb.ValidateGiftCardsRequest{GiftCardsSecret: secrets})
+		require.Nil(t, err)
+		approved, err := cards.ApproveGiftCards(context.Background(),
+			&giftcardpb.ApproveGiftCardsRequest{Uun: "milad", GiftCardsSecret: secrets})
+		require.Nil(t, err)
+		found, err := cards.FindByUun(context.Background(), &giftcardpb.FindByUunRequest{Uun: "milad"})
+		require.Nil(t, err)
+
+		assert.NotZero(t, issued.BatchId)
+		assert.Equal(t, uint32(campaign.Id), issued.GiftCards[0].CampaignId)
+		assert.Equal(t, int32(5000), issued.GiftCards[0].Amount)
+		require.Equal(t, 2, len(validated.GiftCardsStatuses))
+		assert.True(t, validated.GiftCardsStatuses[0].IsValid)
+		require.Equal(t, 2, len(approved.GiftCardsStatuses))
+		assert.Equal(t, "milad", approved.GiftCardsStatuses[1].Uun)
+		assert.Equal(t, 2, len(found.GiftCards))
+	})
+
+	te.Run("with a single card", func(t *testing.T) {
+		t.Parallel()
+		cards, campaigns := createClientsForTest(t, configuration.TimeoutConfiguration{})
+		_, issued := issueCardsForTest(t, cards, campaigns, 1)
+		card := issued.GiftCards[0]
+
+		byId, err := cards.FindById(context.Background(), &giftcardpb.FindGiftCardRequest{Id: uint32(card.Id)})
+		require.Nil(t, err)
+		byKey, err := cards.FindByPublicKey(context.Background(),
+			&giftcardpb.FindByPublicKeyRequest{PublicKey: strings.ToLower(card.PublicCode)})
+		require.Nil(t, err)
+		valid, err := cards.ValidateGiftCard(context.Background(),
+			&giftcardpb.ValidateGiftCardRequest{Secret: card.SecretCode})
+		require.Nil(t, err)
+		approved, err := cards.ApproveGiftCard(context.Background(),
+			&giftcardpb.ApproveGiftCardRequest{Uun: "milad", Secret: card.SecretCode})
+		require.Nil(t, err)
+		_, err = cards.ApproveGiftCard(context.Background(),
+			&giftcardpb.ApproveGiftCardRequest{Uun: "dastan", Secret: card.SecretCode})
+
+		assert.Equal(t, card.SecretCode, byId.SecretCode)
+		assert.Equal(t, card.Id, byKey.Id)
+		assert.True(t, valid.IsValid)
+		assert.Equal(t, "milad", approved.Uun)
+		assertCode(t, codes.FailedPrecondition, err)
+	})
+
+	te.Run("with pages", func(t *testing.T) {
+		t.Parallel()
+		cards, campaigns := createClientsForTest(t, configuration.TimeoutConfiguration{})
+		campaign, _ := issueCardsForTest(t, cards, campaigns, 3)
+		filter := &giftcardpb.GiftCardFilter{CampaignIds: []uint32{uint32(campaign.Id)}}
+
+		page, err := cards.FindPage(context.Background(),
+			&giftcardpb.FindGiftCardPageRequest{Size: 2, Filter: filter})
+		require.Nil(t, err)
+		first, err := cards.FindCursorPage(context.Background(),
+			&giftcardpb.FindGiftCardCursorPageRequest{Size: 2, Filter: filter})
+		require.Nil(t, err)
+		second, err := cards.FindCursorPage(context.Background(),
+			&giftcardpb.FindGiftCardCursorPageRequest{Size: 2, Cursor: first.NextCursor, SkipCount: true})
+		require.Nil(t, err)
+
+		assert.Equal(t, int32(1), page.Page)
+		assert.Equal(t, 2, len(page.GiftCards))
+		assert.Equal(t, int64(3), page.TotalItems)
+		assert.Equal(t, 2, len(first.GiftCards))
+		require.NotNil(t, first.TotalItems)
+		assert.Equal(t, int64(3), *first.TotalItems)
+		assert.Equal(t, 1, len(second.GiftCards))
+		assert.Nil(t, second.TotalItems)
+	})
+
+	te.Run("with invalid requests", func(t *testing.T) {
+		t.Parallel()
+		cards, campaigns := createClientsForTest(t, configuration.TimeoutConfiguration{})
+		campaign, _ := issueCardsForTest(t, cards, campaigns, 1)
+
+		_, invalidCount := cards.CreateSameMany(context.Background(), &giftcardpb.CreateSameManyRequest{
+			ExpireDate: time.Now().AddDate(0, 1, 0).Format("2006-01-02"),
+			Amount:     5000,
+			CampaignId: uint32(campaign.Id),
+		})
+		_, invalidCampaign := cards.Store(context.Background(), &giftcardpb.StoreGiftCardRequest{
+			ExpireDate: time.Now().AddDate(0, 1, 0).Format("2006-01-02"),
+			Amount:     5000,
+			CampaignId: 12,
+		})
+		_, invalidDate := cards.FindPage(context.Background(), &giftcardpb.FindGiftCardPageRequest{
+			Size:   10,
+			Filter: &giftcardpb.GiftCardFilter{CreatedFrom: "yesterday"},
+		})
+		_, sortedCursor := cards.FindCursorPage(context.Background(), &giftcardpb.FindGiftCardCursorPageRequest{
+			Size:   10,
+			Filter: &giftcardpb.GiftCardFilter{Sort: "-amount"},
+		})
+		_, missing := cards.FindById(context.Background(), &giftcardpb.FindGiftCardRequest{Id: 12})
+
+		assertCode(t, codes.InvalidArgument, invalidCount)
+		assertCode(t, codes.InvalidArgument, invalidCampaign)
+		assertCode(t, codes.InvalidArgument, invalidDate)
+		assertCode(t, codes.InvalidArgument, sortedCursor)
+		assertCode(t, codes.NotFound, missing)
+	})
+
+	te.Run("with partially failed batch", func(t *testing.T) {
+		t.Parallel()
+		cards, campaigns := createClientsForTest(t, configuration.TimeoutConfiguration{})
+		campaign, _ := issueCardsForTest(t, cards, campaigns, 1)
+		expireDate := time.Now().AddDate(0, 1, 0).Format("2006-01-02")
+
+		_, err := cards.CreateMany(context.Background(), &giftcardpb.CreateManyRequest{
+			GiftCards: []*giftcardpb.StoreGiftCardRequest{
+				{ExpireDate: expireDate, Amount: 5000, CampaignId: uint32(campaign.Id)},
+				{ExpireDate: expireDate, Amount: 5000, CampaignId: 12},
+			},
+			CreatedBy: "checkout",
+		})
+
+		assertCode(t, codes.InvalidArgument, err)
+		details := status.Convert(err).Details()
+		require.Equal(t, 1, len(details))
+		issued, ok := details[0].(*giftcardpb.GiftCardList)
+		require.True(t, ok)
+		assert.NotZero(t, issued.BatchId)
+		require.Equal(t, 1, len(issued.GiftCards))
+		assert.Equal(t, uint32(campaign.Id), issued.GiftCards[0].CampaignId)
+	})
+
+	te.Run("with request id", func(t *testing.T) {
+		t.Parallel()
+		cards, _ := createClientsForTest(t, configuration.TimeoutConfiguration{})
+		ctx := metadata.AppendToOutgoingContext(context.Background(), requestid.Header, "checkout-12")
+		var header metadata.MD
+
+		_, err := cards.FindPage(ctx, &giftcardpb.FindGiftCardPageRequest{Size: 10}, grpc.Header(&header))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"checkout-12"}, header.Get(requestid.Header))
+	})
+
+	te.Run("with route timeout", func(t *testing.T) {
+		t.Parallel()
+		cards, _ := createClientsForTest(t, configuration.TimeoutConfiguration{
+			Default: time.Minute,
+			Routes:  map[string]time.Duration{"POST /giftcard.v1.GiftCardService/FindById": time.Nanosecond},
+		})
+
+		_, err := cards.FindById(context.Background(), &giftcardpb.FindGiftCardRequest{Id: 1})
+
+		assertCode(t, codes.DeadlineExceeded, err)
+	})
+}
@@ -0,0 +1,3047 @@
+// The gRPC api of the gift card engine. It serves the same services as the REST api, so the dates are written
+// like 2006-01-02, the pages are numbered from 1 and the page sizes are at most 50 on both of them.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: giftcard.proto
+
+package giftcardpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GiftCard struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PublicCode    string `protobuf:"bytes,2,opt,name=public_code,json=publicCode,proto3" json:"public_code,omitempty"`
+	SecretCode    string `protobuf:"bytes,3,opt,name=secret_code,json=secretCode,proto3" json:"secret_code,omitempty"`
+	Uun           string `protobuf:"bytes,4,opt,name=uun,proto3" json:"uun,omitempty"`
+	ExpireDate    string `protobuf:"bytes,5,opt,name=expire_date,json=expireDate,proto3" json:"expire_date,omitempty"`
+	Amount        int32  `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	IsValid       bool   `protobuf:"varint,7,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
+	CampaignId    uint32 `protobuf:"varint,8,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	CampaignTitle string `protobuf:"bytes,9,opt,name=campaign_title,json=campaignTitle,proto3" json:"campaign_title,omitempty"`
+	BatchId       uint32 `protobuf:"varint,10,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	RedeemedAt    string `protobuf:"bytes,11,opt,name=redeemed_at,json=redeemedAt,proto3" json:"redeemed_at,omitempty"`
+}
+
+func (x *GiftCard) Reset() {
+	*x = GiftCard{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GiftCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiftCard) ProtoMessage() {}
+
+func (x *GiftCard) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiftCard.ProtoReflect.Descriptor instead.
+func (*GiftCard) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GiftCard) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GiftCard) GetPublicCode() string {
+	if x != nil {
+		return x.PublicCode
+	}
+	return ""
+}
+
+func (x *GiftCard) GetSecretCode() string {
+	if x != nil {
+		return x.SecretCode
+	}
+	return ""
+}
+
+func (x *GiftCard) GetUun() string {
+	if x != nil {
+		return x.Uun
+	}
+	return ""
+}
+
+func (x *GiftCard) GetExpireDate() string {
+	if x != nil {
+		return x.ExpireDate
+	}
+	return ""
+}
+
+func (x *GiftCard) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *GiftCard) GetIsValid() bool {
+	if x != nil {
+		return x.IsValid
+	}
+	return false
+}
+
+func (x *GiftCard) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *GiftCard) GetCampaignTitle() string {
+	if x != nil {
+		return x.CampaignTitle
+	}
+	return ""
+}
+
+func (x *GiftCard) GetBatchId() uint32 {
+	if x != nil {
+		return x.BatchId
+	}
+	return 0
+}
+
+func (x *GiftCard) GetRedeemedAt() string {
+	if x != nil {
+		return x.RedeemedAt
+	}
+	return ""
+}
+
+type GiftCardStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	IsValid    bool   `protobuf:"varint,2,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
+	Amount     int32  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	SecretKey  string `protobuf:"bytes,4,opt,name=secret_key,json=secretKey,proto3" json:"secret_key,omitempty"`
+	PublicKey  string `protobuf:"bytes,5,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Uun        string `protobuf:"bytes,6,opt,name=uun,proto3" json:"uun,omitempty"`
+	ExpireDate string `protobuf:"bytes,7,opt,name=expire_date,json=expireDate,proto3" json:"expire_date,omitempty"`
+}
+
+func (x *GiftCardStatus) Reset() {
+	*x = GiftCardStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GiftCardStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiftCardStatus) ProtoMessage() {}
+
+func (x *GiftCardStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiftCardStatus.ProtoReflect.Descriptor instead.
+func (*GiftCardStatus) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GiftCardStatus) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GiftCardStatus) GetIsValid() bool {
+	if x != nil {
+		return x.IsValid
+	}
+	return false
+}
+
+func (x *GiftCardStatus) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *GiftCardStatus) GetSecretKey() string {
+	if x != nil {
+		return x.SecretKey
+	}
+	return ""
+}
+
+func (x *GiftCardStatus) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *GiftCardStatus) GetUun() string {
+	if x != nil {
+		return x.Uun
+	}
+	return ""
+}
+
+func (x *GiftCardStatus) GetExpireDate() string {
+	if x != nil {
+		return x.ExpireDate
+	}
+	return ""
+}
+
+type GiftCardList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GiftCards []*GiftCard `protobuf:"bytes,1,rep,name=gift_cards,json=giftCards,proto3" json:"gift_cards,omitempty"`
+	// batch_id is the batch of the issued cards
+	BatchId uint32 `protobuf:"varint,2,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+}
+
+func (x *GiftCardList) Reset() {
+	*x = GiftCardList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GiftCardList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiftCardList) ProtoMessage() {}
+
+func (x *GiftCardList) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiftCardList.ProtoReflect.Descriptor instead.
+func (*GiftCardList) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GiftCardList) GetGiftCards() []*GiftCard {
+	if x != nil {
+		return x.GiftCards
+	}
+	return nil
+}
+
+func (x *GiftCardList) GetBatchId() uint32 {
+	if x != nil {
+		return x.BatchId
+	}
+	return 0
+}
+
+type GiftCardStatusList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GiftCardsStatuses []*GiftCardStatus `protobuf:"bytes,1,rep,name=gift_cards_statuses,json=giftCardsStatuses,proto3" json:"gift_cards_statuses,omitempty"`
+}
+
+func (x *GiftCardStatusList) Reset() {
+	*x = GiftCardStatusList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GiftCardStatusList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiftCardStatusList) ProtoMessage() {}
+
+func (x *GiftCardStatusList) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiftCardStatusList.ProtoReflect.Descriptor instead.
+func (*GiftCardStatusList) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GiftCardStatusList) GetGiftCardsStatuses() []*GiftCardStatus {
+	if x != nil {
+		return x.GiftCardsStatuses
+	}
+	return nil
+}
+
+type GiftCardPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size       int32       `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Page       int32       `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	GiftCards  []*GiftCard `protobuf:"bytes,3,rep,name=gift_cards,json=giftCards,proto3" json:"gift_cards,omitempty"`
+	TotalItems int64       `protobuf:"varint,4,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
+}
+
+func (x *GiftCardPage) Reset() {
+	*x = GiftCardPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GiftCardPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiftCardPage) ProtoMessage() {}
+
+func (x *GiftCardPage) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiftCardPage.ProtoReflect.Descriptor instead.
+func (*GiftCardPage) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GiftCardPage) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GiftCardPage) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GiftCardPage) GetGiftCards() []*GiftCard {
+	if x != nil {
+		return x.GiftCards
+	}
+	return nil
+}
+
+func (x *GiftCardPage) GetTotalItems() int64 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+type GiftCardCursorPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size       int32       `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	GiftCards  []*GiftCard `protobuf:"bytes,2,rep,name=gift_cards,json=giftCards,proto3" json:"gift_cards,omitempty"`
+	NextCursor string      `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// total_items is missing when the count is skipped
+	TotalItems *int64 `protobuf:"varint,4,opt,name=total_items,json=totalItems,proto3,oneof" json:"total_items,omitempty"`
+}
+
+func (x *GiftCardCursorPage) Reset() {
+	*x = GiftCardCursorPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GiftCardCursorPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiftCardCursorPage) ProtoMessage() {}
+
+func (x *GiftCardCursorPage) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiftCardCursorPage.ProtoReflect.Descriptor instead.
+func (*GiftCardCursorPage) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GiftCardCursorPage) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GiftCardCursorPage) GetGiftCards() []*GiftCard {
+	if x != nil {
+		return x.GiftCards
+	}
+	return nil
+}
+
+func (x *GiftCardCursorPage) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GiftCardCursorPage) GetTotalItems() int64 {
+	if x != nil && x.TotalItems != nil {
+		return *x.TotalItems
+	}
+	return 0
+}
+
+// GiftCardFilter is the criteria of the listed cards. the empty fields are not filtered
+type GiftCardFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// search is searched in the public codes
+	Search      string   `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	Uun         string   `protobuf:"bytes,2,opt,name=uun,proto3" json:"uun,omitempty"`
+	Status      *int32   `protobuf:"varint,3,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	AmountFrom  *int32   `protobuf:"varint,4,opt,name=amount_from,json=amountFrom,proto3,oneof" json:"amount_from,omitempty"`
+	AmountTo    *int32   `protobuf:"varint,5,opt,name=amount_to,json=amountTo,proto3,oneof" json:"amount_to,omitempty"`
+	CampaignIds []uint32 `protobuf:"varint,6,rep,packed,name=campaign_ids,json=campaignIds,proto3" json:"campaign_ids,omitempty"`
+	// campaign_title is searched in the titles of the campaigns
+	CampaignTitle  string `protobuf:"bytes,7,opt,name=campaign_title,json=campaignTitle,proto3" json:"campaign_title,omitempty"`
+	IsValid        *bool  `protobuf:"varint,8,opt,name=is_valid,json=isValid,proto3,oneof" json:"is_valid,omitempty"`
+	ExpireDateFrom string `protobuf:"bytes,9,opt,name=expire_date_from,json=expireDateFrom,proto3" json:"expire_date_from,omitempty"`
+	ExpireDateTo   string `protobuf:"bytes,10,opt,name=expire_date_to,json=expireDateTo,proto3" json:"expire_date_to,omitempty"`
+	CreatedFrom    string `protobuf:"bytes,11,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo      string `protobuf:"bytes,12,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	RedeemedFrom   string `protobuf:"bytes,13,opt,name=redeemed_from,json=redeemedFrom,proto3" json:"redeemed_from,omitempty"`
+	RedeemedTo     string `protobuf:"bytes,14,opt,name=redeemed_to,json=redeemedTo,proto3" json:"redeemed_to,omitempty"`
+	// sort is a comma separated list of id, amount, status, expire_date, created_at and redeemed_at. a leading minus
+	// sorts a column descending. the cursor pages cannot be sorted
+	Sort string `protobuf:"bytes,15,opt,name=sort,proto3" json:"sort,omitempty"`
+}
+
+func (x *GiftCardFilter) Reset() {
+	*x = GiftCardFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GiftCardFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiftCardFilter) ProtoMessage() {}
+
+func (x *GiftCardFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiftCardFilter.ProtoReflect.Descriptor instead.
+func (*GiftCardFilter) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GiftCardFilter) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *GiftCardFilter) GetUun() string {
+	if x != nil {
+		return x.Uun
+	}
+	return ""
+}
+
+func (x *GiftCardFilter) GetStatus() int32 {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return 0
+}
+
+func (x *GiftCardFilter) GetAmountFrom() int32 {
+	if x != nil && x.AmountFrom != nil {
+		return *x.AmountFrom
+	}
+	return 0
+}
+
+func (x *GiftCardFilter) GetAmountTo() int32 {
+	if x != nil && x.AmountTo != nil {
+		return *x.AmountTo
+	}
+	return 0
+}
+
+func (x *GiftCardFilter) GetCampaignIds() []uint32 {
+	if x != nil {
+		return x.CampaignIds
+	}
+	return nil
+}
+
+func (x *GiftCardFilter) GetCampaignTitle() string {
+	if x != nil {
+		return x.CampaignTitle
+	}
+	return ""
+}
+
+func (x *GiftCardFilter) GetIsValid() bool {
+	if x != nil && x.IsValid != nil {
+		return *x.IsValid
+	}
+	return false
+}
+
+func (x *GiftCardFilter) GetExpireDateFrom() string {
+	if x != nil {
+		return x.ExpireDateFrom
+	}
+	return ""
+}
+
+func (x *GiftCardFilter) GetExpireDateTo() string {
+	if x != nil {
+		return x.ExpireDateTo
+	}
+	return ""
+}
+
+func (x *GiftCardFilter) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *GiftCardFilter) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *GiftCardFilter) GetRedeemedFrom() string {
+	if x != nil {
+		return x.RedeemedFrom
+	}
+	return ""
+}
+
+func (x *GiftCardFilter) GetRedeemedTo() string {
+	if x != nil {
+		return x.RedeemedTo
+	}
+	return ""
+}
+
+func (x *GiftCardFilter) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type FindGiftCardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *FindGiftCardRequest) Reset() {
+	*x = FindGiftCardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindGiftCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindGiftCardRequest) ProtoMessage() {}
+
+func (x *FindGiftCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindGiftCardRequest.ProtoReflect.Descriptor instead.
+func (*FindGiftCardRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FindGiftCardRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type FindByPublicKeyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey string `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+}
+
+func (x *FindByPublicKeyRequest) Reset() {
+	*x = FindByPublicKeyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindByPublicKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindByPublicKeyRequest) ProtoMessage() {}
+
+func (x *FindByPublicKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindByPublicKeyRequest.ProtoReflect.Descriptor instead.
+func (*FindByPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FindByPublicKeyRequest) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+type FindByUunRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uun string `protobuf:"bytes,1,opt,name=uun,proto3" json:"uun,omitempty"`
+}
+
+func (x *FindByUunRequest) Reset() {
+	*x = FindByUunRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindByUunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindByUunRequest) ProtoMessage() {}
+
+func (x *FindByUunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindByUunRequest.ProtoReflect.Descriptor instead.
+func (*FindByUunRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FindByUunRequest) GetUun() string {
+	if x != nil {
+		return x.Uun
+	}
+	return ""
+}
+
+type FindGiftCardPageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size   uint32          `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Page   uint32          `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Filter *GiftCardFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *FindGiftCardPageRequest) Reset() {
+	*x = FindGiftCardPageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindGiftCardPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindGiftCardPageRequest) ProtoMessage() {}
+
+func (x *FindGiftCardPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindGiftCardPageRequest.ProtoReflect.Descriptor instead.
+func (*FindGiftCardPageRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FindGiftCardPageRequest) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FindGiftCardPageRequest) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *FindGiftCardPageRequest) GetFilter() *GiftCardFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type FindGiftCardCursorPageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size   uint32          `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Cursor string          `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Filter *GiftCardFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// skip_count leaves the total items out, which is faster
+	SkipCount bool `protobuf:"varint,4,opt,name=skip_count,json=skipCount,proto3" json:"skip_count,omitempty"`
+}
+
+func (x *FindGiftCardCursorPageRequest) Reset() {
+	*x = FindGiftCardCursorPageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindGiftCardCursorPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindGiftCardCursorPageRequest) ProtoMessage() {}
+
+func (x *FindGiftCardCursorPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindGiftCardCursorPageRequest.ProtoReflect.Descriptor instead.
+func (*FindGiftCardCursorPageRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FindGiftCardCursorPageRequest) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FindGiftCardCursorPageRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *FindGiftCardCursorPageRequest) GetFilter() *GiftCardFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *FindGiftCardCursorPageRequest) GetSkipCount() bool {
+	if x != nil {
+		return x.SkipCount
+	}
+	return false
+}
+
+type StoreGiftCardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExpireDate string `protobuf:"bytes,1,opt,name=expire_date,json=expireDate,proto3" json:"expire_date,omitempty"`
+	Amount     int32  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	CampaignId uint32 `protobuf:"varint,3,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+}
+
+func (x *StoreGiftCardRequest) Reset() {
+	*x = StoreGiftCardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StoreGiftCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreGiftCardRequest) ProtoMessage() {}
+
+func (x *StoreGiftCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreGiftCardRequest.ProtoReflect.Descriptor instead.
+func (*StoreGiftCardRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StoreGiftCardRequest) GetExpireDate() string {
+	if x != nil {
+		return x.ExpireDate
+	}
+	return ""
+}
+
+func (x *StoreGiftCardRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *StoreGiftCardRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+type UpdateGiftCardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpireDate string `protobuf:"bytes,2,opt,name=expire_date,json=expireDate,proto3" json:"expire_date,omitempty"`
+	Amount     int32  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *UpdateGiftCardRequest) Reset() {
+	*x = UpdateGiftCardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateGiftCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGiftCardRequest) ProtoMessage() {}
+
+func (x *UpdateGiftCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGiftCardRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGiftCardRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateGiftCardRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateGiftCardRequest) GetExpireDate() string {
+	if x != nil {
+		return x.ExpireDate
+	}
+	return ""
+}
+
+func (x *UpdateGiftCardRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type DeleteGiftCardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteGiftCardRequest) Reset() {
+	*x = DeleteGiftCardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteGiftCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGiftCardRequest) ProtoMessage() {}
+
+func (x *DeleteGiftCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGiftCardRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGiftCardRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteGiftCardRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateManyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GiftCards []*StoreGiftCardRequest `protobuf:"bytes,1,rep,name=gift_cards,json=giftCards,proto3" json:"gift_cards,omitempty"`
+	CreatedBy string                  `protobuf:"bytes,2,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+}
+
+func (x *CreateManyRequest) Reset() {
+	*x = CreateManyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateManyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateManyRequest) ProtoMessage() {}
+
+func (x *CreateManyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateManyRequest.ProtoReflect.Descriptor instead.
+func (*CreateManyRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateManyRequest) GetGiftCards() []*StoreGiftCardRequest {
+	if x != nil {
+		return x.GiftCards
+	}
+	return nil
+}
+
+func (x *CreateManyRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+type CreateSameManyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExpireDate string `protobuf:"bytes,1,opt,name=expire_date,json=expireDate,proto3" json:"expire_date,omitempty"`
+	Amount     int32  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Count      int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	CampaignId uint32 `protobuf:"varint,4,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	CreatedBy  string `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+}
+
+func (x *CreateSameManyRequest) Reset() {
+	*x = CreateSameManyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSameManyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSameManyRequest) ProtoMessage() {}
+
+func (x *CreateSameManyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSameManyRequest.ProtoReflect.Descriptor instead.
+func (*CreateSameManyRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateSameManyRequest) GetExpireDate() string {
+	if x != nil {
+		return x.ExpireDate
+	}
+	return ""
+}
+
+func (x *CreateSameManyRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateSameManyRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CreateSameManyRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CreateSameManyRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+type ValidateGiftCardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Secret string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+}
+
+func (x *ValidateGiftCardRequest) Reset() {
+	*x = ValidateGiftCardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateGiftCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateGiftCardRequest) ProtoMessage() {}
+
+func (x *ValidateGiftCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateGiftCardRequest.ProtoReflect.Descriptor instead.
+func (*ValidateGiftCardRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ValidateGiftCardRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ValidateGiftCardsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GiftCardsSecret []string `protobuf:"bytes,1,rep,name=gift_cards_secret,json=giftCardsSecret,proto3" json:"gift_cards_secret,omitempty"`
+}
+
+func (x *ValidateGiftCardsRequest) Reset() {
+	*x = ValidateGiftCardsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateGiftCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateGiftCardsRequest) ProtoMessage() {}
+
+func (x *ValidateGiftCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateGiftCardsRequest.ProtoReflect.Descriptor instead.
+func (*ValidateGiftCardsRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ValidateGiftCardsRequest) GetGiftCardsSecret() []string {
+	if x != nil {
+		return x.GiftCardsSecret
+	}
+	return nil
+}
+
+type ApproveGiftCardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uun    string `protobuf:"bytes,1,opt,name=uun,proto3" json:"uun,omitempty"`
+	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+}
+
+func (x *ApproveGiftCardRequest) Reset() {
+	*x = ApproveGiftCardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveGiftCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveGiftCardRequest) ProtoMessage() {}
+
+func (x *ApproveGiftCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveGiftCardRequest.ProtoReflect.Descriptor instead.
+func (*ApproveGiftCardRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ApproveGiftCardRequest) GetUun() string {
+	if x != nil {
+		return x.Uun
+	}
+	return ""
+}
+
+func (x *ApproveGiftCardRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ApproveGiftCardsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uun             string   `protobuf:"bytes,1,opt,name=uun,proto3" json:"uun,omitempty"`
+	GiftCardsSecret []string `protobuf:"bytes,2,rep,name=gift_cards_secret,json=giftCardsSecret,proto3" json:"gift_cards_secret,omitempty"`
+}
+
+func (x *ApproveGiftCardsRequest) Reset() {
+	*x = ApproveGiftCardsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveGiftCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveGiftCardsRequest) ProtoMessage() {}
+
+func (x *ApproveGiftCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveGiftCardsRequest.ProtoReflect.Descriptor instead.
+func (*ApproveGiftCardsRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ApproveGiftCardsRequest) GetUun() string {
+	if x != nil {
+		return x.Uun
+	}
+	return ""
+}
+
+func (x *ApproveGiftCardsRequest) GetGiftCardsSecret() []string {
+	if x != nil {
+		return x.GiftCardsSecret
+	}
+	return nil
+}
+
+type Campaign struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+}
+
+func (x *Campaign) Reset() {
+	*x = Campaign{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Campaign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Campaign) ProtoMessage() {}
+
+func (x *Campaign) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Campaign.ProtoReflect.Descriptor instead.
+func (*Campaign) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Campaign) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Campaign) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type CampaignPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size       int32       `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Page       int32       `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Campaigns  []*Campaign `protobuf:"bytes,3,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
+	TotalItems int64       `protobuf:"varint,4,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
+}
+
+func (x *CampaignPage) Reset() {
+	*x = CampaignPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignPage) ProtoMessage() {}
+
+func (x *CampaignPage) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignPage.ProtoReflect.Descriptor instead.
+func (*CampaignPage) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CampaignPage) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CampaignPage) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *CampaignPage) GetCampaigns() []*Campaign {
+	if x != nil {
+		return x.Campaigns
+	}
+	return nil
+}
+
+func (x *CampaignPage) GetTotalItems() int64 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+type CampaignCursorPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size       int32       `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Campaigns  []*Campaign `protobuf:"bytes,2,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
+	NextCursor string      `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// total_items is missing when the count is skipped
+	TotalItems *int64 `protobuf:"varint,4,opt,name=total_items,json=totalItems,proto3,oneof" json:"total_items,omitempty"`
+}
+
+func (x *CampaignCursorPage) Reset() {
+	*x = CampaignCursorPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignCursorPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignCursorPage) ProtoMessage() {}
+
+func (x *CampaignCursorPage) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignCursorPage.ProtoReflect.Descriptor instead.
+func (*CampaignCursorPage) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CampaignCursorPage) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CampaignCursorPage) GetCampaigns() []*Campaign {
+	if x != nil {
+		return x.Campaigns
+	}
+	return nil
+}
+
+func (x *CampaignCursorPage) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *CampaignCursorPage) GetTotalItems() int64 {
+	if x != nil && x.TotalItems != nil {
+		return *x.TotalItems
+	}
+	return 0
+}
+
+type FindCampaignPageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size uint32 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Page uint32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// search is searched in the titles
+	Search string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+}
+
+func (x *FindCampaignPageRequest) Reset() {
+	*x = FindCampaignPageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindCampaignPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindCampaignPageRequest) ProtoMessage() {}
+
+func (x *FindCampaignPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindCampaignPageRequest.ProtoReflect.Descriptor instead.
+func (*FindCampaignPageRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *FindCampaignPageRequest) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FindCampaignPageRequest) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *FindCampaignPageRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+type FindCampaignCursorPageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size   uint32 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// search is searched in the titles
+	Search string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	// skip_count leaves the total items out, which is faster
+	SkipCount bool `protobuf:"varint,4,opt,name=skip_count,json=skipCount,proto3" json:"skip_count,omitempty"`
+}
+
+func (x *FindCampaignCursorPageRequest) Reset() {
+	*x = FindCampaignCursorPageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindCampaignCursorPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindCampaignCursorPageRequest) ProtoMessage() {}
+
+func (x *FindCampaignCursorPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindCampaignCursorPageRequest.ProtoReflect.Descriptor instead.
+func (*FindCampaignCursorPageRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FindCampaignCursorPageRequest) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FindCampaignCursorPageRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *FindCampaignCursorPageRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *FindCampaignCursorPageRequest) GetSkipCount() bool {
+	if x != nil {
+		return x.SkipCount
+	}
+	return false
+}
+
+type CreateCampaignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+}
+
+func (x *CreateCampaignRequest) Reset() {
+	*x = CreateCampaignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCampaignRequest) ProtoMessage() {}
+
+func (x *CreateCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCampaignRequest.ProtoReflect.Descriptor instead.
+func (*CreateCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateCampaignRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type UpdateCampaignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+}
+
+func (x *UpdateCampaignRequest) Reset() {
+	*x = UpdateCampaignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCampaignRequest) ProtoMessage() {}
+
+func (x *UpdateCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCampaignRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateCampaignRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCampaignRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type DeleteCampaignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteCampaignRequest) Reset() {
+	*x = DeleteCampaignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCampaignRequest) ProtoMessage() {}
+
+func (x *DeleteCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCampaignRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteCampaignRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CampaignStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CampaignStatsRequest) Reset() {
+	*x = CampaignStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignStatsRequest) ProtoMessage() {}
+
+func (x *CampaignStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignStatsRequest.ProtoReflect.Descriptor instead.
+func (*CampaignStatsRequest) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CampaignStatsRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CardsAggregate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count  int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Amount int64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *CardsAggregate) Reset() {
+	*x = CardsAggregate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CardsAggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardsAggregate) ProtoMessage() {}
+
+func (x *CardsAggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardsAggregate.ProtoReflect.Descriptor instead.
+func (*CardsAggregate) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CardsAggregate) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CardsAggregate) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type CampaignStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// campaign_id is zero for the summary of every campaign
+	CampaignId             int64           `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Issued                 *CardsAggregate `protobuf:"bytes,2,opt,name=issued,proto3" json:"issued,omitempty"`
+	Redeemed               *CardsAggregate `protobuf:"bytes,3,opt,name=redeemed,proto3" json:"redeemed,omitempty"`
+	Expired                *CardsAggregate `protobuf:"bytes,4,opt,name=expired,proto3" json:"expired,omitempty"`
+	Voided                 *CardsAggregate `protobuf:"bytes,5,opt,name=voided,proto3" json:"voided,omitempty"`
+	Deleted                *CardsAggregate `protobuf:"bytes,6,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Outstanding            *CardsAggregate `protobuf:"bytes,7,opt,name=outstanding,proto3" json:"outstanding,omitempty"`
+	RedemptionRate         float64         `protobuf:"fixed64,8,opt,name=redemption_rate,json=redemptionRate,proto3" json:"redemption_rate,omitempty"`
+	AverageSecondsToRedeem float64         `protobuf:"fixed64,9,opt,name=average_seconds_to_redeem,json=averageSecondsToRedeem,proto3" json:"average_seconds_to_redeem,omitempty"`
+}
+
+func (x *CampaignStats) Reset() {
+	*x = CampaignStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_giftcard_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CampaignStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignStats) ProtoMessage() {}
+
+func (x *CampaignStats) ProtoReflect() protoreflect.Message {
+	mi := &file_giftcard_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignStats.ProtoReflect.Descriptor instead.
+func (*CampaignStats) Descriptor() ([]byte, []int) {
+	return file_giftcard_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CampaignStats) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignStats) GetIssued() *CardsAggregate {
+	if x != nil {
+		return x.Issued
+	}
+	return nil
+}
+
+func (x *CampaignStats) GetRedeemed() *CardsAggregate {
+	if x != nil {
+		return x.Redeemed
+	}
+	return nil
+}
+
+func (x *CampaignStats) GetExpired() *CardsAggregate {
+	if x != nil {
+		return x.Expired
+	}
+	return nil
+}
+
+func (x *CampaignStats) GetVoided() *CardsAggregate {
+	if x != nil {
+		return x.Voided
+	}
+	return nil
+}
+
+func (x *CampaignStats) GetDeleted() *CardsAggregate {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+func (x *CampaignStats) GetOutstanding() *CardsAggregate {
+	if x != nil {
+		return x.Outstanding
+	}
+	return nil
+}
+
+func (x *CampaignStats) GetRedemptionRate() float64 {
+	if x != nil {
+		return x.RedemptionRate
+	}
+	return 0
+}
+
+func (x *CampaignStats) GetAverageSecondsToRedeem() float64 {
+	if x != nil {
+		return x.AverageSecondsToRedeem
+	}
+	return 0
+}
+
+var File_giftcard_proto protoreflect.FileDescriptor
+
+var file_giftcard_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0b, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65,
+	0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc6, 0x02, 0x0a, 0x08, 0x47,
+	0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x75, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x75, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x44, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x49, 0x64,
+	0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69,
+	0x67, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65,
+	0x64, 0x41, 0x74, 0x22, 0xc4, 0x01, 0x0a, 0x0e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x75, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x75, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x44, 0x61, 0x74, 0x65, 0x22, 0x5f, 0x0a, 0x0c, 0x47, 0x69,
+	0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x0a, 0x67, 0x69,
+	0x66, 0x74, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66,
+	0x74, 0x43, 0x61, 0x72, 0x64, 0x52, 0x09, 0x67, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73,
+	0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x22, 0x61, 0x0a, 0x12, 0x47,
+	0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x4b, 0x0a, 0x13, 0x67, 0x69, 0x66, 0x74, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x73, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66,
+	0x74, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x11, 0x67, 0x69, 0x66,
+	0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x22, 0x8d,
+	0x01, 0x0a, 0x0c, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x50, 0x61, 0x67, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x67, 0x69, 0x66, 0x74, 0x5f,
+	0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x69,
+	0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61,
+	0x72, 0x64, 0x52, 0x09, 0x67, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xb5,
+	0x01, 0x0a, 0x12, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x43, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x50, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x67, 0x69, 0x66,
+	0x74, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66, 0x74,
+	0x43, 0x61, 0x72, 0x64, 0x52, 0x09, 0x67, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x12, 0x24, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x74,
+	0x65, 0x6d, 0x73, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xab, 0x04, 0x0a, 0x0e, 0x47, 0x69, 0x66, 0x74, 0x43,
+	0x61, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x75, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x75, 0x6e, 0x12, 0x1b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01,
+	0x12, 0x24, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x46,
+	0x72, 0x6f, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x74, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02, 0x52, 0x08, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x54, 0x6f, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6d, 0x70,
+	0x61, 0x69, 0x67, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0b,
+	0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63,
+	0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x74,
+	0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x03, 0x52, 0x07, 0x69, 0x73, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x64, 0x61, 0x74,
+	0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x44, 0x61, 0x74, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x24, 0x0a, 0x0e,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x6f, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x44, 0x61, 0x74, 0x65,
+	0x54, 0x6f, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x74, 0x6f, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x54, 0x6f, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x64,
+	0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x64,
+	0x65, 0x65, 0x6d, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x64,
+	0x65, 0x65, 0x6d, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x64, 0x54, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f,
+	0x72, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x09,
+	0x0a, 0x07, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x74, 0x6f, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x69, 0x73, 0x5f, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x22, 0x25, 0x0a, 0x13, 0x46, 0x69, 0x6e, 0x64, 0x47, 0x69, 0x66, 0x74,
+	0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x22, 0x37, 0x0a, 0x16, 0x46,
+	0x69, 0x6e, 0x64, 0x42, 0x79, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x22, 0x24, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x55, 0x75,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x75, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x75, 0x6e, 0x22, 0x76, 0x0a, 0x17, 0x46, 0x69,
+	0x6e, 0x64, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x50, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a,
+	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66, 0x74,
+	0x43, 0x61, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x22, 0x9f, 0x01, 0x0a, 0x1d, 0x46, 0x69, 0x6e, 0x64, 0x47, 0x69, 0x66, 0x74, 0x43,
+	0x61, 0x72, 0x64, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x50, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x12, 0x33, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x70, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x69, 0x66,
+	0x74, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x44, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70,
+	0x61, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x22, 0x60, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x27, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x74, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x6e, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0a, 0x67, 0x69, 0x66, 0x74, 0x5f, 0x63,
+	0x61, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x67, 0x69, 0x66,
+	0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x69,
+	0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x09, 0x67,
+	0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x22, 0xa6, 0x01, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x53, 0x61, 0x6d, 0x65, 0x4d, 0x61, 0x6e, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x44, 0x61,
+	0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x49,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79,
+	0x22, 0x31, 0x0a, 0x17, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x47, 0x69, 0x66, 0x74,
+	0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x22, 0x46, 0x0a, 0x18, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x47,
+	0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2a, 0x0a, 0x11, 0x67, 0x69, 0x66, 0x74, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x73, 0x5f, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x67, 0x69, 0x66, 0x74,
+	0x43, 0x61, 0x72, 0x64, 0x73, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0x42, 0x0a, 0x16, 0x41,
+	0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x75, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x75, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22,
+	0x57, 0x0a, 0x17, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61,
+	0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x75,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x75, 0x6e, 0x12, 0x2a, 0x0a, 0x11,
+	0x67, 0x69, 0x66, 0x74, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x73, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x67, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72,
+	0x64, 0x73, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0x30, 0x0a, 0x08, 0x43, 0x61, 0x6d, 0x70,
+	0x61, 0x69, 0x67, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x0c, 0x43,
+	0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x50, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x52, 0x09, 0x63,
+	0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x12, 0x43, 0x61,
+	0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x50, 0x61, 0x67, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61,
+	0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x52, 0x09,
+	0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x24, 0x0a, 0x0b, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48,
+	0x00, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x88, 0x01, 0x01,
+	0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x22, 0x59, 0x0a, 0x17, 0x46, 0x69, 0x6e, 0x64, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e,
+	0x50, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x22, 0x82, 0x01, 0x0a, 0x1d,
+	0x46, 0x69, 0x6e, 0x64, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x43, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x50, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0x2d, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69,
+	0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x22,
+	0x3d, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x22, 0x27,
+	0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x22, 0x26, 0x0a, 0x14, 0x43, 0x61, 0x6d, 0x70, 0x61,
+	0x69, 0x67, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x3e, 0x0a, 0x0e, 0x43, 0x61, 0x72, 0x64, 0x73, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0xe4, 0x03, 0x0a, 0x0d, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e,
+	0x49, 0x64, 0x12, 0x33, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x72, 0x64, 0x73, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52,
+	0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x08, 0x72, 0x65, 0x64, 0x65, 0x65,
+	0x6d, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x69, 0x66, 0x74,
+	0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x73, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x08, 0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x64,
+	0x12, 0x35, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x61, 0x72, 0x64, 0x73, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x07,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x06, 0x76, 0x6f, 0x69, 0x64, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61,
+	0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x73, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x52, 0x06, 0x76, 0x6f, 0x69, 0x64, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x07,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64,
+	0x73, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63,
+	0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x73, 0x41, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x6f, 0x75, 0x74, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x64, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x72, 0x65, 0x64,
+	0x65, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x39, 0x0a, 0x19, 0x61,
+	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x5f, 0x74,
+	0x6f, 0x5f, 0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x16,
+	0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x54, 0x6f,
+	0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x32, 0xea, 0x08, 0x0a, 0x0f, 0x47, 0x69, 0x66, 0x74, 0x43,
+	0x61, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x46, 0x69,
+	0x6e, 0x64, 0x42, 0x79, 0x49, 0x64, 0x12, 0x20, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63,
+	0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x12,
+	0x53, 0x0a, 0x0f, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
+	0x65, 0x79, 0x12, 0x23, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61,
+	0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x45, 0x0a, 0x09, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x55, 0x75,
+	0x6e, 0x12, 0x1d, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e,
+	0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x55, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x08, 0x46,
+	0x69, 0x6e, 0x64, 0x50, 0x61, 0x67, 0x65, 0x12, 0x24, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61,
+	0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61,
+	0x72, 0x64, 0x50, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66, 0x74,
+	0x43, 0x61, 0x72, 0x64, 0x50, 0x61, 0x67, 0x65, 0x12, 0x5d, 0x0a, 0x0e, 0x46, 0x69, 0x6e, 0x64,
+	0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x50, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x2e, 0x67, 0x69, 0x66,
+	0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x47, 0x69, 0x66,
+	0x74, 0x43, 0x61, 0x72, 0x64, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x50, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x43, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x50, 0x61, 0x67, 0x65, 0x12, 0x41, 0x0a, 0x05, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x12, 0x21, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x12, 0x43, 0x0a, 0x06, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63,
+	0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x12,
+	0x44, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x67, 0x69, 0x66, 0x74,
+	0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x69,
+	0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x47, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d,
+	0x61, 0x6e, 0x79, 0x12, 0x1e, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x6e, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x4f,
+	0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x6d, 0x65, 0x4d, 0x61, 0x6e, 0x79,
+	0x12, 0x22, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x6d, 0x65, 0x4d, 0x61, 0x6e, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x55, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x47, 0x69, 0x66, 0x74, 0x43,
+	0x61, 0x72, 0x64, 0x12, 0x24, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61,
+	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x69, 0x66, 0x74,
+	0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x5b, 0x0a, 0x11, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x12, 0x25, 0x2e, 0x67, 0x69,
+	0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x53, 0x0a, 0x0f, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x47, 0x69,
+	0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x12, 0x23, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x47, 0x69, 0x66, 0x74,
+	0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x69,
+	0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61,
+	0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x59, 0x0a, 0x10, 0x41, 0x70, 0x70, 0x72,
+	0x6f, 0x76, 0x65, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x12, 0x24, 0x2e, 0x67,
+	0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x69, 0x66, 0x74, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4c,
+	0x69, 0x73, 0x74, 0x32, 0x94, 0x04, 0x0a, 0x0f, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x64, 0x50,
+	0x61, 0x67, 0x65, 0x12, 0x24, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x50, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x69, 0x66, 0x74,
+	0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e,
+	0x50, 0x61, 0x67, 0x65, 0x12, 0x5d, 0x0a, 0x0e, 0x46, 0x69, 0x6e, 0x64, 0x43, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x50, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67,
+	0x6e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x50, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x50,
+	0x61, 0x67, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x22, 0x2e,
+	0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x12, 0x43, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x22, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x12, 0x44, 0x0a,
+	0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61,
+	0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x6d, 0x70,
+	0x61, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x46, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x67,
+	0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6d, 0x70, 0x61,
+	0x69, 0x67, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61,
+	0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x3d, 0x0a, 0x07, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a,
+	0x2e, 0x67, 0x69, 0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6d,
+	0x70, 0x61, 0x69, 0x67, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69,
+	0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x2d, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x69,
+	0x66, 0x74, 0x63, 0x61, 0x72, 0x64, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_giftcard_proto_rawDescOnce sync.Once
+	file_giftcard_proto_rawDescData = file_giftcard_proto_rawDesc
+)
+
+func file_giftcard_proto_rawDescGZIP() []byte {
+	file_giftcard_proto_rawDescOnce.Do(func() {
+		file_giftcard_proto_rawDescData = protoimpl.X.CompressGZIP(file_giftcard_proto_rawDescData)
+	})
+	return file_giftcard_proto_rawDescData
+}
+
+var file_giftcard_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_giftcard_proto_goTypes = []interface{}{
+	(*GiftCard)(nil),                      // 0: giftcard.v1.GiftCard
+	(*GiftCardStatus)(nil),                // 1: giftcard.v1.GiftCardStatus
+	(*GiftCardList)(nil),                  // 2: giftcard.v1.GiftCardList
+	(*GiftCardStatusList)(nil),            // 3: giftcard.v1.GiftCardStatusList
+	(*GiftCardPage)(nil),                  // 4: giftcard.v1.GiftCardPage
+	(*GiftCardCursorPage)(nil),            // 5: giftcard.v1.GiftCardCursorPage
+	(*GiftCardFilter)(nil),                // 6: giftcard.v1.GiftCardFilter
+	(*FindGiftCardRequest)(nil),           // 7: giftcard.v1.FindGiftCardRequest
+	(*FindByPublicKeyRequest)(nil),        // 8: giftcard.v1.FindByPublicKeyRequest
+	(*FindByUunRequest)(nil),              // 9: giftcard.v1.FindByUunRequest
+	(*FindGiftCardPageRequest)(nil),       // 10: giftcard.v1.FindGiftCardPageRequest
+	(*FindGiftCardCursorPageRequest)(nil), // 11: giftcard.v1.FindGiftCardCursorPageRequest
+	(*StoreGiftCardRequest)(nil),          // 12: giftcard.v1.StoreGiftCardRequest
+	(*UpdateGiftCardRequest)(nil),         // 13: giftcard.v1.UpdateGiftCardRequest
+	(*DeleteGiftCardRequest)(nil),         // 14: giftcard.v1.DeleteGiftCardRequest
+	(*CreateManyRequest)(nil),             // 15: giftcard.v1.CreateManyRequest
+	(*CreateSameManyRequest)(nil),         // 16: giftcard.v1.CreateSameManyRequest
+	(*ValidateGiftCardRequest)(nil),       // 17: giftcard.v1.ValidateGiftCardRequest
+	(*ValidateGiftCardsRequest)(nil),      // 18: giftcard.v1.ValidateGiftCardsRequest
+	(*ApproveGiftCardRequest)(nil),        // 19: giftcard.v1.ApproveGiftCardRequest
+	(*ApproveGiftCardsRequest)(nil),       // 20: giftcard.v1.ApproveGiftCardsRequest
+	(*Campaign)(nil),                      // 21: giftcard.v1.Campaign
+	(*CampaignPage)(nil),                  // 22: giftcard.v1.CampaignPage
+	(*CampaignCursorPage)(nil),            // 23: giftcard.v1.CampaignCursorPage
+	(*FindCampaignPageRequest)(nil),       // 24: giftcard.v1.FindCampaignPageRequest
+	(*FindCampaignCursorPageRequest)(nil), // 25: giftcard.v1.FindCampaignCursorPageRequest
+	(*CreateCampaignRequest)(nil),         // 26: giftcard.v1.CreateCampaignRequest
+	(*UpdateCampaignRequest)(nil),         // 27: giftcard.v1.UpdateCampaignRequest
+	(*DeleteCampaignRequest)(nil),         // 28: giftcard.v1.DeleteCampaignRequest
+	(*CampaignStatsRequest)(nil),          // 29: giftcard.v1.CampaignStatsRequest
+	(*CardsAggregate)(nil),                // 30: giftcard.v1.CardsAggregate
+	(*CampaignStats)(nil),                 // 31: giftcard.v1.CampaignStats
+	(*emptypb.Empty)(nil),                 // 32: google.protobuf.Empty
+}
+var file_giftcard_proto_depIdxs = []int32{
+	0,  // 0: giftcard.v1.GiftCardList.gift_cards:type_name -> giftcard.v1.GiftCard
+	1,  // 1: giftcard.v1.GiftCardStatusList.gift_cards_statuses:type_name -> giftcard.v1.GiftCardStatus
+	0,  // 2: giftcard.v1.GiftCardPage.gift_cards:type_name -> giftcard.v1.GiftCard
+	0,  // 3: giftcard.v1.GiftCardCursorPage.gift_cards:type_name -> giftcard.v1.GiftCard
+	6,  // 4: giftcard.v1.FindGiftCardPageRequest.filter:type_name -> giftcard.v1.GiftCardFilter
+	6,  // 5: giftcard.v1.FindGiftCardCursorPageRequest.filter:type_name -> giftcard.v1.GiftCardFilter
+	12, // 6: giftcard.v1.CreateManyRequest.gift_cards:type_name -> giftcard.v1.StoreGiftCardRequest
+	21, // 7: giftcard.v1.CampaignPage.campaigns:type_name -> giftcard.v1.Campaign
+	21, // 8: giftcard.v1.CampaignCursorPage.campaigns:type_name -> giftcard.v1.Campaign
+	30, // 9: giftcard.v1.CampaignStats.issued:type_name -> giftcard.v1.CardsAggregate
+	30, // 10: giftcard.v1.CampaignStats.redeemed:type_name -> giftcard.v1.CardsAggregate
+	30, // 11: giftcard.v1.CampaignStats.expired:type_name -> giftcard.v1.CardsAggregate
+	30, // 12: giftcard.v1.CampaignStats.voided:type_name -> giftcard.v1.CardsAggregate
+	30, // 13: giftcard.v1.CampaignStats.deleted:type_name -> giftcard.v1.CardsAggregate
+	30, // 14: giftcard.v1.CampaignStats.outstanding:type_name -> giftcard.v1.CardsAggregate
+	7,  // 15: giftcard.v1.GiftCardService.FindById:input_type -> giftcard.v1.FindGiftCardRequest
+	8,  // 16: giftcard.v1.GiftCardService.FindByPublicKey:input_type -> giftcard.v1.FindByPublicKeyRequest
+	9,  // 17: giftcard.v1.GiftCardService.FindByUun:input_type -> giftcard.v1.FindByUunRequest
+	10, // 18: giftcard.v1.GiftCardService.FindPage:input_type -> giftcard.v1.FindGiftCardPageRequest
+	11, // 19: giftcard.v1.GiftCardService.FindCursorPage:input_type -> giftcard.v1.FindGiftCardCursorPageRequest
+	12, // 20: giftcard.v1.GiftCardService.Store:input_type -> giftcard.v1.StoreGiftCardRequest
+	13, // 21: giftcard.v1.GiftCardService.Update:input_type -> giftcard.v1.UpdateGiftCardRequest
+	14, // 22: giftcard.v1.GiftCardService.Delete:input_type -> giftcard.v1.DeleteGiftCardRequest
+	15, // 23: giftcard.v1.GiftCardService.CreateMany:input_type -> giftcard.v1.CreateManyRequest
+	16, // 24: giftcard.v1.GiftCardService.CreateSameMany:input_type -> giftcard.v1.CreateSameManyRequest
+	17, // 25: giftcard.v1.GiftCardService.ValidateGiftCard:input_type -> giftcard.v1.ValidateGiftCardRequest
+	18, // 26: giftcard.v1.GiftCardService.ValidateGiftCards:input_type -> giftcard.v1.ValidateGiftCardsRequest
+	19, // 27: giftcard.v1.GiftCardService.ApproveGiftCard:input_type -> giftcard.v1.ApproveGiftCardRequest
+	20, // 28: giftcard.v1.GiftCardService.ApproveGiftCards:input_type -> giftcard.v1.ApproveGiftCardsRequest
+	24, // 29: giftcard.v1.CampaignService.FindPage:input_type -> giftcard.v1.FindCampaignPageRequest
+	25, // 30: giftcard.v1.CampaignService.FindCursorPage:input_type -> giftcard.v1.FindCampaignCursorPageRequest
+	26, // 31: giftcard.v1.CampaignService.Create:input_type -> giftcard.v1.CreateCampaignRequest
+	27, // 32: giftcard.v1.CampaignService.Update:input_type -> giftcard.v1.UpdateCampaignRequest
+	28, // 33: giftcard.v1.CampaignService.Delete:input_type -> giftcard.v1.DeleteCampaignRequest
+	29, // 34: giftcard.v1.CampaignService.Stats:input_type -> giftcard.v1.CampaignStatsRequest
+	32, // 35: giftcard.v1.CampaignService.Summary:input_type -> google.protobuf.Empty
+	0,  // 36: giftcard.v1.GiftCardService.FindById:output_type -> giftcard.v1.GiftCard
+	1,  // 37: giftcard.v1.GiftCardService.FindByPublicKey:output_type -> giftcard.v1.GiftCardStatus
+	2,  // 38: giftcard.v1.GiftCardService.FindByUun:output_type -> giftcard.v1.GiftCardList
+	4,  // 39: giftcard.v1.GiftCardService.FindPage:output_type -> giftcard.v1.GiftCardPage
+	5,  // 40: giftcard.v1.GiftCardService.FindCursorPage:output_type -> giftcard.v1.GiftCardCursorPage
+	0,  // 41: giftcard.v1.GiftCardService.Store:output_type -> giftcard.v1.GiftCard
+	0,  // 42: giftcard.v1.GiftCardService.Update:output_type -> giftcard.v1.GiftCard
+	32, // 43: giftcard.v1.GiftCardService.Delete:output_type -> google.protobuf.Empty
+	2,  // 44: giftcard.v1.GiftCardService.CreateMany:output_type -> giftcard.v1.GiftCardList
+	2,  // 45: giftcard.v1.GiftCardService.CreateSameMany:output_type -> giftcard.v1.GiftCardList
+	1,  // 46: giftcard.v1.GiftCardService.ValidateGiftCard:output_type -> giftcard.v1.GiftCardStatus
+	3,  // 47: giftcard.v1.GiftCardService.ValidateGiftCards:output_type -> giftcard.v1.GiftCardStatusList
+	1,  // 48: giftcard.v1.GiftCardService.ApproveGiftCard:output_type -> giftcard.v1.GiftCardStatus
+	3,  // 49: giftcard.v1.GiftCardService.ApproveGiftCards:output_type -> giftcard.v1.GiftCardStatusList
+	22, // 50: giftcard.v1.CampaignService.FindPage:output_type -> giftcard.v1.CampaignPage
+	23, // 51: giftcard.v1.CampaignService.FindCursorPage:output_type -> giftcard.v1.CampaignCursorPage
+	21, // 52: giftcard.v1.CampaignService.Create:output_type -> giftcard.v1.Campaign
+	21, // 53: giftcard.v1.CampaignService.Update:output_type -> giftcard.v1.Campaign
+	32, // 54: giftcard.v1.CampaignService.Delete:output_type -> google.protobuf.Empty
+	31, // 55: giftcard.v1.CampaignService.Stats:output_type -> giftcard.v1.CampaignStats
+	31, // 56: giftcard.v1.CampaignService.Summary:output_type -> giftcard.v1.CampaignStats
+	36, // [36:57] is the sub-list for method output_type
+	15, // [15:36] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_giftcard_proto_init() }
+func file_giftcard_proto_init() {
+	if File_giftcard_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_giftcard_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GiftCard); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GiftCardStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GiftCardList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GiftCardStatusList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GiftCardPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GiftCardCursorPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GiftCardFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindGiftCardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindByPublicKeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindByUunRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindGiftCardPageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindGiftCardCursorPageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StoreGiftCardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateGiftCardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteGiftCardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateManyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSameManyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidateGiftCardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidateGiftCardsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApproveGiftCardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApproveGiftCardsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Campaign); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignCursorPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindCampaignPageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindCampaignCursorPageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateCampaignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateCampaignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCampaignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CardsAggregate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_giftcard_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CampaignStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_giftcard_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	file_giftcard_proto_msgTypes[6].OneofWrappers = []interface{}{}
+	file_giftcard_proto_msgTypes[23].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_giftcard_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   32,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_giftcard_proto_goTypes,
+		DependencyIndexes: file_giftcard_proto_depIdxs,
+		MessageInfos:      file_giftcard_proto_msgTypes,
+	}.Build()
+	File_giftcard_proto = out.File
+	file_giftcard_proto_rawDesc = nil
+	file_giftcard_proto_goTypes = nil
+	file_giftcard_proto_depIdxs = nil
+}
@@ -0,0 +1,894 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: giftcard.proto
+
+package giftcardpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GiftCardServiceClient is the client API for GiftCardService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GiftCardServiceClient interface {
+	FindById(ctx context.Context, in *FindGiftCardRequest, opts ...grpc.CallOption) (*GiftCard, error)
+	FindByPublicKey(ctx context.Context, in *FindByPublicKeyRequest, opts ...grpc.CallOption) (*GiftCardStatus, error)
+	// FindByUun returns the cards approved by the user, or NOT_FOUND when there is none
+	FindByUun(ctx context.Context, in *FindByUunRequest, opts ...grpc.CallOption) (*GiftCardList, error)
+	FindPage(ctx context.Context, in *FindGiftCardPageRequest, opts ...grpc.CallOption) (*GiftCardPage, error)
+	// FindCursorPage returns the cards after the cursor. next_cursor of the page is the cursor of the next one
+	FindCursorPage(ctx context.Context, in *FindGiftCardCursorPageRequest, opts ...grpc.CallOption) (*GiftCardCursorPage, error)
+	Store(ctx context.Context, in *StoreGiftCardRequest, opts ...grpc.CallOption) (*GiftCard, error)
+	// Update changes the amount and the expire date of a card which is not approved yet
+	Update(ctx context.Context, in *UpdateGiftCardRequest, opts ...grpc.CallOption) (*GiftCard, error)
+	Delete(ctx context.Context, in *DeleteGiftCardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// CreateMany issues the cards of a batch at once. when some of the cards fail, the error has the issued cards
+	// and the batch id as a GiftCardList detail
+	CreateMany(ctx context.Context, in *CreateManyRequest, opts ...grpc.CallOption) (*GiftCardList, error)
+	// CreateSameMany issues a batch of the same cards at once, and fails like CreateMany
+	CreateSameMany(ctx context.Context, in *CreateSameManyRequest, opts ...grpc.CallOption) (*GiftCardList, error)
+	ValidateGiftCard(ctx context.Context, in *ValidateGiftCardRequest, opts ...grpc.CallOption) (*GiftCardStatus, error)
+	ValidateGiftCards(ctx context.Context, in *ValidateGiftCardsRequest, opts ...grpc.CallOption) (*GiftCardStatusList, error)
+	// ApproveGiftCard redeems the card for the user
+	ApproveGiftCard(ctx context.Context, in *ApproveGiftCardRequest, opts ...grpc.CallOption) (*GiftCardStatus, error)
+	// ApproveGiftCards redeems every card for the user, or none of them when one of them fails
+	ApproveGiftCards(ctx context.Context, in *ApproveGiftCardsRequest, opts ...grpc.CallOption) (*GiftCardStatusList, error)
+}
+
+type giftCardServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGiftCardServiceClient(cc grpc.ClientConnInterface) GiftCardServiceClient {
+	return &giftCardServiceClient{cc}
+}
+
+func (c *giftCardServiceClient) FindById(ctx context.Context, in *FindGiftCardRequest, opts ...grpc.CallOption) (*GiftCard, error) {
+	out := new(GiftCard)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/FindById", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) FindByPublicKey(ctx context.Context, in *FindByPublicKeyRequest, opts ...grpc.CallOption) (*GiftCardStatus, error) {
+	out := new(GiftCardStatus)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/FindByPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) FindByUun(ctx context.Context, in *FindByUunRequest, opts ...grpc.CallOption) (*GiftCardList, error) {
+	out := new(GiftCardList)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/FindByUun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) FindPage(ctx context.Context, in *FindGiftCardPageRequest, opts ...grpc.CallOption) (*GiftCardPage, error) {
+	out := new(GiftCardPage)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/FindPage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) FindCursorPage(ctx context.Context, in *FindGiftCardCursorPageRequest, opts ...grpc.CallOption) (*GiftCardCursorPage, error) {
+	out := new(GiftCardCursorPage)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/FindCursorPage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) Store(ctx context.Context, in *StoreGiftCardRequest, opts ...grpc.CallOption) (*GiftCard, error) {
+	out := new(GiftCard)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/Store", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) Update(ctx context.Context, in *UpdateGiftCardRequest, opts ...grpc.CallOption) (*GiftCard, error) {
+	out := new(GiftCard)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) Delete(ctx context.Context, in *DeleteGiftCardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) CreateMany(ctx context.Context, in *CreateManyRequest, opts ...grpc.CallOption) (*GiftCardList, error) {
+	out := new(GiftCardList)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/CreateMany", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) CreateSameMany(ctx context.Context, in *CreateSameManyRequest, opts ...grpc.CallOption) (*GiftCardList, error) {
+	out := new(GiftCardList)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/CreateSameMany", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) ValidateGiftCard(ctx context.Context, in *ValidateGiftCardRequest, opts ...grpc.CallOption) (*GiftCardStatus, error) {
+	out := new(GiftCardStatus)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/ValidateGiftCard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) ValidateGiftCards(ctx context.Context, in *ValidateGiftCardsRequest, opts ...grpc.CallOption) (*GiftCardStatusList, error) {
+	out := new(GiftCardStatusList)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/ValidateGiftCards", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) ApproveGiftCard(ctx context.Context, in *ApproveGiftCardRequest, opts ...grpc.CallOption) (*GiftCardStatus, error) {
+	out := new(GiftCardStatus)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/ApproveGiftCard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftCardServiceClient) ApproveGiftCards(ctx context.Context, in *ApproveGiftCardsRequest, opts ...grpc.CallOption) (*GiftCardStatusList, error) {
+	out := new(GiftCardStatusList)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.GiftCardService/ApproveGiftCards", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GiftCardServiceServer is the server API for GiftCardService service.
+// All implementations must embed UnimplementedGiftCardServiceServer
+// for forward compatibility
+type GiftCardServiceServer interface {
+	FindById(context.Context, *FindGiftCardRequest) (*GiftCard, error)
+	FindByPublicKey(context.Context, *FindByPublicKeyRequest) (*GiftCardStatus, error)
+	// FindByUun returns the cards approved by the user, or NOT_FOUND when there is none
+	FindByUun(context.Context, *FindByUunRequest) (*GiftCardList, error)
+	FindPage(context.Context, *FindGiftCardPageRequest) (*GiftCardPage, error)
+	// FindCursorPage returns the cards after the cursor. next_cursor of the page is the cursor of the next one
+	FindCursorPage(context.Context, *FindGiftCardCursorPageRequest) (*GiftCardCursorPage, error)
+	Store(context.Context, *StoreGiftCardRequest) (*GiftCard, error)
+	// Update changes the amount and the expire date of a card which is not approved yet
+	Update(context.Context, *UpdateGiftCardRequest) (*GiftCard, error)
+	Delete(context.Context, *DeleteGiftCardRequest) (*emptypb.Empty, error)
+	// CreateMany issues the cards of a batch at once. when some of the cards fail, the error has the issued cards
+	// and the batch id as a GiftCardList detail
+	CreateMany(context.Context, *CreateManyRequest) (*GiftCardList, error)
+	// CreateSameMany issues a batch of the same cards at once, and fails like CreateMany
+	CreateSameMany(context.Context, *CreateSameManyRequest) (*GiftCardList, error)
+	ValidateGiftCard(context.Context, *ValidateGiftCardRequest) (*GiftCardStatus, error)
+	ValidateGiftCards(context.Context, *ValidateGiftCardsRequest) (*GiftCardStatusList, error)
+	// ApproveGiftCard redeems the card for the user
+	ApproveGiftCard(context.Context, *ApproveGiftCardRequest) (*GiftCardStatus, error)
+	// ApproveGiftCards redeems every card for the user, or none of them when one of them fails
+	ApproveGiftCards(context.Context, *ApproveGiftCardsRequest) (*GiftCardStatusList, error)
+	mustEmbedUnimplementedGiftCardServiceServer()
+}
+
+// UnimplementedGiftCardServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGiftCardServiceServer struct {
+}
+
+func (UnimplementedGiftCardServiceServer) FindById(context.Context, *FindGiftCardRequest) (*GiftCard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindById not implemented")
+}
+func (UnimplementedGiftCardServiceServer) FindByPublicKey(context.Context, *FindByPublicKeyRequest) (*GiftCardStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindByPublicKey not implemented")
+}
+func (UnimplementedGiftCardServiceServer) FindByUun(context.Context, *FindByUunRequest) (*GiftCardList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindByUun not implemented")
+}
+func (UnimplementedGiftCardServiceServer) FindPage(context.Context, *FindGiftCardPageRequest) (*GiftCardPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindPage not implemented")
+}
+func (UnimplementedGiftCardServiceServer) FindCursorPage(context.Context, *FindGiftCardCursorPageRequest) (*GiftCardCursorPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindCursorPage not implemented")
+}
+func (UnimplementedGiftCardServiceServer) Store(context.Context, *StoreGiftCardRequest) (*GiftCard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Store not implemented")
+}
+func (UnimplementedGiftCardServiceServer) Update(context.Context, *UpdateGiftCardRequest) (*GiftCard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedGiftCardServiceServer) Delete(context.Context, *DeleteGiftCardRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedGiftCardServiceServer) CreateMany(context.Context, *CreateManyRequest) (*GiftCardList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMany not implemented")
+}
+func (UnimplementedGiftCardServiceServer) CreateSameMany(context.Context, *CreateSameManyRequest) (*GiftCardList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSameMany not implemented")
+}
+func (UnimplementedGiftCardServiceServer) ValidateGiftCard(context.Context, *ValidateGiftCardRequest) (*GiftCardStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateGiftCard not implemented")
+}
+func (UnimplementedGiftCardServiceServer) ValidateGiftCards(context.Context, *ValidateGiftCardsRequest) (*GiftCardStatusList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateGiftCards not implemented")
+}
+func (UnimplementedGiftCardServiceServer) ApproveGiftCard(context.Context, *ApproveGiftCardRequest) (*GiftCardStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveGiftCard not implemented")
+}
+func (UnimplementedGiftCardServiceServer) ApproveGiftCards(context.Context, *ApproveGiftCardsRequest) (*GiftCardStatusList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveGiftCards not implemented")
+}
+func (UnimplementedGiftCardServiceServer) mustEmbedUnimplementedGiftCardServiceServer() {}
+
+// UnsafeGiftCardServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GiftCardServiceServer will
+// result in compilation errors.
+type UnsafeGiftCardServiceServer interface {
+	mustEmbedUnimplementedGiftCardServiceServer()
+}
+
+func RegisterGiftCardServiceServer(s grpc.ServiceRegistrar, srv GiftCardServiceServer) {
+	s.RegisterService(&GiftCardService_ServiceDesc, srv)
+}
+
+func _GiftCardService_FindById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindGiftCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).FindById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/FindById",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).FindById(ctx, req.(*FindGiftCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_FindByPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindByPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).FindByPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/FindByPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).FindByPublicKey(ctx, req.(*FindByPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_FindByUun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindByUunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).FindByUun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/FindByUun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).FindByUun(ctx, req.(*FindByUunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_FindPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindGiftCardPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).FindPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/FindPage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).FindPage(ctx, req.(*FindGiftCardPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_FindCursorPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindGiftCardCursorPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).FindCursorPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/FindCursorPage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).FindCursorPage(ctx, req.(*FindGiftCardCursorPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_Store_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreGiftCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).Store(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/Store",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).Store(ctx, req.(*StoreGiftCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGiftCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).Update(ctx, req.(*UpdateGiftCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGiftCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).Delete(ctx, req.(*DeleteGiftCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_CreateMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).CreateMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/CreateMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).CreateMany(ctx, req.(*CreateManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_CreateSameMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSameManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).CreateSameMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/CreateSameMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).CreateSameMany(ctx, req.(*CreateSameManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_ValidateGiftCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateGiftCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).ValidateGiftCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/ValidateGiftCard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).ValidateGiftCard(ctx, req.(*ValidateGiftCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_ValidateGiftCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateGiftCardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).ValidateGiftCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/ValidateGiftCards",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).ValidateGiftCards(ctx, req.(*ValidateGiftCardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_ApproveGiftCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveGiftCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).ApproveGiftCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/ApproveGiftCard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).ApproveGiftCard(ctx, req.(*ApproveGiftCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftCardService_ApproveGiftCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveGiftCardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftCardServiceServer).ApproveGiftCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.GiftCardService/ApproveGiftCards",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftCardServiceServer).ApproveGiftCards(ctx, req.(*ApproveGiftCardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GiftCardService_ServiceDesc is the grpc.ServiceDesc for GiftCardService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GiftCardService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "giftcard.v1.GiftCardService",
+	HandlerType: (*GiftCardServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FindById",
+			Handler:    _GiftCardService_FindById_Handler,
+		},
+		{
+			MethodName: "FindByPublicKey",
+			Handler:    _GiftCardService_FindByPublicKey_Handler,
+		},
+		{
+			MethodName: "FindByUun",
+			Handler:    _GiftCardService_FindByUun_Handler,
+		},
+		{
+			MethodName: "FindPage",
+			Handler:    _GiftCardService_FindPage_Handler,
+		},
+		{
+			MethodName: "FindCursorPage",
+			Handler:    _GiftCardService_FindCursorPage_Handler,
+		},
+		{
+			MethodName: "Store",
+			Handler:    _GiftCardService_Store_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _GiftCardService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _GiftCardService_Delete_Handler,
+		},
+		{
+			MethodName: "CreateMany",
+			Handler:    _GiftCardService_CreateMany_Handler,
+		},
+		{
+			MethodName: "CreateSameMany",
+			Handler:    _GiftCardService_CreateSameMany_Handler,
+		},
+		{
+			MethodName: "ValidateGiftCard",
+			Handler:    _GiftCardService_ValidateGiftCard_Handler,
+		},
+		{
+			MethodName: "ValidateGiftCards",
+			Handler:    _GiftCardService_ValidateGiftCards_Handler,
+		},
+		{
+			MethodName: "ApproveGiftCard",
+			Handler:    _GiftCardService_ApproveGiftCard_Handler,
+		},
+		{
+			MethodName: "ApproveGiftCards",
+			Handler:    _GiftCardService_ApproveGiftCards_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "giftcard.proto",
+}
+
+// CampaignServiceClient is the client API for CampaignService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CampaignServiceClient interface {
+	FindPage(ctx context.Context, in *FindCampaignPageRequest, opts ...grpc.CallOption) (*CampaignPage, error)
+	FindCursorPage(ctx context.Context, in *FindCampaignCursorPageRequest, opts ...grpc.CallOption) (*CampaignCursorPage, error)
+	Create(ctx context.Context, in *CreateCampaignRequest, opts ...grpc.CallOption) (*Campaign, error)
+	Update(ctx context.Context, in *UpdateCampaignRequest, opts ...grpc.CallOption) (*Campaign, error)
+	Delete(ctx context.Context, in *DeleteCampaignRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Stats(ctx context.Context, in *CampaignStatsRequest, opts ...grpc.CallOption) (*CampaignStats, error)
+	// Summary returns the stats of every campaign together
+	Summary(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CampaignStats, error)
+}
+
+type campaignServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCampaignServiceClient(cc grpc.ClientConnInterface) CampaignServiceClient {
+	return &campaignServiceClient{cc}
+}
+
+func (c *campaignServiceClient) FindPage(ctx context.Context, in *FindCampaignPageRequest, opts ...grpc.CallOption) (*CampaignPage, error) {
+	out := new(CampaignPage)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.CampaignService/FindPage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *campaignServiceClient) FindCursorPage(ctx context.Context, in *FindCampaignCursorPageRequest, opts ...grpc.CallOption) (*CampaignCursorPage, error) {
+	out := new(CampaignCursorPage)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.CampaignService/FindCursorPage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *campaignServiceClient) Create(ctx context.Context, in *CreateCampaignRequest, opts ...grpc.CallOption) (*Campaign, error) {
+	out := new(Campaign)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.CampaignService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *campaignServiceClient) Update(ctx context.Context, in *UpdateCampaignRequest, opts ...grpc.CallOption) (*Campaign, error) {
+	out := new(Campaign)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.CampaignService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *campaignServiceClient) Delete(ctx context.Context, in *DeleteCampaignRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.CampaignService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *campaignServiceClient) Stats(ctx context.Context, in *CampaignStatsRequest, opts ...grpc.CallOption) (*CampaignStats, error) {
+	out := new(CampaignStats)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.CampaignService/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *campaignServiceClient) Summary(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CampaignStats, error) {
+	out := new(CampaignStats)
+	err := c.cc.Invoke(ctx, "/giftcard.v1.CampaignService/Summary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CampaignServiceServer is the server API for CampaignService service.
+// All implementations must embed UnimplementedCampaignServiceServer
+// for forward compatibility
+type CampaignServiceServer interface {
+	FindPage(context.Context, *FindCampaignPageRequest) (*CampaignPage, error)
+	FindCursorPage(context.Context, *FindCampaignCursorPageRequest) (*CampaignCursorPage, error)
+	Create(context.Context, *CreateCampaignRequest) (*Campaign, error)
+	Update(context.Context, *UpdateCampaignRequest) (*Campaign, error)
+	Delete(context.Context, *DeleteCampaignRequest) (*emptypb.Empty, error)
+	Stats(context.Context, *CampaignStatsRequest) (*CampaignStats, error)
+	// Summary returns the stats of every campaign together
+	Summary(context.Context, *emptypb.Empty) (*CampaignStats, error)
+	mustEmbedUnimplementedCampaignServiceServer()
+}
+
+// UnimplementedCampaignServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCampaignServiceServer struct {
+}
+
+func (UnimplementedCampaignServiceServer) FindPage(context.Context, *FindCampaignPageRequest) (*CampaignPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindPage not implemented")
+}
+func (UnimplementedCampaignServiceServer) FindCursorPage(context.Context, *FindCampaignCursorPageRequest) (*CampaignCursorPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindCursorPage not implemented")
+}
+func (UnimplementedCampaignServiceServer) Create(context.Context, *CreateCampaignRequest) (*Campaign, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedCampaignServiceServer) Update(context.Context, *UpdateCampaignRequest) (*Campaign, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedCampaignServiceServer) Delete(context.Context, *DeleteCampaignRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedCampaignServiceServer) Stats(context.Context, *CampaignStatsRequest) (*CampaignStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedCampaignServiceServer) Summary(context.Context, *emptypb.Empty) (*CampaignStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Summary not implemented")
+}
+func (UnimplementedCampaignServiceServer) mustEmbedUnimplementedCampaignServiceServer() {}
+
+// UnsafeCampaignServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CampaignServiceServer will
+// result in compilation errors.
+type UnsafeCampaignServiceServer interface {
+	mustEmbedUnimplementedCampaignServiceServer()
+}
+
+func RegisterCampaignServiceServer(s grpc.ServiceRegistrar, srv CampaignServiceServer) {
+	s.RegisterService(&CampaignService_ServiceDesc, srv)
+}
+
+func _CampaignService_FindPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindCampaignPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CampaignServiceServer).FindPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.CampaignService/FindPage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CampaignServiceServer).FindPage(ctx, req.(*FindCampaignPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CampaignService_FindCursorPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindCampaignCursorPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CampaignServiceServer).FindCursorPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.CampaignService/FindCursorPage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CampaignServiceServer).FindCursorPage(ctx, req.(*FindCampaignCursorPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CampaignService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CampaignServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.CampaignService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CampaignServiceServer).Create(ctx, req.(*CreateCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CampaignService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CampaignServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.CampaignService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CampaignServiceServer).Update(ctx, req.(*UpdateCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CampaignService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CampaignServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.CampaignService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CampaignServiceServer).Delete(ctx, req.(*DeleteCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CampaignService_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CampaignStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CampaignServiceServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.CampaignService/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CampaignServiceServer).Stats(ctx, req.(*CampaignStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CampaignService_Summary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CampaignServiceServer).Summary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/giftcard.v1.CampaignService/Summary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CampaignServiceServer).Summary(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CampaignService_ServiceDesc is the grpc.ServiceDesc for CampaignService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CampaignService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "giftcard.v1.CampaignService",
+	HandlerType: (*CampaignServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FindPage",
+			Handler:    _CampaignService_FindPage_Handler,
+		},
+		{
+			MethodName: "FindCursorPage",
+			Handler:    _CampaignService_FindCursorPage_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _CampaignService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _CampaignService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _CampaignService_Delete_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _CampaignService_Stats_Handler,
+		},
+		{
+			MethodName: "Summary",
+			Handler:    _CampaignService_Summary_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "giftcard.proto",
+}
@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"context"
+	"giftcard-engine/application/rpc/giftcardpb"
+	"giftcard-engine/infrastructure/health"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// healthServices are the services of the health protocol with the probes which decide their health. the empty
+// service is the whole server, and the probes can be checked by their names, like the grpc probes of kubernetes
+var healthServices = map[string]health.Probe{
+	"": health.Readiness,
+	giftcardpb.GiftCardService_ServiceDesc.ServiceName: health.Readiness,
+	giftcardpb.CampaignService_ServiceDesc.ServiceName: health.Readiness,
+	string(health.Liveness):                            health.Liveness,
+	string(health.Readiness):                           health.Readiness,
+	string(health.Startup):                             health.Startup,
+}
+
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	run      func(ctx context.Context, probe health.Probe) health.Report
+	interval time.Duration
+}
+
+// NewHealthServer returns the server of the standard grpc health protocol, which runs the checkers of the probes
+// with run. a service is serving when its probe is healthy or degraded, and its watchers are told about its changes
+// every interval
+func NewHealthServer(run func(ctx context.Context, probe health.Probe) health.Report,
+	interval time.Duration) healthpb.HealthServer {
+	return &healthServer{run: run, interval: interval}
+}
+
+func (s *healthServer) Check(ctx context.Context,
+	request *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	probe, ok := healthServices[request.GetService()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", request.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: s.status(ctx, probe)}, nil
+}
+
+// Watch sends the status of the service at once and then every time it changes. an unknown service is sent as
+// SERVICE_UNKNOWN, since it may be served later
+func (s *healthServer) Watch(request *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+	probe, known := healthServices[request.GetService()]
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	sent := false
+	var last healthpb.HealthCheckResponse_ServingStatus
+	for {
+		current := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if known {
+			current = s.status(ctx, probe)
+		}
+		if !sent || current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			sent, last = true, current
+		}
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *healthServer) status(ctx context.Context, probe health.Probe) healthpb.HealthCheckResponse_ServingStatus {
+	if s.run(ctx, probe).Status == health.UnHealthy {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
@@ -0,0 +1,106 @@
+package rpc_test
+
+import (
+	"context"
+	"giftcard-engine/application/rpc"
+	"giftcard-engine/infrastructure/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeProbes returns the health which is set for each probe, and healthy for the others
+type fakeProbes struct {
+	mu     sync.Mutex
+	health map[health.Probe]health.Health
+}
+
+func (p *fakeProbes) set(probe health.Probe, status health.Health) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.health[probe] = status
+}
+
+func (p *fakeProbes) run(_ context.Context, probe health.Probe) health.Report {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status, ok := p.health[probe]
+	if !ok {
+		status = health.Healthy
+	}
+	return health.Report{Status: status}
+}
+
+func createHealthClientForTest(t *testing.T) (healthpb.HealthClient, *fakeProbes) {
+	probes := &fakeProbes{health: map[health.Probe]health.Health{}}
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, rpc.NewHealthServer(probes.run, 10*time.Millisecond))
+	return healthpb.NewHealthClient(dial(t, server)), probes
+}
+
+func TestHealthServerCheck(t *testing.T) {
+	t.Parallel()
+	client, probes := createHealthClientForTest(t)
+	probes.set(health.Readiness, health.UnHealthy)
+	probes.set(health.Startup, health.Degraded)
+	check := func(service string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+		response, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		return response.GetStatus(), err
+	}
+
+	server, serverErr := check("")
+	cards, cardsErr := check("giftcard.v1.GiftCardService")
+	live, liveErr := check("live")
+	startup, startupErr := check("startup")
+	_, unknownErr := check("giftcard.v1.BatchService")
+
+	assert.Nil(t, serverErr)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, server)
+	assert.Nil(t, cardsErr)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, cards)
+	assert.Nil(t, liveErr)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, live)
+	assert.Nil(t, startupErr, "a degraded service still serves")
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, startup)
+	assertCode(t, codes.NotFound, unknownErr)
+}
+
+func TestHealthServerWatch(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		client, probes := createHealthClientForTest(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "giftcard.v1.CampaignService"})
+		require.Nil(t, err)
+		first, err := stream.Recv()
+		require.Nil(t, err)
+		probes.set(health.Readiness, health.UnHealthy)
+		second, err := stream.Recv()
+		require.Nil(t, err)
+
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, first.Status)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, second.Status)
+	})
+
+	te.Run("with unknown service", func(t *testing.T) {
+		t.Parallel()
+		client, _ := createHealthClientForTest(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "giftcard.v1.BatchService"})
+		require.Nil(t, err)
+		response, err := stream.Recv()
+
+		assert.Nil(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, response.Status)
+	})
+}
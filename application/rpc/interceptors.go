@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/infrastructure/requestid"
+	"giftcard-engine/infrastructure/timeout"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strings"
+)
+
+// requestIdKey is the metadata key of the request id, which is the http header in lower case like grpc keeps it
+var requestIdKey = strings.ToLower(requestid.Header)
+
+// recovery answers the panic of a call as an internal error, like gin does for the REST api
+func recovery(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (response interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			logger.WithContext(ctx).WithData(map[string]interface{}{
+				"method": info.FullMethod,
+			}).Error(fmt.Sprintf("panic while serving a grpc call: %v", recovered))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, request)
+}
+
+// withRequestId keeps the request id of the metadata of the call, or generates one when it is missing or invalid,
+// puts it in the context and returns it in the header of the response
+func withRequestId(ctx context.Context, request interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIdKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	id = requestid.Accept(id)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIdKey, id))
+	return handler(requestid.NewContext(ctx, id), request)
+}
+
+// withDeadline sets the deadline of the method on the context like the timeouts of the routes. grpc posts its
+// calls, so the method is timed out by the route timeout like "POST /giftcard.v1.GiftCardService/CreateMany". a
+// shorter deadline of the client is kept
+func withDeadline(deadlines *timeout.Deadlines) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		limit := deadlines.For(http.MethodPost, info.FullMethod)
+		if limit <= 0 {
+			return handler(ctx, request)
+		}
+		ctx, cancel := context.WithTimeout(ctx, limit)
+		defer cancel()
+		return handler(ctx, request)
+	}
+}
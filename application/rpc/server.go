@@ -0,0 +1,52 @@
+// Package rpc serves the gift card and the campaign services over grpc, next to the REST api
+package rpc
+
+import (
+	"context"
+	"giftcard-engine/application/rpc/giftcardpb"
+	"giftcard-engine/core"
+	"giftcard-engine/infrastructure/health"
+	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/infrastructure/timeout"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net"
+	"time"
+)
+
+// NewServer returns the grpc server of the services and of the health protocol, whose watchers are told about the
+// changes of the health every health interval
+func NewServer(cardService core.GiftCardService, campaignService core.CampaignService, deadlines *timeout.Deadlines,
+	healthInterval time.Duration) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(recovery, withRequestId, withDeadline(deadlines)))
+	giftcardpb.RegisterGiftCardServiceServer(server, NewGiftCardServer(cardService))
+	giftcardpb.RegisterCampaignServiceServer(server, NewCampaignServer(campaignService))
+	healthpb.RegisterHealthServer(server, NewHealthServer(health.Run, healthInterval))
+	return server
+}
+
+// Serve serves the calls of the listener until the returned function is called, which waits for the running calls
+// and closes the rest of them once its context is done
+func Serve(server *grpc.Server, listener net.Listener) func(ctx context.Context) error {
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		if err := server.Serve(listener); err != nil {
+			logger.ErrorException(err, "Error running the grpc server")
+		}
+	}()
+	return func(ctx context.Context) error {
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			server.Stop()
+		}
+		<-served
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,62 @@
+package rpc_test
+
+import (
+	"context"
+	"giftcard-engine/application/rpc"
+	"giftcard-engine/application/rpc/giftcardpb"
+	"giftcard-engine/core/logic"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/repository/memory"
+	"giftcard-engine/infrastructure/repository/sql"
+	"giftcard-engine/infrastructure/search"
+	"giftcard-engine/infrastructure/timeout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"testing"
+	"time"
+)
+
+// dial serves the server on a connection in memory and returns a client connection to it
+func dial(t *testing.T, server *grpc.Server) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	stop := rpc.Serve(server, listener)
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithInsecure())
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+		assert.Empty(t, stop(context.Background()))
+	})
+	return conn
+}
+
+// createClientsForTest serves the services of the gift cards and the campaigns which keep them in memory
+func createClientsForTest(t *testing.T, config configuration.TimeoutConfiguration) (
+	giftcardpb.GiftCardServiceClient, giftcardpb.CampaignServiceClient) {
+	database := memory.NewDatabase()
+	mapper := sql.NewMapper()
+	cardRepository := memory.NewGiftCardRepository(database)
+	index := search.NewDisabledGiftCardIndex()
+	cardService := logic.NewGiftCardService(cardRepository, memory.NewBatchRepository(database), index, mapper)
+	campaignService := logic.NewCampaignService(memory.NewCampaignRepository(database), cardRepository, index,
+		mapper)
+
+	server := rpc.NewServer(cardService, campaignService, timeout.NewDeadlines(config), time.Second)
+	conn := dial(t, server)
+	return giftcardpb.NewGiftCardServiceClient(conn), giftcardpb.NewCampaignServiceClient(conn)
+}
+
+// assertCode checks the status code of the error of a call
+func assertCode(t *testing.T, code codes.Code, err error) {
+	t.Helper()
+	require.Error(t, err)
+	assert.Equal(t, code, status.Code(err), err.Error())
+}
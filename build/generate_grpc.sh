@@ -0,0 +1,3 @@
+cd ../application/rpc/proto/
+protoc --go_out=../giftcardpb --go_opt=paths=source_relative --go-grpc_out=../giftcardpb --go-grpc_opt=paths=source_relative giftcard.proto
+cd ../../../build
\ No newline at end of file
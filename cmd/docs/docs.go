@@ -33,9 +33,9 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/campaign": {
-            "put": {
-                "description": "updates a campaign",
+        "/admin/configuration": {
+            "get": {
+                "description": "settings of the running service with their secrets masked, and the keys of the reloadable ones",
                 "consumes": [
                     "application/json"
                 ],
@@ -43,97 +43,122 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Campaign"
+                    "Admin"
                 ],
-                "summary": "updates a campaign",
-                "operationId": "update",
+                "summary": "running configuration",
+                "operationId": "admin-configuration",
                 "parameters": [
                     {
-                        "description": "Update Campaign dto",
-                        "name": "campaignDTO",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateCampaignDto"
-                        }
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignDTO"
+                            "$ref": "#/definitions/dto.ConfigurationDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/indraframework.IndraException"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "the admin token is not configured"
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "runs the liveness checks, which fail when the process should be restarted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "liveness probe",
+                "operationId": "health-live",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/indraframework.IndraException"
+                            "$ref": "#/definitions/health.Report"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
-                            "$ref": "#/definitions/indraframework.IndraException"
+                            "$ref": "#/definitions/health.Report"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "store a new campaign and generates the keys",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "runs the readiness checks, which fail when the service should not receive requests for now, like\nwhen a critical dependency is down or the service is shutting down",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Campaign"
+                    "Public"
                 ],
-                "summary": "store a campaign",
-                "operationId": "store",
-                "parameters": [
-                    {
-                        "description": "Create campaign dto",
-                        "name": "campaignDTO",
-                        "in": "body",
-                        "required": true,
+                "summary": "readiness probe",
+                "operationId": "health-ready",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateCampaignDTO"
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
                         }
                     }
+                }
+            }
+        },
+        "/health/startup": {
+            "get": {
+                "description": "runs the startup checks, which fail until the service has started",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
                 ],
+                "summary": "startup probe",
+                "operationId": "health-startup",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignDTO"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/indraframework.IndraException"
+                            "$ref": "#/definitions/health.Report"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
-                            "$ref": "#/definitions/indraframework.IndraException"
+                            "$ref": "#/definitions/health.Report"
                         }
                     }
                 }
             }
         },
-        "/v1/campaign/page/{size}/{number}": {
+        "/v1/batch/cards/{id}": {
             "get": {
-                "description": "get list of campaigns in paging object",
+                "description": "get list of the gift cards issued in a batch",
                 "consumes": [
                     "application/json"
                 ],
@@ -141,37 +166,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Campaign"
+                    "Batch"
                 ],
-                "summary": "Campaign paging",
-                "operationId": "find-page",
+                "summary": "batch gift cards",
+                "operationId": "batch-find-cards",
                 "parameters": [
                     {
-                        "type": "number",
-                        "description": "page size",
-                        "name": "size",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "description": "page number",
-                        "name": "number",
+                        "type": "integer",
+                        "description": "batch id",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "search by title",
-                        "name": "search",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CampaignPageDTO"
+                            "$ref": "#/definitions/dto.GiftCardsListDTO"
                         }
                     },
                     "400": {
@@ -179,28 +191,37 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/indraframework.IndraException"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
                     }
                 }
             }
         },
-        "/v1/campaign/{id}": {
-            "delete": {
-                "description": "deletes a campaign by id",
-                "consumes": [
-                    "application/json"
-                ],
+        "/v1/batch/export/{id}": {
+            "get": {
+                "description": "download the gift cards of a batch as a csv file, without their secret codes",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "Campaign"
+                    "Batch"
                 ],
-                "summary": "deletes a campaign",
-                "operationId": "delete",
+                "summary": "export batch",
+                "operationId": "batch-export",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "campaign's id",
+                        "description": "batch id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -208,9 +229,9 @@ var doc = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "csv file",
                         "schema": {
-                            "$ref": "#/definitions/dto.DeleteMessageDTO"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -224,13 +245,19 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/indraframework.IndraException"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
                     }
                 }
             }
         },
-        "/v1/gift-card": {
+        "/v1/batch/extend-expiry/{id}": {
             "put": {
-                "description": "updates a gift card. just the expire date and the amount can be updated",
+                "description": "changes the expire date of all of the unused gift cards of a batch",
                 "consumes": [
                     "application/json"
                 ],
@@ -238,18 +265,25 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gift Card"
+                    "Batch"
                 ],
-                "summary": "updates a gift card",
-                "operationId": "update",
+                "summary": "extend batch expire date",
+                "operationId": "batch-extend-expiry",
                 "parameters": [
                     {
-                        "description": "Update Gift Card dto",
-                        "name": "giftCardDTO",
+                        "type": "integer",
+                        "description": "batch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new expire date",
+                        "name": "extendBatchExpiryDTO",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateGiftCardDto"
+                            "$ref": "#/definitions/dto.ExtendBatchExpiryDTO"
                         }
                     }
                 ],
@@ -257,7 +291,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardDTO"
+                            "$ref": "#/definitions/dto.BatchOperationDTO"
                         }
                     },
                     "400": {
@@ -279,9 +313,11 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "store a new gift card and generates the keys",
+            }
+        },
+        "/v1/batch/page/{size}/{number}": {
+            "get": {
+                "description": "get list of gift card batches in paging object",
                 "consumes": [
                     "application/json"
                 ],
@@ -289,26 +325,37 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gift Card"
+                    "Batch"
                 ],
-                "summary": "store a gift card",
-                "operationId": "store",
+                "summary": "Batch paging",
+                "operationId": "batch-find-page",
                 "parameters": [
                     {
-                        "description": "Create Gift Card dto",
-                        "name": "giftCardDto",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateGiftCardDTO"
-                        }
+                        "type": "number",
+                        "description": "page size",
+                        "name": "size",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "page number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by the creator of the batch",
+                        "name": "createdBy",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardDTO"
+                            "$ref": "#/definitions/dto.BatchPageDTO"
                         }
                     },
                     "400": {
@@ -316,19 +363,13 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/indraframework.IndraException"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/indraframework.IndraException"
-                        }
                     }
                 }
             }
         },
-        "/v1/gift-card/approve-gift-card/{uun}/{secret}": {
-            "put": {
-                "description": "approve for single gift card",
+        "/v1/batch/void/{id}": {
+            "post": {
+                "description": "voids all of the unused gift cards of a batch at once",
                 "consumes": [
                     "application/json"
                 ],
@@ -336,22 +377,15 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gift Card"
+                    "Batch"
                 ],
-                "summary": "approve gift card",
-                "operationId": "approve-gift-card",
+                "summary": "void batch",
+                "operationId": "batch-void",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "uun",
-                        "name": "uun",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "gift card secret",
-                        "name": "secret",
+                        "type": "integer",
+                        "description": "batch id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -360,7 +394,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardStatusDTO"
+                            "$ref": "#/definitions/dto.BatchOperationDTO"
                         }
                     },
                     "400": {
@@ -384,9 +418,9 @@ var doc = `{
                 }
             }
         },
-        "/v1/gift-card/approve-gift-cards": {
-            "post": {
-                "description": "bulk approve for gift cards",
+        "/v1/campaign": {
+            "put": {
+                "description": "updates a campaign",
                 "consumes": [
                     "application/json"
                 ],
@@ -394,18 +428,18 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gift Card"
+                    "Campaign"
                 ],
-                "summary": "bulk approve gift cards",
-                "operationId": "approve-gift-cards",
+                "summary": "updates a campaign",
+                "operationId": "update",
                 "parameters": [
                     {
-                        "description": "bulk approve dto",
-                        "name": "approveGiftCardsDto",
+                        "description": "Update Campaign dto",
+                        "name": "campaignDTO",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ApproveGiftCardsDTO"
+                            "$ref": "#/definitions/dto.UpdateCampaignDto"
                         }
                     }
                 ],
@@ -413,7 +447,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardStatusListDTO"
+                            "$ref": "#/definitions/dto.CampaignDTO"
                         }
                     },
                     "400": {
@@ -422,6 +456,12 @@ var doc = `{
                             "$ref": "#/definitions/indraframework.IndraException"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -429,11 +469,9 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/gift-card/create-many": {
+            },
             "post": {
-                "description": "bulk insert for different gift cards",
+                "description": "store a new campaign and generates the keys",
                 "consumes": [
                     "application/json"
                 ],
@@ -441,14 +479,493 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gift Card"
+                    "Campaign"
                 ],
-                "summary": "bulk insert gift cards",
-                "operationId": "create-many",
+                "summary": "store a campaign",
+                "operationId": "store",
                 "parameters": [
                     {
-                        "description": "bulk insert gift cards list",
-                        "name": "createGiftCards",
+                        "description": "Create campaign dto",
+                        "name": "campaignDTO",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCampaignDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CampaignDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/campaign/cursor/{size}": {
+            "get": {
+                "description": "get list of campaigns after the given cursor. use next_cursor of the response to get the next page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Campaign keyset paging",
+                "operationId": "campaign-find-cursor-page",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page size",
+                        "name": "size",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "do not calculate total items",
+                        "name": "skipCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search by title",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CampaignCursorPageDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/campaign/page/{size}/{number}": {
+            "get": {
+                "description": "get list of campaigns in paging object",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Campaign paging",
+                "operationId": "find-page",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page size",
+                        "name": "size",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "page number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "search by title",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CampaignPageDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/campaign/stats": {
+            "get": {
+                "description": "counts and amounts of the issued, redeemed, expired, voided, deleted and outstanding cards of all campaigns",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "statistics summary",
+                "operationId": "campaign-stats-summary",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CampaignStatsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/campaign/{id}": {
+            "delete": {
+                "description": "deletes a campaign by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "deletes a campaign",
+                "operationId": "delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "campaign's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteMessageDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/campaign/{id}/stats": {
+            "get": {
+                "description": "counts and amounts of the issued, redeemed, expired, voided, deleted and outstanding cards of a campaign",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "campaign statistics",
+                "operationId": "campaign-stats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "campaign's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CampaignStatsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card": {
+            "put": {
+                "description": "updates a gift card. just the expire date and the amount can be updated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "updates a gift card",
+                "operationId": "update",
+                "parameters": [
+                    {
+                        "description": "Update Gift Card dto",
+                        "name": "giftCardDTO",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateGiftCardDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GiftCardDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "store a new gift card and generates the keys",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "store a gift card",
+                "operationId": "store",
+                "parameters": [
+                    {
+                        "description": "Create Gift Card dto",
+                        "name": "giftCardDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateGiftCardDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GiftCardDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card/approve-gift-card/{uun}/{secret}": {
+            "put": {
+                "description": "approve for single gift card",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "approve gift card",
+                "operationId": "approve-gift-card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uun",
+                        "name": "uun",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "gift card secret",
+                        "name": "secret",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GiftCardStatusDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card/approve-gift-cards": {
+            "post": {
+                "description": "bulk approve for gift cards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "bulk approve gift cards",
+                "operationId": "approve-gift-cards",
+                "parameters": [
+                    {
+                        "description": "bulk approve dto",
+                        "name": "approveGiftCardsDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApproveGiftCardsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GiftCardStatusListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card/create-many": {
+            "post": {
+                "description": "bulk insert for different gift cards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "bulk insert gift cards",
+                "operationId": "create-many",
+                "parameters": [
+                    {
+                        "description": "bulk insert gift cards list",
+                        "name": "createGiftCards",
                         "in": "body",
                         "required": true,
                         "schema": {
@@ -460,7 +977,455 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardsListDTO"
+                            "$ref": "#/definitions/dto.GiftCardsListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card/create-same-many": {
+            "post": {
+                "description": "bulk insert for the same gift cards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "bulk insert gift cards",
+                "operationId": "create-same-many",
+                "parameters": [
+                    {
+                        "description": "bulk insert for the same gift cards dto",
+                        "name": "createGiftCards",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkCreateSameGiftCardsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GiftCardsListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card/cursor/{size}": {
+            "get": {
+                "description": "get list of gift cards after the given cursor. use next_cursor of the response to get the next page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "gift cards keyset paging",
+                "operationId": "find-cursor-page",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "size",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "do not calculate total items",
+                        "name": "skipCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search in public key",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "exact uun",
+                        "name": "uun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "status of gift card",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "minimum amount",
+                        "name": "amountFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum amount",
+                        "name": "amountTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "campaign ids, repeatable or comma separated",
+                        "name": "campaignId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search in campaign title",
+                        "name": "campaignTitle",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is valid gift card",
+                        "name": "isValid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "expire date from",
+                        "name": "expireDateFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "expire date to",
+                        "name": "expireDateTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created date from",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created date to",
+                        "name": "createdTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "redeemed date from",
+                        "name": "redeemedFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "redeemed date to",
+                        "name": "redeemedTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GiftCardsCursorPageDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card/find-by-public-key/{key}": {
+            "get": {
+                "description": "find a gift card from the db",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "Get gift card details by public key",
+                "operationId": "find-by-public-key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gift Card public key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GiftCardStatusDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card/find/{id}": {
+            "get": {
+                "description": "find a gift card from the db",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "Get an gift card by id",
+                "operationId": "get-gift-card-by-id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gift Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GiftCardDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card/health": {
+            "get": {
+                "description": "the same as /health/ready, which should be used instead",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "readiness probe of the old clients",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card/info": {
+            "get": {
+                "description": "get 200 response",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Info",
+                "responses": {
+                    "200": {}
+                }
+            }
+        },
+        "/v1/gift-card/page/{size}/{number}": {
+            "get": {
+                "description": "get list of gift cards in paging object",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "gift cards paging",
+                "operationId": "find-page",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "size",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "search in public key",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "exact uun",
+                        "name": "uun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "status of gift card",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "minimum amount",
+                        "name": "amountFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum amount",
+                        "name": "amountTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "campaign ids, repeatable or comma separated",
+                        "name": "campaignId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search in campaign title",
+                        "name": "campaignTitle",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is valid gift card",
+                        "name": "isValid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "expire date from",
+                        "name": "expireDateFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "expire date to",
+                        "name": "expireDateTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created date from",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created date to",
+                        "name": "createdTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "redeemed date from",
+                        "name": "redeemedFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "redeemed date to",
+                        "name": "redeemedTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated columns, prefix with - for descending. id, amount, status, expire_date, created_at, redeemed_at",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GiftCardsPageDTO"
                         }
                     },
                     "400": {
@@ -472,9 +1437,9 @@ var doc = `{
                 }
             }
         },
-        "/v1/gift-card/create-same-many": {
-            "post": {
-                "description": "bulk insert for the same gift cards",
+        "/v1/gift-card/search/{size}/{number}": {
+            "get": {
+                "description": "fuzzy search of gift cards by the start of the public code or uun, campaign title and amount",
                 "consumes": [
                     "application/json"
                 ],
@@ -484,24 +1449,36 @@ var doc = `{
                 "tags": [
                     "Gift Card"
                 ],
-                "summary": "bulk insert gift cards",
-                "operationId": "create-same-many",
+                "summary": "gift card search",
+                "operationId": "search-gift-cards",
                 "parameters": [
                     {
-                        "description": "bulk insert for the same gift cards dto",
-                        "name": "createGiftCards",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.BulkCreateSameGiftCardsDTO"
-                        }
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "size",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardsListDTO"
+                            "$ref": "#/definitions/dto.GiftCardsPageDTO"
                         }
                     },
                     "400": {
@@ -509,13 +1486,19 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/indraframework.IndraException"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
                     }
                 }
             }
         },
-        "/v1/gift-card/find-by-public-key/{key}": {
+        "/v1/gift-card/user-gift-cards/{uun}": {
             "get": {
-                "description": "find a gift card from the db",
+                "description": "get list of user's gift cards",
                 "consumes": [
                     "application/json"
                 ],
@@ -525,13 +1508,13 @@ var doc = `{
                 "tags": [
                     "Gift Card"
                 ],
-                "summary": "Get gift card details by public key",
-                "operationId": "find-by-public-key",
+                "summary": "user gift cards",
+                "operationId": "find-by-uun",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Gift Card public key",
-                        "name": "key",
+                        "description": "uun",
+                        "name": "uun",
                         "in": "path",
                         "required": true
                     }
@@ -540,7 +1523,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardStatusDTO"
+                            "$ref": "#/definitions/dto.GiftCardsListDTO"
                         }
                     },
                     "404": {
@@ -552,9 +1535,9 @@ var doc = `{
                 }
             }
         },
-        "/v1/gift-card/find/{id}": {
+        "/v1/gift-card/validate-gift-card/{secret}": {
             "get": {
-                "description": "find a gift card from the db",
+                "description": "validate gift card",
                 "consumes": [
                     "application/json"
                 ],
@@ -564,12 +1547,98 @@ var doc = `{
                 "tags": [
                     "Gift Card"
                 ],
-                "summary": "Get an gift card by id",
-                "operationId": "get-gift-card-by-id",
+                "summary": "validate gift card",
+                "operationId": "validate-gift-card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "gift card secret",
+                        "name": "secret",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GiftCardStatusDTO"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card/validate-gift-cards": {
+            "post": {
+                "description": "bulk validate for gift cards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "bulk validate gift cards",
+                "operationId": "validate-gift-cards",
+                "parameters": [
+                    {
+                        "description": "bulk validate dto",
+                        "name": "validateGiftCardsDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidateGiftCardsDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GiftCardStatusListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gift-card/{id}": {
+            "delete": {
+                "description": "deletes a gift card by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Card"
+                ],
+                "summary": "deletes a gift card",
+                "operationId": "delete",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Gift Card ID",
+                        "description": "Gift Card's id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -579,7 +1648,148 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardDTO"
+                            "$ref": "#/definitions/dto.DeleteMessageDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/gift-card": {
+            "get": {
+                "description": "issued and redeemed count and value of gift cards per day, week or month of a date range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "gift card time series",
+                "operationId": "report-gift-card-series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "first day of the report. 2006-01-02",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day of the report. 2006-01-02",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "day, week or month. default is day",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the periods like Asia/Tehran. default is UTC",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "campaign id",
+                        "name": "campaignId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json or csv. default is json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/liability": {
+            "get": {
+                "description": "outstanding count and value of the unredeemed and unexpired gift cards of every campaign at the end of a date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "outstanding liability",
+                "operationId": "report-liability",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "the as of date. 2006-01-02",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the date like Asia/Tehran. default is UTC",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "campaign id",
+                        "name": "campaignId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json or csv. default is json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LiabilityDTO"
                         }
                     },
                     "400": {
@@ -588,8 +1798,8 @@ var doc = `{
                             "$ref": "#/definitions/indraframework.IndraException"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/indraframework.IndraException"
                         }
@@ -597,27 +1807,9 @@ var doc = `{
                 }
             }
         },
-        "/v1/gift-card/health": {
-            "get": {
-                "description": "get 200 response",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Public"
-                ],
-                "summary": "test Endpoint",
-                "responses": {
-                    "200": {}
-                }
-            }
-        },
-        "/v1/gift-card/info": {
+        "/v1/webhook": {
             "get": {
-                "description": "get 200 response",
+                "description": "get every webhook subscription. the secrets are not returned",
                 "consumes": [
                     "application/json"
                 ],
@@ -625,17 +1817,27 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Public"
+                    "Webhook"
                 ],
-                "summary": "Info",
+                "summary": "webhook subscriptions",
+                "operationId": "webhook-find-all",
                 "responses": {
-                    "200": {}
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookListDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    }
                 }
-            }
-        },
-        "/v1/gift-card/page/{size}/{number}": {
-            "get": {
-                "description": "get list of gift cards in paging object",
+            },
+            "put": {
+                "description": "changes the url and the events of a webhook subscription. the secret is kept when it is empty",
                 "consumes": [
                     "application/json"
                 ],
@@ -643,61 +1845,26 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gift Card"
+                    "Webhook"
                 ],
-                "summary": "gift cards paging",
-                "operationId": "find-page",
+                "summary": "updates a webhook",
+                "operationId": "webhook-update",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "size",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page number",
-                        "name": "number",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "campaign id",
-                        "name": "campaignId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "search in public key",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "is valid gift card",
-                        "name": "isValid",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "expire date from",
-                        "name": "expireDateFrom",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "expire date to",
-                        "name": "expireDateTo",
-                        "in": "query"
+                        "description": "Update webhook dto",
+                        "name": "webhookDTO",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateWebhookDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardsPageDTO"
+                            "$ref": "#/definitions/dto.WebhookDTO"
                         }
                     },
                     "400": {
@@ -705,13 +1872,23 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/indraframework.IndraException"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
                     }
                 }
-            }
-        },
-        "/v1/gift-card/user-gift-cards/{uun}": {
-            "get": {
-                "description": "get list of user's gift cards",
+            },
+            "post": {
+                "description": "subscribes an url to the events of the gift cards, which are posted to it as json signed with\nthe secret in the X-Gift-Card-Signature header. the events are gift_card.issued,\ngift_card.approved, gift_card.rolled_back, gift_card.updated, gift_card.voided,\ngift_card.expired and gift_card.deleted",
                 "consumes": [
                     "application/json"
                 ],
@@ -719,28 +1896,36 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gift Card"
+                    "Webhook"
                 ],
-                "summary": "user gift cards",
-                "operationId": "find-by-uun",
+                "summary": "subscribe a webhook",
+                "operationId": "webhook-create",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "uun",
-                        "name": "uun",
-                        "in": "path",
-                        "required": true
+                        "description": "Create webhook dto",
+                        "name": "webhookDTO",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWebhookDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardsListDTO"
+                            "$ref": "#/definitions/dto.WebhookDTO"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/indraframework.IndraException"
                         }
@@ -748,9 +1933,9 @@ var doc = `{
                 }
             }
         },
-        "/v1/gift-card/validate-gift-card/{secret}": {
+        "/v1/webhook/dead-letter/{size}/{number}": {
             "get": {
-                "description": "validate gift card",
+                "description": "get the webhook deliveries which ran out of attempts in paging object, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -758,15 +1943,22 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gift Card"
+                    "Webhook"
                 ],
-                "summary": "validate gift card",
-                "operationId": "validate-gift-card",
+                "summary": "dead webhook deliveries",
+                "operationId": "webhook-dead-letters",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "gift card secret",
-                        "name": "secret",
+                        "type": "number",
+                        "description": "page size",
+                        "name": "size",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "page number",
+                        "name": "number",
                         "in": "path",
                         "required": true
                     }
@@ -775,15 +1967,21 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardStatusDTO"
+                            "$ref": "#/definitions/dto.WebhookDeliveryPageDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
                         }
                     }
                 }
             }
         },
-        "/v1/gift-card/validate-gift-cards": {
+        "/v1/webhook/redeliver/{id}": {
             "post": {
-                "description": "bulk validate for gift cards",
+                "description": "makes a dead webhook delivery pending again, so it is sent with all of its attempts",
                 "consumes": [
                     "application/json"
                 ],
@@ -791,26 +1989,24 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gift Card"
+                    "Webhook"
                 ],
-                "summary": "bulk validate gift cards",
-                "operationId": "validate-gift-cards",
+                "summary": "redeliver a webhook",
+                "operationId": "webhook-redeliver",
                 "parameters": [
                     {
-                        "description": "bulk validate dto",
-                        "name": "validateGiftCardsDto",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ValidateGiftCardsDto"
-                        }
+                        "type": "integer",
+                        "description": "delivery id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GiftCardStatusListDTO"
+                            "$ref": "#/definitions/dto.WebhookDeliveryDTO"
                         }
                     },
                     "400": {
@@ -818,13 +2014,25 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/indraframework.IndraException"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/indraframework.IndraException"
+                        }
                     }
                 }
             }
         },
-        "/v1/gift-card/{id}": {
+        "/v1/webhook/{id}": {
             "delete": {
-                "description": "deletes a gift card by id",
+                "description": "deletes a webhook subscription. its pending deliveries become dead letters",
                 "consumes": [
                     "application/json"
                 ],
@@ -832,14 +2040,14 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gift Card"
+                    "Webhook"
                 ],
-                "summary": "deletes a gift card",
-                "operationId": "delete",
+                "summary": "deletes a webhook",
+                "operationId": "webhook-delete",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Gift Card's id",
+                        "description": "webhook's id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -883,9 +2091,54 @@ var doc = `{
                 }
             }
         },
+        "dto.BatchOperationDTO": {
+            "type": "object",
+            "properties": {
+                "affected_cards": {
+                    "type": "integer"
+                },
+                "batch_id": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BatchPageDTO": {
+            "type": "object",
+            "properties": {
+                "batches": {
+                    "type": "array",
+                    "items": {
+                        "type": "BatchDTO"
+                    }
+                },
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.BulkCreateGiftCardsDTO": {
             "type": "object",
             "properties": {
+                "created_by": {
+                    "type": "string"
+                },
                 "gift_cards": {
                     "type": "array",
                     "items": {
@@ -900,14 +2153,41 @@ var doc = `{
                 "amount": {
                     "type": "integer"
                 },
-                "campaign_id": {
-                    "type": "integer"
+                "campaign_id": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "expire_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CampaignCursorPageDTO": {
+            "type": "object",
+            "properties": {
+                "campaigns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CampaignDTO"
+                    }
+                },
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
                 },
-                "count": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "size": {
                     "type": "integer"
                 },
-                "expire_date": {
-                    "type": "string"
+                "total_items": {
+                    "type": "integer"
                 }
             }
         },
@@ -951,6 +2231,63 @@ var doc = `{
                 }
             }
         },
+        "dto.CampaignStatsDTO": {
+            "type": "object",
+            "properties": {
+                "average_seconds_to_redeem": {
+                    "type": "number"
+                },
+                "campaign_id": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "type": "CardsAggregateDTO"
+                },
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
+                },
+                "expired": {
+                    "type": "CardsAggregateDTO"
+                },
+                "issued": {
+                    "type": "CardsAggregateDTO"
+                },
+                "outstanding": {
+                    "type": "CardsAggregateDTO"
+                },
+                "redeemed": {
+                    "type": "CardsAggregateDTO"
+                },
+                "redemption_rate": {
+                    "type": "number"
+                },
+                "voided": {
+                    "type": "CardsAggregateDTO"
+                }
+            }
+        },
+        "dto.ConfigurationDTO": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
+                },
+                "reloadable": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "settings": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "dto.CreateCampaignDTO": {
             "type": "object",
             "properties": {
@@ -973,6 +2310,23 @@ var doc = `{
                 }
             }
         },
+        "dto.CreateWebhookDTO": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.DeleteMessageDTO": {
             "type": "object",
             "properties": {
@@ -989,12 +2343,23 @@ var doc = `{
                 }
             }
         },
+        "dto.ExtendBatchExpiryDTO": {
+            "type": "object",
+            "properties": {
+                "expire_date": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.GiftCardDTO": {
             "type": "object",
             "properties": {
                 "amount": {
                     "type": "integer"
                 },
+                "batch_id": {
+                    "type": "integer"
+                },
                 "campaign_id": {
                     "type": "integer"
                 },
@@ -1018,6 +2383,9 @@ var doc = `{
                 "public_code": {
                     "type": "string"
                 },
+                "redeemed_at": {
+                    "type": "string"
+                },
                 "secret_code": {
                     "type": "string"
                 },
@@ -1071,9 +2439,36 @@ var doc = `{
                 }
             }
         },
+        "dto.GiftCardsCursorPageDTO": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
+                },
+                "gift_cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GiftCardDTO"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.GiftCardsListDTO": {
             "type": "object",
             "properties": {
+                "batch_id": {
+                    "type": "integer"
+                },
                 "error": {
                     "type": "object",
                     "$ref": "#/definitions/indraframework.IndraException"
@@ -1110,6 +2505,63 @@ var doc = `{
                 }
             }
         },
+        "dto.LiabilityDTO": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string"
+                },
+                "campaigns": {
+                    "type": "array",
+                    "items": {
+                        "type": "CampaignLiabilityDTO"
+                    }
+                },
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
+                },
+                "outstanding_amount": {
+                    "type": "integer"
+                },
+                "outstanding_count": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReportDTO": {
+            "type": "object",
+            "properties": {
+                "campaign_id": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "type": "ReportPointDTO"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.UpdateCampaignDto": {
             "type": "object",
             "properties": {
@@ -1135,6 +2587,26 @@ var doc = `{
                 }
             }
         },
+        "dto.UpdateWebhookDTO": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.ValidateGiftCardsDto": {
             "type": "object",
             "properties": {
@@ -1146,6 +2618,124 @@ var doc = `{
                 }
             }
         },
+        "dto.WebhookDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WebhookDeliveryDTO": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.WebhookDeliveryPageDTO": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookDeliveryDTO"
+                    }
+                },
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.WebhookListDTO": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/indraframework.IndraException"
+                },
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookDTO"
+                    }
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/health.HealthResultDto"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "totalDuration": {
+                    "type": "string"
+                }
+            }
+        },
         "indraframework.IndraException": {
             "type": "object",
             "properties": {
@@ -1155,6 +2745,9 @@ var doc = `{
                 "message": {
                     "type": "string"
                 },
+                "requestId": {
+                    "type": "string"
+                },
                 "severity": {
                     "type": "integer"
                 },
@@ -1,50 +1,203 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"giftcard-engine/application/api"
 	"giftcard-engine/application/api/handlers"
+	"giftcard-engine/application/rpc"
 	"giftcard-engine/cmd/docs"
+	"giftcard-engine/core"
 	"giftcard-engine/core/logic"
+	"giftcard-engine/infrastructure/admin"
+	"giftcard-engine/infrastructure/broker"
 	"giftcard-engine/infrastructure/config"
+	"giftcard-engine/infrastructure/config/configuration"
 	"giftcard-engine/infrastructure/health"
+	"giftcard-engine/infrastructure/lifecycle"
 	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/infrastructure/metrics"
+	"giftcard-engine/infrastructure/outbox"
+	"giftcard-engine/infrastructure/repository/memory"
 	"giftcard-engine/infrastructure/repository/sql"
+	"giftcard-engine/infrastructure/search"
+	"giftcard-engine/infrastructure/timeout"
+	"giftcard-engine/infrastructure/tracing"
+	"giftcard-engine/infrastructure/webhook"
+	"giftcard-engine/utils/random"
 	"github.com/jinzhu/gorm"
 	"github.com/swaggo/files"
 	"github.com/swaggo/gin-swagger"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+	_ "time/tzdata"
 )
 
 var db *gorm.DB
 
+// configReloadInterval is how often the configuration file is checked for changes
+const configReloadInterval = 5 * time.Second
+
+// grpcHealthInterval is how often the watchers of the grpc health are told about its changes
+const grpcHealthInterval = 5 * time.Second
+
 func main() {
+	config.BindFlags(flag.CommandLine)
+	flag.Parse()
 	configurations := config.Get()
+	random.SetKeyLengths(configurations.Security.SecretCodeLength, configurations.Security.PublicCodeLength)
 
-	db = sql.InitDatabase(configurations.ConnectionStrings.DefaultConnection)
-	defer db.Close()
+	gRepository, campaignRepository, batchRepository, webhookRepository, outboxRepository, commandRepository :=
+		repositories(configurations.ConnectionStrings)
 	logger.ConfigureLogger(
 		logger.LoggerConfiguration{
 			ServiceName: configurations.ServiceName,
 			Environment: configurations.Environment,
-			ElasticUrl:  configurations.ElasticUrl,
+			ElasticUrl:  configurations.Logging.ElasticUrl,
+			Level:       configurations.Logging.Level,
 		})
-	health.ConfigureHealthChecks(db)
-	gRepository := sql.NewGiftCardRepository(db)
-	campaignRepository := sql.NewCampaignRepository(db)
+	shutdownTracing, err := tracing.ConfigureTracing(configurations.ServiceName, configurations.Environment,
+		configurations.Tracing)
+	if err != nil {
+		logger.FatalException(err, "Error configuring the tracing exporter")
+	}
+	health.ConfigureHealthChecks(db, configurations.Health)
+	metrics.ConfigureMetrics(db)
+	gIndex := search.InitGiftCardIndex(configurations.Search.Url, configurations.Search.Index)
+	if closer, ok := gIndex.(io.Closer); ok {
+		lifecycle.OnStop("search index", func(context.Context) error { return closer.Close() })
+	}
 	gMapper := sql.NewMapper()
-	gService := logic.NewGiftCardService(gRepository, gMapper)
-	campaignService := logic.NewCampaignService(campaignRepository, gMapper)
+	webhookService := logic.NewWebhookService(webhookRepository, gRepository, gMapper)
+	gService := logic.NewGiftCardService(gRepository, batchRepository, gIndex, gMapper)
+	campaignService := logic.NewCampaignService(campaignRepository, gRepository, gIndex, gMapper)
+	batchService := logic.NewBatchService(batchRepository, gRepository, gIndex, gMapper)
+	searchService := logic.NewSearchService(gRepository, gIndex, gMapper)
+	reportService := logic.NewReportService(gRepository)
 	gHandler := handlers.NewGiftCardHandler(gService)
 	cHandler := handlers.NewCampaignHandler(campaignService)
+	bHandler := handlers.NewBatchHandler(batchService)
+	sHandler := handlers.NewSearchHandler(searchService)
+	rHandler := handlers.NewReportHandler(reportService)
+	wHandler := handlers.NewWebhookHandler(webhookService)
 	//routes
-	route := api.CreateRoute(gHandler, cHandler)
+	deadlines := timeout.NewDeadlines(configurations.Timeout)
+	route := api.CreateRoute(gHandler, cHandler, bHandler, sHandler, rHandler, wHandler, deadlines)
 	//swagger
-	docs.SwaggerInfo.Host = fmt.Sprintf("%s:%v", configurations.Server.OutSideOfContainerHost,
-		configurations.Server.OutSideOfContainerPort)
-	route.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	if configurations.Features.Swagger {
+		docs.SwaggerInfo.Host = fmt.Sprintf("%s:%v", configurations.Server.OutSideOfContainerHost,
+			configurations.Server.OutSideOfContainerPort)
+		route.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
+	//admin
+	adminRoute := route.Group("admin", admin.Middleware(func() string { return config.Get().Security.AdminToken }))
+	adminRoute.GET("/configuration", handlers.NewAdminHandler(config.Get).Configuration)
 
-	err := route.Run(fmt.Sprintf(":%d", configurations.Server.Port))
-	if err != nil {
-		logger.Panic(err.Error())
+	//reload
+	config.Subscribe(func(configurations configuration.Configurations) {
+		logger.SetLevel(configurations.Logging.Level)
+		random.SetKeyLengths(configurations.Security.SecretCodeLength, configurations.Security.PublicCodeLength)
+		deadlines.Set(configurations.Timeout)
+	})
+	stopWatch := config.Watch(configReloadInterval)
+	lifecycle.OnStop("configuration watch", func(context.Context) error {
+		stopWatch()
+		return nil
+	})
+
+	//events
+	bus := core.NewEventBus()
+	bus.Subscribe("webhooks", webhook.NewEventPublisher(webhookService, gMapper))
+	if messageBroker := connectBroker(configurations); messageBroker != nil {
+		lifecycle.OnStop("message broker", func(context.Context) error { return messageBroker.Close() })
+		prefix := configurations.Broker.SubjectPrefix
+		bus.Subscribe("broker", broker.NewEventPublisher(messageBroker, prefix))
+		if configurations.Broker.Commands {
+			stopCommands, err := broker.NewConsumer(messageBroker, gService, commandRepository, prefix).Start()
+			if err != nil {
+				logger.FatalException(err, "Error subscribing to the broker commands")
+			}
+			lifecycle.OnStop("broker commands", stopCommands)
+		}
+	}
+	if configurations.Outbox.LogEvents {
+		bus.Subscribe("log", outbox.NewLogPublisher())
+	}
+	lifecycle.OnStop("outbox relay", outbox.NewRelay(outboxRepository, bus, configurations.Outbox).Start())
+
+	//webhooks
+	lifecycle.OnStop("webhook dispatcher", webhook.NewDispatcher(webhookRepository, configurations.Webhooks).Start())
+	if configurations.Webhooks.ExpirySweep > 0 {
+		lifecycle.OnStop("webhook expiry sweep",
+			webhook.StartExpirySweep(webhookService, configurations.Webhooks.ExpirySweep))
+	}
+
+	//grpc
+	if port := configurations.Server.GrpcPort; port > 0 {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			logger.FatalException(err, "Error listening for the grpc calls")
+		}
+		grpcServer := rpc.NewServer(gService, campaignService, deadlines, grpcHealthInterval)
+		lifecycle.OnStop("grpc server", rpc.Serve(grpcServer, listener))
+	}
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", configurations.Server.Port),
+		Handler:           route,
+		ReadHeaderTimeout: configurations.Server.ReadHeaderTimeout,
+		IdleTimeout:       configurations.Server.IdleTimeout,
+	}
+	serve(server, configurations.Server, func(ctx context.Context) {
+		lifecycle.Stop(ctx)
+		if err := shutdownTracing(ctx); err != nil {
+			logger.ErrorException(err, "Error flushing the spans")
+		}
+		if err := logger.Flush(ctx); err != nil {
+			log.Println("Error flushing the logs:", err)
+		}
+		if db == nil {
+			return
+		}
+		if err := db.Close(); err != nil {
+			log.Println("Error closing the database:", err)
+		}
+	})
+}
+
+// connectBroker returns the message broker of the configured driver, or nil when the broker is disabled
+func connectBroker(configurations configuration.Configurations) broker.Broker {
+	switch configurations.Broker.Driver {
+	case "nats":
+		ctx, cancel := context.WithTimeout(context.Background(), configurations.Broker.Timeout)
+		defer cancel()
+		messageBroker, err := broker.DialNATS(ctx, configurations.Broker, configurations.ServiceName)
+		if err != nil {
+			logger.FatalException(err, "Error connecting to the message broker")
+		}
+		return messageBroker
+	case "memory":
+		logger.Print("The broker messages are kept in the process and are not sent to the other services\n")
+		return broker.NewMemoryBroker()
+	}
+	return nil
+}
+
+// repositories returns the repositories of the configured driver. db is left nil when the data is kept in memory
+func repositories(config configuration.DatabaseConfiguration) (core.GiftCardRepository, core.CampaignRepository,
+	core.BatchRepository, core.WebhookRepository, core.OutboxRepository, core.CommandRepository) {
+	if config.Driver == memory.Driver {
+		logger.Print("The data is kept in memory and is lost on shutdown\n")
+		database := memory.NewDatabase()
+		return memory.NewGiftCardRepository(database), memory.NewCampaignRepository(database),
+			memory.NewBatchRepository(database), memory.NewWebhookRepository(database),
+			memory.NewOutboxRepository(database), memory.NewCommandRepository(database)
 	}
+	db = sql.InitDatabase(config)
+	return sql.NewGiftCardRepository(db), sql.NewCampaignRepository(db), sql.NewBatchRepository(db),
+		sql.NewWebhookRepository(db), sql.NewOutboxRepository(db), sql.NewCommandRepository(db)
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"giftcard-engine/infrastructure/config"
+	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/infrastructure/repository/sql"
+	"giftcard-engine/infrastructure/repository/sql/migration"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const usage = `usage: migrate [flags] up | down [steps] | status
+
+  up       applies the pending migrations
+  down     reverts the last applied migration, or the given number of them
+  status   lists the migrations and when they were applied
+
+flags:
+`
+
+// migrate changes the schema of the sql database to the versions of this build
+func main() {
+	timeout := flag.Duration("timeout", 5*time.Minute, "time to wait for the schema lock and the migrations")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	config.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	configurations := config.Get()
+	logger.ConfigureLogger(
+		logger.LoggerConfiguration{
+			ServiceName: configurations.ServiceName,
+			Environment: configurations.Environment,
+			ElasticUrl:  configurations.Logging.ElasticUrl,
+			Level:       configurations.Logging.Level,
+		})
+
+	db, err := sql.Open(configurations.ConnectionStrings.Driver, configurations.ConnectionStrings.DefaultConnection)
+	if err != nil {
+		logger.FatalException(err, "Error connecting to the database")
+	}
+	defer db.Close()
+	migrator := sql.NewMigrator(db)
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	switch flag.Arg(0) {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		report("applied", applied)
+		if err != nil {
+			logger.FatalException(err, "Error applying the migrations")
+		}
+	case "down":
+		steps := 1
+		if flag.NArg() > 1 {
+			steps, err = strconv.Atoi(flag.Arg(1))
+			if err != nil || steps < 1 {
+				logger.Fatal("the steps of down should be a positive number")
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		report("reverted", reverted)
+		if err != nil {
+			logger.FatalException(err, "Error reverting the migrations")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			logger.FatalException(err, "Error reading the schema version")
+		}
+		printStatus(statuses)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func report(action string, migrations []migration.Migration) {
+	for _, m := range migrations {
+		logger.Info(fmt.Sprintf("%s migration %d: %s", action, m.Version, m.Description))
+	}
+	if len(migrations) == 0 {
+		logger.Info(fmt.Sprintf("no migration is %s", action))
+	}
+}
+
+func printStatus(statuses []migration.Status) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tDESCRIPTION\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.Applied() {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\n", status.Version, status.Description, appliedAt)
+	}
+	_ = writer.Flush()
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"giftcard-engine/core/logic"
+	"giftcard-engine/infrastructure/config"
+	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/infrastructure/repository/sql"
+	"giftcard-engine/infrastructure/search"
+)
+
+// reindex writes every gift card of the sql database into the search index
+func main() {
+	chunkSize := flag.Uint("chunk", 1000, "number of gift cards indexed in each request")
+	config.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	configurations := config.Get()
+	logger.ConfigureLogger(
+		logger.LoggerConfiguration{
+			ServiceName: configurations.ServiceName,
+			Environment: configurations.Environment,
+			ElasticUrl:  configurations.Logging.ElasticUrl,
+			Level:       configurations.Logging.Level,
+		})
+	if configurations.Search.Url == "" {
+		logger.Fatal("search url is not configured")
+	}
+
+	db := sql.InitDatabase(configurations.ConnectionStrings)
+	defer db.Close()
+	index := search.InitGiftCardIndex(configurations.Search.Url, configurations.Search.Index)
+	service := logic.NewSearchService(sql.NewGiftCardRepository(db), index, sql.NewMapper())
+
+	indexed, err := service.Reindex(context.Background(), *chunkSize)
+	if err != nil {
+		logger.FatalException(err, fmt.Sprintf("reindex stopped after %d gift cards", indexed))
+	}
+	logger.Info(fmt.Sprintf("%d gift cards are indexed", indexed))
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/health"
+	"giftcard-engine/infrastructure/logger"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// serve runs the server until SIGINT or SIGTERM. on the signal the service reports unready for the shutdown
+// delay so the load balancers stop sending requests, then the in-flight requests are drained until the shutdown
+// timeout and at last stop releases the rest of the resources until the stop timeout, so a slow drain does not
+// leave the jobs, the traces and the logs without time to stop
+func serve(server *http.Server, config configuration.ServerConfiguration, stop func(ctx context.Context)) {
+	failed := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			failed <- err
+		}
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-failed:
+		logger.ErrorException(err, "Error running the server")
+		stopWithin(config.StopTimeout, stop)
+		os.Exit(1)
+	case received := <-signals:
+		logger.WithData(map[string]interface{}{"signal": received.String()}).Info("Shutting down")
+	}
+	signal.Stop(signals)
+
+	health.BeginShutdown()
+	time.Sleep(config.ShutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		logger.ErrorException(err, "Error draining the in-flight requests")
+	}
+	stopWithin(config.StopTimeout, stop)
+}
+
+// stopWithin runs stop with a context of its own timeout
+func stopWithin(timeout time.Duration, stop func(ctx context.Context)) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	stop(ctx)
+}
@@ -11,8 +11,25 @@ var (
 	GiftCardIsTaken             = errors.New("the Gift card is taken by another user")
 	GiftCardIsNotValid          = errors.New("this gift card is not valid anymore. you cannot update it")
 	ExpireDateIsNotInValidRange = errors.New("the expire date should be after today")
+	ExpireDateIsBeforeCurrent   = errors.New("the expire date should not be before the current one of the cards")
 	DuplicatedCampaignTitle     = errors.New("duplicated campaign name")
 	InvalidCampaign             = errors.New("invalid campaign")
 	InvalidCampaignQueryParam   = errors.New("invalid campaign query param")
 	InvalidIsValidQueryParam    = errors.New("invalid isValid query param")
+	BatchNotFound               = errors.New("batch cannot be found")
+	GiftCardIsVoided            = errors.New("the gift card is voided")
+	InvalidCursor               = errors.New("invalid cursor")
+	InvalidStatusQueryParam     = errors.New("invalid status query param")
+	InvalidAmountQueryParam     = errors.New("invalid amount query param")
+	InvalidSortQueryParam       = errors.New("invalid sort query param")
+	SortIsNotSupportedByCursor  = errors.New("sort is not supported by cursor paging")
+	EmptySearchQuery            = errors.New("search query cannot be empty")
+	SearchIsNotAvailable        = errors.New("search is not available")
+	InvalidTimezone             = errors.New("invalid timezone")
+	InvalidReportRange          = errors.New("the end of the report is before its start")
+	ReportRangeIsTooLong        = errors.New("the report has too many periods")
+	InvalidReportFormat         = errors.New("invalid report format")
+	WebhookNotFound             = errors.New("webhook subscription cannot be found")
+	WebhookDeliveryNotFound     = errors.New("webhook delivery cannot be found")
+	WebhookDeliveryIsNotDead    = errors.New("only the dead webhook deliveries can be redelivered")
 )
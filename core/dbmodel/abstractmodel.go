@@ -1,10 +1,6 @@
 package dbmodel
 
-import (
-	"time"
-
-	_ "github.com/jinzhu/gorm/dialects/mssql"
-)
+import "time"
 
 //Model Using int instead of uint for default gorm model
 type AbstractModel struct {
@@ -0,0 +1,28 @@
+package dbmodel
+
+// Batch is a sql model for grouping the gift cards issued by a single bulk request
+type Batch struct {
+	AbstractModel
+	CreatedBy   string `gorm:"column:CreatedBy"`
+	Parameters  string `gorm:"column:Parameters;type:text"`
+	Count       int    `gorm:"column:Count;not null"`
+	TotalAmount int64  `gorm:"column:TotalAmount;not null"`
+}
+
+func NewBatch(createdBy, parameters string) *Batch {
+	return &Batch{
+		CreatedBy:  createdBy,
+		Parameters: parameters,
+	}
+}
+
+// AddCard counts a card with the given amount in the batch totals
+func (b *Batch) AddCard(amount int32) {
+	b.Count++
+	b.TotalAmount += int64(amount)
+}
+
+// TableName returns the sql table name for changing the default naming system
+func (*Batch) TableName() string {
+	return "Batch"
+}
@@ -0,0 +1,29 @@
+package dbmodel_test
+
+import (
+	"giftcard-engine/core/dbmodel"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewBatch(t *testing.T) {
+	t.Parallel()
+	batch := dbmodel.NewBatch("milawd", "{}")
+
+	assert.Equal(t, "milawd", batch.CreatedBy)
+	assert.Equal(t, "{}", batch.Parameters)
+	assert.Empty(t, batch.ID)
+	assert.Empty(t, batch.Count)
+	assert.Empty(t, batch.TotalAmount)
+}
+
+func TestBatchAddCard(t *testing.T) {
+	t.Parallel()
+	batch := dbmodel.NewBatch("milawd", "{}")
+
+	batch.AddCard(2000)
+	batch.AddCard(3000)
+
+	assert.Equal(t, 2, batch.Count)
+	assert.Equal(t, int64(5000), batch.TotalAmount)
+}
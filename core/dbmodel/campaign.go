@@ -3,12 +3,16 @@ package dbmodel
 type Campaign struct {
 	AbstractModel
 	Title string `gorm:"column:Title;unique_index;not null"`
+	// events are raised by the changes of the campaign since it is loaded, and are stored in the outbox with it
+	events []string
 }
 
 func NewCampaign(title string) *Campaign {
-	return &Campaign{
+	campaign := &Campaign{
 		Title: title,
 	}
+	campaign.raise(CampaignCreatedEvent)
+	return campaign
 }
 
 func EmptyCampaign() Campaign {
@@ -16,9 +20,27 @@ func EmptyCampaign() Campaign {
 }
 
 func (c *Campaign) Update(title string) {
+	if c.Title != title {
+		c.raise(CampaignUpdatedEvent)
+	}
 	c.Title = title
 }
 
 func (*Campaign) TableName() string {
 	return "Campaign"
 }
+
+// raise records an event of the campaign
+func (c *Campaign) raise(event string) {
+	c.events = append(c.events, event)
+}
+
+// Events returns the events raised since the campaign is loaded or its events are cleared
+func (c Campaign) Events() []string {
+	return c.events
+}
+
+// ClearEvents forgets the events once they are stored in the outbox
+func (c *Campaign) ClearEvents() {
+	c.events = nil
+}
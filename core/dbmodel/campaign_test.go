@@ -37,3 +37,19 @@ func TestUpdateCampaign(t *testing.T) {
 	assert.NotEqual(t, "test", camp.Title)
 	assert.Equal(t, "dastan", camp.Title)
 }
+
+func TestCampaignEvents(t *testing.T) {
+	t.Parallel()
+	camp := dbmodel.NewCampaign("test")
+	assert.Equal(t, []string{dbmodel.CampaignCreatedEvent}, camp.Events())
+	camp.ClearEvents()
+
+	camp.Update("test")
+	camp.Update("dastan")
+
+	assert.Equal(t, []string{dbmodel.CampaignUpdatedEvent}, camp.Events(),
+		"an update of the same title should raise no event")
+	camp.ClearEvents()
+	assert.Empty(t, camp.Events())
+	assert.Empty(t, dbmodel.EmptyCampaign().Events())
+}
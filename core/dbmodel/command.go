@@ -0,0 +1,37 @@
+package dbmodel
+
+import "time"
+
+// Command is a sql model for a command of another service, which is claimed by the instance handling it. the
+// result is kept once it is handled, so a repeated command is answered with it instead of being handled again
+type Command struct {
+	ID        int `gorm:"primary_key"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CommandId string     `gorm:"column:CommandId;unique_index;not null"`
+	ClaimedAt time.Time  `gorm:"column:ClaimedAt;not null"`
+	Result    string     `gorm:"column:Result;type:text"`
+	HandledAt *time.Time `gorm:"column:HandledAt"`
+}
+
+// TableName returns the sql table name for changing the default naming system
+func (*Command) TableName() string {
+	return "Command"
+}
+
+// NewCommand returns a command which is claimed at the given moment
+func NewCommand(commandId string, at time.Time) *Command {
+	return &Command{CommandId: commandId, ClaimedAt: at.UTC()}
+}
+
+// Handled keeps the result of the command
+func (c *Command) Handled(result string, at time.Time) {
+	at = at.UTC()
+	c.Result = result
+	c.HandledAt = &at
+}
+
+// IsHandled reports whether the command has its result, or it is still being handled
+func (c Command) IsHandled() bool {
+	return c.HandledAt != nil
+}
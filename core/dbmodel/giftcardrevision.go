@@ -0,0 +1,18 @@
+package dbmodel
+
+import "time"
+
+// GiftCardRevision keeps the terms a gift card had until they were changed at RevisedAt. the history
+// lets the reports find the amount and the expire date of a card at any moment of the past
+type GiftCardRevision struct {
+	ID         int       `gorm:"primary_key"`
+	GiftCardId int       `gorm:"column:GiftCardId;index;not null"`
+	Amount     int32     `gorm:"column:Amount;not null"`
+	ExpireDate time.Time `gorm:"column:ExpireDate;not null"`
+	RevisedAt  time.Time `gorm:"column:RevisedAt;not null"`
+}
+
+// TableName returns the sql table name for changing the default naming system
+func (*GiftCardRevision) TableName() string {
+	return "GiftCardRevision"
+}
@@ -4,4 +4,5 @@ const (
 	_ = iota
 	Empty
 	Approved
+	Voided
 )
@@ -0,0 +1,35 @@
+package dbmodel
+
+import "time"
+
+// GiftCardValidity is the single definition of a valid gift card. the model checks it with Matches
+// and the repositories translate it into query criteria so listings and IsValid always agree
+type GiftCardValidity struct {
+	// Status is the only status a valid card can have
+	Status int
+	// ExpireAfter is the moment the expire date must be after
+	ExpireAfter time.Time
+}
+
+// ValidityAt returns the validity rule at the given moment. a card stays valid for one day after its expire date
+func ValidityAt(now time.Time) GiftCardValidity {
+	return GiftCardValidity{
+		Status:      Empty,
+		ExpireAfter: now.AddDate(0, 0, -1).UTC(),
+	}
+}
+
+// CurrentValidity returns the validity rule at this moment
+func CurrentValidity() GiftCardValidity {
+	return ValidityAt(time.Now())
+}
+
+// Matches reports whether the card is valid under the rule
+func (v GiftCardValidity) Matches(g GiftCard) bool {
+	return g.UUN == "" && g.Status == v.Status && v.IsDateValid(g.ExpireDate)
+}
+
+// IsDateValid reports whether the expire date is still valid under the rule
+func (v GiftCardValidity) IsDateValid(expireDate time.Time) bool {
+	return v.ExpireAfter.Before(expireDate)
+}
@@ -0,0 +1,21 @@
+package dbmodel_test
+
+import (
+	"giftcard-engine/core/dbmodel"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestValidityAt(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC)
+	rule := dbmodel.ValidityAt(now)
+
+	assert.Equal(t, dbmodel.Empty, rule.Status)
+	assert.Equal(t, now.AddDate(0, 0, -1), rule.ExpireAfter)
+	assert.True(t, rule.Matches(dbmodel.GiftCard{ExpireDate: now.Add(-time.Hour), Status: dbmodel.Empty}))
+	assert.False(t, rule.Matches(dbmodel.GiftCard{ExpireDate: now.AddDate(0, 0, -2), Status: dbmodel.Empty}))
+	assert.False(t, rule.Matches(dbmodel.GiftCard{ExpireDate: now, Status: dbmodel.Voided}))
+	assert.False(t, rule.Matches(dbmodel.GiftCard{ExpireDate: now, Status: dbmodel.Empty, UUN: "milad"}))
+}
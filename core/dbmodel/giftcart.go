@@ -4,21 +4,26 @@ import (
 	"giftcard-engine/core/common"
 	"giftcard-engine/utils/random"
 	"time"
-
-	_ "github.com/jinzhu/gorm/dialects/mssql"
 )
 
 // GiftCard is a sql model for saving and modifying gift cards
 type GiftCard struct {
 	AbstractModel
-	Amount     int32     `gorm:"column:Amount;not null"`
-	PublicCode string    `gorm:"column:PublicCode;unique_index;not null"`
-	SecretCode string    `gorm:"column:SecretCode;unique_index;not null"`
-	UUN        string    `gorm:"column:UUN"`
-	ExpireDate time.Time `gorm:"column:ExpireDate;not null"`
-	Status     int       `gorm:"column:Status;not null;default:1"`
-	CampaignId uint      `gorm:"column:CampaignId;not null;"`
+	Amount     int32      `gorm:"column:Amount;not null"`
+	PublicCode string     `gorm:"column:PublicCode;unique_index;not null"`
+	SecretCode string     `gorm:"column:SecretCode;unique_index;not null"`
+	UUN        string     `gorm:"column:UUN"`
+	ExpireDate time.Time  `gorm:"column:ExpireDate;not null"`
+	Status     int        `gorm:"column:Status;not null;default:1"`
+	CampaignId uint       `gorm:"column:CampaignId;not null;"`
 	Campaign   *Campaign  `gorm:"foreignkey:ID;references:CampaignId"`
+	BatchId    *uint      `gorm:"column:BatchId;index"`
+	RedeemedAt *time.Time `gorm:"column:RedeemedAt"`
+	VoidedAt   *time.Time `gorm:"column:VoidedAt"`
+	// Revisions are the previous terms of the card. only the new ones are loaded and saved with the card
+	Revisions []GiftCardRevision `gorm:"foreignkey:GiftCardId"`
+	// events are raised by the changes of the card since it is loaded, and are stored in the outbox with it
+	events []string
 }
 
 //TableName returns the sql table name for changing the default naming system
@@ -27,7 +32,7 @@ func (*GiftCard) TableName() string {
 }
 
 func NewGiftCard(amount int32, expireDate time.Time) *GiftCard {
-	return &GiftCard{
+	card := &GiftCard{
 		Amount:     amount,
 		PublicCode: random.GiftCardPublicKey(),
 		SecretCode: random.GiftCardSecretKey(),
@@ -35,26 +40,38 @@ func NewGiftCard(amount int32, expireDate time.Time) *GiftCard {
 		ExpireDate: expireDate,
 		Status:     Empty,
 	}
+	card.raise(CardIssuedEvent)
+	return card
 }
 
 func (g GiftCard) IsValid() bool {
-	return g.UUN == "" && g.IsDateValid() && g.Status == Empty
+	return CurrentValidity().Matches(g)
 }
 
 func (g *GiftCard) SetCampaign(campaignId uint) {
 	g.CampaignId = campaignId
 }
 
+func (g *GiftCard) SetBatch(batchId uint) {
+	g.BatchId = &batchId
+}
+
 func (g GiftCard) IsDateValid() bool {
-	return time.Now().AddDate(0, 0, -1).UTC().Before(g.ExpireDate)
+	return CurrentValidity().IsDateValid(g.ExpireDate)
 }
 
 func (g *GiftCard) SetUUN(uun string) error {
+	if g.Status == Voided {
+		return common.GiftCardIsVoided
+	}
 	if g.UUN != "" {
 		return common.GiftCardIsTaken
 	}
+	now := time.Now().UTC()
 	g.Status = Approved
 	g.UUN = uun
+	g.RedeemedAt = &now
+	g.raise(CardApprovedEvent)
 	return nil
 }
 
@@ -62,6 +79,15 @@ func (g *GiftCard) Update(amount int32, expireDate time.Time) error {
 	if !g.IsValid() {
 		return common.GiftCardIsNotValid
 	}
+	if amount != g.Amount || !expireDate.Equal(g.ExpireDate) {
+		g.Revisions = append(g.Revisions, GiftCardRevision{
+			GiftCardId: g.ID,
+			Amount:     g.Amount,
+			ExpireDate: g.ExpireDate,
+			RevisedAt:  time.Now().UTC(),
+		})
+		g.raise(CardUpdatedEvent)
+	}
 	g.Amount = amount
 	g.ExpireDate = expireDate
 	return nil
@@ -70,9 +96,43 @@ func (g *GiftCard) Update(amount int32, expireDate time.Time) error {
 func (g *GiftCard) RollBack() {
 	g.UUN = ""
 	g.Status = Empty
+	g.RedeemedAt = nil
+	g.raise(CardRolledBackEvent)
+}
+
+// IsUnused reports whether the card is neither taken by a user nor voided
+func (g GiftCard) IsUnused() bool {
+	return g.UUN == "" && g.Status == Empty
+}
+
+// Void makes an unused gift card permanently invalid
+func (g *GiftCard) Void() error {
+	if !g.IsUnused() {
+		return common.GiftCardIsTaken
+	}
+	now := time.Now().UTC()
+	g.Status = Voided
+	g.VoidedAt = &now
+	g.raise(CardVoidedEvent)
+	return nil
 }
 
 func (g *GiftCard) GenerateKey() {
 	g.PublicCode = random.GiftCardPublicKey()
 	g.SecretCode = random.GiftCardSecretKey()
 }
+
+// raise records an event of the card
+func (g *GiftCard) raise(event string) {
+	g.events = append(g.events, event)
+}
+
+// Events returns the events raised since the card is loaded or its events are cleared
+func (g GiftCard) Events() []string {
+	return g.events
+}
+
+// ClearEvents forgets the events once they are stored in the outbox
+func (g *GiftCard) ClearEvents() {
+	g.events = nil
+}
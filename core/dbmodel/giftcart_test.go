@@ -7,8 +7,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
-
-	_ "github.com/jinzhu/gorm/dialects/mssql"
 )
 
 func TestNewGiftCard(t *testing.T) {
@@ -101,6 +99,22 @@ func TestUpdate(t *testing.T) {
 	assert.Equal(t, amountForUpdate, card1.Amount)
 	assert.Equal(t, dateForUpdate, card1.ExpireDate)
 	assert.Equal(t, common.GiftCardIsNotValid, err2)
+	assert.Equal(t, 1, len(card1.Revisions))
+	assert.Equal(t, int32(2000), card1.Revisions[0].Amount)
+	assert.Equal(t, date, card1.Revisions[0].ExpireDate)
+	assert.Empty(t, card2.Revisions)
+}
+
+func TestUpdateWithSameTerms(t *testing.T) {
+	t.Parallel()
+	date := time.Now().Add(time.Hour * 25).UTC()
+	card := dbmodel.GiftCard{Amount: int32(2000), PublicCode: "public",
+		SecretCode: "secret", UUN: "", ExpireDate: date, Status: dbmodel.Empty}
+
+	err := card.Update(int32(2000), date)
+
+	assert.Empty(t, err)
+	assert.Empty(t, card.Revisions)
 }
 
 func TestRollBack(t *testing.T) {
@@ -128,3 +142,62 @@ func TestGenerateKey(t *testing.T) {
 	assert.Equal(t, utils.GiftCardPublicKeyLength, len(card1.PublicCode))
 	assert.Equal(t, utils.GiftCardSecretKeyLength, len(card1.SecretCode))
 }
+
+func TestVoid(t *testing.T) {
+	t.Parallel()
+	date := time.Now().Add(time.Hour * 25).UTC()
+	card1 := dbmodel.GiftCard{Amount: int32(2000), PublicCode: "public",
+		SecretCode: "secret", UUN: "", ExpireDate: date, Status: dbmodel.Empty}
+	card2 := dbmodel.GiftCard{Amount: int32(2000), PublicCode: "public",
+		SecretCode: "secret", UUN: "milawd", ExpireDate: date, Status: dbmodel.Approved}
+
+	err1 := card1.Void()
+	err2 := card2.Void()
+
+	assert.Empty(t, err1)
+	assert.Equal(t, dbmodel.Voided, card1.Status)
+	assert.NotEmpty(t, card1.VoidedAt)
+	assert.Equal(t, false, card1.IsValid())
+	assert.Equal(t, common.GiftCardIsTaken, err2)
+	assert.Equal(t, dbmodel.Approved, card2.Status)
+	assert.Empty(t, card2.VoidedAt)
+}
+
+func TestSetUUNOnVoidedCard(t *testing.T) {
+	t.Parallel()
+	date := time.Now().Add(time.Hour * 25).UTC()
+	card := dbmodel.GiftCard{Amount: int32(2000), PublicCode: "public",
+		SecretCode: "secret", UUN: "", ExpireDate: date, Status: dbmodel.Voided}
+
+	err := card.SetUUN("some_one")
+
+	assert.Equal(t, common.GiftCardIsVoided, err)
+	assert.Empty(t, card.UUN)
+}
+
+func TestSetBatch(t *testing.T) {
+	t.Parallel()
+	card := dbmodel.NewGiftCard(2000, time.Now().UTC())
+
+	card.SetBatch(7)
+
+	assert.Equal(t, uint(7), *card.BatchId)
+}
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+	card := dbmodel.NewGiftCard(2000, time.Now().Add(time.Hour).UTC())
+	assert.Equal(t, []string{dbmodel.CardIssuedEvent}, card.Events())
+	card.ClearEvents()
+
+	assert.Empty(t, card.SetUUN("milawd"))
+	card.RollBack()
+	assert.Empty(t, card.Update(card.Amount, card.ExpireDate))
+	assert.Empty(t, card.Update(3000, card.ExpireDate))
+	assert.Empty(t, card.Void())
+
+	assert.Equal(t, []string{dbmodel.CardApprovedEvent, dbmodel.CardRolledBackEvent, dbmodel.CardUpdatedEvent,
+		dbmodel.CardVoidedEvent}, card.Events(), "an update which changes nothing should raise no event")
+	card.ClearEvents()
+	assert.Empty(t, card.Events())
+}
@@ -0,0 +1,174 @@
+package dbmodel
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// the events of the gift cards. the webhooks are named after them too
+const (
+	CardIssuedEvent     = "gift_card.issued"
+	CardApprovedEvent   = "gift_card.approved"
+	CardRolledBackEvent = "gift_card.rolled_back"
+	CardUpdatedEvent    = "gift_card.updated"
+	CardVoidedEvent     = "gift_card.voided"
+	CardExpiredEvent    = "gift_card.expired"
+	CardDeletedEvent    = "gift_card.deleted"
+)
+
+// the events of the campaigns
+const (
+	CampaignCreatedEvent = "campaign.created"
+	CampaignUpdatedEvent = "campaign.updated"
+	CampaignDeletedEvent = "campaign.deleted"
+)
+
+// GiftCardSnapshot is the state a gift card is left in by an event. the secret code is left out, so the events
+// can be sent to the other services
+type GiftCardSnapshot struct {
+	ID         int        `json:"id"`
+	PublicCode string     `json:"public_code"`
+	UUN        string     `json:"uun,omitempty"`
+	Amount     int32      `json:"amount"`
+	Status     int        `json:"status"`
+	ExpireDate time.Time  `json:"expire_date"`
+	CampaignId uint       `json:"campaign_id"`
+	BatchId    *uint      `json:"batch_id,omitempty"`
+	RedeemedAt *time.Time `json:"redeemed_at,omitempty"`
+	VoidedAt   *time.Time `json:"voided_at,omitempty"`
+}
+
+func NewGiftCardSnapshot(card GiftCard) GiftCardSnapshot {
+	return GiftCardSnapshot{
+		ID:         card.ID,
+		PublicCode: card.PublicCode,
+		UUN:        card.UUN,
+		Amount:     card.Amount,
+		Status:     card.Status,
+		ExpireDate: card.ExpireDate,
+		CampaignId: card.CampaignId,
+		BatchId:    card.BatchId,
+		RedeemedAt: card.RedeemedAt,
+		VoidedAt:   card.VoidedAt,
+	}
+}
+
+// GiftCard returns the card of the snapshot, without its secret code and campaign
+func (s GiftCardSnapshot) GiftCard() GiftCard {
+	return GiftCard{
+		AbstractModel: AbstractModel{ID: s.ID},
+		PublicCode:    s.PublicCode,
+		UUN:           s.UUN,
+		Amount:        s.Amount,
+		Status:        s.Status,
+		ExpireDate:    s.ExpireDate,
+		CampaignId:    s.CampaignId,
+		BatchId:       s.BatchId,
+		RedeemedAt:    s.RedeemedAt,
+		VoidedAt:      s.VoidedAt,
+	}
+}
+
+// CampaignSnapshot is the state a campaign is left in by an event
+type CampaignSnapshot struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+func NewCampaignSnapshot(campaign Campaign) CampaignSnapshot {
+	return CampaignSnapshot{ID: campaign.ID, Title: campaign.Title}
+}
+
+// OutboxEvent is a sql model for a domain event which is stored in the transaction of the change it is raised by,
+// so it is published even if the process stops right after the change. the event is retried until every
+// publisher gets it or it runs out of attempts and is dead, and the publishers which already got it are kept so
+// they are not sent it again
+type OutboxEvent struct {
+	ID            int `gorm:"primary_key"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Event         string     `gorm:"column:Event;not null"`
+	AggregateId   int        `gorm:"column:AggregateId;index;not null"` // the card or the campaign by the event name
+	Payload       string     `gorm:"column:Payload;type:text;not null"`
+	PublishedTo   string     `gorm:"column:PublishedTo"`
+	Attempts      int        `gorm:"column:Attempts;not null"`
+	NextAttemptAt time.Time  `gorm:"column:NextAttemptAt;index;not null"`
+	LastError     string     `gorm:"column:LastError;type:text"`
+	PublishedAt   *time.Time `gorm:"column:PublishedAt;index"`
+	DeadAt        *time.Time `gorm:"column:DeadAt;index"`
+}
+
+// TableName returns the sql table name for changing the default naming system
+func (*OutboxEvent) TableName() string {
+	return "OutboxEvent"
+}
+
+// NewGiftCardOutboxEvent returns an event of the card with the snapshot of the card as its payload, which is due
+// now. the card should be stored before, so its id is known
+func NewGiftCardOutboxEvent(event string, card GiftCard) (*OutboxEvent, error) {
+	return newOutboxEvent(event, card.ID, NewGiftCardSnapshot(card))
+}
+
+// NewCampaignOutboxEvent returns an event of the campaign like NewGiftCardOutboxEvent
+func NewCampaignOutboxEvent(event string, campaign Campaign) (*OutboxEvent, error) {
+	return newOutboxEvent(event, campaign.ID, NewCampaignSnapshot(campaign))
+}
+
+func newOutboxEvent(event string, aggregateId int, snapshot interface{}) (*OutboxEvent, error) {
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		Event:         event,
+		AggregateId:   aggregateId,
+		Payload:       string(payload),
+		NextAttemptAt: time.Now().UTC(),
+	}, nil
+}
+
+// IsPublishedTo reports whether the publisher already got the event
+func (e OutboxEvent) IsPublishedTo(publisher string) bool {
+	for _, published := range strings.Split(e.PublishedTo, ",") {
+		if published == publisher {
+			return true
+		}
+	}
+	return false
+}
+
+// PublishTo records that the publisher got the event. the publishers are kept comma separated in a single column
+func (e *OutboxEvent) PublishTo(publisher string) {
+	if e.IsPublishedTo(publisher) {
+		return
+	}
+	if e.PublishedTo != "" {
+		e.PublishedTo += ","
+	}
+	e.PublishedTo += publisher
+}
+
+// Published records the attempt after which every publisher got the event
+func (e *OutboxEvent) Published(at time.Time) {
+	at = at.UTC()
+	e.Attempts++
+	e.PublishedAt = &at
+	e.LastError = ""
+}
+
+// Failed records an attempt after which a publisher still has not got the event, which is retried at the given
+// moment
+func (e *OutboxEvent) Failed(reason string, retryAt time.Time) {
+	e.Attempts++
+	e.LastError = reason
+	e.NextAttemptAt = retryAt.UTC()
+}
+
+// Die records a failed attempt after which the event is not relayed anymore
+func (e *OutboxEvent) Die(reason string, at time.Time) {
+	at = at.UTC()
+	e.Attempts++
+	e.LastError = reason
+	e.DeadAt = &at
+}
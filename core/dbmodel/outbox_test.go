@@ -0,0 +1,52 @@
+package dbmodel_test
+
+import (
+	"encoding/json"
+	"giftcard-engine/core/dbmodel"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewGiftCardOutboxEvent(t *testing.T) {
+	t.Parallel()
+	batchId := uint(4)
+	card := dbmodel.GiftCard{AbstractModel: dbmodel.AbstractModel{ID: 12}, PublicCode: "public", SecretCode: "secret",
+		Amount: 2000, Status: dbmodel.Approved, UUN: "milawd", CampaignId: 3, BatchId: &batchId,
+		ExpireDate: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	event, err := dbmodel.NewGiftCardOutboxEvent(dbmodel.CardApprovedEvent, card)
+	var snapshot dbmodel.GiftCardSnapshot
+
+	assert.Empty(t, err)
+	assert.Equal(t, dbmodel.CardApprovedEvent, event.Event)
+	assert.Equal(t, 12, event.AggregateId)
+	assert.NotContains(t, event.Payload, "secret", "the secret code should be left out of the events")
+	assert.False(t, event.NextAttemptAt.After(time.Now()))
+	assert.Empty(t, json.Unmarshal([]byte(event.Payload), &snapshot))
+	card.SecretCode = ""
+	assert.Equal(t, card, snapshot.GiftCard())
+}
+
+func TestOutboxEventAttempts(t *testing.T) {
+	t.Parallel()
+	event := dbmodel.OutboxEvent{Event: dbmodel.CardIssuedEvent}
+	retryAt := time.Now().Add(time.Minute)
+
+	event.PublishTo("webhooks")
+	event.PublishTo("broker")
+	event.PublishTo("webhooks")
+	event.Failed("log: unavailable", retryAt)
+	assert.Equal(t, "webhooks,broker", event.PublishedTo)
+	assert.True(t, event.IsPublishedTo("broker"))
+	assert.False(t, event.IsPublishedTo("log"))
+	assert.False(t, event.IsPublishedTo("web"))
+	assert.Equal(t, 1, event.Attempts)
+	assert.True(t, retryAt.Equal(event.NextAttemptAt))
+	assert.Nil(t, event.PublishedAt)
+
+	event.Published(time.Now())
+	assert.Equal(t, 2, event.Attempts)
+	assert.NotNil(t, event.PublishedAt)
+	assert.Empty(t, event.LastError)
+}
@@ -0,0 +1,128 @@
+package dbmodel
+
+import (
+	"giftcard-engine/core/common"
+	"strings"
+	"time"
+)
+
+// WebhookEvents are the events of the gift cards a subscription can choose from
+var WebhookEvents = []string{CardIssuedEvent, CardApprovedEvent, CardRolledBackEvent, CardUpdatedEvent,
+	CardVoidedEvent, CardExpiredEvent, CardDeletedEvent}
+
+// the statuses of the webhook deliveries
+const (
+	_ = iota
+	DeliveryPending
+	DeliveryDelivered
+	DeliveryDead
+)
+
+// WebhookSubscription is a sql model for an url which is notified of the chosen events of the gift cards.
+// the payloads are signed with the secret so the receiver can check they are sent by this service
+type WebhookSubscription struct {
+	AbstractModel
+	Url    string `gorm:"column:Url;not null"`
+	Events string `gorm:"column:Events;not null"`
+	Secret string `gorm:"column:Secret;not null"`
+}
+
+// TableName returns the sql table name for changing the default naming system
+func (*WebhookSubscription) TableName() string {
+	return "WebhookSubscription"
+}
+
+func NewWebhookSubscription(url string, events []string, secret string) *WebhookSubscription {
+	subscription := &WebhookSubscription{Url: url, Secret: secret}
+	subscription.SetEvents(events)
+	return subscription
+}
+
+// SetEvents keeps the events comma separated in a single column
+func (s *WebhookSubscription) SetEvents(events []string) {
+	s.Events = strings.Join(events, ",")
+}
+
+// EventList returns the events the subscription is notified of
+func (s WebhookSubscription) EventList() []string {
+	if s.Events == "" {
+		return []string{}
+	}
+	return strings.Split(s.Events, ",")
+}
+
+// Subscribes reports whether the subscription is notified of the event
+func (s WebhookSubscription) Subscribes(event string) bool {
+	for _, subscribed := range s.EventList() {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is a sql model for an event which is sent, or is going to be sent, to a subscription.
+// the pending deliveries are retried until they are delivered or run out of attempts and become dead
+type WebhookDelivery struct {
+	ID             int `gorm:"primary_key"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SubscriptionId uint       `gorm:"column:SubscriptionId;index;not null"`
+	Event          string     `gorm:"column:Event;not null"`
+	Payload        string     `gorm:"column:Payload;type:text;not null"`
+	Status         int        `gorm:"column:Status;not null;default:1"`
+	Attempts       int        `gorm:"column:Attempts;not null"`
+	NextAttemptAt  time.Time  `gorm:"column:NextAttemptAt;index;not null"`
+	LastError      string     `gorm:"column:LastError;type:text"`
+	DeliveredAt    *time.Time `gorm:"column:DeliveredAt"`
+}
+
+// TableName returns the sql table name for changing the default naming system
+func (*WebhookDelivery) TableName() string {
+	return "WebhookDelivery"
+}
+
+// NewWebhookDelivery returns a pending delivery of the payload which is due now
+func NewWebhookDelivery(subscriptionId uint, event, payload string) *WebhookDelivery {
+	return &WebhookDelivery{
+		SubscriptionId: subscriptionId,
+		Event:          event,
+		Payload:        payload,
+		Status:         DeliveryPending,
+		NextAttemptAt:  time.Now().UTC(),
+	}
+}
+
+// Delivered records a successful attempt
+func (d *WebhookDelivery) Delivered(at time.Time) {
+	at = at.UTC()
+	d.Attempts++
+	d.Status = DeliveryDelivered
+	d.DeliveredAt = &at
+	d.LastError = ""
+}
+
+// Failed records a failed attempt which is retried at the given moment
+func (d *WebhookDelivery) Failed(reason string, retryAt time.Time) {
+	d.Attempts++
+	d.LastError = reason
+	d.NextAttemptAt = retryAt.UTC()
+}
+
+// Die records a failed attempt after which the delivery is not retried anymore
+func (d *WebhookDelivery) Die(reason string) {
+	d.Attempts++
+	d.LastError = reason
+	d.Status = DeliveryDead
+}
+
+// Redeliver makes a dead delivery pending again with all of its attempts and due now
+func (d *WebhookDelivery) Redeliver() error {
+	if d.Status != DeliveryDead {
+		return common.WebhookDeliveryIsNotDead
+	}
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = time.Now().UTC()
+	return nil
+}
@@ -0,0 +1,64 @@
+package dbmodel_test
+
+import (
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWebhookSubscriptionEvents(t *testing.T) {
+	t.Parallel()
+	subscription := dbmodel.NewWebhookSubscription("http://crm/hooks",
+		[]string{dbmodel.CardIssuedEvent, dbmodel.CardApprovedEvent}, "a-long-enough-secret")
+
+	assert.Equal(t, []string{dbmodel.CardIssuedEvent, dbmodel.CardApprovedEvent}, subscription.EventList())
+	assert.True(t, subscription.Subscribes(dbmodel.CardApprovedEvent))
+	assert.False(t, subscription.Subscribes(dbmodel.CardExpiredEvent))
+	assert.False(t, subscription.Subscribes("gift_card"))
+}
+
+func TestWebhookDeliveryAttempts(t *testing.T) {
+	t.Parallel()
+	delivery := dbmodel.NewWebhookDelivery(1, dbmodel.CardIssuedEvent, "{}")
+	retryAt := time.Now().Add(time.Minute)
+
+	delivery.Failed("the receiver answered 500", retryAt)
+	assert.Equal(t, dbmodel.DeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.True(t, retryAt.Equal(delivery.NextAttemptAt))
+
+	delivery.Delivered(time.Now())
+	assert.Equal(t, dbmodel.DeliveryDelivered, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.NotNil(t, delivery.DeliveredAt)
+	assert.Empty(t, delivery.LastError)
+}
+
+func TestWebhookDeliveryRedeliver(te *testing.T) {
+	te.Parallel()
+	te.Run("dead delivery", func(t *testing.T) {
+		t.Parallel()
+		delivery := dbmodel.NewWebhookDelivery(1, dbmodel.CardIssuedEvent, "{}")
+		delivery.Die("the receiver answered 500")
+
+		err := delivery.Redeliver()
+
+		assert.Empty(t, err)
+		assert.Equal(t, dbmodel.DeliveryPending, delivery.Status)
+		assert.Equal(t, 0, delivery.Attempts)
+		assert.False(t, delivery.NextAttemptAt.After(time.Now()))
+	})
+
+	te.Run("delivered delivery", func(t *testing.T) {
+		t.Parallel()
+		delivery := dbmodel.NewWebhookDelivery(1, dbmodel.CardIssuedEvent, "{}")
+		delivery.Delivered(time.Now())
+
+		err := delivery.Redeliver()
+
+		assert.Equal(t, common.WebhookDeliveryIsNotDead, err)
+		assert.Equal(t, dbmodel.DeliveryDelivered, delivery.Status)
+	})
+}
@@ -1,7 +1,7 @@
 package dto
 
 import (
-	"giftcard-engine/utils"
+	"giftcard-engine/utils/random"
 	"github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -14,6 +14,6 @@ func (a ApproveGiftCardsDTO) Validate() error {
 	return validation.ValidateStruct(&a,
 		validation.Field(&a.UUN, validation.Required),
 		validation.Field(&a.GiftCardsSecret,
-			validation.Each(validation.Length(utils.GiftCardSecretKeyLength, utils.GiftCardSecretKeyLength))),
+			validation.Each(validation.Length(random.SecretKeyLength(), random.SecretKeyLength()))),
 	)
 }
@@ -0,0 +1,18 @@
+package dto
+
+import "giftcard-engine/utils/indraframework"
+
+// BatchDTO describes a group of gift cards issued by a single bulk request.
+type BatchDTO struct {
+	ID          int                            `json:"id,string,omitempty"`
+	CreatedBy   string                         `json:"created_by"`
+	CreatedAt   string                         `json:"created_at"`
+	Parameters  string                         `json:"parameters"`
+	Count       int                            `json:"count"`
+	TotalAmount int64                          `json:"total_amount"`
+	Error       *indraframework.IndraException `json:"error"`
+}
+
+func (a *BatchDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "giftcard-engine/utils/indraframework"
+
+// BatchOperationDTO reports the result of an operation applied to the cards of a batch.
+type BatchOperationDTO struct {
+	BatchId       int                            `json:"batch_id"`
+	AffectedCards int                            `json:"affected_cards"`
+	Message       string                         `json:"message"`
+	Error         *indraframework.IndraException `json:"error"`
+}
+
+func (a *BatchOperationDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
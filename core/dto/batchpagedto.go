@@ -0,0 +1,24 @@
+package dto
+
+import "giftcard-engine/utils/indraframework"
+
+type BatchPageDTO struct {
+	Size       int                            `json:"size"`
+	Page       int                            `json:"page"`
+	Batches    []BatchDTO                     `json:"batches"`
+	TotalItems int                            `json:"total_items"`
+	Error      *indraframework.IndraException `json:"error"`
+}
+
+func NewBatchPageDTO(batches []BatchDTO, size, page, total int) BatchPageDTO {
+	return BatchPageDTO{
+		Size:       size,
+		Page:       page + 1,
+		Batches:    batches,
+		TotalItems: total,
+	}
+}
+
+func (a *BatchPageDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
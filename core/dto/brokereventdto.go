@@ -0,0 +1,14 @@
+package dto
+
+import "encoding/json"
+
+// BrokerEventDTO is a domain event which is published to the message broker. the id is the id of the event in the
+// outbox, so a consumer which gets an event again can ignore the repeated one. the data is the state the card or
+// the campaign is left in by the event
+type BrokerEventDTO struct {
+	ID          int             `json:"id"`
+	Event       string          `json:"event"`
+	AggregateId int             `json:"aggregate_id"`
+	OccurredAt  string          `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
@@ -2,6 +2,7 @@ package dto
 
 type BulkCreateGiftCardsDTO struct {
 	GiftCards []CreateGiftCardDTO            `json:"gift_cards"`
+	CreatedBy string                         `json:"created_by"`
 }
 
 func (a BulkCreateGiftCardsDTO) Validate() error {
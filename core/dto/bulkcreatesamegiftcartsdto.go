@@ -9,6 +9,7 @@ type BulkCreateSameGiftCardsDTO struct {
 	Amount     int32  `json:"amount" `
 	Count      int    `json:"count" `
 	CampaignId uint   `json:"campaign_id"`
+	CreatedBy  string `json:"created_by"`
 }
 
 func (a BulkCreateSameGiftCardsDTO) Validate() error {
@@ -0,0 +1,28 @@
+package dto
+
+import "giftcard-engine/utils/indraframework"
+
+// CampaignCursorPageDTO is a keyset page of campaigns. NextCursor is empty on the last page
+type CampaignCursorPageDTO struct {
+	Size       int                            `json:"size"`
+	Campaigns  []CampaignDTO                  `json:"campaigns"`
+	NextCursor string                         `json:"next_cursor"`
+	TotalItems *int                           `json:"total_items,omitempty"`
+	Error      *indraframework.IndraException `json:"error"`
+}
+
+func NewCampaignCursorPageDTO(campaigns []CampaignDTO, size int, nextCursor string, total int) CampaignCursorPageDTO {
+	page := CampaignCursorPageDTO{
+		Size:       size,
+		Campaigns:  campaigns,
+		NextCursor: nextCursor,
+	}
+	if total >= 0 {
+		page.TotalItems = &total
+	}
+	return page
+}
+
+func (a *CampaignCursorPageDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
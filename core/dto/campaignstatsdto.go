@@ -0,0 +1,27 @@
+package dto
+
+import "giftcard-engine/utils/indraframework"
+
+// CardsAggregateDTO is the number and the total amount of a group of gift cards
+type CardsAggregateDTO struct {
+	Count  int64 `json:"count"`
+	Amount int64 `json:"amount"`
+}
+
+// CampaignStatsDTO is the performance of a campaign, or of all campaigns when CampaignId is empty
+type CampaignStatsDTO struct {
+	CampaignId             int                            `json:"campaign_id,omitempty"`
+	Issued                 CardsAggregateDTO              `json:"issued"`
+	Redeemed               CardsAggregateDTO              `json:"redeemed"`
+	Expired                CardsAggregateDTO              `json:"expired"`
+	Voided                 CardsAggregateDTO              `json:"voided"`
+	Deleted                CardsAggregateDTO              `json:"deleted"`
+	Outstanding            CardsAggregateDTO              `json:"outstanding"`
+	RedemptionRate         float64                        `json:"redemption_rate"`
+	AverageSecondsToRedeem float64                        `json:"average_seconds_to_redeem"`
+	Error                  *indraframework.IndraException `json:"error"`
+}
+
+func (a *CampaignStatsDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
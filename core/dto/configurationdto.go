@@ -0,0 +1,14 @@
+package dto
+
+import "giftcard-engine/utils/indraframework"
+
+// ConfigurationDTO is the running configuration of the service with its secrets masked
+type ConfigurationDTO struct {
+	Settings   map[string]string              `json:"settings"`
+	Reloadable []string                       `json:"reloadable"`
+	Error      *indraframework.IndraException `json:"error"`
+}
+
+func (a *ConfigurationDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"errors"
+	"giftcard-engine/core/dbmodel"
+	"github.com/go-ozzo/ozzo-validation/v4"
+	"net/url"
+)
+
+// minWebhookSecretLength keeps the signatures of the payloads from being guessed
+const minWebhookSecretLength = 16
+
+// CreateWebhookDTO subscribes an url to the events of the gift cards
+type CreateWebhookDTO struct {
+	Url    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+func (a CreateWebhookDTO) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Url, validation.Required, validation.By(httpUrl)),
+		validation.Field(&a.Events, validation.Required, validation.Each(validation.In(webhookEvents()...))),
+		validation.Field(&a.Secret, validation.Required, validation.Length(minWebhookSecretLength, 256)),
+	)
+}
+
+// httpUrl is the rule of the urls the webhooks can be sent to
+func httpUrl(value interface{}) error {
+	text, _ := value.(string)
+	parsed, err := url.Parse(text)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("must be an http or https url")
+	}
+	return nil
+}
+
+func webhookEvents() []interface{} {
+	events := make([]interface{}, len(dbmodel.WebhookEvents))
+	for i, event := range dbmodel.WebhookEvents {
+		events[i] = event
+	}
+	return events
+}
@@ -27,6 +27,15 @@ func TestValidateApproveGiftCardsDTO(te *testing.T) {
 		assert.NotEmpty(t, err)
 	})
 
+	te.Run("secret key with another length than the configured one in the approveGiftCardsDTO", func(t *testing.T) {
+		approveGiftCardsDTO := dto.ApproveGiftCardsDTO{
+			UUN:             "milawd",
+			GiftCardsSecret: []string{"1234567890", "1234567890123456"},
+		}
+		err := approveGiftCardsDTO.Validate()
+		assert.NotEmpty(t, err)
+	})
+
 	te.Run("empty uun in the approveGiftCardsDTO", func(t *testing.T) {
 		approveGiftCardsDTO := dto.ApproveGiftCardsDTO{
 			UUN:             "",
@@ -293,3 +302,28 @@ func TestValidateUpdateCampaignDto(te *testing.T) {
 		assert.NotEmpty(t, err)
 	})
 }
+
+func TestValidateExtendBatchExpiryDTO(te *testing.T) {
+	te.Parallel()
+	te.Run("valid ExtendBatchExpiryDTO", func(t *testing.T) {
+		item := dto.ExtendBatchExpiryDTO{
+			ExpireDate: "2300-02-02",
+		}
+		err := item.Validate()
+		assert.Empty(t, err)
+	})
+
+	te.Run("past expire date in ExtendBatchExpiryDTO", func(t *testing.T) {
+		item := dto.ExtendBatchExpiryDTO{
+			ExpireDate: "2012-02-02",
+		}
+		err := item.Validate()
+		assert.Equal(t, common.ExpireDateIsNotInValidRange, err)
+	})
+
+	te.Run("empty expire date in ExtendBatchExpiryDTO", func(t *testing.T) {
+		item := dto.ExtendBatchExpiryDTO{}
+		err := item.Validate()
+		assert.NotEmpty(t, err)
+	})
+}
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type ExtendBatchExpiryDTO struct {
+	ExpireDate string `json:"expire_date"`
+}
+
+func (a ExtendBatchExpiryDTO) Validate() error {
+	if err := CheckForDate(a.ExpireDate); err != nil {
+		return err
+	}
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.ExpireDate, validation.Required),
+	)
+}
@@ -14,6 +14,8 @@ type GiftCardDTO struct {
 	Error         *indraframework.IndraException `json:"error"`
 	CampaignId    uint                           `json:"campaign_id"`
 	CampaignTitle string                         `json:"campaign_title"`
+	BatchId       uint                           `json:"batch_id,omitempty"`
+	RedeemedAt    string                         `json:"redeemed_at,omitempty"`
 }
 
 func (a *GiftCardDTO) SetError(exc *indraframework.IndraException) {
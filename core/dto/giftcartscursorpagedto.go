@@ -0,0 +1,28 @@
+package dto
+
+import "giftcard-engine/utils/indraframework"
+
+// GiftCardsCursorPageDTO is a keyset page of gift cards. NextCursor is empty on the last page
+type GiftCardsCursorPageDTO struct {
+	Size       int                            `json:"size"`
+	GiftCards  []GiftCardDTO                  `json:"gift_cards"`
+	NextCursor string                         `json:"next_cursor"`
+	TotalItems *int                           `json:"total_items,omitempty"`
+	Error      *indraframework.IndraException `json:"error"`
+}
+
+func NewGiftCardsCursorPageDTO(giftCards []GiftCardDTO, size int, nextCursor string, total int) *GiftCardsCursorPageDTO {
+	page := &GiftCardsCursorPageDTO{
+		Size:       size,
+		GiftCards:  giftCards,
+		NextCursor: nextCursor,
+	}
+	if total >= 0 {
+		page.TotalItems = &total
+	}
+	return page
+}
+
+func (a *GiftCardsCursorPageDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
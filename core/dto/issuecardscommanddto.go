@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// IssueCardsCommandDTO is a command of another service to issue the same gift cards, which is consumed from the
+// message broker. the command id is sent back with the result, so the sender can match them, and the cards of a
+// command id are issued once: a repeated command is answered with the result of the first one
+type IssueCardsCommandDTO struct {
+	CommandId string `json:"command_id"`
+	BulkCreateSameGiftCardsDTO
+}
+
+func (a IssueCardsCommandDTO) Validate() error {
+	if err := validation.ValidateStruct(&a, validation.Field(&a.CommandId, validation.Required)); err != nil {
+		return err
+	}
+	return a.BulkCreateSameGiftCardsDTO.Validate()
+}
+
+// IssueCardsResultDTO is the answer of an issue cards command, which is published to the reply subject of the
+// command if it has one
+type IssueCardsResultDTO struct {
+	CommandId string `json:"command_id"`
+	GiftCardsListDTO
+}
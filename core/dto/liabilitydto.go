@@ -0,0 +1,25 @@
+package dto
+
+import "giftcard-engine/utils/indraframework"
+
+// CampaignLiabilityDTO is the outstanding value of the cards of a campaign
+type CampaignLiabilityDTO struct {
+	CampaignId        int    `json:"campaign_id"`
+	CampaignTitle     string `json:"campaign_title"`
+	OutstandingCount  int64  `json:"outstanding_count"`
+	OutstandingAmount int64  `json:"outstanding_amount"`
+}
+
+// LiabilityDTO is the outstanding value of the unredeemed and unexpired gift cards at the end of a date
+type LiabilityDTO struct {
+	AsOf              string                         `json:"as_of"`
+	Timezone          string                         `json:"timezone"`
+	Campaigns         []CampaignLiabilityDTO         `json:"campaigns"`
+	OutstandingCount  int64                          `json:"outstanding_count"`
+	OutstandingAmount int64                          `json:"outstanding_amount"`
+	Error             *indraframework.IndraException `json:"error"`
+}
+
+func (a *LiabilityDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/go-ozzo/ozzo-validation/v4"
+
+// LiabilityRequestDTO asks for the outstanding value at the end of a date in the timezone
+type LiabilityRequestDTO struct {
+	CampaignId *uint
+	AsOf       string
+	Timezone   string
+}
+
+func (a LiabilityRequestDTO) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.AsOf, validation.Required, validation.By(isDate)),
+		validation.Field(&a.Timezone, validation.Required),
+	)
+}
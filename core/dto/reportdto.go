@@ -0,0 +1,27 @@
+package dto
+
+import "giftcard-engine/utils/indraframework"
+
+// ReportPointDTO is the issued and redeemed totals of a single period
+type ReportPointDTO struct {
+	PeriodStart    string `json:"period_start"`
+	IssuedCount    int64  `json:"issued_count"`
+	IssuedAmount   int64  `json:"issued_amount"`
+	RedeemedCount  int64  `json:"redeemed_count"`
+	RedeemedAmount int64  `json:"redeemed_amount"`
+}
+
+// ReportDTO is a time series of the gift cards. every period of the range has a point even if it is empty
+type ReportDTO struct {
+	CampaignId int                            `json:"campaign_id,omitempty"`
+	Interval   string                         `json:"interval"`
+	Timezone   string                         `json:"timezone"`
+	From       string                         `json:"from"`
+	To         string                         `json:"to"`
+	Points     []ReportPointDTO               `json:"points"`
+	Error      *indraframework.IndraException `json:"error"`
+}
+
+func (a *ReportDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"giftcard-engine/utils/date"
+	"github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Report intervals
+const (
+	DailyReport   = "day"
+	WeeklyReport  = "week"
+	MonthlyReport = "month"
+)
+
+// ReportRequestDTO asks for the series of a date range. both dates are inclusive and are read in the timezone
+type ReportRequestDTO struct {
+	CampaignId *uint
+	From       string
+	To         string
+	Interval   string
+	Timezone   string
+}
+
+func (a ReportRequestDTO) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.From, validation.Required, validation.By(isDate)),
+		validation.Field(&a.To, validation.Required, validation.By(isDate)),
+		validation.Field(&a.Interval, validation.Required, validation.In(DailyReport, WeeklyReport, MonthlyReport)),
+		validation.Field(&a.Timezone, validation.Required),
+	)
+}
+
+func isDate(value interface{}) error {
+	_, err := date.DefaultToTime(value.(string))
+	return err
+}
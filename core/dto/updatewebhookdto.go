@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// UpdateWebhookDTO changes the url and the events of a subscription. the secret is kept when it is empty
+type UpdateWebhookDTO struct {
+	ID     int      `json:"id"`
+	Url    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+func (a UpdateWebhookDTO) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.ID, validation.Required),
+		validation.Field(&a.Url, validation.Required, validation.By(httpUrl)),
+		validation.Field(&a.Events, validation.Required, validation.Each(validation.In(webhookEvents()...))),
+		validation.Field(&a.Secret, validation.Length(minWebhookSecretLength, 256)),
+	)
+}
@@ -1,7 +1,7 @@
 package dto
 
 import (
-	"giftcard-engine/utils"
+	"giftcard-engine/utils/random"
 	"github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -12,6 +12,6 @@ type ValidateGiftCardsDto struct {
 func (a ValidateGiftCardsDto) Validate() error {
 	return validation.ValidateStruct(&a,
 		validation.Field(&a.GiftCardsSecret,
-			validation.Each(validation.Length(utils.GiftCardSecretKeyLength, utils.GiftCardSecretKeyLength))),
+			validation.Each(validation.Length(random.SecretKeyLength(), random.SecretKeyLength()))),
 	)
 }
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"encoding/json"
+	"giftcard-engine/utils/indraframework"
+)
+
+// WebhookDeliveryDTO is an event sent, or going to be sent, to a subscription
+type WebhookDeliveryDTO struct {
+	ID             int                            `json:"id,string,omitempty"`
+	SubscriptionId uint                           `json:"subscription_id"`
+	Event          string                         `json:"event"`
+	Status         string                         `json:"status"`
+	Attempts       int                            `json:"attempts"`
+	LastError      string                         `json:"last_error,omitempty"`
+	NextAttemptAt  string                         `json:"next_attempt_at,omitempty"`
+	CreatedAt      string                         `json:"created_at"`
+	Payload        json.RawMessage                `json:"payload" swaggertype:"object"`
+	Error          *indraframework.IndraException `json:"error"`
+}
+
+func (a *WebhookDeliveryDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
+
+type WebhookDeliveryPageDTO struct {
+	Size       int                            `json:"size"`
+	Page       int                            `json:"page"`
+	Deliveries []WebhookDeliveryDTO           `json:"deliveries"`
+	TotalItems int                            `json:"total_items"`
+	Error      *indraframework.IndraException `json:"error"`
+}
+
+func NewWebhookDeliveryPageDTO(deliveries []WebhookDeliveryDTO, size, page, total int) WebhookDeliveryPageDTO {
+	return WebhookDeliveryPageDTO{
+		Size:       size,
+		Page:       page + 1,
+		Deliveries: deliveries,
+		TotalItems: total,
+	}
+}
+
+func (a *WebhookDeliveryPageDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "giftcard-engine/utils/indraframework"
+
+// WebhookDTO is a subscription to the events of the gift cards. the secret is never returned
+type WebhookDTO struct {
+	ID        int                            `json:"id,string,omitempty"`
+	Url       string                         `json:"url"`
+	Events    []string                       `json:"events"`
+	CreatedAt string                         `json:"created_at"`
+	Error     *indraframework.IndraException `json:"error"`
+}
+
+func (a *WebhookDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
+
+type WebhookListDTO struct {
+	Webhooks []WebhookDTO                   `json:"webhooks"`
+	Error    *indraframework.IndraException `json:"error"`
+}
+
+func (a *WebhookListDTO) SetError(exc *indraframework.IndraException) {
+	a.Error = exc
+}
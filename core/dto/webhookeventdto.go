@@ -0,0 +1,35 @@
+package dto
+
+// WebhookEventDTO is the json body of the webhooks. the id is the same for every subscription of the event, so a
+// receiver which is sent an event twice, like after a redelivery, can ignore the repeated one
+type WebhookEventDTO struct {
+	ID         string           `json:"id"`
+	Event      string           `json:"event"`
+	OccurredAt string           `json:"occurred_at"`
+	Cards      []WebhookCardDTO `json:"cards"`
+}
+
+// WebhookCardDTO is a gift card in the webhooks. the secret code is left out so the receivers cannot redeem it
+type WebhookCardDTO struct {
+	ID         int    `json:"id"`
+	PublicCode string `json:"public_code"`
+	UUN        string `json:"uun,omitempty"`
+	Amount     int32  `json:"amount"`
+	ExpireDate string `json:"expire_date"`
+	CampaignId uint   `json:"campaign_id,omitempty"`
+	BatchId    uint   `json:"batch_id,omitempty"`
+	RedeemedAt string `json:"redeemed_at,omitempty"`
+}
+
+func NewWebhookCardDTO(card GiftCardDTO) WebhookCardDTO {
+	return WebhookCardDTO{
+		ID:         card.ID,
+		PublicCode: card.PublicCode,
+		UUN:        card.UUN,
+		Amount:     card.Amount,
+		ExpireDate: card.ExpireDate,
+		CampaignId: card.CampaignId,
+		BatchId:    card.BatchId,
+		RedeemedAt: card.RedeemedAt,
+	}
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// DomainEvent is a committed change which is read from the outbox. the payload of the events of the gift cards is
+// a dbmodel.GiftCardSnapshot
+type DomainEvent struct {
+	ID          int
+	Name        string
+	AggregateId int
+	OccurredAt  time.Time
+	Payload     json.RawMessage
+}
+
+// EventPublisher forwards the domain events out of the process. the outbox relay hands an event again until
+// the publisher returns no error, so the receivers should find the repeated events by their ids
+type EventPublisher interface {
+	Publish(ctx context.Context, events []DomainEvent) error
+}
+
+// EventBus hands the domain events to the publishers subscribed to it. the publishers are known by their names,
+// which are stored with the events, so a failed publisher is retried without the others getting the events again.
+// the publishers are subscribed while the service starts, before the events are published
+type EventBus struct {
+	names      []string
+	publishers map[string]EventPublisher
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{publishers: map[string]EventPublisher{}}
+}
+
+// Subscribe adds the publisher, or replaces the one of the same name
+func (b *EventBus) Subscribe(name string, publisher EventPublisher) {
+	if _, ok := b.publishers[name]; !ok {
+		b.names = append(b.names, name)
+	}
+	b.publishers[name] = publisher
+}
+
+// Publishers returns the names of the publishers in the order they are subscribed
+func (b *EventBus) Publishers() []string {
+	return b.names
+}
+
+// Publish hands the events to the publisher of the name. there is nothing to do for a name which is not
+// subscribed anymore
+func (b *EventBus) Publish(ctx context.Context, name string, events []DomainEvent) error {
+	publisher, ok := b.publishers[name]
+	if !ok || len(events) == 0 {
+		return nil
+	}
+	return publisher.Publish(ctx, events)
+}
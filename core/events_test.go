@@ -0,0 +1,38 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"giftcard-engine/core"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type recordingPublisher struct {
+	events []core.DomainEvent
+	err    error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, events []core.DomainEvent) error {
+	p.events = append(p.events, events...)
+	return p.err
+}
+
+func TestEventBus(t *testing.T) {
+	t.Parallel()
+	bus := core.NewEventBus()
+	webhooks := &recordingPublisher{}
+	failing := &recordingPublisher{err: errors.New("unavailable")}
+	bus.Subscribe("webhooks", &recordingPublisher{})
+	bus.Subscribe("broker", failing)
+	bus.Subscribe("webhooks", webhooks)
+	events := []core.DomainEvent{{ID: 1, Name: "gift_card.issued"}}
+
+	assert.Equal(t, []string{"webhooks", "broker"}, bus.Publishers(), "a replaced publisher should keep its order")
+	assert.Empty(t, bus.Publish(context.Background(), "webhooks", events))
+	assert.Equal(t, events, webhooks.events)
+	assert.Equal(t, failing.err, bus.Publish(context.Background(), "broker", events))
+	assert.Empty(t, bus.Publish(context.Background(), "log", events), "an unknown publisher should be skipped")
+	assert.Empty(t, bus.Publish(context.Background(), "webhooks", nil))
+	assert.Equal(t, 1, len(webhooks.events))
+}
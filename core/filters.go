@@ -0,0 +1,62 @@
+package core
+
+import (
+	"giftcard-engine/core/common"
+	"strings"
+	"time"
+)
+
+// GiftCardSortColumns are the columns which gift card listings can be sorted by
+var GiftCardSortColumns = []string{"id", "amount", "status", "expire_date", "created_at", "redeemed_at"}
+
+// SortField is a single sort column. Column is one of the whitelisted api names
+type SortField struct {
+	Column     string
+	Descending bool
+}
+
+// GiftCardFilter holds the criteria of the gift card listings. nil and empty fields are ignored
+type GiftCardFilter struct {
+	Search         string
+	UUN            string
+	Status         *int
+	AmountFrom     *int32
+	AmountTo       *int32
+	CampaignIds    []uint
+	CampaignTitle  string
+	IsValid        *bool
+	ExpireDateFrom *time.Time
+	ExpireDateTo   *time.Time
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	RedeemedFrom   *time.Time
+	RedeemedTo     *time.Time
+	Sort           []SortField
+}
+
+// ParseGiftCardSort parses a comma separated list of columns like "-amount,created_at".
+// a leading minus means descending order
+func ParseGiftCardSort(value string) ([]SortField, error) {
+	var fields []SortField
+	if value == "" {
+		return fields, nil
+	}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		field := SortField{Column: strings.TrimPrefix(item, "-"), Descending: strings.HasPrefix(item, "-")}
+		if !isGiftCardSortColumn(field.Column) {
+			return nil, common.InvalidSortQueryParam
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func isGiftCardSortColumn(column string) bool {
+	for _, item := range GiftCardSortColumns {
+		if item == column {
+			return true
+		}
+	}
+	return false
+}
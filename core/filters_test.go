@@ -0,0 +1,32 @@
+package core_test
+
+import (
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseGiftCardSort(te *testing.T) {
+	te.Parallel()
+	te.Run("valid sort", func(t *testing.T) {
+		fields, err := core.ParseGiftCardSort("-amount, created_at")
+		assert.Empty(t, err)
+		assert.Equal(t, []core.SortField{
+			{Column: "amount", Descending: true},
+			{Column: "created_at", Descending: false},
+		}, fields)
+	})
+
+	te.Run("empty sort", func(t *testing.T) {
+		fields, err := core.ParseGiftCardSort("")
+		assert.Empty(t, err)
+		assert.Empty(t, fields)
+	})
+
+	te.Run("not whitelisted column", func(t *testing.T) {
+		fields, err := core.ParseGiftCardSort("amount,SecretCode")
+		assert.Equal(t, common.InvalidSortQueryParam, err)
+		assert.Nil(t, fields)
+	})
+}
@@ -0,0 +1,9 @@
+package core
+
+// CampaignLiability is the outstanding value of the cards of a campaign at some moment
+type CampaignLiability struct {
+	CampaignId    uint
+	CampaignTitle string
+	Count         int64
+	Amount        int64
+}
@@ -0,0 +1,119 @@
+package logic
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/utils/date"
+)
+
+type batchService struct {
+	batchRepo    core.BatchRepository
+	giftCardRepo core.GiftCardRepository
+	index        core.GiftCardIndex
+	mapper       core.Mapper
+}
+
+func (b *batchService) FindPage(ctx context.Context, size, page uint, createdBy string) dto.BatchPageDTO {
+	ctx, span := tracer.Start(ctx, "batchService.FindPage")
+	defer span.End()
+	batches, total := b.batchRepo.FindPage(ctx, size, page, createdBy)
+	return dto.NewBatchPageDTO(b.mapper.ToListOfBatches(batches), int(size), int(page), total)
+}
+
+func (b *batchService) FindByID(ctx context.Context, id uint) (*dto.BatchDTO, error) {
+	ctx, span := tracer.Start(ctx, "batchService.FindByID")
+	defer span.End()
+	batch, err := b.batchRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	batchDto := b.mapper.ToBatchDTO(*batch)
+	return &batchDto, nil
+}
+
+// FindCards returns every gift card issued in the batch
+func (b *batchService) FindCards(ctx context.Context, id uint) (*dto.GiftCardsListDTO, error) {
+	ctx, span := tracer.Start(ctx, "batchService.FindCards")
+	defer span.End()
+	if _, err := b.batchRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	cards := b.mapper.ToListOfGiftCardDTO(b.giftCardRepo.FindByBatch(ctx, id))
+	cards.BatchId = id
+	return cards, nil
+}
+
+// ExtendExpiry changes the expire date of the unused cards of the batch. the date is rejected when it is before the
+// expire date of any of them, so extending never shortens the life of a card
+func (b *batchService) ExtendExpiry(ctx context.Context, id uint,
+	expiry *dto.ExtendBatchExpiryDTO) (*dto.BatchOperationDTO, error) {
+	ctx, span := tracer.Start(ctx, "batchService.ExtendExpiry")
+	defer span.End()
+	expDate, err := date.DefaultToTime(expiry.ExpireDate)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = b.batchRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	for _, card := range b.giftCardRepo.FindByBatch(ctx, id) {
+		if card.IsUnused() && expDate.Before(card.ExpireDate) {
+			return nil, common.ExpireDateIsBeforeCurrent
+		}
+	}
+	affected, err := b.giftCardRepo.ExtendBatchExpiry(ctx, id, expDate)
+	if err != nil {
+		logger.WithContext(ctx).WithData(map[string]interface{}{
+			"batchId":    id,
+			"expireDate": expiry.ExpireDate,
+		}).ErrorException(err, "error while extending a batch expire date")
+		return nil, err
+	}
+	b.syncBatch(ctx, id)
+	return &dto.BatchOperationDTO{
+		BatchId:       int(id),
+		AffectedCards: affected,
+		Message:       "Batch expire date extended!",
+	}, nil
+}
+
+// Void makes all of the unused cards of the batch invalid at once
+func (b *batchService) Void(ctx context.Context, id uint) (*dto.BatchOperationDTO, error) {
+	ctx, span := tracer.Start(ctx, "batchService.Void")
+	defer span.End()
+	if _, err := b.batchRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	affected, err := b.giftCardRepo.VoidBatch(ctx, id)
+	if err != nil {
+		logger.WithContext(ctx).WithData(map[string]interface{}{
+			"batchId": id,
+		}).ErrorException(err, "error while voiding a batch")
+		return nil, err
+	}
+	b.syncBatch(ctx, id)
+	return &dto.BatchOperationDTO{
+		BatchId:       int(id),
+		AffectedCards: affected,
+		Message:       "Batch voided!",
+	}, nil
+}
+
+// syncBatch writes the cards of the batch into the search index after a batch operation
+func (b *batchService) syncBatch(ctx context.Context, id uint) {
+	cards := b.giftCardRepo.FindByBatch(ctx, id)
+	ids := make([]int, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	syncGiftCards(ctx, b.giftCardRepo, b.index, ids)
+}
+
+func NewBatchService(batchRepository core.BatchRepository, giftCardRepository core.GiftCardRepository,
+	index core.GiftCardIndex, mapper core.Mapper) core.BatchService {
+	return &batchService{batchRepo: batchRepository, giftCardRepo: giftCardRepository, index: index,
+		mapper: mapper}
+}
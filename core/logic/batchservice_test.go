@@ -0,0 +1,234 @@
+package logic_test
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/core/logic"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeBatchRepo struct {
+	findByIDCall int32
+	storeCall    int32
+	deleteCall   int32
+	findPageCall int32
+	strategy     int
+}
+
+func (r *fakeBatchRepo) FindByID(ctx context.Context, id uint) (*dbmodel.Batch, error) {
+	atomic.AddInt32(&r.findByIDCall, 1)
+	if r.strategy == notFound {
+		return nil, common.BatchNotFound
+	}
+	batch := dbmodel.NewBatch("milawd", "{}")
+	batch.ID = int(id)
+	return batch, nil
+}
+
+func (r *fakeBatchRepo) Store(ctx context.Context, batch *dbmodel.Batch) error {
+	atomic.AddInt32(&r.storeCall, 1)
+	if r.strategy == internalError {
+		return fakeInternalError
+	}
+	if batch.ID == 0 {
+		batch.ID = 1
+	}
+	return nil
+}
+
+func (r *fakeBatchRepo) Delete(ctx context.Context, batch dbmodel.Batch) error {
+	atomic.AddInt32(&r.deleteCall, 1)
+	return nil
+}
+
+func (r *fakeBatchRepo) FindPage(ctx context.Context, size, number uint, createdBy string) ([]dbmodel.Batch, int) {
+	atomic.AddInt32(&r.findPageCall, 1)
+	return []dbmodel.Batch{*dbmodel.NewBatch(createdBy, "{}")}, 1
+}
+
+func newFakeBatchRepo(strategy int) *fakeBatchRepo {
+	return &fakeBatchRepo{
+		strategy: strategy,
+	}
+}
+
+func createBatchServiceForTest(batchStrategy, cardStrategy int) (core.BatchService, *fakeBatchRepo,
+	*fakeGiftCardRepo) {
+	batchRepo := newFakeBatchRepo(batchStrategy)
+	cardRepo := newFakeGiftCardRepo(cardStrategy)
+	return logic.NewBatchService(batchRepo, cardRepo, newFakeGiftCardIndex(), newFakeGiftCardMapper()),
+		batchRepo, cardRepo
+}
+
+func TestBatchFindPage(t *testing.T) {
+	t.Parallel()
+	service, repo, _ := createBatchServiceForTest(defaultBehavior, defaultBehavior)
+
+	page := service.FindPage(context.Background(), 10, 0, "milawd")
+
+	assert.Equal(t, 1, page.Page)
+	assert.Equal(t, 10, page.Size)
+	assert.Equal(t, 1, page.TotalItems)
+	assert.Equal(t, "milawd", page.Batches[0].CreatedBy)
+	assert.Equal(t, int32(1), repo.findPageCall)
+}
+
+func TestBatchFindCards(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		service, batchRepo, cardRepo := createBatchServiceForTest(defaultBehavior, defaultBehavior)
+
+		cards, err := service.FindCards(context.Background(), 12)
+
+		assert.Empty(t, err)
+		assert.Equal(t, uint(12), cards.BatchId)
+		assert.Equal(t, 1, len(cards.Cards))
+		assert.Equal(t, uint(12), cards.Cards[0].BatchId)
+		assert.Equal(t, int32(1), batchRepo.findByIDCall)
+		assert.Equal(t, int32(1), cardRepo.findByBatchCall)
+	})
+
+	te.Run("with not found strategy", func(t *testing.T) {
+		t.Parallel()
+		service, _, cardRepo := createBatchServiceForTest(notFound, defaultBehavior)
+
+		cards, err := service.FindCards(context.Background(), 12)
+
+		assert.Equal(t, common.BatchNotFound, err)
+		assert.Empty(t, cards)
+		assert.Equal(t, int32(0), cardRepo.findByBatchCall)
+	})
+}
+
+func TestBatchExtendExpiry(te *testing.T) {
+	te.Parallel()
+	expiry := &dto.ExtendBatchExpiryDTO{ExpireDate: time.Now().AddDate(1, 0, 0).Format("2006-01-02")}
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		service, _, cardRepo := createBatchServiceForTest(defaultBehavior, defaultBehavior)
+
+		result, err := service.ExtendExpiry(context.Background(), 3, expiry)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 3, result.BatchId)
+		assert.Equal(t, 2, result.AffectedCards)
+		assert.Equal(t, int32(1), cardRepo.extendBatchExpiryCall)
+	})
+
+	te.Run("with not found strategy", func(t *testing.T) {
+		t.Parallel()
+		service, _, cardRepo := createBatchServiceForTest(notFound, defaultBehavior)
+
+		result, err := service.ExtendExpiry(context.Background(), 3, expiry)
+
+		assert.Equal(t, common.BatchNotFound, err)
+		assert.Empty(t, result)
+		assert.Equal(t, int32(0), cardRepo.extendBatchExpiryCall)
+	})
+
+	te.Run("with a date before the current expire date", func(t *testing.T) {
+		t.Parallel()
+		service, _, cardRepo := createBatchServiceForTest(defaultBehavior, defaultBehavior)
+		tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+
+		result, err := service.ExtendExpiry(context.Background(), 3, &dto.ExtendBatchExpiryDTO{ExpireDate: tomorrow})
+
+		assert.Equal(t, common.ExpireDateIsBeforeCurrent, err)
+		assert.Empty(t, result)
+		assert.Equal(t, int32(0), cardRepo.extendBatchExpiryCall)
+	})
+
+	te.Run("with invalid date", func(t *testing.T) {
+		t.Parallel()
+		service, batchRepo, _ := createBatchServiceForTest(defaultBehavior, defaultBehavior)
+
+		result, err := service.ExtendExpiry(context.Background(), 3, &dto.ExtendBatchExpiryDTO{ExpireDate: "invalid"})
+
+		assert.NotEmpty(t, err)
+		assert.Empty(t, result)
+		assert.Equal(t, int32(0), batchRepo.findByIDCall)
+	})
+
+	te.Run("with internal error strategy", func(t *testing.T) {
+		t.Parallel()
+		service, _, _ := createBatchServiceForTest(defaultBehavior, internalError)
+
+		result, err := service.ExtendExpiry(context.Background(), 3, expiry)
+
+		assert.Equal(t, fakeInternalError, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestBatchVoid(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		service, _, cardRepo := createBatchServiceForTest(defaultBehavior, defaultBehavior)
+
+		result, err := service.Void(context.Background(), 5)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 5, result.BatchId)
+		assert.Equal(t, 2, result.AffectedCards)
+		assert.Equal(t, int32(1), cardRepo.voidBatchCall)
+	})
+
+	te.Run("with not found strategy", func(t *testing.T) {
+		t.Parallel()
+		service, _, cardRepo := createBatchServiceForTest(notFound, defaultBehavior)
+
+		result, err := service.Void(context.Background(), 5)
+
+		assert.Equal(t, common.BatchNotFound, err)
+		assert.Empty(t, result)
+		assert.Equal(t, int32(0), cardRepo.voidBatchCall)
+	})
+}
+
+func TestCreateManyAssignsBatch(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		batchRepo := newFakeBatchRepo(defaultBehavior)
+		service := logic.NewGiftCardService(newFakeGiftCardRepo(defaultBehavior), batchRepo, newFakeGiftCardIndex(),
+			newFakeGiftCardMapper())
+
+		cards, err := service.CreateSameMany(context.Background(), &dto.BulkCreateSameGiftCardsDTO{
+			ExpireDate: "2400-02-02",
+			Amount:     2000,
+			Count:      3,
+			CreatedBy:  "milawd",
+		})
+
+		assert.Empty(t, err)
+		assert.Equal(t, uint(1), cards.BatchId)
+		for _, card := range cards.Cards {
+			assert.Equal(t, uint(1), card.BatchId)
+		}
+		assert.Equal(t, int32(2), batchRepo.storeCall, "batch should be stored once and updated once")
+	})
+
+	te.Run("with batch internal error strategy", func(t *testing.T) {
+		t.Parallel()
+		cardRepo := newFakeGiftCardRepo(defaultBehavior)
+		service := logic.NewGiftCardService(cardRepo, newFakeBatchRepo(internalError), newFakeGiftCardIndex(),
+			newFakeGiftCardMapper())
+
+		cards, err := service.CreateMany(context.Background(),
+			&dto.BulkCreateGiftCardsDTO{GiftCards: []dto.CreateGiftCardDTO{
+				{ExpireDate: "2400-02-02", Amount: 3000},
+			}})
+
+		assert.Equal(t, fakeInternalError, err)
+		assert.Empty(t, cards.Cards)
+		assert.Equal(t, int32(0), cardRepo.storeCall)
+	})
+}
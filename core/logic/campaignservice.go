@@ -1,54 +1,149 @@
 package logic
 
 import (
+	"context"
 	"giftcard-engine/core"
+	"giftcard-engine/core/common"
 	"giftcard-engine/core/dto"
 	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/utils/cursor"
 )
 
 type campaignService struct {
-	repo   core.CampaignRepository
-	mapper core.Mapper
+	repo         core.CampaignRepository
+	giftCardRepo core.GiftCardRepository
+	index        core.GiftCardIndex
+	mapper       core.Mapper
 }
 
-func (g *campaignService) Create(campaign dto.CreateCampaignDTO) (dto.CampaignDTO, error) {
+func (g *campaignService) Create(ctx context.Context, campaign dto.CreateCampaignDTO) (dto.CampaignDTO, error) {
+	ctx, span := tracer.Start(ctx, "campaignService.Create")
+	defer span.End()
 	c := g.mapper.ToCampaign(campaign)
-	err := g.repo.Store(&c)
+	err := g.repo.Store(ctx, &c)
 	campaignDto := g.mapper.ToCampaignDTO(c)
 	if err != nil {
-		logger.ErrorException(err, "error in creating new campaign")
+		logger.WithContext(ctx).ErrorException(err, "error in creating new campaign")
 		return dto.EmptyCampaignDTO(), err
 	}
 	return campaignDto, nil
 }
 
-func (g *campaignService) Update(campaign dto.UpdateCampaignDto) (dto.CampaignDTO, error) {
-	campaignModel, err := g.repo.FindByID(uint(campaign.ID))
+func (g *campaignService) Update(ctx context.Context, campaign dto.UpdateCampaignDto) (dto.CampaignDTO, error) {
+	ctx, span := tracer.Start(ctx, "campaignService.Update")
+	defer span.End()
+	campaignModel, err := g.repo.FindByID(ctx, uint(campaign.ID))
 	if err != nil {
-		logger.WithData(campaign).ErrorException(err, "error in updating a campaign")
+		logger.WithContext(ctx).WithData(campaign).ErrorException(err, "error in updating a campaign")
 		return dto.EmptyCampaignDTO(), err
 	}
+	title := campaignModel.Title
 	(&campaignModel).Update(campaign.Title)
 	campaignDto := g.mapper.ToCampaignDTO(campaignModel)
-	return campaignDto, g.repo.Store(&campaignModel)
+	if err = g.repo.Store(ctx, &campaignModel); err != nil {
+		return campaignDto, err
+	}
+	if campaignModel.Title != title {
+		syncCampaignCards(ctx, g.giftCardRepo, g.index, uint(campaignModel.ID))
+	}
+	return campaignDto, nil
 }
 
-func (g *campaignService) Delete(id uint) error {
-	campaign, err := g.repo.FindByID(id)
+func (g *campaignService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "campaignService.Delete")
+	defer span.End()
+	campaign, err := g.repo.FindByID(ctx, id)
 	if err != nil {
-		logger.WithData(map[string]interface{}{
+		logger.WithContext(ctx).WithData(map[string]interface{}{
 			"id" : id,
 		}).ErrorException(err, "error in deleting a campaign")
 		return err
 	}
-	return g.repo.Delete(campaign)
+	return g.repo.Delete(ctx, campaign)
 }
 
-func (g *campaignService) FindPage(size, page uint, search string) dto.CampaignPageDTO {
-	campaigns, total := g.repo.FindPage(size, page, search)
+func (g *campaignService) FindPage(ctx context.Context, size, page uint, search string) dto.CampaignPageDTO {
+	ctx, span := tracer.Start(ctx, "campaignService.FindPage")
+	defer span.End()
+	campaigns, total := g.repo.FindPage(ctx, size, page, search)
 	return dto.NewCampaignPageDTO(g.mapper.ToListOfCampaigns(campaigns), int(size), int(page), total)
 }
 
-func NewCampaignService(repository core.CampaignRepository, mapper core.Mapper) core.CampaignService {
-	return &campaignService{repo: repository, mapper: mapper}
+// FindCursorPage returns a keyset page of campaigns starting after the given cursor
+func (g *campaignService) FindCursorPage(ctx context.Context, size uint, token string, search string,
+	withCount bool) (dto.CampaignCursorPageDTO, error) {
+	ctx, span := tracer.Start(ctx, "campaignService.FindCursorPage")
+	defer span.End()
+	after, err := cursor.Decode(token)
+	if err != nil {
+		return dto.CampaignCursorPageDTO{}, common.InvalidCursor
+	}
+	campaigns, total := g.repo.FindCursorPage(ctx, size+1, after, search, withCount)
+	nextCursor := ""
+	if size > 0 && len(campaigns) > int(size) {
+		campaigns = campaigns[:size]
+		nextCursor = cursor.Encode(campaigns[size-1].ID)
+	}
+	return dto.NewCampaignCursorPageDTO(g.mapper.ToListOfCampaigns(campaigns), int(size), nextCursor, total), nil
+}
+
+// Stats returns the performance of a single campaign
+func (g *campaignService) Stats(ctx context.Context, id uint) (*dto.CampaignStatsDTO, error) {
+	ctx, span := tracer.Start(ctx, "campaignService.Stats")
+	defer span.End()
+	if _, err := g.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	campaignId := id
+	stats, err := g.giftCardRepo.Stats(ctx, &campaignId)
+	if err != nil {
+		logger.WithContext(ctx).WithData(map[string]interface{}{
+			"id": id,
+		}).ErrorException(err, "error in calculating the stats of a campaign")
+		return nil, err
+	}
+	statsDto := toCampaignStatsDTO(stats)
+	statsDto.CampaignId = int(id)
+	return &statsDto, nil
+}
+
+// Summary returns the performance of all of the campaigns together
+func (g *campaignService) Summary(ctx context.Context) (*dto.CampaignStatsDTO, error) {
+	ctx, span := tracer.Start(ctx, "campaignService.Summary")
+	defer span.End()
+	stats, err := g.giftCardRepo.Stats(ctx, nil)
+	if err != nil {
+		logger.WithContext(ctx).ErrorException(err, "error in calculating the stats summary")
+		return nil, err
+	}
+	statsDto := toCampaignStatsDTO(stats)
+	return &statsDto, nil
+}
+
+func toCardsAggregateDTO(aggregate core.CardsAggregate) dto.CardsAggregateDTO {
+	return dto.CardsAggregateDTO{Count: aggregate.Count, Amount: aggregate.Amount}
+}
+
+// toCampaignStatsDTO calculates the rates of the stats. deleted cards are not counted as issued in the rate
+func toCampaignStatsDTO(stats core.GiftCardStats) dto.CampaignStatsDTO {
+	statsDto := dto.CampaignStatsDTO{
+		Issued:      toCardsAggregateDTO(stats.Issued),
+		Redeemed:    toCardsAggregateDTO(stats.Redeemed),
+		Expired:     toCardsAggregateDTO(stats.Expired),
+		Voided:      toCardsAggregateDTO(stats.Voided),
+		Deleted:     toCardsAggregateDTO(stats.Deleted),
+		Outstanding: toCardsAggregateDTO(stats.Outstanding),
+	}
+	if live := stats.Issued.Count - stats.Deleted.Count; live > 0 {
+		statsDto.RedemptionRate = float64(stats.Redeemed.Count) / float64(live)
+	}
+	if stats.TimedRedeems > 0 {
+		statsDto.AverageSecondsToRedeem = float64(stats.RedeemSeconds) / float64(stats.TimedRedeems)
+	}
+	return statsDto
+}
+
+func NewCampaignService(repository core.CampaignRepository, giftCardRepository core.GiftCardRepository,
+	index core.GiftCardIndex, mapper core.Mapper) core.CampaignService {
+	return &campaignService{repo: repository, giftCardRepo: giftCardRepository, index: index, mapper: mapper}
 }
@@ -1,6 +1,7 @@
 package logic_test
 
 import (
+	"context"
 	"giftcard-engine/core"
 	"giftcard-engine/core/common"
 	"giftcard-engine/core/dbmodel"
@@ -12,18 +13,19 @@ import (
 )
 
 type fakeCampaignRepo struct {
-	findByIDCall int32
-	storeCall    int32
-	deleteCall   int32
-	findPageCall int32
-	strategy     int
+	findByIDCall       int32
+	storeCall          int32
+	deleteCall         int32
+	findPageCall       int32
+	findCursorPageCall int32
+	strategy           int
 }
 
 var defaultCampaign = dbmodel.Campaign{
 	Title: "dastan",
 }
 
-func (r *fakeCampaignRepo) FindByID(id uint) (dbmodel.Campaign, error) {
+func (r *fakeCampaignRepo) FindByID(ctx context.Context, id uint) (dbmodel.Campaign, error) {
 	atomic.AddInt32(&r.findByIDCall, 1)
 
 	if r.strategy == notFound {
@@ -32,7 +34,7 @@ func (r *fakeCampaignRepo) FindByID(id uint) (dbmodel.Campaign, error) {
 	return defaultCampaign, nil
 }
 
-func (r *fakeCampaignRepo) Store(campaign *dbmodel.Campaign) error {
+func (r *fakeCampaignRepo) Store(ctx context.Context, campaign *dbmodel.Campaign) error {
 	atomic.AddInt32(&r.storeCall, 1)
 	if r.strategy == internalError {
 		return fakeInternalError
@@ -43,7 +45,7 @@ func (r *fakeCampaignRepo) Store(campaign *dbmodel.Campaign) error {
 	return nil
 }
 
-func (r *fakeCampaignRepo) Delete(campaign dbmodel.Campaign) error {
+func (r *fakeCampaignRepo) Delete(ctx context.Context, campaign dbmodel.Campaign) error {
 	atomic.AddInt32(&r.deleteCall, 1)
 
 	if r.strategy == notFound {
@@ -57,13 +59,33 @@ func (r *fakeCampaignRepo) Delete(campaign dbmodel.Campaign) error {
 	return nil
 }
 
-func (r *fakeCampaignRepo) FindPage(size, number uint, search string) ([]dbmodel.Campaign, int) {
+func (r *fakeCampaignRepo) FindPage(ctx context.Context, size, number uint, search string) ([]dbmodel.Campaign, int) {
 	atomic.AddInt32(&r.findPageCall, 1)
 	return []dbmodel.Campaign{
 		defaultCampaign,
 	}, 1
 }
 
+// FindCursorPage pages through five campaigns with ids 5 to 1
+func (r *fakeCampaignRepo) FindCursorPage(ctx context.Context, size uint, after *int, search string,
+	withCount bool) ([]dbmodel.Campaign, int) {
+	atomic.AddInt32(&r.findCursorPageCall, 1)
+	id := 5
+	if after != nil {
+		id = *after - 1
+	}
+	campaigns := []dbmodel.Campaign{}
+	for ; id > 0 && len(campaigns) < int(size); id-- {
+		campaign := defaultCampaign
+		campaign.ID = id
+		campaigns = append(campaigns, campaign)
+	}
+	if !withCount {
+		return campaigns, -1
+	}
+	return campaigns, 5
+}
+
 func newFakeCampaignRepo(strategy int) *fakeCampaignRepo {
 	return &fakeCampaignRepo{
 		strategy: strategy,
@@ -73,9 +95,23 @@ func newFakeCampaignRepo(strategy int) *fakeCampaignRepo {
 //////////////////
 
 func createCampaignServiceForTest(strategy int) (core.CampaignService, *fakeCampaignRepo, *fakeGiftCardMapper) {
+	service, repo, _, mapper := createCampaignServiceWithCardsForTest(strategy, defaultBehavior)
+	return service, repo, mapper
+}
+
+func createCampaignServiceWithCardsForTest(strategy, cardStrategy int) (core.CampaignService, *fakeCampaignRepo,
+	*fakeGiftCardRepo, *fakeGiftCardMapper) {
+	service, repo, cardRepo, mapper, _ := createCampaignServiceWithIndexForTest(strategy, cardStrategy)
+	return service, repo, cardRepo, mapper
+}
+
+func createCampaignServiceWithIndexForTest(strategy, cardStrategy int) (core.CampaignService, *fakeCampaignRepo,
+	*fakeGiftCardRepo, *fakeGiftCardMapper, *fakeGiftCardIndex) {
 	mapper := newFakeGiftCardMapper()
 	repo := newFakeCampaignRepo(strategy)
-	return logic.NewCampaignService(repo, mapper), repo, mapper
+	cardRepo := newFakeGiftCardRepo(cardStrategy)
+	index := newFakeGiftCardIndex()
+	return logic.NewCampaignService(repo, cardRepo, index, mapper), repo, cardRepo, mapper, index
 }
 
 func TestCampaignCreate(te *testing.T) {
@@ -85,7 +121,7 @@ func TestCampaignCreate(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createCampaignServiceForTest(defaultBehavior)
 
-		camp, err := service.Create(dto.CreateCampaignDTO{Title: "dastan"})
+		camp, err := service.Create(context.Background(), dto.CreateCampaignDTO{Title: "dastan"})
 
 		assert.Empty(t, err)
 		assert.Equal(t, int32(1), repo.storeCall)
@@ -97,7 +133,7 @@ func TestCampaignCreate(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createCampaignServiceForTest(invalidOperation)
 
-		_, err := service.Create(dto.CreateCampaignDTO{Title: "dastan"})
+		_, err := service.Create(context.Background(), dto.CreateCampaignDTO{Title: "dastan"})
 
 		assert.NotEmpty(t, err)
 		assert.Equal(t, common.DuplicatedCampaignTitle, err)
@@ -109,7 +145,7 @@ func TestCampaignCreate(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createCampaignServiceForTest(internalError)
 
-		_, err := service.Create(dto.CreateCampaignDTO{Title: "dastan"})
+		_, err := service.Create(context.Background(), dto.CreateCampaignDTO{Title: "dastan"})
 
 		assert.NotEmpty(t, err)
 		assert.NotNil(t, err)
@@ -125,7 +161,7 @@ func TestCampaignUpdate(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createCampaignServiceForTest(defaultBehavior)
 
-		camp, err := service.Update(dto.UpdateCampaignDto{Title: "dastan",
+		camp, err := service.Update(context.Background(), dto.UpdateCampaignDto{Title: "dastan",
 			ID: 1})
 
 		assert.Empty(t, err)
@@ -139,7 +175,7 @@ func TestCampaignUpdate(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createCampaignServiceForTest(notFound)
 
-		_, err := service.Update(dto.UpdateCampaignDto{Title: "dastan",
+		_, err := service.Update(context.Background(), dto.UpdateCampaignDto{Title: "dastan",
 			ID: 1})
 
 		assert.NotNil(t, err)
@@ -153,7 +189,7 @@ func TestCampaignUpdate(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createCampaignServiceForTest(internalError)
 
-		_, err := service.Update(dto.UpdateCampaignDto{Title: "dastan",
+		_, err := service.Update(context.Background(), dto.UpdateCampaignDto{Title: "dastan",
 			ID: 1})
 
 		assert.NotNil(t, err)
@@ -161,6 +197,28 @@ func TestCampaignUpdate(te *testing.T) {
 		assert.Equal(t, int32(1), repo.findByIDCall)
 		assert.Equal(t, int32(1), mapper.ToCampaignDTOCall)
 	})
+
+	te.Run("with changed title", func(t *testing.T) {
+		t.Parallel()
+		service, _, cardRepo, _, index := createCampaignServiceWithIndexForTest(defaultBehavior, defaultBehavior)
+
+		_, err := service.Update(context.Background(), dto.UpdateCampaignDto{Title: "yalda", ID: 1})
+
+		assert.Empty(t, err)
+		assert.Equal(t, 5, index.count(), "the cards should be indexed with the new title")
+		assert.Equal(t, int32(2), cardRepo.findCursorPageCall)
+	})
+
+	te.Run("with same title", func(t *testing.T) {
+		t.Parallel()
+		service, _, cardRepo, _, index := createCampaignServiceWithIndexForTest(defaultBehavior, defaultBehavior)
+
+		_, err := service.Update(context.Background(), dto.UpdateCampaignDto{Title: "dastan", ID: 1})
+
+		assert.Empty(t, err)
+		assert.Equal(t, 0, index.count())
+		assert.Equal(t, int32(0), cardRepo.findCursorPageCall)
+	})
 }
 
 func TestCampaignDelete(te *testing.T) {
@@ -170,7 +228,7 @@ func TestCampaignDelete(te *testing.T) {
 		t.Parallel()
 		service, repo, _ := createCampaignServiceForTest(defaultBehavior)
 
-		err := service.Delete(12)
+		err := service.Delete(context.Background(), 12)
 
 		assert.Empty(t, err)
 		assert.Equal(t, int32(1), repo.deleteCall)
@@ -181,7 +239,7 @@ func TestCampaignDelete(te *testing.T) {
 		t.Parallel()
 		service, repo, _ := createCampaignServiceForTest(notFound)
 
-		err := service.Delete(12)
+		err := service.Delete(context.Background(), 12)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, int32(0), repo.deleteCall)
@@ -193,7 +251,7 @@ func TestCampaignDelete(te *testing.T) {
 		t.Parallel()
 		service, repo, _ := createCampaignServiceForTest(internalError)
 
-		err := service.Delete(12)
+		err := service.Delete(context.Background(), 12)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, int32(1), repo.deleteCall)
@@ -206,9 +264,94 @@ func TestCampaignFindPage(t *testing.T) {
 
 	service, repo, mapper := createCampaignServiceForTest(defaultBehavior)
 
-	camps := service.FindPage(1, 1, "")
+	camps := service.FindPage(context.Background(), 1, 1, "")
 
 	assert.NotNil(t, camps)
 	assert.Equal(t, int32(1), repo.findPageCall)
 	assert.Equal(t, int32(1), mapper.ToListOfCampaignsCall)
 }
+
+func TestCampaignFindCursorPage(te *testing.T) {
+	te.Parallel()
+	te.Run("walk through all pages", func(t *testing.T) {
+		t.Parallel()
+		service, repo, _ := createCampaignServiceForTest(defaultBehavior)
+
+		first, err := service.FindCursorPage(context.Background(), 2, "", "", true)
+		assert.Empty(t, err)
+		assert.Equal(t, 2, len(first.Campaigns))
+		assert.Equal(t, 5, *first.TotalItems)
+		assert.NotEmpty(t, first.NextCursor)
+
+		second, err := service.FindCursorPage(context.Background(), 2, first.NextCursor, "", false)
+		assert.Empty(t, err)
+		assert.Equal(t, 3, second.Campaigns[0].ID)
+		assert.Nil(t, second.TotalItems)
+		assert.NotEmpty(t, second.NextCursor)
+
+		last, err := service.FindCursorPage(context.Background(), 2, second.NextCursor, "", false)
+		assert.Empty(t, err)
+		assert.Equal(t, 1, len(last.Campaigns))
+		assert.Empty(t, last.NextCursor)
+		assert.Equal(t, int32(3), repo.findCursorPageCall)
+	})
+
+	te.Run("with invalid cursor", func(t *testing.T) {
+		t.Parallel()
+		service, repo, _ := createCampaignServiceForTest(defaultBehavior)
+
+		_, err := service.FindCursorPage(context.Background(), 2, "invalid", "", true)
+
+		assert.Equal(t, common.InvalidCursor, err)
+		assert.Equal(t, int32(0), repo.findCursorPageCall)
+	})
+}
+
+func TestCampaignStats(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		service, repo, cardRepo, _ := createCampaignServiceWithCardsForTest(defaultBehavior, defaultBehavior)
+
+		stats, err := service.Stats(context.Background(), 12)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 12, stats.CampaignId)
+		assert.Equal(t, uint(12), *cardRepo.statsCampaignId)
+		assert.Equal(t, int64(10), stats.Issued.Count)
+		assert.Equal(t, 0.5, stats.RedemptionRate, "deleted cards should not be counted")
+		assert.Equal(t, float64(150), stats.AverageSecondsToRedeem)
+		assert.Equal(t, int32(1), repo.findByIDCall)
+	})
+
+	te.Run("with not found strategy", func(t *testing.T) {
+		t.Parallel()
+		service, _, cardRepo, _ := createCampaignServiceWithCardsForTest(notFound, defaultBehavior)
+
+		_, err := service.Stats(context.Background(), 12)
+
+		assert.Equal(t, common.CampaignNotFound, err)
+		assert.Equal(t, int32(0), cardRepo.statsCall)
+	})
+
+	te.Run("with internal error strategy", func(t *testing.T) {
+		t.Parallel()
+		service, _, _, _ := createCampaignServiceWithCardsForTest(defaultBehavior, internalError)
+
+		_, err := service.Stats(context.Background(), 12)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestCampaignSummary(t *testing.T) {
+	t.Parallel()
+	service, _, cardRepo, _ := createCampaignServiceWithCardsForTest(defaultBehavior, defaultBehavior)
+
+	stats, err := service.Summary(context.Background())
+
+	assert.Empty(t, err)
+	assert.Equal(t, 0, stats.CampaignId)
+	assert.Nil(t, cardRepo.statsCampaignId)
+	assert.Equal(t, int64(3000), stats.Outstanding.Amount)
+}
@@ -0,0 +1,23 @@
+package logic
+
+import (
+	"context"
+	"time"
+)
+
+// detachedContext keeps the values of a context, like its trace and request id, without its deadline and
+// cancellation
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (d detachedContext) Value(key interface{}) interface{} { return d.parent.Value(key) }
+
+// detach is used by the bookkeeping after a fan-out, like closing a batch or rolling back the approved cards,
+// which should finish even when the request is canceled so the stored data stays consistent
+func detach(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
@@ -1,24 +1,31 @@
 package logic
 
 import (
+	"context"
+	"encoding/json"
 	"giftcard-engine/core"
 	"giftcard-engine/core/common"
 	"giftcard-engine/core/dbmodel"
 	"giftcard-engine/core/dto"
 	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/infrastructure/metrics"
+	"giftcard-engine/utils/cursor"
 	"giftcard-engine/utils/date"
 	"strings"
 	"sync"
-	"time"
 )
 
 type giftCardService struct {
 	giftCardRepo core.GiftCardRepository
+	batchRepo    core.BatchRepository
+	index        core.GiftCardIndex
 	mapper       core.Mapper
 }
 
-func (g *giftCardService) FindByUUN(uun string) (*dto.GiftCardsListDTO, error) {
-	giftCards := g.giftCardRepo.FindByUUN(uun)
+func (g *giftCardService) FindByUUN(ctx context.Context, uun string) (*dto.GiftCardsListDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.FindByUUN")
+	defer span.End()
+	giftCards := g.giftCardRepo.FindByUUN(ctx, uun)
 	if len(giftCards) == 0 {
 		return nil, common.NoGiftCardFoundForUser
 	}
@@ -26,8 +33,10 @@ func (g *giftCardService) FindByUUN(uun string) (*dto.GiftCardsListDTO, error) {
 }
 
 // FindByID find a gift card by ID
-func (g *giftCardService) FindByID(id uint) (*dto.GiftCardDTO, error) {
-	giftCard, err := g.giftCardRepo.FindByID(id)
+func (g *giftCardService) FindByID(ctx context.Context, id uint) (*dto.GiftCardDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.FindByID")
+	defer span.End()
+	giftCard, err := g.giftCardRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -35,8 +44,10 @@ func (g *giftCardService) FindByID(id uint) (*dto.GiftCardDTO, error) {
 	return &giftCardDto, nil
 }
 
-func (g *giftCardService) FindByPublicKey(key string) (*dto.GiftCardStatusDTO, error) {
-	giftCard, err := g.giftCardRepo.FindByPublicKey(key)
+func (g *giftCardService) FindByPublicKey(ctx context.Context, key string) (*dto.GiftCardStatusDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.FindByPublicKey")
+	defer span.End()
+	giftCard, err := g.giftCardRepo.FindByPublicKey(ctx, key)
 	if err != nil {
 		return nil, err
 	}
@@ -45,45 +56,72 @@ func (g *giftCardService) FindByPublicKey(key string) (*dto.GiftCardStatusDTO, e
 }
 
 // Store store a gift card
-func (g *giftCardService) Store(card *dto.CreateGiftCardDTO) (*dto.GiftCardDTO, error) {
+func (g *giftCardService) Store(ctx context.Context, card *dto.CreateGiftCardDTO) (*dto.GiftCardDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.Store")
+	defer span.End()
 	giftCard := g.mapper.ToGiftCard(*card)
-	err := g.giftCardRepo.Store(giftCard)
+	err := g.giftCardRepo.Store(ctx, giftCard)
 	if err != nil {
-		logger.WithData(card).ErrorException(err,"error while storing a gift card")
+		logger.WithContext(ctx).WithData(card).ErrorException(err,"error while storing a gift card")
 		return nil, err
 	}
+	metrics.CardsIssued(1)
+	syncGiftCards(ctx, g.giftCardRepo, g.index, []int{giftCard.ID})
 	giftCardDto := g.mapper.ToGiftCardDTO(giftCard)
 	return &giftCardDto, nil
 }
 
 // Store store a gift card
-func (g *giftCardService) Update(card *dto.UpdateGiftCardDto) (*dto.GiftCardDTO, error) {
+func (g *giftCardService) Update(ctx context.Context, card *dto.UpdateGiftCardDto) (*dto.GiftCardDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.Update")
+	defer span.End()
 	expDate, err := date.DefaultToTime(card.ExpireDate)
 	if err != nil {
 		return nil, err
 	}
-	giftCard, err := g.giftCardRepo.FindByID(uint(card.ID))
+	giftCard, err := g.giftCardRepo.FindByID(ctx, uint(card.ID))
 	if err != nil {
 		return nil, err
 	}
 	err = giftCard.Update(card.Amount, expDate)
 	if err != nil {
-		logger.WithData(card).ErrorException(err,"error while updating a gift card")
+		logger.WithContext(ctx).WithData(card).ErrorException(err,"error while updating a gift card")
 		return nil, err
 	}
 	giftCardDto := g.mapper.ToGiftCardDTO(giftCard)
-	return &giftCardDto, g.giftCardRepo.Store(giftCard)
+	if err = g.giftCardRepo.Store(ctx, giftCard); err != nil {
+		return &giftCardDto, err
+	}
+	syncGiftCards(ctx, g.giftCardRepo, g.index, []int{giftCard.ID})
+	return &giftCardDto, nil
 }
 
-func (g *giftCardService) Delete(id uint) error {
-	card, err := g.giftCardRepo.FindByID(id)
+func (g *giftCardService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "giftCardService.Delete")
+	defer span.End()
+	card, err := g.giftCardRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	return g.giftCardRepo.Delete(*card)
+	if err = g.giftCardRepo.Delete(ctx, *card); err != nil {
+		return err
+	}
+	if err = g.index.Delete(card.ID); err != nil {
+		logger.WithContext(ctx).WithData(map[string]interface{}{
+			"id": card.ID,
+		}).ErrorException(err, "error while removing a gift card from the search index")
+	}
+	return nil
 }
 
-func (g *giftCardService) CreateMany(cards *dto.BulkCreateGiftCardsDTO) (*dto.GiftCardsListDTO, error) {
+func (g *giftCardService) CreateMany(ctx context.Context,
+	cards *dto.BulkCreateGiftCardsDTO) (*dto.GiftCardsListDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.CreateMany")
+	defer span.End()
+	batch, err := g.openBatch(ctx, cards.CreatedBy, cards)
+	if err != nil {
+		return &dto.GiftCardsListDTO{Cards: []dto.GiftCardDTO{}}, err
+	}
 	c := make(chan dto.GiftCardDTO, len(cards.GiftCards))
 	errorChannel := make(chan error, len(cards.GiftCards))
 	defer close(c)
@@ -91,12 +129,11 @@ func (g *giftCardService) CreateMany(cards *dto.BulkCreateGiftCardsDTO) (*dto.Gi
 	cardsLength := len(cards.GiftCards)
 	for i := 0; i < cardsLength; i++ {
 		card := cards.GiftCards[i]
-		go g.createGiftCard(card.ExpireDate, card.Amount, card.CampaignId, c, errorChannel)
+		go g.createGiftCard(ctx, card.ExpireDate, card.Amount, card.CampaignId, uint(batch.ID), c, errorChannel)
 	}
 
 	cardsDto := make([]dto.GiftCardDTO, 0, cardsLength)
 
-	var err error
 	for i := 0; i < cardsLength; i++ {
 		select {
 		case item := <-c:
@@ -104,26 +141,35 @@ func (g *giftCardService) CreateMany(cards *dto.BulkCreateGiftCardsDTO) (*dto.Gi
 		case err = <-errorChannel:
 		}
 	}
+	batchId := g.closeBatch(detach(ctx), batch, cardsDto)
+	g.syncCreatedCards(detach(ctx), cardsDto)
 
 	return &dto.GiftCardsListDTO{
-		Cards: cardsDto,
-		Error: nil,
+		Cards:   cardsDto,
+		BatchId: batchId,
+		Error:   nil,
 	}, err
 }
 
-func (g *giftCardService) CreateSameMany(cards *dto.BulkCreateSameGiftCardsDTO) (*dto.GiftCardsListDTO, error) {
+func (g *giftCardService) CreateSameMany(ctx context.Context,
+	cards *dto.BulkCreateSameGiftCardsDTO) (*dto.GiftCardsListDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.CreateSameMany")
+	defer span.End()
+	batch, err := g.openBatch(ctx, cards.CreatedBy, cards)
+	if err != nil {
+		return &dto.GiftCardsListDTO{Cards: []dto.GiftCardDTO{}}, err
+	}
 	c := make(chan dto.GiftCardDTO, cards.Count)
 	errorChannel := make(chan error, cards.Count)
 	defer close(c)
 	defer close(errorChannel)
 
 	for i := 0; i < cards.Count; i++ {
-		go g.createGiftCard(cards.ExpireDate, cards.Amount, cards.CampaignId, c, errorChannel)
+		go g.createGiftCard(ctx, cards.ExpireDate, cards.Amount, cards.CampaignId, uint(batch.ID), c, errorChannel)
 	}
 
 	cardsDto := make([]dto.GiftCardDTO, 0, cards.Count)
 
-	var err error
 	for i := 0; i < cards.Count; i++ {
 		select {
 		case item := <-c:
@@ -131,26 +177,111 @@ func (g *giftCardService) CreateSameMany(cards *dto.BulkCreateSameGiftCardsDTO)
 		case err = <-errorChannel:
 		}
 	}
+	batchId := g.closeBatch(detach(ctx), batch, cardsDto)
+	g.syncCreatedCards(detach(ctx), cardsDto)
 
 	return &dto.GiftCardsListDTO{
-		Cards: cardsDto,
-		Error: nil,
+		Cards:   cardsDto,
+		BatchId: batchId,
+		Error:   nil,
 	}, err
 }
 
-func (g *giftCardService) FindPage(size, page uint, search string, campaignId *int, isValid *bool,
-	expireDateFrom *time.Time, expireDateTo *time.Time) dto.GiftCardsPageDTO {
-	cards, total := g.giftCardRepo.FindPage(size, page, search, campaignId, isValid, expireDateFrom, expireDateTo)
+// openBatch stores a new batch for a bulk request so the created cards can be grouped by it
+func (g *giftCardService) openBatch(ctx context.Context, createdBy string,
+	parameters interface{}) (*dbmodel.Batch, error) {
+	params, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, err
+	}
+	batch := dbmodel.NewBatch(createdBy, string(params))
+	if err = g.batchRepo.Store(ctx, batch); err != nil {
+		logger.WithContext(ctx).WithData(parameters).ErrorException(err, "error while creating a gift card batch")
+		return nil, err
+	}
+	return batch, nil
+}
+
+// syncCreatedCards writes the cards of a bulk request into the search index
+func (g *giftCardService) syncCreatedCards(ctx context.Context, cards []dto.GiftCardDTO) {
+	ids := make([]int, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	syncGiftCards(ctx, g.giftCardRepo, g.index, ids)
+}
+
+// syncStatusCards writes the cards of an approval into the search index
+func (g *giftCardService) syncStatusCards(ctx context.Context, cards []dto.GiftCardStatusDTO) {
+	ids := make([]int, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.Id)
+	}
+	syncGiftCards(ctx, g.giftCardRepo, g.index, ids)
+}
+
+// closeBatch updates the batch totals with the cards which are actually created and returns the batch id. the
+// batch is deleted when no card is created, so an empty batch is not left behind, and the id is zero then
+func (g *giftCardService) closeBatch(ctx context.Context, batch *dbmodel.Batch, cards []dto.GiftCardDTO) uint {
+	if len(cards) == 0 {
+		if err := g.batchRepo.Delete(ctx, *batch); err != nil {
+			logger.WithContext(ctx).WithData(map[string]interface{}{
+				"batchId": batch.ID,
+			}).ErrorException(err, "error while deleting an empty gift card batch")
+		}
+		return 0
+	}
+	for _, card := range cards {
+		batch.AddCard(card.Amount)
+	}
+	if err := g.batchRepo.Store(ctx, batch); err != nil {
+		logger.WithContext(ctx).WithData(map[string]interface{}{
+			"batchId": batch.ID,
+		}).ErrorException(err, "error while updating a gift card batch")
+	}
+	return uint(batch.ID)
+}
+
+func (g *giftCardService) FindPage(ctx context.Context, size, page uint,
+	filter core.GiftCardFilter) dto.GiftCardsPageDTO {
+	ctx, span := tracer.Start(ctx, "giftCardService.FindPage")
+	defer span.End()
+	cards, total := g.giftCardRepo.FindPage(ctx, size, page, filter)
 	return *dto.NewGiftCardsPageDTO(g.mapper.ToListOfGiftCardDTO(cards).Cards, int(size), int(page), total)
 }
 
-func (g *giftCardService) ValidateGiftCards(validateDto *dto.ValidateGiftCardsDto) *dto.GiftCardStatusListDTO {
+// FindCursorPage returns a keyset page of gift cards starting after the given cursor
+// the cursor only walks by id so a custom sort is rejected
+func (g *giftCardService) FindCursorPage(ctx context.Context, size uint, token string, filter core.GiftCardFilter,
+	withCount bool) (*dto.GiftCardsCursorPageDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.FindCursorPage")
+	defer span.End()
+	if len(filter.Sort) > 0 {
+		return nil, common.SortIsNotSupportedByCursor
+	}
+	after, err := cursor.Decode(token)
+	if err != nil {
+		return nil, common.InvalidCursor
+	}
+	cards, total := g.giftCardRepo.FindCursorPage(ctx, size+1, after, filter, withCount)
+	nextCursor := ""
+	if size > 0 && len(cards) > int(size) {
+		cards = cards[:size]
+		nextCursor = cursor.Encode(cards[size-1].ID)
+	}
+	return dto.NewGiftCardsCursorPageDTO(g.mapper.ToListOfGiftCardDTO(cards).Cards, int(size), nextCursor, total), nil
+}
+
+func (g *giftCardService) ValidateGiftCards(ctx context.Context,
+	validateDto *dto.ValidateGiftCardsDto) (*dto.GiftCardStatusListDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.ValidateGiftCards")
+	defer span.End()
 	secretsCount := len(validateDto.GiftCardsSecret)
 	c := make(chan dto.GiftCardStatusDTO, secretsCount)
 	defer close(c)
 
 	for i := 0; i < secretsCount; i++ {
-		go g.validateSecretKey(validateDto.GiftCardsSecret[i], c)
+		go g.validateSecretKey(ctx, validateDto.GiftCardsSecret[i], c)
 	}
 
 	cardsDto := make([]dto.GiftCardStatusDTO, 0, secretsCount)
@@ -158,14 +289,21 @@ func (g *giftCardService) ValidateGiftCards(validateDto *dto.ValidateGiftCardsDt
 	for i := 0; i < secretsCount; i++ {
 		cardsDto = append(cardsDto, <-c)
 	}
+	// the cards which are not looked up before the cancellation are reported as invalid
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	return &dto.GiftCardStatusListDTO{
 		Cards: cardsDto,
 		Error: nil,
-	}
+	}, nil
 }
 
-func (g *giftCardService) ApproveGiftCards(approveDto *dto.ApproveGiftCardsDTO) (*dto.GiftCardStatusListDTO, error) {
+func (g *giftCardService) ApproveGiftCards(ctx context.Context,
+	approveDto *dto.ApproveGiftCardsDTO) (*dto.GiftCardStatusListDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.ApproveGiftCards")
+	defer span.End()
 	secretsCount := len(approveDto.GiftCardsSecret)
 	c := make(chan dto.GiftCardStatusDTO, secretsCount)
 	errorChannel := make(chan error)
@@ -173,7 +311,7 @@ func (g *giftCardService) ApproveGiftCards(approveDto *dto.ApproveGiftCardsDTO)
 	defer close(errorChannel)
 
 	for i := 0; i < secretsCount; i++ {
-		go g.approveUser(approveDto.UUN, approveDto.GiftCardsSecret[i], c, errorChannel)
+		go g.approveUser(ctx, approveDto.UUN, approveDto.GiftCardsSecret[i], c, errorChannel)
 	}
 
 	doneSecrets := make([]dto.GiftCardStatusDTO, 0, secretsCount)
@@ -183,12 +321,16 @@ func (g *giftCardService) ApproveGiftCards(approveDto *dto.ApproveGiftCardsDTO)
 		case secret := <-c:
 			doneSecrets = append(doneSecrets, secret)
 		case err = <-errorChannel:
+			metrics.ApproveFailed(err)
 		}
 	}
 	if err != nil {
-		g.rollBackApprovedCards(doneSecrets)
+		g.rollBackApprovedCards(detach(ctx), doneSecrets)
+		g.syncStatusCards(detach(ctx), doneSecrets)
 		return nil, err
 	}
+	g.syncStatusCards(detach(ctx), doneSecrets)
+	metrics.CardsApproved(len(doneSecrets))
 
 	return &dto.GiftCardStatusListDTO{
 		Cards: doneSecrets,
@@ -196,99 +338,125 @@ func (g *giftCardService) ApproveGiftCards(approveDto *dto.ApproveGiftCardsDTO)
 	}, nil
 }
 
-func (g *giftCardService) ValidateGiftCard(giftCardSecret string) dto.GiftCardStatusDTO {
+func (g *giftCardService) ValidateGiftCard(ctx context.Context,
+	giftCardSecret string) (dto.GiftCardStatusDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.ValidateGiftCard")
+	defer span.End()
 	c := make(chan dto.GiftCardStatusDTO)
 	defer close(c)
-	go g.validateSecretKey(giftCardSecret, c)
-	return <-c
+	go g.validateSecretKey(ctx, giftCardSecret, c)
+	status := <-c
+	if err := ctx.Err(); err != nil {
+		return dto.GiftCardStatusDTO{}, err
+	}
+	return status, nil
 }
 
-func (g *giftCardService) ApproveGiftCard(uun, giftCardSecret string) (dto.GiftCardStatusDTO, error) {
+func (g *giftCardService) ApproveGiftCard(ctx context.Context,
+	uun, giftCardSecret string) (dto.GiftCardStatusDTO, error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.ApproveGiftCard")
+	defer span.End()
 	c := make(chan dto.GiftCardStatusDTO)
 	errorChannel := make(chan error)
 	defer close(c)
 	defer close(errorChannel)
 
-	go g.approveUser(uun, giftCardSecret, c, errorChannel)
+	go g.approveUser(ctx, uun, giftCardSecret, c, errorChannel)
 
 	select {
 	case secret := <-c:
+		g.syncStatusCards(detach(ctx), []dto.GiftCardStatusDTO{secret})
+		metrics.CardsApproved(1)
 		return secret, nil
 	case err := <-errorChannel:
+		metrics.ApproveFailed(err)
 		return dto.GiftCardStatusDTO{}, err
 	}
 }
 
-func (g *giftCardService) rollBackApprovedCards(doneSecrets []dto.GiftCardStatusDTO) {
+func (g *giftCardService) rollBackApprovedCards(ctx context.Context, doneSecrets []dto.GiftCardStatusDTO) {
 	wg := &sync.WaitGroup{}
 	wg.Add(len(doneSecrets))
 	for _, card := range doneSecrets {
-		go func() {
-			err := g.giftCardRepo.RollBackApprove(card.SecretKey)
+		go func(secret string) {
+			err := g.giftCardRepo.RollBackApprove(ctx, secret)
 			if err != nil {
-				logger.ErrorException(err,"error while rolling back a gift card")
+				logger.WithContext(ctx).ErrorException(err,"error while rolling back a gift card")
 			}
 			wg.Done()
-		}()
+		}(card.SecretKey)
 	}
 	wg.Wait()
 }
 
-func (g *giftCardService) createGiftCard(expireDate string, amount int32, campaignId uint, channel chan<- dto.GiftCardDTO,
-	errorChannel chan<- error) {
+func (g *giftCardService) createGiftCard(ctx context.Context, expireDate string, amount int32, campaignId, batchId uint,
+	channel chan<- dto.GiftCardDTO, errorChannel chan<- error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.createGiftCard")
+	defer span.End()
 	giftCard := dbmodel.NewGiftCard(amount, date.DefaultToTimeOrDefault(expireDate))
 	giftCard.SetCampaign(campaignId)
+	giftCard.SetBatch(batchId)
 	for {
-		err := g.giftCardRepo.Store(giftCard)
+		err := g.giftCardRepo.Store(ctx, giftCard)
 		if err == nil {
 			break
 		}
 		if !strings.Contains(err.Error(), "duplicate") {
-			logger.ErrorException(err,"error while creating a new gift card")
+			logger.WithContext(ctx).ErrorException(err,"error while creating a new gift card")
 			errorChannel <- err
 			return
 		}
 		giftCard.GenerateKey()
 	}
+	metrics.CardsIssued(1)
 	channel <- g.mapper.ToGiftCardDTO(giftCard)
 }
 
-func (g *giftCardService) validateSecretKey(secret string, c chan<- dto.GiftCardStatusDTO) {
+func (g *giftCardService) validateSecretKey(ctx context.Context, secret string, c chan<- dto.GiftCardStatusDTO) {
+	ctx, span := tracer.Start(ctx, "giftCardService.validateSecretKey")
+	defer span.End()
 	secret = strings.ToUpper(secret)
-	card, err := g.giftCardRepo.FindBySecretKey(secret)
+	card, err := g.giftCardRepo.FindBySecretKey(ctx, secret)
 	if err != nil {
+		metrics.CardValidated(false)
 		c <- dto.GiftCardStatusDTO{
 			IsValid:   false,
 			SecretKey: secret,
 		}
 		return
 	}
-	c <- g.mapper.ToGiftCardStatusDTO(*card)
+	status := g.mapper.ToGiftCardStatusDTO(*card)
+	metrics.CardValidated(status.IsValid)
+	c <- status
 }
 
-func (g *giftCardService) approveUser(uun, secret string, data chan<- dto.GiftCardStatusDTO, errorChannel chan<- error) {
+func (g *giftCardService) approveUser(ctx context.Context, uun, secret string, data chan<- dto.GiftCardStatusDTO,
+	errorChannel chan<- error) {
+	ctx, span := tracer.Start(ctx, "giftCardService.approveUser")
+	defer span.End()
 	secret = strings.ToUpper(secret)
-	card, err := g.giftCardRepo.FindBySecretKey(secret)
+	card, err := g.giftCardRepo.FindBySecretKey(ctx, secret)
 	if err != nil {
 		errorChannel <- err
 		return
 	}
 	err = card.SetUUN(uun)
 	if err != nil {
-		logger.Error(err.Error())
+		logger.WithContext(ctx).Error(err.Error())
 		errorChannel <- err
 		return
 	}
-	err = g.giftCardRepo.Store(card)
+	err = g.giftCardRepo.Store(ctx, card)
 	if err != nil {
 		errorChannel <- err
-		logger.ErrorException(err,"error while approving a gift card")
+		logger.WithContext(ctx).ErrorException(err,"error while approving a gift card")
 		return
 	}
 
 	data <- g.mapper.ApprovedToGiftCardStatusDTO(*card)
 }
 
-func NewGiftCardService(repository core.GiftCardRepository, mapper core.Mapper) core.GiftCardService {
-	return &giftCardService{giftCardRepo: repository, mapper: mapper}
+func NewGiftCardService(repository core.GiftCardRepository, batchRepository core.BatchRepository,
+	index core.GiftCardIndex, mapper core.Mapper) core.GiftCardService {
+	return &giftCardService{giftCardRepo: repository, batchRepo: batchRepository, index: index, mapper: mapper}
 }
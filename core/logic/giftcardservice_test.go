@@ -1,7 +1,9 @@
 package logic_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"giftcard-engine/core"
 	"giftcard-engine/core/common"
 	"giftcard-engine/core/dbmodel"
@@ -24,20 +26,34 @@ const (
 )
 
 type fakeGiftCardRepo struct {
-	findByUUNCall       int32
-	findByIDCall        int32
-	storeCall           int32
-	deleteCall          int32
-	findByPublicKeyCall int32
-	findPageCall        int32
-	findBySecretKeyCall int32
-	rollBackApproveCall int32
-	strategy            int
+	findByUUNCall         int32
+	findByIDCall          int32
+	findByIDsCall         int32
+	storeCall             int32
+	deleteCall            int32
+	findByPublicKeyCall   int32
+	findPageCall          int32
+	findCursorPageCall    int32
+	findBySecretKeyCall   int32
+	rollBackApproveCall   int32
+	findByBatchCall       int32
+	extendBatchExpiryCall int32
+	voidBatchCall         int32
+	statsCall             int32
+	statsCampaignId       *uint
+	hourlyTotalsCall      int32
+	hourlyTotalsFrom      time.Time
+	hourlyTotalsTo        time.Time
+	hourlyTotals          map[core.ReportEvent][]core.HourlyTotal
+	outstandingAtCall     int32
+	outstandingAt         time.Time
+	findExpiredCall       int32
+	strategy              int
 }
 
 var fakeInternalError = errors.New("repository internal error")
 
-func (f *fakeGiftCardRepo) FindByUUN(uun string) []dbmodel.GiftCard {
+func (f *fakeGiftCardRepo) FindByUUN(ctx context.Context, uun string) []dbmodel.GiftCard {
 	atomic.AddInt32(&f.findByUUNCall, 1)
 	if f.strategy == emptyData {
 		return []dbmodel.GiftCard{}
@@ -48,7 +64,7 @@ func (f *fakeGiftCardRepo) FindByUUN(uun string) []dbmodel.GiftCard {
 	}
 }
 
-func (f *fakeGiftCardRepo) FindByID(id uint) (*dbmodel.GiftCard, error) {
+func (f *fakeGiftCardRepo) FindByID(ctx context.Context, id uint) (*dbmodel.GiftCard, error) {
 	atomic.AddInt32(&f.findByIDCall, 1)
 	if f.strategy == notFound {
 		return nil, common.GiftCardNotFound
@@ -63,7 +79,23 @@ func (f *fakeGiftCardRepo) FindByID(id uint) (*dbmodel.GiftCard, error) {
 	}, nil
 }
 
-func (f *fakeGiftCardRepo) Store(card *dbmodel.GiftCard) error {
+// FindByIDs returns the cards in reverse order and misses the id 404 like a card deleted after indexing
+func (f *fakeGiftCardRepo) FindByIDs(ctx context.Context, ids []int) []dbmodel.GiftCard {
+	atomic.AddInt32(&f.findByIDsCall, 1)
+	cards := []dbmodel.GiftCard{}
+	for i := len(ids) - 1; i >= 0; i-- {
+		if ids[i] == 404 {
+			continue
+		}
+		card := dbmodel.GiftCard{Amount: int32(2000), PublicCode: fmt.Sprintf("public%d", ids[i]),
+			SecretCode: "secret", ExpireDate: time.Now().Add(25 * time.Hour).UTC(), Status: dbmodel.Empty}
+		card.ID = ids[i]
+		cards = append(cards, card)
+	}
+	return cards
+}
+
+func (f *fakeGiftCardRepo) Store(ctx context.Context, card *dbmodel.GiftCard) error {
 	atomic.AddInt32(&f.storeCall, 1)
 	if f.strategy == internalError {
 		return fakeInternalError
@@ -75,7 +107,7 @@ func (f *fakeGiftCardRepo) Store(card *dbmodel.GiftCard) error {
 	return nil
 }
 
-func (f *fakeGiftCardRepo) Delete(card dbmodel.GiftCard) error {
+func (f *fakeGiftCardRepo) Delete(ctx context.Context, card dbmodel.GiftCard) error {
 	atomic.AddInt32(&f.deleteCall, 1)
 	if f.strategy == notFound {
 		return common.GiftCardNotFound
@@ -83,7 +115,7 @@ func (f *fakeGiftCardRepo) Delete(card dbmodel.GiftCard) error {
 	return nil
 }
 
-func (f *fakeGiftCardRepo) FindByPublicKey(key string) (*dbmodel.GiftCard, error) {
+func (f *fakeGiftCardRepo) FindByPublicKey(ctx context.Context, key string) (*dbmodel.GiftCard, error) {
 	atomic.AddInt32(&f.findByPublicKeyCall, 1)
 	if f.strategy == notFound {
 		return nil, common.GiftCardNotFound
@@ -98,13 +130,34 @@ func (f *fakeGiftCardRepo) FindByPublicKey(key string) (*dbmodel.GiftCard, error
 	}, nil
 }
 
-func (f *fakeGiftCardRepo) FindPage(size, number uint, search string, campaignId *int,
-	isValid *bool, expireDateFrom *time.Time, expireDateTo *time.Time) ([]dbmodel.GiftCard, int) {
+func (f *fakeGiftCardRepo) FindPage(ctx context.Context, size, number uint,
+	filter core.GiftCardFilter) ([]dbmodel.GiftCard, int) {
 	atomic.AddInt32(&f.findPageCall, 1)
 	return []dbmodel.GiftCard{}, 0
 }
 
-func (f *fakeGiftCardRepo) FindBySecretKey(secret string) (*dbmodel.GiftCard, error) {
+// FindCursorPage pages through five gift cards with ids 5 to 1
+func (f *fakeGiftCardRepo) FindCursorPage(ctx context.Context, size uint, after *int, filter core.GiftCardFilter,
+	withCount bool) ([]dbmodel.GiftCard, int) {
+	atomic.AddInt32(&f.findCursorPageCall, 1)
+	id := 5
+	if after != nil {
+		id = *after - 1
+	}
+	cards := []dbmodel.GiftCard{}
+	for ; id > 0 && len(cards) < int(size); id-- {
+		card := dbmodel.GiftCard{Amount: int32(2000), PublicCode: "public", SecretCode: "secret",
+			ExpireDate: time.Now().Add(25 * time.Hour).UTC(), Status: dbmodel.Empty}
+		card.ID = id
+		cards = append(cards, card)
+	}
+	if !withCount {
+		return cards, -1
+	}
+	return cards, 5
+}
+
+func (f *fakeGiftCardRepo) FindBySecretKey(ctx context.Context, secret string) (*dbmodel.GiftCard, error) {
 	atomic.AddInt32(&f.findBySecretKeyCall, 1)
 	if f.strategy == notFound {
 		return nil, common.GiftCardNotFound
@@ -119,7 +172,7 @@ func (f *fakeGiftCardRepo) FindBySecretKey(secret string) (*dbmodel.GiftCard, er
 	}, nil
 }
 
-func (f *fakeGiftCardRepo) RollBackApprove(secret string) error {
+func (f *fakeGiftCardRepo) RollBackApprove(ctx context.Context, secret string) error {
 	atomic.AddInt32(&f.rollBackApproveCall, 1)
 
 	if f.strategy == notFound {
@@ -132,6 +185,88 @@ func (f *fakeGiftCardRepo) RollBackApprove(secret string) error {
 	return nil
 }
 
+func (f *fakeGiftCardRepo) FindByBatch(ctx context.Context, batchId uint) []dbmodel.GiftCard {
+	atomic.AddInt32(&f.findByBatchCall, 1)
+	if f.strategy == emptyData {
+		return []dbmodel.GiftCard{}
+	}
+	return []dbmodel.GiftCard{
+		{Amount: int32(2000), PublicCode: "public", SecretCode: "secret", BatchId: &batchId,
+			ExpireDate: time.Now().Add(25 * time.Hour).UTC(), Status: dbmodel.Empty},
+	}
+}
+
+func (f *fakeGiftCardRepo) ExtendBatchExpiry(ctx context.Context, batchId uint, expireDate time.Time) (int, error) {
+	atomic.AddInt32(&f.extendBatchExpiryCall, 1)
+	if f.strategy == internalError {
+		return 0, fakeInternalError
+	}
+	return 2, nil
+}
+
+func (f *fakeGiftCardRepo) VoidBatch(ctx context.Context, batchId uint) (int, error) {
+	atomic.AddInt32(&f.voidBatchCall, 1)
+	if f.strategy == internalError {
+		return 0, fakeInternalError
+	}
+	return 2, nil
+}
+
+func (f *fakeGiftCardRepo) Stats(ctx context.Context, campaignId *uint) (core.GiftCardStats, error) {
+	atomic.AddInt32(&f.statsCall, 1)
+	f.statsCampaignId = campaignId
+	if f.strategy == internalError {
+		return core.GiftCardStats{}, fakeInternalError
+	}
+	return core.GiftCardStats{
+		Issued:        core.CardsAggregate{Count: 10, Amount: 10000},
+		Redeemed:      core.CardsAggregate{Count: 3, Amount: 3000},
+		Deleted:       core.CardsAggregate{Count: 4, Amount: 4000},
+		Outstanding:   core.CardsAggregate{Count: 3, Amount: 3000},
+		TimedRedeems:  2,
+		RedeemSeconds: 300,
+	}, nil
+}
+
+func (f *fakeGiftCardRepo) HourlyTotals(ctx context.Context, event core.ReportEvent, campaignId *uint,
+	from, to time.Time,
+	location *time.Location) ([]core.HourlyTotal, error) {
+	atomic.AddInt32(&f.hourlyTotalsCall, 1)
+	f.hourlyTotalsFrom = from
+	f.hourlyTotalsTo = to
+	if f.strategy == internalError {
+		return nil, fakeInternalError
+	}
+	return f.hourlyTotals[event], nil
+}
+
+func (f *fakeGiftCardRepo) OutstandingAt(ctx context.Context, at time.Time,
+	campaignId *uint) ([]core.CampaignLiability, error) {
+	atomic.AddInt32(&f.outstandingAtCall, 1)
+	f.outstandingAt = at
+	if f.strategy == internalError {
+		return nil, fakeInternalError
+	}
+	return []core.CampaignLiability{
+		{CampaignId: 1, CampaignTitle: "yalda", Count: 2, Amount: 2000},
+		{CampaignId: 2, CampaignTitle: "nowruz", Count: 3, Amount: 1500},
+	}, nil
+}
+
+func (f *fakeGiftCardRepo) FindExpired(ctx context.Context, from, to time.Time) ([]dbmodel.GiftCard, error) {
+	atomic.AddInt32(&f.findExpiredCall, 1)
+	if f.strategy == internalError {
+		return nil, fakeInternalError
+	}
+	if f.strategy == emptyData {
+		return []dbmodel.GiftCard{}, nil
+	}
+	return []dbmodel.GiftCard{
+		{AbstractModel: dbmodel.AbstractModel{ID: 4}, PublicCode: "expired", SecretCode: "secret", Amount: 2000,
+			ExpireDate: from},
+	}, nil
+}
+
 func newFakeGiftCardRepo(strategy int) *fakeGiftCardRepo {
 	return &fakeGiftCardRepo{
 		strategy: strategy,
@@ -148,6 +283,8 @@ type fakeGiftCardMapper struct {
 	ToCampaignCall                  int32
 	ToCampaignDTOCall               int32
 	ToListOfCampaignsCall           int32
+	ToBatchDTOCall                  int32
+	ToListOfBatchesCall             int32
 	actualMapper                    core.Mapper
 }
 
@@ -187,6 +324,16 @@ func (f *fakeGiftCardMapper) ToListOfCampaigns(campaigns []dbmodel.Campaign) []d
 	return f.actualMapper.ToListOfCampaigns(campaigns)
 }
 
+func (f *fakeGiftCardMapper) ToBatchDTO(batch dbmodel.Batch) dto.BatchDTO {
+	atomic.AddInt32(&f.ToBatchDTOCall, 1)
+	return f.actualMapper.ToBatchDTO(batch)
+}
+
+func (f *fakeGiftCardMapper) ToListOfBatches(batches []dbmodel.Batch) []dto.BatchDTO {
+	atomic.AddInt32(&f.ToListOfBatchesCall, 1)
+	return f.actualMapper.ToListOfBatches(batches)
+}
+
 func newFakeGiftCardMapper() *fakeGiftCardMapper {
 	return &fakeGiftCardMapper{
 		actualMapper: sql.NewMapper(),
@@ -196,9 +343,16 @@ func newFakeGiftCardMapper() *fakeGiftCardMapper {
 //////end of fake dependencies
 
 func createServiceForTest(strategy int) (core.GiftCardService, *fakeGiftCardRepo, *fakeGiftCardMapper) {
+	service, repo, mapper, _ := createServiceWithIndexForTest(strategy)
+	return service, repo, mapper
+}
+
+func createServiceWithIndexForTest(strategy int) (core.GiftCardService, *fakeGiftCardRepo, *fakeGiftCardMapper,
+	*fakeGiftCardIndex) {
 	mapper := newFakeGiftCardMapper()
 	repo := newFakeGiftCardRepo(strategy)
-	return logic.NewGiftCardService(repo, mapper), repo, mapper
+	index := newFakeGiftCardIndex()
+	return logic.NewGiftCardService(repo, newFakeBatchRepo(defaultBehavior), index, mapper), repo, mapper, index
 }
 
 func TestFindByUUN(te *testing.T) {
@@ -208,7 +362,7 @@ func TestFindByUUN(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(defaultBehavior)
 
-		cards, err := service.FindByUUN("someone")
+		cards, err := service.FindByUUN(context.Background(), "someone")
 
 		assert.Empty(t, err)
 		assert.Equal(t, int32(1), repo.findByUUNCall)
@@ -221,7 +375,7 @@ func TestFindByUUN(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(emptyData)
 
-		cards, err := service.FindByUUN("someone")
+		cards, err := service.FindByUUN(context.Background(), "someone")
 
 		assert.NotEmpty(t, err)
 		assert.Equal(t, common.NoGiftCardFoundForUser, err)
@@ -237,7 +391,7 @@ func TestFindByID(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(defaultBehavior)
 
-		card, err := service.FindByID(123)
+		card, err := service.FindByID(context.Background(), 123)
 
 		assert.Empty(t, err)
 		assert.NotEmpty(t, card)
@@ -249,7 +403,7 @@ func TestFindByID(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(notFound)
 
-		card, err := service.FindByID(123)
+		card, err := service.FindByID(context.Background(), 123)
 
 		assert.NotEmpty(t, err)
 		assert.Empty(t, card)
@@ -265,7 +419,7 @@ func TestFindByPublicKey(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(defaultBehavior)
 
-		card, err := service.FindByPublicKey("123456123456")
+		card, err := service.FindByPublicKey(context.Background(), "123456123456")
 
 		assert.Empty(t, err)
 		assert.NotEmpty(t, card)
@@ -277,7 +431,7 @@ func TestFindByPublicKey(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(notFound)
 
-		card, err := service.FindByPublicKey("123456123456")
+		card, err := service.FindByPublicKey(context.Background(), "123456123456")
 
 		assert.NotEmpty(t, err)
 		assert.Empty(t, card)
@@ -293,7 +447,7 @@ func TestStore(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(defaultBehavior)
 
-		card, err := service.Store(&dto.CreateGiftCardDTO{
+		card, err := service.Store(context.Background(), &dto.CreateGiftCardDTO{
 			ExpireDate: "2300-02-02",
 			Amount:     2000,
 		})
@@ -309,7 +463,7 @@ func TestStore(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(internalError)
 
-		card, err := service.Store(&dto.CreateGiftCardDTO{
+		card, err := service.Store(context.Background(), &dto.CreateGiftCardDTO{
 			ExpireDate: "2300-02-02",
 			Amount:     2000,
 		})
@@ -328,7 +482,7 @@ func TestUpdate(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(defaultBehavior)
 
-		card, err := service.Update(&dto.UpdateGiftCardDto{
+		card, err := service.Update(context.Background(), &dto.UpdateGiftCardDto{
 			ExpireDate: "2300-02-02",
 			Amount:     2000,
 			ID:         10,
@@ -345,7 +499,7 @@ func TestUpdate(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(defaultBehavior)
 
-		card, err := service.Update(&dto.UpdateGiftCardDto{
+		card, err := service.Update(context.Background(), &dto.UpdateGiftCardDto{
 			ExpireDate: "invalid date",
 			Amount:     2000,
 			ID:         10,
@@ -362,7 +516,7 @@ func TestUpdate(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(notFound)
 
-		card, err := service.Update(&dto.UpdateGiftCardDto{
+		card, err := service.Update(context.Background(), &dto.UpdateGiftCardDto{
 			ExpireDate: "2400-02-02",
 			Amount:     2000,
 			ID:         10,
@@ -379,7 +533,7 @@ func TestUpdate(te *testing.T) {
 	te.Run("with internal server error strategy", func(t *testing.T) {
 		service, repo, mapper := createServiceForTest(internalError)
 
-		card, err := service.Update(&dto.UpdateGiftCardDto{
+		card, err := service.Update(context.Background(), &dto.UpdateGiftCardDto{
 			ExpireDate: "2400-02-02",
 			Amount:     2000,
 			ID:         10,
@@ -399,7 +553,7 @@ func TestDelete(te *testing.T) {
 		t.Parallel()
 		service, repo, _ := createServiceForTest(defaultBehavior)
 
-		err := service.Delete(123)
+		err := service.Delete(context.Background(), 123)
 
 		assert.Empty(t, err)
 		assert.Equal(t, int32(1), repo.deleteCall)
@@ -410,7 +564,7 @@ func TestDelete(te *testing.T) {
 		t.Parallel()
 		service, repo, _ := createServiceForTest(notFound)
 
-		err := service.Delete(123)
+		err := service.Delete(context.Background(), 123)
 
 		assert.NotEmpty(t, err)
 		assert.Equal(t, common.GiftCardNotFound, err)
@@ -429,7 +583,7 @@ func TestCreateMany(te *testing.T) {
 			{ExpireDate: "2400-02-10", Amount: 4000},
 		}}
 
-		cards, err := service.CreateMany(&bulkDto)
+		cards, err := service.CreateMany(context.Background(), &bulkDto)
 
 		assert.Empty(t, err)
 		assert.NotEmpty(t, cards)
@@ -446,7 +600,7 @@ func TestCreateMany(te *testing.T) {
 			{ExpireDate: "2400-02-10", Amount: 4000},
 		}}
 
-		cards, err := service.CreateMany(&bulkDto)
+		cards, err := service.CreateMany(context.Background(), &bulkDto)
 
 		assert.NotEmpty(t, err)
 		assert.Empty(t, cards.Cards)
@@ -463,7 +617,7 @@ func TestCreateMany(te *testing.T) {
 			{ExpireDate: "2400-02-10", Amount: 4000},
 		}}
 
-		cards, err := service.CreateMany(&bulkDto)
+		cards, err := service.CreateMany(context.Background(), &bulkDto)
 
 		assert.NotEmpty(t, err)
 		assert.NotEmpty(t, cards)
@@ -479,7 +633,7 @@ func TestCreateSameMany(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(defaultBehavior)
 
-		cards, err := service.CreateSameMany(&dto.BulkCreateSameGiftCardsDTO{
+		cards, err := service.CreateSameMany(context.Background(), &dto.BulkCreateSameGiftCardsDTO{
 			ExpireDate: "2400-02-02",
 			Amount:     2000,
 			Count:      20000,
@@ -496,7 +650,7 @@ func TestCreateSameMany(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(internalError)
 
-		cards, err := service.CreateSameMany(&dto.BulkCreateSameGiftCardsDTO{
+		cards, err := service.CreateSameMany(context.Background(), &dto.BulkCreateSameGiftCardsDTO{
 			ExpireDate: "2400-02-02",
 			Amount:     2000,
 			Count:      200,
@@ -508,6 +662,24 @@ func TestCreateSameMany(te *testing.T) {
 		assert.Equal(t, int32(200), repo.storeCall)
 		assert.Equal(t, int32(0), mapper.ToGiftCardDTOCall)
 	})
+
+	te.Run("deletes the batch when no card is created", func(t *testing.T) {
+		t.Parallel()
+		batchRepo := newFakeBatchRepo(defaultBehavior)
+		service := logic.NewGiftCardService(newFakeGiftCardRepo(internalError), batchRepo, newFakeGiftCardIndex(),
+			newFakeGiftCardMapper())
+
+		cards, err := service.CreateSameMany(context.Background(), &dto.BulkCreateSameGiftCardsDTO{
+			ExpireDate: "2400-02-02",
+			Amount:     2000,
+			Count:      3,
+		})
+
+		assert.NotEmpty(t, err)
+		assert.Zero(t, cards.BatchId)
+		assert.Equal(t, int32(1), batchRepo.storeCall)
+		assert.Equal(t, int32(1), batchRepo.deleteCall)
+	})
 }
 
 func TestFindPage(te *testing.T) {
@@ -515,8 +687,8 @@ func TestFindPage(te *testing.T) {
 	service, repo, mapper := createServiceForTest(defaultBehavior)
 	startDate := date.DefaultToTimeOrDefault("2050-01-01")
 	endDate := date.DefaultToTimeOrDefault("2050-01-02")
-	pageRes := service.FindPage(10, 10, "", nil, nil,
-		&startDate, &endDate)
+	pageRes := service.FindPage(context.Background(), 10, 10, core.GiftCardFilter{ExpireDateFrom: &startDate,
+		ExpireDateTo: &endDate})
 
 	assert.NotEmpty(te, pageRes)
 	assert.Equal(te, 11, pageRes.Page)
@@ -527,19 +699,81 @@ func TestFindPage(te *testing.T) {
 	assert.Equal(te, int32(1), repo.findPageCall)
 }
 
+func TestFindCursorPage(te *testing.T) {
+	te.Parallel()
+	te.Run("walk through all pages", func(t *testing.T) {
+		t.Parallel()
+		service, repo, _ := createServiceForTest(defaultBehavior)
+
+		ids := []int{}
+		token := ""
+		for {
+			page, err := service.FindCursorPage(context.Background(), 2, token, core.GiftCardFilter{}, true)
+			assert.Empty(t, err)
+			assert.Equal(t, 5, *page.TotalItems)
+			for _, card := range page.GiftCards {
+				ids = append(ids, card.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			token = page.NextCursor
+		}
+
+		assert.Equal(t, []int{5, 4, 3, 2, 1}, ids)
+		assert.Equal(t, int32(3), repo.findCursorPageCall)
+	})
+
+	te.Run("skip the total count", func(t *testing.T) {
+		t.Parallel()
+		service, _, _ := createServiceForTest(defaultBehavior)
+
+		page, err := service.FindCursorPage(context.Background(), 5, "", core.GiftCardFilter{}, false)
+
+		assert.Empty(t, err)
+		assert.Nil(t, page.TotalItems)
+		assert.Equal(t, 5, len(page.GiftCards))
+		assert.Empty(t, page.NextCursor)
+	})
+
+	te.Run("with invalid cursor", func(t *testing.T) {
+		t.Parallel()
+		service, repo, _ := createServiceForTest(defaultBehavior)
+
+		page, err := service.FindCursorPage(context.Background(), 2, "invalid", core.GiftCardFilter{}, true)
+
+		assert.Equal(t, common.InvalidCursor, err)
+		assert.Nil(t, page)
+		assert.Equal(t, int32(0), repo.findCursorPageCall)
+	})
+
+	te.Run("with sort", func(t *testing.T) {
+		t.Parallel()
+		service, repo, _ := createServiceForTest(defaultBehavior)
+
+		page, err := service.FindCursorPage(context.Background(), 2, "",
+			core.GiftCardFilter{Sort: []core.SortField{{Column: "amount"}}}, true)
+
+		assert.Equal(t, common.SortIsNotSupportedByCursor, err)
+		assert.Nil(t, page)
+		assert.Equal(t, int32(0), repo.findCursorPageCall)
+	})
+}
+
 func TestValidateGiftCards(te *testing.T) {
 	te.Parallel()
 	te.Run("default behavior", func(t *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(defaultBehavior)
 
-		cards := service.ValidateGiftCards(&dto.ValidateGiftCardsDto{
+		cards, err := service.ValidateGiftCards(context.Background(), &dto.ValidateGiftCardsDto{
 			GiftCardsSecret: []string{
 				"1234567890123456",
 				"2234567890123456",
 			},
 		})
 
+		assert.Empty(t, err)
 		assert.NotEmpty(t, cards)
 		assert.Equal(t, 2, len(cards.Cards))
 		assert.Equal(t, int32(2), repo.findBySecretKeyCall)
@@ -552,13 +786,14 @@ func TestValidateGiftCards(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(notFound)
 
-		cards := service.ValidateGiftCards(&dto.ValidateGiftCardsDto{
+		cards, err := service.ValidateGiftCards(context.Background(), &dto.ValidateGiftCardsDto{
 			GiftCardsSecret: []string{
 				"1234567890123456",
 				"2234567890123456",
 			},
 		})
 
+		assert.Empty(t, err)
 		assert.NotEmpty(t, cards)
 		assert.Equal(t, 2, len(cards.Cards))
 		assert.Equal(t, int32(2), repo.findBySecretKeyCall)
@@ -566,6 +801,20 @@ func TestValidateGiftCards(te *testing.T) {
 		assert.Equal(t, false, cards.Cards[0].IsValid)
 		assert.Equal(t, false, cards.Cards[1].IsValid)
 	})
+
+	te.Run("with canceled context", func(t *testing.T) {
+		t.Parallel()
+		service, _, _ := createServiceForTest(defaultBehavior)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		cards, err := service.ValidateGiftCards(ctx, &dto.ValidateGiftCardsDto{
+			GiftCardsSecret: []string{"1234567890123456"},
+		})
+
+		assert.Equal(t, context.Canceled, err)
+		assert.Nil(t, cards)
+	})
 }
 
 func TestApproveGiftCards(te *testing.T) {
@@ -574,7 +823,7 @@ func TestApproveGiftCards(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(defaultBehavior)
 
-		cards, err := service.ApproveGiftCards(&dto.ApproveGiftCardsDTO{
+		cards, err := service.ApproveGiftCards(context.Background(), &dto.ApproveGiftCardsDTO{
 			UUN: "milawd",
 			GiftCardsSecret: []string{
 				"1234567890123456",
@@ -595,7 +844,7 @@ func TestApproveGiftCards(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(notFound)
 
-		cards, err := service.ApproveGiftCards(&dto.ApproveGiftCardsDTO{
+		cards, err := service.ApproveGiftCards(context.Background(), &dto.ApproveGiftCardsDTO{
 			UUN: "milawd",
 			GiftCardsSecret: []string{
 				"1234567890123456",
@@ -614,7 +863,7 @@ func TestApproveGiftCards(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(internalError)
 
-		cards, err := service.ApproveGiftCards(&dto.ApproveGiftCardsDTO{
+		cards, err := service.ApproveGiftCards(context.Background(), &dto.ApproveGiftCardsDTO{
 			UUN: "milawd",
 			GiftCardsSecret: []string{
 				"1234567890123456",
@@ -635,8 +884,9 @@ func TestValidateGiftCard(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(defaultBehavior)
 
-		card := service.ValidateGiftCard("1234567890123456")
+		card, err := service.ValidateGiftCard(context.Background(), "1234567890123456")
 
+		assert.Empty(t, err)
 		assert.NotEmpty(t, card)
 		assert.Equal(t, int32(1), repo.findBySecretKeyCall)
 		assert.Equal(t, int32(1), mapper.ToGiftCardStatusDTOCall)
@@ -647,8 +897,9 @@ func TestValidateGiftCard(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(notFound)
 
-		card := service.ValidateGiftCard("1234567890123456")
+		card, err := service.ValidateGiftCard(context.Background(), "1234567890123456")
 
+		assert.Empty(t, err)
 		assert.NotEmpty(t, card)
 		assert.Equal(t, int32(1), repo.findBySecretKeyCall)
 		assert.Equal(t, int32(0), mapper.ToGiftCardStatusDTOCall)
@@ -663,7 +914,7 @@ func TestApproveGiftCard(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(defaultBehavior)
 
-		card, err := service.ApproveGiftCard("milawd", "1234567890123456")
+		card, err := service.ApproveGiftCard(context.Background(), "milawd", "1234567890123456")
 
 		assert.Empty(t, err)
 		assert.NotEmpty(t, card)
@@ -676,7 +927,7 @@ func TestApproveGiftCard(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(notFound)
 
-		card, err := service.ApproveGiftCard("milawd", "2234567890123456")
+		card, err := service.ApproveGiftCard(context.Background(), "milawd", "2234567890123456")
 
 		assert.NotEmpty(t, err)
 		assert.Empty(t, card)
@@ -690,7 +941,7 @@ func TestApproveGiftCard(te *testing.T) {
 		t.Parallel()
 		service, repo, mapper := createServiceForTest(internalError)
 
-		card, err := service.ApproveGiftCard("milawd", "2234567890123456")
+		card, err := service.ApproveGiftCard(context.Background(), "milawd", "2234567890123456")
 
 		assert.NotEmpty(t, err)
 		assert.Empty(t, card)
@@ -0,0 +1,155 @@
+package logic
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/logger"
+	"time"
+)
+
+// maxReportPeriods keeps a report small enough to be rendered and downloaded at once
+const maxReportPeriods = 1000
+
+const reportDateFormat = "2006-01-02"
+
+type reportService struct {
+	giftCardRepo core.GiftCardRepository
+}
+
+// GiftCardSeries rolls the hourly totals of the repository up into the periods of the requested timezone
+func (r *reportService) GiftCardSeries(ctx context.Context, request dto.ReportRequestDTO) (*dto.ReportDTO, error) {
+	ctx, span := tracer.Start(ctx, "reportService.GiftCardSeries")
+	defer span.End()
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(request.Timezone)
+	if err != nil {
+		return nil, common.InvalidTimezone
+	}
+	from, _ := time.ParseInLocation(reportDateFormat, request.From, location)
+	to, _ := time.ParseInLocation(reportDateFormat, request.To, location)
+	if to.Before(from) {
+		return nil, common.InvalidReportRange
+	}
+	end := to.AddDate(0, 0, 1)
+
+	var periods []time.Time
+	for period := periodStart(from, request.Interval); period.Before(end); period = nextPeriod(period,
+		request.Interval) {
+		if len(periods) == maxReportPeriods {
+			return nil, common.ReportRangeIsTooLong
+		}
+		periods = append(periods, period)
+	}
+	points := make(map[int64]*dto.ReportPointDTO, len(periods))
+	report := &dto.ReportDTO{
+		Interval: request.Interval,
+		Timezone: location.String(),
+		From:     request.From,
+		To:       request.To,
+		Points:   make([]dto.ReportPointDTO, len(periods)),
+	}
+	if request.CampaignId != nil {
+		report.CampaignId = int(*request.CampaignId)
+	}
+	for i, period := range periods {
+		report.Points[i].PeriodStart = period.Format(reportDateFormat)
+		points[period.Unix()] = &report.Points[i]
+	}
+
+	issued, err := r.giftCardRepo.HourlyTotals(ctx, core.IssuedEvent, request.CampaignId, from, end, location)
+	if err != nil {
+		logger.WithContext(ctx).WithData(request).ErrorException(err, "error while reporting the issued gift cards")
+		return nil, err
+	}
+	for _, total := range issued {
+		point, ok := points[periodStart(total.Hour.In(location), request.Interval).Unix()]
+		if !ok {
+			continue
+		}
+		point.IssuedCount += total.Count
+		point.IssuedAmount += total.Amount
+	}
+
+	redeemed, err := r.giftCardRepo.HourlyTotals(ctx, core.RedeemedEvent, request.CampaignId, from, end, location)
+	if err != nil {
+		logger.WithContext(ctx).WithData(request).ErrorException(err, "error while reporting the redeemed gift cards")
+		return nil, err
+	}
+	for _, total := range redeemed {
+		point, ok := points[periodStart(total.Hour.In(location), request.Interval).Unix()]
+		if !ok {
+			continue
+		}
+		point.RedeemedCount += total.Count
+		point.RedeemedAmount += total.Amount
+	}
+	return report, nil
+}
+
+// Liability finds the outstanding cards at the first moment after the requested date in its timezone
+func (r *reportService) Liability(ctx context.Context, request dto.LiabilityRequestDTO) (*dto.LiabilityDTO, error) {
+	ctx, span := tracer.Start(ctx, "reportService.Liability")
+	defer span.End()
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(request.Timezone)
+	if err != nil {
+		return nil, common.InvalidTimezone
+	}
+	asOf, _ := time.ParseInLocation(reportDateFormat, request.AsOf, location)
+
+	liabilities, err := r.giftCardRepo.OutstandingAt(ctx, asOf.AddDate(0, 0, 1), request.CampaignId)
+	if err != nil {
+		logger.WithContext(ctx).WithData(request).ErrorException(err, "error while reporting the outstanding gift cards")
+		return nil, err
+	}
+	report := &dto.LiabilityDTO{
+		AsOf:      request.AsOf,
+		Timezone:  location.String(),
+		Campaigns: make([]dto.CampaignLiabilityDTO, 0, len(liabilities)),
+	}
+	for _, liability := range liabilities {
+		report.Campaigns = append(report.Campaigns, dto.CampaignLiabilityDTO{
+			CampaignId:        int(liability.CampaignId),
+			CampaignTitle:     liability.CampaignTitle,
+			OutstandingCount:  liability.Count,
+			OutstandingAmount: liability.Amount,
+		})
+		report.OutstandingCount += liability.Count
+		report.OutstandingAmount += liability.Amount
+	}
+	return report, nil
+}
+
+// periodStart returns the midnight which starts the day, the week from monday or the month of t
+func periodStart(t time.Time, interval string) time.Time {
+	year, month, day := t.Date()
+	switch interval {
+	case dto.WeeklyReport:
+		return time.Date(year, month, day-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case dto.MonthlyReport:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+func nextPeriod(t time.Time, interval string) time.Time {
+	switch interval {
+	case dto.WeeklyReport:
+		return t.AddDate(0, 0, 7)
+	case dto.MonthlyReport:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+func NewReportService(giftCardRepository core.GiftCardRepository) core.ReportService {
+	return &reportService{giftCardRepo: giftCardRepository}
+}
@@ -0,0 +1,198 @@
+package logic_test
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/core/logic"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func createReportServiceForTest(strategy int) (core.ReportService, *fakeGiftCardRepo) {
+	repo := newFakeGiftCardRepo(strategy)
+	repo.hourlyTotals = map[core.ReportEvent][]core.HourlyTotal{
+		core.IssuedEvent: {
+			{Hour: time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), Count: 2, Amount: 2000},
+			{Hour: time.Date(2020, 1, 6, 22, 0, 0, 0, time.UTC), Count: 1, Amount: 500},
+			{Hour: time.Date(2020, 2, 3, 8, 0, 0, 0, time.UTC), Count: 3, Amount: 3000},
+		},
+		core.RedeemedEvent: {
+			{Hour: time.Date(2020, 1, 6, 21, 0, 0, 0, time.UTC), Count: 1, Amount: 1000},
+		},
+	}
+	return logic.NewReportService(repo), repo
+}
+
+func TestReportGiftCardSeries(te *testing.T) {
+	te.Parallel()
+	te.Run("daily", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createReportServiceForTest(defaultBehavior)
+		campaignId := uint(3)
+
+		report, err := service.GiftCardSeries(context.Background(), dto.ReportRequestDTO{CampaignId: &campaignId,
+			From: "2020-01-01", To: "2020-01-07", Interval: dto.DailyReport, Timezone: "UTC"})
+
+		assert.Empty(t, err)
+		assert.Equal(t, 3, report.CampaignId)
+		assert.Equal(t, 7, len(report.Points))
+		assert.Equal(t, dto.ReportPointDTO{PeriodStart: "2020-01-01", IssuedCount: 2, IssuedAmount: 2000},
+			report.Points[0])
+		assert.Equal(t, dto.ReportPointDTO{PeriodStart: "2020-01-02"}, report.Points[1])
+		assert.Equal(t, dto.ReportPointDTO{PeriodStart: "2020-01-06", IssuedCount: 1, IssuedAmount: 500,
+			RedeemedCount: 1, RedeemedAmount: 1000}, report.Points[5])
+		assert.Equal(t, time.Date(2020, 1, 8, 0, 0, 0, 0, time.UTC), repo.hourlyTotalsTo.UTC())
+		assert.Equal(t, int32(2), repo.hourlyTotalsCall)
+	})
+
+	te.Run("weekly", func(t *testing.T) {
+		t.Parallel()
+		service, _ := createReportServiceForTest(defaultBehavior)
+
+		report, err := service.GiftCardSeries(context.Background(), dto.ReportRequestDTO{
+			From: "2020-01-01", To: "2020-01-31", Interval: dto.WeeklyReport, Timezone: "UTC"})
+
+		assert.Empty(t, err)
+		assert.Equal(t, 5, len(report.Points))
+		assert.Equal(t, "2019-12-30", report.Points[0].PeriodStart)
+		assert.Equal(t, int64(2), report.Points[0].IssuedCount)
+		assert.Equal(t, "2020-01-06", report.Points[1].PeriodStart)
+		assert.Equal(t, int64(1), report.Points[1].IssuedCount)
+		assert.Equal(t, int64(1), report.Points[1].RedeemedCount)
+	})
+
+	te.Run("monthly", func(t *testing.T) {
+		t.Parallel()
+		service, _ := createReportServiceForTest(defaultBehavior)
+
+		report, err := service.GiftCardSeries(context.Background(), dto.ReportRequestDTO{
+			From: "2020-01-01", To: "2020-03-31", Interval: dto.MonthlyReport, Timezone: "UTC"})
+
+		assert.Empty(t, err)
+		assert.Equal(t, 3, len(report.Points))
+		assert.Equal(t, dto.ReportPointDTO{PeriodStart: "2020-01-01", IssuedCount: 3, IssuedAmount: 2500,
+			RedeemedCount: 1, RedeemedAmount: 1000}, report.Points[0])
+		assert.Equal(t, dto.ReportPointDTO{PeriodStart: "2020-02-01", IssuedCount: 3, IssuedAmount: 3000},
+			report.Points[1])
+		assert.Equal(t, dto.ReportPointDTO{PeriodStart: "2020-03-01"}, report.Points[2])
+	})
+
+	te.Run("in another timezone", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createReportServiceForTest(defaultBehavior)
+
+		report, err := service.GiftCardSeries(context.Background(), dto.ReportRequestDTO{
+			From: "2020-01-06", To: "2020-01-07", Interval: dto.DailyReport, Timezone: "Asia/Tehran"})
+
+		assert.Empty(t, err)
+		assert.Equal(t, "Asia/Tehran", report.Timezone)
+		assert.Equal(t, 2, len(report.Points))
+		assert.Equal(t, dto.ReportPointDTO{PeriodStart: "2020-01-06"}, report.Points[0])
+		assert.Equal(t, dto.ReportPointDTO{PeriodStart: "2020-01-07", IssuedCount: 1, IssuedAmount: 500,
+			RedeemedCount: 1, RedeemedAmount: 1000}, report.Points[1])
+		assert.Equal(t, time.Date(2020, 1, 5, 20, 30, 0, 0, time.UTC), repo.hourlyTotalsFrom.UTC())
+	})
+
+	te.Run("with invalid interval", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createReportServiceForTest(defaultBehavior)
+
+		_, err := service.GiftCardSeries(context.Background(), dto.ReportRequestDTO{
+			From: "2020-01-01", To: "2020-01-07", Interval: "year", Timezone: "UTC"})
+
+		assert.NotEmpty(t, err)
+		assert.Equal(t, int32(0), repo.hourlyTotalsCall)
+	})
+
+	te.Run("with invalid timezone", func(t *testing.T) {
+		t.Parallel()
+		service, _ := createReportServiceForTest(defaultBehavior)
+
+		_, err := service.GiftCardSeries(context.Background(), dto.ReportRequestDTO{
+			From: "2020-01-01", To: "2020-01-07", Interval: dto.DailyReport, Timezone: "Mars/Olympus"})
+
+		assert.Equal(t, common.InvalidTimezone, err)
+	})
+
+	te.Run("with reversed range", func(t *testing.T) {
+		t.Parallel()
+		service, _ := createReportServiceForTest(defaultBehavior)
+
+		_, err := service.GiftCardSeries(context.Background(), dto.ReportRequestDTO{
+			From: "2020-01-07", To: "2020-01-01", Interval: dto.DailyReport, Timezone: "UTC"})
+
+		assert.Equal(t, common.InvalidReportRange, err)
+	})
+
+	te.Run("with too long range", func(t *testing.T) {
+		t.Parallel()
+		service, _ := createReportServiceForTest(defaultBehavior)
+
+		_, err := service.GiftCardSeries(context.Background(), dto.ReportRequestDTO{
+			From: "2000-01-01", To: "2020-01-01", Interval: dto.DailyReport, Timezone: "UTC"})
+
+		assert.Equal(t, common.ReportRangeIsTooLong, err)
+	})
+
+	te.Run("with repository error", func(t *testing.T) {
+		t.Parallel()
+		service, _ := createReportServiceForTest(internalError)
+
+		_, err := service.GiftCardSeries(context.Background(), dto.ReportRequestDTO{
+			From: "2020-01-01", To: "2020-01-07", Interval: dto.DailyReport, Timezone: "UTC"})
+
+		assert.Equal(t, fakeInternalError, err)
+	})
+}
+
+func TestReportLiability(te *testing.T) {
+	te.Parallel()
+	te.Run("with valid behavior", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createReportServiceForTest(defaultBehavior)
+
+		report, err := service.Liability(context.Background(), dto.LiabilityRequestDTO{AsOf: "2020-01-31",
+			Timezone: "Asia/Tehran"})
+
+		assert.Empty(t, err)
+		assert.Equal(t, "2020-01-31", report.AsOf)
+		assert.Equal(t, 2, len(report.Campaigns))
+		assert.Equal(t, dto.CampaignLiabilityDTO{CampaignId: 1, CampaignTitle: "yalda", OutstandingCount: 2,
+			OutstandingAmount: 2000}, report.Campaigns[0])
+		assert.Equal(t, int64(5), report.OutstandingCount)
+		assert.Equal(t, int64(3500), report.OutstandingAmount)
+		assert.Equal(t, time.Date(2020, 1, 31, 20, 30, 0, 0, time.UTC), repo.outstandingAt.UTC())
+	})
+
+	te.Run("without date", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createReportServiceForTest(defaultBehavior)
+
+		_, err := service.Liability(context.Background(), dto.LiabilityRequestDTO{Timezone: "UTC"})
+
+		assert.NotEmpty(t, err)
+		assert.Equal(t, int32(0), repo.outstandingAtCall)
+	})
+
+	te.Run("with invalid timezone", func(t *testing.T) {
+		t.Parallel()
+		service, _ := createReportServiceForTest(defaultBehavior)
+
+		_, err := service.Liability(context.Background(), dto.LiabilityRequestDTO{AsOf: "2020-01-31",
+			Timezone: "Mars/Olympus"})
+
+		assert.Equal(t, common.InvalidTimezone, err)
+	})
+
+	te.Run("with repository error", func(t *testing.T) {
+		t.Parallel()
+		service, _ := createReportServiceForTest(internalError)
+
+		_, err := service.Liability(context.Background(), dto.LiabilityRequestDTO{AsOf: "2020-01-31", Timezone: "UTC"})
+
+		assert.Equal(t, fakeInternalError, err)
+	})
+}
@@ -0,0 +1,137 @@
+package logic
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/logger"
+	"strings"
+	"time"
+)
+
+// indexChunkSize keeps the id lists of the hydration queries below the parameter limit of the databases
+const indexChunkSize = 1000
+
+type searchService struct {
+	giftCardRepo core.GiftCardRepository
+	index        core.GiftCardIndex
+	mapper       core.Mapper
+}
+
+// SearchGiftCards finds the matched ids in the index and loads the cards from sql in the order of the index
+func (s *searchService) SearchGiftCards(ctx context.Context, query string,
+	size, page uint) (*dto.GiftCardsPageDTO, error) {
+	ctx, span := tracer.Start(ctx, "searchService.SearchGiftCards")
+	defer span.End()
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, common.EmptySearchQuery
+	}
+	ids, total, err := s.index.Search(query, size, size*page)
+	if err != nil {
+		logger.WithContext(ctx).WithData(map[string]interface{}{
+			"query": query,
+		}).ErrorException(err, "error while searching gift cards")
+		return nil, common.SearchIsNotAvailable
+	}
+	cards := make(map[int]dbmodel.GiftCard, len(ids))
+	for _, card := range s.giftCardRepo.FindByIDs(ctx, ids) {
+		cards[card.ID] = card
+	}
+	ordered := make([]dbmodel.GiftCard, 0, len(ids))
+	for _, id := range ids {
+		if card, ok := cards[id]; ok {
+			ordered = append(ordered, card)
+		}
+	}
+	return dto.NewGiftCardsPageDTO(s.mapper.ToListOfGiftCardDTO(ordered).Cards, int(size), int(page), total), nil
+}
+
+// Reindex writes every card into the index chunk by chunk and then prunes the cards which are gone from sql. it
+// stops with the error of the context when the request is gone, since the pages of a cancelled context are empty
+// and would look like the end of the cards
+func (s *searchService) Reindex(ctx context.Context, chunkSize uint) (int, error) {
+	ctx, span := tracer.Start(ctx, "searchService.Reindex")
+	defer span.End()
+	if chunkSize == 0 || chunkSize > indexChunkSize {
+		chunkSize = indexChunkSize
+	}
+	start := time.Now()
+	indexed, err := indexPages(ctx, s.giftCardRepo, s.index, chunkSize, core.GiftCardFilter{})
+	if err != nil {
+		return indexed, err
+	}
+	return indexed, s.index.Prune(start)
+}
+
+// indexPages writes the cards matching the filter into the index page by page and returns their count
+func indexPages(ctx context.Context, repository core.GiftCardRepository, index core.GiftCardIndex, chunkSize uint,
+	filter core.GiftCardFilter) (int, error) {
+	indexed := 0
+	var after *int
+	for {
+		cards, _ := repository.FindCursorPage(ctx, chunkSize, after, filter, false)
+		if err := ctx.Err(); err != nil {
+			return indexed, err
+		}
+		if len(cards) == 0 {
+			return indexed, nil
+		}
+		ids := make([]int, 0, len(cards))
+		for _, card := range cards {
+			ids = append(ids, card.ID)
+		}
+		if err := indexGiftCards(ctx, repository, index, ids); err != nil {
+			return indexed, err
+		}
+		indexed += len(cards)
+		after = &ids[len(ids)-1]
+	}
+}
+
+// indexGiftCards loads the cards with their campaigns and writes them into the index
+func indexGiftCards(ctx context.Context, repository core.GiftCardRepository, index core.GiftCardIndex,
+	ids []int) error {
+	for start := 0; start < len(ids); start += indexChunkSize {
+		end := start + indexChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		if err := index.Index(repository.FindByIDs(ctx, ids[start:end])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncGiftCards keeps the index in sync after a write. sql is the source of truth so a failure
+// is only logged and fixed by the next write or a reindex
+func syncGiftCards(ctx context.Context, repository core.GiftCardRepository, index core.GiftCardIndex, ids []int) {
+	if len(ids) == 0 {
+		return
+	}
+	if err := indexGiftCards(ctx, repository, index, ids); err != nil {
+		logger.WithContext(ctx).WithData(map[string]interface{}{
+			"count": len(ids),
+		}).ErrorException(err, "error while indexing gift cards")
+	}
+}
+
+// syncCampaignCards rewrites the cards of a campaign in the index after its title changes, since the documents of
+// the cards carry the title of their campaign
+func syncCampaignCards(ctx context.Context, repository core.GiftCardRepository, index core.GiftCardIndex,
+	campaignId uint) {
+	filter := core.GiftCardFilter{CampaignIds: []uint{campaignId}}
+	if _, err := indexPages(ctx, repository, index, indexChunkSize, filter); err != nil {
+		logger.WithContext(ctx).WithData(map[string]interface{}{
+			"campaign_id": campaignId,
+		}).ErrorException(err, "error while indexing the gift cards of a campaign")
+	}
+}
+
+func NewSearchService(giftCardRepository core.GiftCardRepository, index core.GiftCardIndex,
+	mapper core.Mapper) core.SearchService {
+	return &searchService{giftCardRepo: giftCardRepository, index: index, mapper: mapper}
+}
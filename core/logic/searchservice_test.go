@@ -0,0 +1,272 @@
+package logic_test
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/core/logic"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeGiftCardIndex is an in-process search index which matches the start of the codes and any part of the campaign
+// title
+type fakeGiftCardIndex struct {
+	mutex     sync.Mutex
+	cards     map[int]dbmodel.GiftCard
+	indexedAt map[int]time.Time
+	deleted   []int
+	fail      bool
+}
+
+func (f *fakeGiftCardIndex) Index(cards []dbmodel.GiftCard) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.fail {
+		return fakeInternalError
+	}
+	for _, card := range cards {
+		f.cards[card.ID] = card
+		f.indexedAt[card.ID] = time.Now()
+	}
+	return nil
+}
+
+func (f *fakeGiftCardIndex) Delete(id int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.cards, id)
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeGiftCardIndex) Prune(before time.Time) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for id, at := range f.indexedAt {
+		if at.Before(before) {
+			delete(f.cards, id)
+			delete(f.indexedAt, id)
+		}
+	}
+	return nil
+}
+
+func (f *fakeGiftCardIndex) Search(query string, size, from uint) ([]int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.fail {
+		return nil, 0, fakeInternalError
+	}
+	query = strings.ToLower(query)
+	ids := []int{}
+	for id, card := range f.cards {
+		title := ""
+		if card.Campaign != nil {
+			title = card.Campaign.Title
+		}
+		if strings.HasPrefix(strings.ToLower(card.PublicCode), query) ||
+			strings.HasPrefix(strings.ToLower(card.UUN), query) ||
+			strings.Contains(strings.ToLower(title), query) ||
+			strconv.Itoa(int(card.Amount)) == query {
+			ids = append(ids, id)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(ids)))
+	total := len(ids)
+	if int(from) >= total {
+		return []int{}, total, nil
+	}
+	ids = ids[from:]
+	if len(ids) > int(size) {
+		ids = ids[:size]
+	}
+	return ids, total, nil
+}
+
+func (f *fakeGiftCardIndex) count() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return len(f.cards)
+}
+
+func newFakeGiftCardIndex() *fakeGiftCardIndex {
+	return &fakeGiftCardIndex{
+		cards:     map[int]dbmodel.GiftCard{},
+		indexedAt: map[int]time.Time{},
+	}
+}
+
+func createSearchServiceForTest() (core.SearchService, *fakeGiftCardRepo, *fakeGiftCardIndex) {
+	repo := newFakeGiftCardRepo(defaultBehavior)
+	index := newFakeGiftCardIndex()
+	return logic.NewSearchService(repo, index, newFakeGiftCardMapper()), repo, index
+}
+
+func indexedCard(id int, publicCode string) dbmodel.GiftCard {
+	card := dbmodel.GiftCard{PublicCode: publicCode, Amount: 2000}
+	card.ID = id
+	return card
+}
+
+func TestSearchGiftCards(te *testing.T) {
+	te.Parallel()
+	te.Run("hydrate the matches in the order of the index", func(t *testing.T) {
+		t.Parallel()
+		service, repo, index := createSearchServiceForTest()
+		_ = index.Index([]dbmodel.GiftCard{indexedCard(1, "ABC111"), indexedCard(2, "XYZ222"),
+			indexedCard(3, "ABC333"), indexedCard(404, "ABC404")})
+
+		page, err := service.SearchGiftCards(context.Background(), " abc ", 10, 0)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 3, page.TotalItems)
+		assert.Equal(t, 1, page.Page)
+		assert.Equal(t, 2, len(page.GiftCards), "the card which is not in sql should be skipped")
+		assert.Equal(t, 3, page.GiftCards[0].ID)
+		assert.Equal(t, 1, page.GiftCards[1].ID)
+		assert.Equal(t, "public3", page.GiftCards[0].PublicCode, "the card should be loaded from sql")
+		assert.Equal(t, int32(1), repo.findByIDsCall)
+	})
+
+	te.Run("second page", func(t *testing.T) {
+		t.Parallel()
+		service, _, index := createSearchServiceForTest()
+		_ = index.Index([]dbmodel.GiftCard{indexedCard(1, "ABC1"), indexedCard(2, "ABC2"), indexedCard(3, "ABC3")})
+
+		page, err := service.SearchGiftCards(context.Background(), "abc", 2, 1)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 2, page.Page)
+		assert.Equal(t, 1, len(page.GiftCards))
+		assert.Equal(t, 1, page.GiftCards[0].ID)
+	})
+
+	te.Run("with empty query", func(t *testing.T) {
+		t.Parallel()
+		service, repo, _ := createSearchServiceForTest()
+
+		_, err := service.SearchGiftCards(context.Background(), "  ", 10, 0)
+
+		assert.Equal(t, common.EmptySearchQuery, err)
+		assert.Equal(t, int32(0), repo.findByIDsCall)
+	})
+
+	te.Run("with unavailable index", func(t *testing.T) {
+		t.Parallel()
+		service, repo, index := createSearchServiceForTest()
+		index.fail = true
+
+		_, err := service.SearchGiftCards(context.Background(), "abc", 10, 0)
+
+		assert.Equal(t, common.SearchIsNotAvailable, err)
+		assert.Equal(t, int32(0), repo.findByIDsCall)
+	})
+}
+
+func TestReindex(te *testing.T) {
+	te.Parallel()
+	te.Run("index every card in chunks", func(t *testing.T) {
+		t.Parallel()
+		service, repo, index := createSearchServiceForTest()
+
+		indexed, err := service.Reindex(context.Background(), 2)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 5, indexed)
+		assert.Equal(t, 5, index.count())
+		assert.Equal(t, int32(4), repo.findCursorPageCall)
+		assert.Equal(t, int32(3), repo.findByIDsCall)
+	})
+
+	te.Run("with removed card", func(t *testing.T) {
+		t.Parallel()
+		service, _, index := createSearchServiceForTest()
+		assert.Empty(t, index.Index([]dbmodel.GiftCard{indexedCard(404, "removed")}))
+		time.Sleep(time.Millisecond)
+
+		indexed, err := service.Reindex(context.Background(), 2)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 5, indexed)
+		assert.Equal(t, 5, index.count(), "the documents of the removed cards should be deleted")
+		_, ok := index.cards[404]
+		assert.False(t, ok)
+	})
+
+	te.Run("with unavailable index", func(t *testing.T) {
+		t.Parallel()
+		service, _, index := createSearchServiceForTest()
+		index.fail = true
+
+		indexed, err := service.Reindex(context.Background(), 2)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, indexed)
+	})
+
+	te.Run("with cancelled context", func(t *testing.T) {
+		t.Parallel()
+		service, _, index := createSearchServiceForTest()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		indexed, err := service.Reindex(ctx, 2)
+
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, 0, indexed)
+		assert.Equal(t, 0, index.count())
+	})
+}
+
+func TestGiftCardWritesAreIndexed(te *testing.T) {
+	te.Parallel()
+	te.Run("store", func(t *testing.T) {
+		t.Parallel()
+		service, repo, _, index := createServiceWithIndexForTest(defaultBehavior)
+
+		_, err := service.Store(context.Background(), &dto.CreateGiftCardDTO{ExpireDate: "2300-02-02", Amount: 2000})
+
+		assert.Empty(t, err)
+		assert.Equal(t, 1, index.count())
+		assert.Equal(t, int32(1), repo.findByIDsCall)
+	})
+
+	te.Run("failed store", func(t *testing.T) {
+		t.Parallel()
+		service, _, _, index := createServiceWithIndexForTest(internalError)
+
+		_, err := service.Store(context.Background(), &dto.CreateGiftCardDTO{ExpireDate: "2300-02-02", Amount: 2000})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, index.count())
+	})
+
+	te.Run("delete", func(t *testing.T) {
+		t.Parallel()
+		service, _, _, index := createServiceWithIndexForTest(defaultBehavior)
+
+		err := service.Delete(context.Background(), 123)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 1, len(index.deleted))
+	})
+
+	te.Run("unavailable index does not fail the write", func(t *testing.T) {
+		t.Parallel()
+		service, repo, _, index := createServiceWithIndexForTest(defaultBehavior)
+		index.fail = true
+
+		_, err := service.Store(context.Background(), &dto.CreateGiftCardDTO{ExpireDate: "2300-02-02", Amount: 2000})
+
+		assert.Empty(t, err)
+		assert.Equal(t, int32(1), repo.storeCall)
+	})
+}
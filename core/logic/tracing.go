@@ -0,0 +1,5 @@
+package logic
+
+import "go.opentelemetry.io/otel"
+
+var tracer = otel.Tracer("giftcard-engine/core/logic")
@@ -0,0 +1,220 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/logger"
+	"time"
+)
+
+// maxCardsPerEvent keeps the payloads of the bulk requests small enough for the receivers
+const maxCardsPerEvent = 100
+
+type webhookService struct {
+	repo         core.WebhookRepository
+	giftCardRepo core.GiftCardRepository
+	mapper       core.Mapper
+}
+
+func (w *webhookService) FindAll(ctx context.Context) (*dto.WebhookListDTO, error) {
+	ctx, span := tracer.Start(ctx, "webhookService.FindAll")
+	defer span.End()
+	subscriptions, err := w.repo.FindSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	webhooks := make([]dto.WebhookDTO, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		webhooks = append(webhooks, toWebhookDTO(subscription))
+	}
+	return &dto.WebhookListDTO{Webhooks: webhooks}, nil
+}
+
+func (w *webhookService) Create(ctx context.Context, webhook dto.CreateWebhookDTO) (*dto.WebhookDTO, error) {
+	ctx, span := tracer.Start(ctx, "webhookService.Create")
+	defer span.End()
+	subscription := dbmodel.NewWebhookSubscription(webhook.Url, webhook.Events, webhook.Secret)
+	if err := w.repo.StoreSubscription(ctx, subscription); err != nil {
+		logger.WithContext(ctx).ErrorException(err, "error while creating a webhook subscription")
+		return nil, err
+	}
+	webhookDto := toWebhookDTO(*subscription)
+	return &webhookDto, nil
+}
+
+func (w *webhookService) Update(ctx context.Context, webhook dto.UpdateWebhookDTO) (*dto.WebhookDTO, error) {
+	ctx, span := tracer.Start(ctx, "webhookService.Update")
+	defer span.End()
+	subscription, err := w.repo.FindSubscription(ctx, uint(webhook.ID))
+	if err != nil {
+		return nil, err
+	}
+	subscription.Url = webhook.Url
+	subscription.SetEvents(webhook.Events)
+	if webhook.Secret != "" {
+		subscription.Secret = webhook.Secret
+	}
+	if err = w.repo.StoreSubscription(ctx, subscription); err != nil {
+		logger.WithContext(ctx).ErrorException(err, "error while updating a webhook subscription")
+		return nil, err
+	}
+	webhookDto := toWebhookDTO(*subscription)
+	return &webhookDto, nil
+}
+
+func (w *webhookService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "webhookService.Delete")
+	defer span.End()
+	subscription, err := w.repo.FindSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	return w.repo.DeleteSubscription(ctx, *subscription)
+}
+
+func (w *webhookService) FindDeadLetters(ctx context.Context, size, page uint) dto.WebhookDeliveryPageDTO {
+	ctx, span := tracer.Start(ctx, "webhookService.FindDeadLetters")
+	defer span.End()
+	deliveries, total := w.repo.FindDeadDeliveries(ctx, size, page)
+	deliveriesDto := make([]dto.WebhookDeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveriesDto = append(deliveriesDto, toWebhookDeliveryDTO(delivery))
+	}
+	return dto.NewWebhookDeliveryPageDTO(deliveriesDto, int(size), int(page), total)
+}
+
+func (w *webhookService) Redeliver(ctx context.Context, id uint) (*dto.WebhookDeliveryDTO, error) {
+	ctx, span := tracer.Start(ctx, "webhookService.Redeliver")
+	defer span.End()
+	delivery, err := w.repo.FindDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = delivery.Redeliver(); err != nil {
+		return nil, err
+	}
+	if err = w.repo.StoreDelivery(ctx, delivery); err != nil {
+		logger.WithContext(ctx).ErrorException(err, "error while redelivering a webhook")
+		return nil, err
+	}
+	deliveryDto := toWebhookDeliveryDTO(*delivery)
+	return &deliveryDto, nil
+}
+
+// Publish stores a pending delivery of the event for every subscription of it. the cards of the bulk requests
+// are split into events of maxCardsPerEvent cards. every subscription is tried, and the first error is returned
+func (w *webhookService) Publish(ctx context.Context, event dto.WebhookEventDTO) error {
+	ctx, span := tracer.Start(ctx, "webhookService.Publish")
+	defer span.End()
+	if len(event.Cards) == 0 {
+		return nil
+	}
+	subscriptions, err := w.repo.FindSubscriptions(ctx)
+	if err != nil {
+		logger.WithContext(ctx).ErrorException(err, "error while finding the webhook subscriptions")
+		return err
+	}
+	subscribed := make([]dbmodel.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if subscription.Subscribes(event.Event) {
+			subscribed = append(subscribed, subscription)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	var failed error
+	cards := event.Cards
+	for start := 0; start < len(cards); start += maxCardsPerEvent {
+		end := start + maxCardsPerEvent
+		if end > len(cards) {
+			end = len(cards)
+		}
+		part := event
+		part.Cards = cards[start:end]
+		if len(cards) > maxCardsPerEvent {
+			part.ID = fmt.Sprintf("%s-%d", event.ID, start/maxCardsPerEvent)
+		}
+		payload, err := json.Marshal(part)
+		if err != nil {
+			logger.WithContext(ctx).ErrorException(err, "error while encoding a webhook event")
+			return err
+		}
+		for _, subscription := range subscribed {
+			delivery := dbmodel.NewWebhookDelivery(uint(subscription.ID), event.Event, string(payload))
+			if err := w.repo.StoreDelivery(ctx, delivery); err != nil {
+				logger.WithContext(ctx).WithData(map[string]interface{}{
+					"event":          event.Event,
+					"subscriptionId": subscription.ID,
+				}).ErrorException(err, "error while queuing a webhook delivery")
+				if failed == nil {
+					failed = err
+				}
+			}
+		}
+	}
+	return failed
+}
+
+func (w *webhookService) PublishExpired(ctx context.Context, from, to time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "webhookService.PublishExpired")
+	defer span.End()
+	expired, err := w.giftCardRepo.FindExpired(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	cards := make([]dto.WebhookCardDTO, 0, len(expired))
+	for i := range expired {
+		cards = append(cards, dto.NewWebhookCardDTO(w.mapper.ToGiftCardDTO(&expired[i])))
+	}
+	// the id is made of the range, so the instances which sweep the same range publish the same events
+	err = w.Publish(ctx, dto.WebhookEventDTO{
+		ID:         fmt.Sprintf("expired-%d-%d", from.Unix(), to.Unix()),
+		Event:      dbmodel.CardExpiredEvent,
+		OccurredAt: to.UTC().Format(time.RFC3339Nano),
+		Cards:      cards,
+	})
+	return len(cards), err
+}
+
+func toWebhookDTO(subscription dbmodel.WebhookSubscription) dto.WebhookDTO {
+	return dto.WebhookDTO{
+		ID:        subscription.ID,
+		Url:       subscription.Url,
+		Events:    subscription.EventList(),
+		CreatedAt: subscription.CreatedAt.Local().String(),
+	}
+}
+
+var deliveryStatuses = map[int]string{
+	dbmodel.DeliveryPending:   "pending",
+	dbmodel.DeliveryDelivered: "delivered",
+	dbmodel.DeliveryDead:      "dead",
+}
+
+func toWebhookDeliveryDTO(delivery dbmodel.WebhookDelivery) dto.WebhookDeliveryDTO {
+	deliveryDto := dto.WebhookDeliveryDTO{
+		ID:             delivery.ID,
+		SubscriptionId: delivery.SubscriptionId,
+		Event:          delivery.Event,
+		Status:         deliveryStatuses[delivery.Status],
+		Attempts:       delivery.Attempts,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Local().String(),
+		Payload:        json.RawMessage(delivery.Payload),
+	}
+	if delivery.Status == dbmodel.DeliveryPending {
+		deliveryDto.NextAttemptAt = delivery.NextAttemptAt.Local().String()
+	}
+	return deliveryDto
+}
+
+func NewWebhookService(repository core.WebhookRepository, giftCardRepository core.GiftCardRepository,
+	mapper core.Mapper) core.WebhookService {
+	return &webhookService{repo: repository, giftCardRepo: giftCardRepository, mapper: mapper}
+}
@@ -0,0 +1,220 @@
+package logic_test
+
+import (
+	"context"
+	"encoding/json"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/core/logic"
+	"giftcard-engine/infrastructure/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func createWebhookServiceForTest(strategy int) (core.WebhookService, core.WebhookRepository) {
+	repo := memory.NewWebhookRepository(memory.NewDatabase())
+	return logic.NewWebhookService(repo, newFakeGiftCardRepo(strategy), newFakeGiftCardMapper()), repo
+}
+
+func subscribeForTest(t *testing.T, service core.WebhookService, events ...string) *dto.WebhookDTO {
+	webhook, err := service.Create(context.Background(), dto.CreateWebhookDTO{
+		Url:    "http://crm/hooks",
+		Events: events,
+		Secret: "a-long-enough-secret",
+	})
+	assert.Empty(t, err)
+	return webhook
+}
+
+func dueDeliveries(t *testing.T, repo core.WebhookRepository) []dbmodel.WebhookDelivery {
+	deliveries, err := repo.ClaimDueDeliveries(context.Background(), time.Now(), time.Minute, 1000)
+	assert.Empty(t, err)
+	return deliveries
+}
+
+func TestWebhookServiceCreate(t *testing.T) {
+	t.Parallel()
+	service, _ := createWebhookServiceForTest(defaultBehavior)
+
+	webhook := subscribeForTest(t, service, dbmodel.CardIssuedEvent, dbmodel.CardExpiredEvent)
+	webhooks, err := service.FindAll(context.Background())
+
+	assert.Empty(t, err)
+	assert.NotZero(t, webhook.ID)
+	assert.Equal(t, []string{dbmodel.CardIssuedEvent, dbmodel.CardExpiredEvent}, webhook.Events)
+	assert.Equal(t, 1, len(webhooks.Webhooks))
+}
+
+func TestWebhookServiceUpdate(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createWebhookServiceForTest(defaultBehavior)
+		webhook := subscribeForTest(t, service, dbmodel.CardIssuedEvent)
+
+		updated, err := service.Update(context.Background(), dto.UpdateWebhookDTO{
+			ID:     webhook.ID,
+			Url:    "http://erp/hooks",
+			Events: []string{dbmodel.CardDeletedEvent},
+		})
+		subscription, _ := repo.FindSubscription(context.Background(), uint(webhook.ID))
+
+		assert.Empty(t, err)
+		assert.Equal(t, "http://erp/hooks", updated.Url)
+		assert.Equal(t, []string{dbmodel.CardDeletedEvent}, subscription.EventList())
+		assert.Equal(t, "a-long-enough-secret", subscription.Secret, "an empty secret should keep the old one")
+	})
+
+	te.Run("with not found webhook", func(t *testing.T) {
+		t.Parallel()
+		service, _ := createWebhookServiceForTest(defaultBehavior)
+
+		_, err := service.Update(context.Background(), dto.UpdateWebhookDTO{
+			ID:     12,
+			Url:    "http://erp/hooks",
+			Events: []string{dbmodel.CardDeletedEvent},
+		})
+
+		assert.Equal(t, common.WebhookNotFound, err)
+	})
+}
+
+func TestWebhookServicePublish(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createWebhookServiceForTest(defaultBehavior)
+		issued := subscribeForTest(t, service, dbmodel.CardIssuedEvent)
+		subscribeForTest(t, service, dbmodel.CardApprovedEvent)
+
+		err := service.Publish(context.Background(), dto.WebhookEventDTO{
+			ID:    "outbox-1",
+			Event: dbmodel.CardIssuedEvent,
+			Cards: []dto.WebhookCardDTO{{ID: 1, PublicCode: "first"}, {ID: 2, PublicCode: "second"}},
+		})
+		deliveries := dueDeliveries(t, repo)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 1, len(deliveries), "only the subscriptions of the event should get it")
+		assert.Equal(t, uint(issued.ID), deliveries[0].SubscriptionId)
+		var event dto.WebhookEventDTO
+		assert.Empty(t, json.Unmarshal([]byte(deliveries[0].Payload), &event))
+		assert.Equal(t, "outbox-1", event.ID)
+		assert.Equal(t, dbmodel.CardIssuedEvent, event.Event)
+		assert.Equal(t, 2, len(event.Cards))
+	})
+
+	te.Run("with many cards", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createWebhookServiceForTest(defaultBehavior)
+		subscribeForTest(t, service, dbmodel.CardIssuedEvent)
+
+		err := service.Publish(context.Background(), dto.WebhookEventDTO{
+			ID:    "outbox-1",
+			Event: dbmodel.CardIssuedEvent,
+			Cards: make([]dto.WebhookCardDTO, 250),
+		})
+		deliveries := dueDeliveries(t, repo)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 3, len(deliveries), "the cards should be split into events of 100 cards")
+		var ids []string
+		for _, delivery := range deliveries {
+			var event dto.WebhookEventDTO
+			assert.Empty(t, json.Unmarshal([]byte(delivery.Payload), &event))
+			ids = append(ids, event.ID)
+		}
+		assert.ElementsMatch(t, []string{"outbox-1-0", "outbox-1-1", "outbox-1-2"}, ids)
+	})
+
+	te.Run("without subscriptions", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createWebhookServiceForTest(defaultBehavior)
+
+		err := service.Publish(context.Background(), dto.WebhookEventDTO{
+			ID:    "outbox-1",
+			Event: dbmodel.CardIssuedEvent,
+			Cards: []dto.WebhookCardDTO{{ID: 1}},
+		})
+
+		assert.Empty(t, err)
+		assert.Empty(t, dueDeliveries(t, repo))
+	})
+}
+
+func TestWebhookServicePublishExpired(te *testing.T) {
+	te.Parallel()
+	from := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	to := from.Add(15 * time.Minute)
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createWebhookServiceForTest(defaultBehavior)
+		subscribeForTest(t, service, dbmodel.CardExpiredEvent)
+
+		count, err := service.PublishExpired(context.Background(), from, to)
+		deliveries := dueDeliveries(t, repo)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, 1, len(deliveries))
+		var event dto.WebhookEventDTO
+		assert.Empty(t, json.Unmarshal([]byte(deliveries[0].Payload), &event))
+		assert.Equal(t, "expired-1709287200-1709288100", event.ID, "the id should be made of the range")
+		assert.Equal(t, "expired", event.Cards[0].PublicCode)
+	})
+
+	te.Run("with internal error strategy", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createWebhookServiceForTest(internalError)
+		subscribeForTest(t, service, dbmodel.CardExpiredEvent)
+
+		_, err := service.PublishExpired(context.Background(), from, to)
+
+		assert.Equal(t, fakeInternalError, err)
+		assert.Empty(t, dueDeliveries(t, repo))
+	})
+}
+
+func TestWebhookServiceRedeliver(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createWebhookServiceForTest(defaultBehavior)
+		delivery := dbmodel.NewWebhookDelivery(1, dbmodel.CardIssuedEvent, `{}`)
+		delivery.Die("the receiver answered 500")
+		assert.Empty(t, repo.StoreDelivery(context.Background(), delivery))
+
+		deadLetters := service.FindDeadLetters(context.Background(), 10, 0)
+		redelivered, err := service.Redeliver(context.Background(), uint(delivery.ID))
+
+		assert.Equal(t, 1, deadLetters.TotalItems)
+		assert.Empty(t, err)
+		assert.Equal(t, "pending", redelivered.Status)
+		assert.Equal(t, 0, redelivered.Attempts)
+		assert.Equal(t, 0, service.FindDeadLetters(context.Background(), 10, 0).TotalItems)
+		assert.Equal(t, 1, len(dueDeliveries(t, repo)))
+	})
+
+	te.Run("with pending delivery", func(t *testing.T) {
+		t.Parallel()
+		service, repo := createWebhookServiceForTest(defaultBehavior)
+		delivery := dbmodel.NewWebhookDelivery(1, dbmodel.CardIssuedEvent, `{}`)
+		assert.Empty(t, repo.StoreDelivery(context.Background(), delivery))
+
+		_, err := service.Redeliver(context.Background(), uint(delivery.ID))
+
+		assert.Equal(t, common.WebhookDeliveryIsNotDead, err)
+	})
+
+	te.Run("with not found delivery", func(t *testing.T) {
+		t.Parallel()
+		service, _ := createWebhookServiceForTest(defaultBehavior)
+
+		_, err := service.Redeliver(context.Background(), 12)
+
+		assert.Equal(t, common.WebhookDeliveryNotFound, err)
+	})
+}
@@ -15,4 +15,6 @@ type Mapper interface {
 	ToCampaign(dto dto.CreateCampaignDTO) dbmodel.Campaign
 	ToCampaignDTO(campaign dbmodel.Campaign) dto.CampaignDTO
 	ToListOfCampaigns(campaigns []dbmodel.Campaign) []dto.CampaignDTO
+	ToBatchDTO(batch dbmodel.Batch) dto.BatchDTO
+	ToListOfBatches(batches []dbmodel.Batch) []dto.BatchDTO
 }
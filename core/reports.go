@@ -0,0 +1,22 @@
+package core
+
+import "time"
+
+// ReportEvent is the moment of a gift card life which is reported
+type ReportEvent int
+
+const (
+	_ ReportEvent = iota
+	// IssuedEvent is reported by the creation time of the cards
+	IssuedEvent
+	// RedeemedEvent is reported by the redemption time of the cards
+	RedeemedEvent
+)
+
+// HourlyTotal is the number and the total amount of the cards of a single hour
+type HourlyTotal struct {
+	// Hour is the start of the hour. it is aligned to the hours of the location of the query
+	Hour   time.Time
+	Count  int64
+	Amount int64
+}
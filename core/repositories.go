@@ -1,25 +1,96 @@
 package core
 
 import (
+	"context"
 	"giftcard-engine/core/dbmodel"
 	"time"
 )
 
+// GiftCardRepository stores the events of the changed cards in the outbox in the transaction of the change.
+// the deleted cards and the cards changed by the batch operations get their events too
 type GiftCardRepository interface {
-	FindByUUN(uun string) []dbmodel.GiftCard
-	FindByID(id uint) (*dbmodel.GiftCard, error)
-	Store(card *dbmodel.GiftCard) error
-	Delete(card dbmodel.GiftCard) error
-	FindByPublicKey(key string) (*dbmodel.GiftCard, error)
-	FindPage(size, number uint, search string, campaignId *int, isValid *bool,
-		expireDateFrom *time.Time, expireDateTo *time.Time) ([]dbmodel.GiftCard, int)
-	FindBySecretKey(secret string) (*dbmodel.GiftCard, error)
-	RollBackApprove(secret string) error
+	FindByUUN(ctx context.Context, uun string) []dbmodel.GiftCard
+	FindByID(ctx context.Context, id uint) (*dbmodel.GiftCard, error)
+	// FindByIDs returns the existing cards of the given ids with their campaigns in no particular order
+	FindByIDs(ctx context.Context, ids []int) []dbmodel.GiftCard
+	Store(ctx context.Context, card *dbmodel.GiftCard) error
+	Delete(ctx context.Context, card dbmodel.GiftCard) error
+	FindByPublicKey(ctx context.Context, key string) (*dbmodel.GiftCard, error)
+	FindPage(ctx context.Context, size, number uint, filter GiftCardFilter) ([]dbmodel.GiftCard, int)
+	// FindCursorPage returns up to size cards ordered by id desc with ids lower than after.
+	// the sort of the filter is ignored and total is -1 when withCount is false
+	FindCursorPage(ctx context.Context, size uint, after *int, filter GiftCardFilter,
+		withCount bool) ([]dbmodel.GiftCard, int)
+	FindBySecretKey(ctx context.Context, secret string) (*dbmodel.GiftCard, error)
+	RollBackApprove(ctx context.Context, secret string) error
+	FindByBatch(ctx context.Context, batchId uint) []dbmodel.GiftCard
+	ExtendBatchExpiry(ctx context.Context, batchId uint, expireDate time.Time) (int, error)
+	VoidBatch(ctx context.Context, batchId uint) (int, error)
+	// Stats aggregates the cards of the campaign or all of the cards when campaignId is nil
+	Stats(ctx context.Context, campaignId *uint) (GiftCardStats, error)
+	// HourlyTotals returns the totals of the event for the hours of [from, to) which have any card.
+	// the hours are aligned to the given location so they can be rolled up into its days
+	HourlyTotals(ctx context.Context, event ReportEvent, campaignId *uint, from, to time.Time,
+		location *time.Location) ([]HourlyTotal, error)
+	// OutstandingAt returns the liability of every campaign which had valid cards at the given moment,
+	// using the recorded redeem, void and delete times and the terms the cards had at that moment
+	OutstandingAt(ctx context.Context, at time.Time, campaignId *uint) ([]CampaignLiability, error)
+	// FindExpired returns the unused cards which stopped being valid in (from, to], ordered by id
+	FindExpired(ctx context.Context, from, to time.Time) ([]dbmodel.GiftCard, error)
 }
 
 type CampaignRepository interface {
-	FindByID(id uint) (dbmodel.Campaign, error)
-	Store(card *dbmodel.Campaign) error
-	Delete(card dbmodel.Campaign) error
-	FindPage(size, number uint, search string) ([]dbmodel.Campaign, int)
+	FindByID(ctx context.Context, id uint) (dbmodel.Campaign, error)
+	Store(ctx context.Context, card *dbmodel.Campaign) error
+	Delete(ctx context.Context, card dbmodel.Campaign) error
+	FindPage(ctx context.Context, size, number uint, search string) ([]dbmodel.Campaign, int)
+	// FindCursorPage returns up to size campaigns ordered by id desc with ids lower than after.
+	// total is -1 when withCount is false
+	FindCursorPage(ctx context.Context, size uint, after *int, search string, withCount bool) ([]dbmodel.Campaign, int)
+}
+
+type BatchRepository interface {
+	FindByID(ctx context.Context, id uint) (*dbmodel.Batch, error)
+	Store(ctx context.Context, batch *dbmodel.Batch) error
+	Delete(ctx context.Context, batch dbmodel.Batch) error
+	FindPage(ctx context.Context, size, number uint, createdBy string) ([]dbmodel.Batch, int)
+}
+
+type WebhookRepository interface {
+	FindSubscription(ctx context.Context, id uint) (*dbmodel.WebhookSubscription, error)
+	// FindSubscriptions returns every subscription ordered by id
+	FindSubscriptions(ctx context.Context) ([]dbmodel.WebhookSubscription, error)
+	StoreSubscription(ctx context.Context, subscription *dbmodel.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, subscription dbmodel.WebhookSubscription) error
+	FindDelivery(ctx context.Context, id uint) (*dbmodel.WebhookDelivery, error)
+	StoreDelivery(ctx context.Context, delivery *dbmodel.WebhookDelivery) error
+	// ClaimDueDeliveries returns up to limit pending deliveries which are due at the given moment and postpones
+	// them by the lease, so the other instances do not send them at the same time. a claimed delivery which is
+	// not stored again before the lease ends is due again
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration,
+		limit int) ([]dbmodel.WebhookDelivery, error)
+	// FindDeadDeliveries returns a page of the dead deliveries ordered by id desc and their total
+	FindDeadDeliveries(ctx context.Context, size, number uint) ([]dbmodel.WebhookDelivery, int)
+}
+
+type OutboxRepository interface {
+	// ClaimDueEvents returns up to limit unpublished events which are due at the given moment ordered by their
+	// next attempt and id, and postpones them by the lease like ClaimDueDeliveries
+	ClaimDueEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]dbmodel.OutboxEvent, error)
+	StoreEvent(ctx context.Context, event *dbmodel.OutboxEvent) error
+	// DeletePublished deletes the events which are published before the given moment and returns their count
+	DeletePublished(ctx context.Context, before time.Time) (int, error)
+}
+
+// CommandRepository keeps the commands of the other services, so a repeated command is answered with the result
+// of the first one
+type CommandRepository interface {
+	// ClaimCommand stores the command as claimed at the given moment and returns it with true. a stored command is
+	// returned with false, unless it is not handled within the lease, which is claimed again since the instance
+	// handling it is assumed to be gone
+	ClaimCommand(ctx context.Context, commandId string, now time.Time, lease time.Duration) (*dbmodel.Command, bool,
+		error)
+	StoreCommand(ctx context.Context, command *dbmodel.Command) error
+	// DeleteCommand deletes a claimed command, so it is handled again when it is repeated
+	DeleteCommand(ctx context.Context, command dbmodel.Command) error
 }
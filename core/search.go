@@ -0,0 +1,20 @@
+package core
+
+import (
+	"giftcard-engine/core/dbmodel"
+	"time"
+)
+
+// GiftCardIndex is a full text search index of gift cards. the sql database stays the source of truth
+// so the index only has to return the ids of the matched cards
+type GiftCardIndex interface {
+	// Index adds or replaces the given cards in the index
+	Index(cards []dbmodel.GiftCard) error
+	// Delete removes a card from the index. deleting a missing card is not an error
+	Delete(id int) error
+	// Search returns the ids of the best matches for the query and the total number of matches
+	Search(query string, size, from uint) ([]int, int, error)
+	// Prune removes the cards which were not written since the moment. a reindex prunes the cards which are gone
+	// from sql once it has written every card
+	Prune(before time.Time) error
+}
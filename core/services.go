@@ -1,32 +1,76 @@
 package core
 
 import (
+	"context"
 	"giftcard-engine/core/dto"
 	"time"
 )
 
 // GiftCardService works with requests to api
 type GiftCardService interface {
-	FindPage(size, page uint, search string, campaignId *int,
-		isValid *bool, expireDateFrom *time.Time, expireDateTo *time.Time) dto.GiftCardsPageDTO
-	FindByID(id uint) (*dto.GiftCardDTO, error)
-	Store(card *dto.CreateGiftCardDTO) (*dto.GiftCardDTO, error)
-	Update(card *dto.UpdateGiftCardDto) (*dto.GiftCardDTO, error)
-	Delete(id uint) error
-	CreateMany(cards *dto.BulkCreateGiftCardsDTO) (*dto.GiftCardsListDTO, error)
-	CreateSameMany(cards *dto.BulkCreateSameGiftCardsDTO) (*dto.GiftCardsListDTO, error)
-	FindByPublicKey(key string) (*dto.GiftCardStatusDTO, error)
-
-	FindByUUN(uun string) (*dto.GiftCardsListDTO, error)
-	ValidateGiftCards(cards *dto.ValidateGiftCardsDto) *dto.GiftCardStatusListDTO
-	ApproveGiftCards(cards *dto.ApproveGiftCardsDTO) (*dto.GiftCardStatusListDTO, error)
-	ValidateGiftCard(giftCardSecret string) dto.GiftCardStatusDTO
-	ApproveGiftCard(uun, giftCardSecret string) (dto.GiftCardStatusDTO, error)
+	FindPage(ctx context.Context, size, page uint, filter GiftCardFilter) dto.GiftCardsPageDTO
+	FindCursorPage(ctx context.Context, size uint, cursor string, filter GiftCardFilter,
+		withCount bool) (*dto.GiftCardsCursorPageDTO, error)
+	FindByID(ctx context.Context, id uint) (*dto.GiftCardDTO, error)
+	Store(ctx context.Context, card *dto.CreateGiftCardDTO) (*dto.GiftCardDTO, error)
+	Update(ctx context.Context, card *dto.UpdateGiftCardDto) (*dto.GiftCardDTO, error)
+	Delete(ctx context.Context, id uint) error
+	CreateMany(ctx context.Context, cards *dto.BulkCreateGiftCardsDTO) (*dto.GiftCardsListDTO, error)
+	CreateSameMany(ctx context.Context, cards *dto.BulkCreateSameGiftCardsDTO) (*dto.GiftCardsListDTO, error)
+	FindByPublicKey(ctx context.Context, key string) (*dto.GiftCardStatusDTO, error)
+
+	FindByUUN(ctx context.Context, uun string) (*dto.GiftCardsListDTO, error)
+	ValidateGiftCards(ctx context.Context, cards *dto.ValidateGiftCardsDto) (*dto.GiftCardStatusListDTO, error)
+	ApproveGiftCards(ctx context.Context, cards *dto.ApproveGiftCardsDTO) (*dto.GiftCardStatusListDTO, error)
+	ValidateGiftCard(ctx context.Context, giftCardSecret string) (dto.GiftCardStatusDTO, error)
+	ApproveGiftCard(ctx context.Context, uun, giftCardSecret string) (dto.GiftCardStatusDTO, error)
 }
 
 type CampaignService interface {
-	FindPage(size, page uint, search string) dto.CampaignPageDTO
-	Create(campaign dto.CreateCampaignDTO) (dto.CampaignDTO, error)
-	Update(campaign dto.UpdateCampaignDto) (dto.CampaignDTO, error)
-	Delete(id uint) error
+	FindPage(ctx context.Context, size, page uint, search string) dto.CampaignPageDTO
+	FindCursorPage(ctx context.Context, size uint, cursor string, search string,
+		withCount bool) (dto.CampaignCursorPageDTO, error)
+	Create(ctx context.Context, campaign dto.CreateCampaignDTO) (dto.CampaignDTO, error)
+	Update(ctx context.Context, campaign dto.UpdateCampaignDto) (dto.CampaignDTO, error)
+	Delete(ctx context.Context, id uint) error
+	Stats(ctx context.Context, id uint) (*dto.CampaignStatsDTO, error)
+	Summary(ctx context.Context) (*dto.CampaignStatsDTO, error)
+}
+
+type BatchService interface {
+	FindPage(ctx context.Context, size, page uint, createdBy string) dto.BatchPageDTO
+	FindByID(ctx context.Context, id uint) (*dto.BatchDTO, error)
+	FindCards(ctx context.Context, id uint) (*dto.GiftCardsListDTO, error)
+	ExtendExpiry(ctx context.Context, id uint, expiry *dto.ExtendBatchExpiryDTO) (*dto.BatchOperationDTO, error)
+	Void(ctx context.Context, id uint) (*dto.BatchOperationDTO, error)
+}
+
+// SearchService serves the full text search of gift cards and keeps the search index filled
+type SearchService interface {
+	SearchGiftCards(ctx context.Context, query string, size, page uint) (*dto.GiftCardsPageDTO, error)
+	// Reindex writes every gift card into the search index in chunks and returns the number of indexed cards
+	Reindex(ctx context.Context, chunkSize uint) (int, error)
+}
+
+// ReportService builds the time series and the liability reports of gift cards
+type ReportService interface {
+	// GiftCardSeries returns the issued and redeemed totals of every period of the requested range
+	GiftCardSeries(ctx context.Context, request dto.ReportRequestDTO) (*dto.ReportDTO, error)
+	// Liability returns the outstanding value of every campaign at the end of the requested date
+	Liability(ctx context.Context, request dto.LiabilityRequestDTO) (*dto.LiabilityDTO, error)
+}
+
+// WebhookService manages the webhook subscriptions and their failed deliveries
+type WebhookService interface {
+	WebhookPublisher
+	FindAll(ctx context.Context) (*dto.WebhookListDTO, error)
+	Create(ctx context.Context, webhook dto.CreateWebhookDTO) (*dto.WebhookDTO, error)
+	Update(ctx context.Context, webhook dto.UpdateWebhookDTO) (*dto.WebhookDTO, error)
+	Delete(ctx context.Context, id uint) error
+	// FindDeadLetters returns a page of the deliveries which ran out of attempts
+	FindDeadLetters(ctx context.Context, size, page uint) dto.WebhookDeliveryPageDTO
+	// Redeliver makes a dead delivery pending again, so it is sent with all of its attempts
+	Redeliver(ctx context.Context, id uint) (*dto.WebhookDeliveryDTO, error)
+	// PublishExpired publishes the expired event of the cards which stopped being valid in (from, to]
+	PublishExpired(ctx context.Context, from, to time.Time) (int, error)
 }
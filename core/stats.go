@@ -0,0 +1,22 @@
+package core
+
+// CardsAggregate is the number and the total amount of a group of gift cards
+type CardsAggregate struct {
+	Count  int64
+	Amount int64
+}
+
+// GiftCardStats is the aggregated state of a set of gift cards. except for Issued and Deleted the groups
+// only contain the cards which are not deleted and do not overlap
+type GiftCardStats struct {
+	Issued      CardsAggregate
+	Redeemed    CardsAggregate
+	Expired     CardsAggregate
+	Voided      CardsAggregate
+	Deleted     CardsAggregate
+	Outstanding CardsAggregate
+	// TimedRedeems is the number of the redeemed cards which have a redeem time
+	TimedRedeems int64
+	// RedeemSeconds is the total time between creating and redeeming the timed redeems
+	RedeemSeconds int64
+}
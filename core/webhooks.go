@@ -0,0 +1,13 @@
+package core
+
+import (
+	"context"
+	"giftcard-engine/core/dto"
+)
+
+// WebhookPublisher queues an event of the gift cards for every subscription of the event. the events are sent
+// later, so publishing never waits for the receivers. an event of many cards is split into parts, whose ids are
+// the id of the event and the number of the part, so an event which is published again keeps the ids of its parts
+type WebhookPublisher interface {
+	Publish(ctx context.Context, event dto.WebhookEventDTO) error
+}
@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"giftcard-engine/infrastructure/requestid"
+	"giftcard-engine/utils/indraframework"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+// Middleware lets in the requests which send the admin token as their bearer token. the admin endpoints are not
+// found while the token is empty. the token is read on every request so it can be rotated by a reload
+func Middleware(token func() string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		expected := token()
+		if expected == "" {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		given := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(expected)) != 1 {
+			err := indraframework.NewIndraException("unauthorized", "the admin token is not valid",
+				http.StatusUnauthorized)
+			err.RequestId = c.GetString(requestid.Key)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err})
+			return
+		}
+		c.Next()
+	}
+}
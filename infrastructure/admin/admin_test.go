@@ -0,0 +1,45 @@
+package admin_test
+
+import (
+	"giftcard-engine/infrastructure/admin"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serve(token, authorization string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	route := gin.New()
+	route.GET("/admin", admin.Middleware(func() string { return token }), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	request := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+	recorder := httptest.NewRecorder()
+	route.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestMiddlewareLetsInTheAdminToken(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, http.StatusOK, serve("secret", "Bearer secret").Code)
+}
+
+func TestMiddlewareRejectsOtherTokens(t *testing.T) {
+	t.Parallel()
+	for _, authorization := range []string{"", "Bearer other", "secret "} {
+		recorder := serve("secret", authorization)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code, authorization)
+		assert.Contains(t, recorder.Body.String(), "the admin token is not valid")
+	}
+}
+
+func TestMiddlewareHidesTheEndpointsWithoutAToken(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, http.StatusNotFound, serve("", "Bearer ").Code)
+}
@@ -0,0 +1,75 @@
+// Package broker connects the service to a message broker, which the domain events are published to and the
+// commands of the other services are consumed from
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrClosed is returned by a broker which is closed
+	ErrClosed = errors.New("the broker is closed")
+	// ErrNotConnected is returned by a broker which lost its connection and is connecting again
+	ErrNotConnected = errors.New("the broker is not connected")
+)
+
+// Message is a message of a subject. the subjects are tokens separated by dots, like giftcard.events.gift_card.issued
+type Message struct {
+	Subject string
+	Reply   string // subject the answer is published to, when the sender waits for one
+	Data    []byte
+}
+
+// Handler handles the messages of a subscription one at a time
+type Handler func(ctx context.Context, message Message)
+
+// Broker publishes and subscribes the messages like nats does. a message is handed to the subscriptions which are
+// subscribed when it is published, so a subscription which is not running loses it. the messages are delivered at
+// most once, like core nats without a durable stream, and are not redelivered when a handler fails
+type Broker interface {
+	// Publish returns once the broker has got the message
+	Publish(ctx context.Context, message Message) error
+	// Subscribe hands the messages of the subject to the handler until the subscription is unsubscribed. the
+	// subject may have wildcards: * matches a token and > matches the rest of the tokens. the subscriptions of the
+	// same queue share the messages, so each message is handled by only one of them
+	Subscribe(subject, queue string, handler Handler) (Subscription, error)
+	Close() error
+}
+
+type Subscription interface {
+	Unsubscribe() error
+}
+
+// validSubject checks a subject which is published to, or is subscribed to if wildcards are allowed
+func validSubject(subject string, wildcards bool) error {
+	tokens := strings.Split(subject, ".")
+	for i, token := range tokens {
+		switch {
+		case token == "" || strings.ContainsAny(token, " \t\r\n"):
+			return fmt.Errorf("%q is not a valid subject", subject)
+		case !wildcards && (token == "*" || token == ">"):
+			return fmt.Errorf("the subject %q of a message cannot have wildcards", subject)
+		case token == ">" && i != len(tokens)-1:
+			return fmt.Errorf("the > wildcard of %q should be its last token", subject)
+		}
+	}
+	return nil
+}
+
+// matches reports whether the subject of a message is one of the subjects of a subscription
+func matches(pattern, subject string) bool {
+	patterns := strings.Split(pattern, ".")
+	tokens := strings.Split(subject, ".")
+	for i, token := range patterns {
+		if token == ">" {
+			return len(tokens) > i
+		}
+		if i >= len(tokens) || (token != "*" && token != tokens[i]) {
+			return false
+		}
+	}
+	return len(patterns) == len(tokens)
+}
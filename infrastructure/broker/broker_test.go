@@ -0,0 +1,122 @@
+package broker_test
+
+import (
+	"context"
+	"giftcard-engine/infrastructure/broker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+// collector keeps the messages of the subscriptions it handles
+type collector struct {
+	mu       sync.Mutex
+	messages []broker.Message
+}
+
+func (c *collector) handle(_ context.Context, message broker.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, message)
+}
+
+func (c *collector) subjects() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	subjects := []string{}
+	for _, message := range c.messages {
+		subjects = append(subjects, message.Subject)
+	}
+	return subjects
+}
+
+// eventually waits until the collector has the number of messages
+func (c *collector) eventually(t *testing.T, count int) {
+	assert.Eventually(t, func() bool { return len(c.subjects()) >= count }, time.Second, 5*time.Millisecond)
+}
+
+// subscribe subscribes a new collector
+func subscribe(t *testing.T, b broker.Broker, subject, queue string) *collector {
+	c := &collector{}
+	_, err := b.Subscribe(subject, queue, c.handle)
+	require.Nil(t, err)
+	return c
+}
+
+func publish(t *testing.T, b broker.Broker, subjects ...string) {
+	for _, subject := range subjects {
+		require.Nil(t, b.Publish(context.Background(), broker.Message{Subject: subject, Data: []byte(subject)}))
+	}
+}
+
+func TestMemoryBroker(te *testing.T) {
+	te.Parallel()
+	te.Run("with wildcards", func(t *testing.T) {
+		t.Parallel()
+		b := broker.NewMemoryBroker()
+		defer b.Close()
+		exact := subscribe(t, b, "giftcard.events.gift_card.issued", "")
+		token := subscribe(t, b, "giftcard.events.*.issued", "")
+		rest := subscribe(t, b, "giftcard.events.>", "")
+
+		publish(t, b, "giftcard.events.gift_card.issued", "giftcard.events.campaign.created", "giftcard.events",
+			"giftcard.commands.issue_cards")
+		rest.eventually(t, 2)
+
+		assert.Equal(t, []string{"giftcard.events.gift_card.issued"}, exact.subjects())
+		assert.Equal(t, []string{"giftcard.events.gift_card.issued"}, token.subjects())
+		assert.Equal(t, []string{"giftcard.events.gift_card.issued", "giftcard.events.campaign.created"},
+			rest.subjects())
+	})
+
+	te.Run("with queue", func(t *testing.T) {
+		t.Parallel()
+		b := broker.NewMemoryBroker()
+		defer b.Close()
+		first := subscribe(t, b, "giftcard.commands.>", "engine")
+		second := subscribe(t, b, "giftcard.commands.>", "engine")
+		all := subscribe(t, b, "giftcard.commands.>", "")
+
+		publish(t, b, "giftcard.commands.a", "giftcard.commands.b", "giftcard.commands.c", "giftcard.commands.d")
+		all.eventually(t, 4)
+		first.eventually(t, 2)
+		second.eventually(t, 2)
+
+		assert.Equal(t, []string{"giftcard.commands.a", "giftcard.commands.c"}, first.subjects(),
+			"the members of a queue should share the messages")
+		assert.Equal(t, []string{"giftcard.commands.b", "giftcard.commands.d"}, second.subjects())
+	})
+
+	te.Run("with unsubscribed subscription", func(t *testing.T) {
+		t.Parallel()
+		b := broker.NewMemoryBroker()
+		c := &collector{}
+		subscription, err := b.Subscribe("giftcard.events.>", "", c.handle)
+		require.Nil(t, err)
+		other := subscribe(t, b, "giftcard.>", "")
+
+		assert.Empty(t, subscription.Unsubscribe())
+		publish(t, b, "giftcard.events.gift_card.issued")
+		other.eventually(t, 1)
+
+		assert.Empty(t, c.subjects())
+		assert.Empty(t, b.Close())
+		assert.Equal(t, broker.ErrClosed, b.Publish(context.Background(), broker.Message{Subject: "giftcard.events"}))
+	})
+
+	te.Run("with invalid subjects", func(t *testing.T) {
+		t.Parallel()
+		b := broker.NewMemoryBroker()
+		defer b.Close()
+
+		for _, subject := range []string{"", "giftcard..events", "giftcard.*", "giftcard.>", "gift card"} {
+			assert.Error(t, b.Publish(context.Background(), broker.Message{Subject: subject}), subject)
+		}
+		for _, subject := range []string{"", "giftcard.>.events", "gift card"} {
+			_, err := b.Subscribe(subject, "", func(context.Context, broker.Message) {})
+			assert.Error(t, err, subject)
+		}
+	})
+}
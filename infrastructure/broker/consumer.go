@@ -0,0 +1,198 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/utils/indraframework"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// commandsQueue is the queue of the command subscriptions, so a command is handled by a single instance
+	commandsQueue = "giftcard-engine"
+	// commandLease is how long a command is left to the instance which claimed it, before a repeated command is
+	// handled again because the instance is assumed to be gone
+	commandLease = 10 * time.Minute
+)
+
+// IssueCardsSubject returns the subject the issue cards commands are consumed from
+func IssueCardsSubject(prefix string) string {
+	return prefix + ".commands.issue_cards"
+}
+
+// Consumer issues the same gift cards for the issue cards commands of the other services, and answers the result to
+// the reply subject of a command if it has one. the cards of a command are issued in a batch created by the sender
+// of the command, like the cards of the bulk endpoint. the broker delivers a command at most once, so the senders
+// retry the commands they get no answer for, and a repeated command is answered with the stored result of the
+// first one instead of issuing the cards again
+type Consumer struct {
+	broker   Broker
+	service  core.GiftCardService
+	commands core.CommandRepository
+	prefix   string
+
+	mu      sync.Mutex
+	stopped bool
+	running sync.WaitGroup
+}
+
+func NewConsumer(broker Broker, service core.GiftCardService, commands core.CommandRepository,
+	prefix string) *Consumer {
+	return &Consumer{broker: broker, service: service, commands: commands, prefix: prefix}
+}
+
+// Start subscribes to the commands until the returned function is called, which waits for the command being handled
+func (c *Consumer) Start() (func(ctx context.Context) error, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	subscription, err := c.broker.Subscribe(IssueCardsSubject(c.prefix), commandsQueue,
+		func(_ context.Context, message Message) {
+			c.mu.Lock()
+			if c.stopped {
+				c.mu.Unlock()
+				return
+			}
+			c.running.Add(1)
+			c.mu.Unlock()
+			defer c.running.Done()
+			c.IssueCards(ctx, message)
+		})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return func(stopCtx context.Context) error {
+		err := subscription.Unsubscribe()
+		c.mu.Lock()
+		c.stopped = true
+		c.mu.Unlock()
+		done := make(chan struct{})
+		go func() {
+			c.running.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-stopCtx.Done():
+			cancel()
+			return stopCtx.Err()
+		}
+		cancel()
+		return err
+	}, nil
+}
+
+// IssueCards handles an issue cards command
+func (c *Consumer) IssueCards(ctx context.Context, message Message) {
+	var command dto.IssueCardsCommandDTO
+	result := dto.IssueCardsResultDTO{GiftCardsListDTO: dto.GiftCardsListDTO{Cards: []dto.GiftCardDTO{}}}
+	err := json.Unmarshal(message.Data, &command)
+	if err == nil {
+		result.CommandId = command.CommandId
+		err = command.Validate()
+	}
+	if err != nil {
+		result.Error = indraframework.BadRequestException(err.Error(), "bad request")
+		c.reply(ctx, message, result)
+		return
+	}
+	claimed, ok, err := c.commands.ClaimCommand(ctx, command.CommandId, time.Now(), commandLease)
+	if err != nil {
+		logger.WithContext(ctx).WithData(map[string]interface{}{
+			"commandId": command.CommandId,
+		}).ErrorException(err, "error while claiming an issue cards command")
+		result.Error = indraframework.InternalServerException(err.Error(), "internal error")
+		c.reply(ctx, message, result)
+		return
+	}
+	if !ok {
+		c.reply(ctx, message, c.repeated(*claimed, result))
+		return
+	}
+
+	cards, err := c.service.CreateSameMany(ctx, &command.BulkCreateSameGiftCardsDTO)
+	if cards != nil {
+		result.GiftCardsListDTO = *cards
+	}
+	log := logger.WithContext(ctx).WithData(map[string]interface{}{
+		"commandId": command.CommandId,
+		"batchId":   result.BatchId,
+		"issued":    len(result.Cards),
+	})
+	switch {
+	case err == common.InvalidCampaign:
+		result.Error = indraframework.BadRequestException(err.Error(), "bad request")
+	case err != nil:
+		log.ErrorException(err, "error while issuing the gift cards of a command")
+		result.Error = indraframework.InternalServerException(err.Error(), "internal error")
+	default:
+		log.Info("Issued the gift cards of a command")
+	}
+	c.complete(claimed, result)
+	c.reply(ctx, message, result)
+}
+
+// repeated returns the stored result of a repeated command, or a conflict while the first one is being handled
+func (c *Consumer) repeated(command dbmodel.Command, result dto.IssueCardsResultDTO) dto.IssueCardsResultDTO {
+	if !command.IsHandled() {
+		result.Error = indraframework.NewIndraException("the command is being handled", "conflict",
+			http.StatusConflict)
+		return result
+	}
+	var stored dto.IssueCardsResultDTO
+	if err := json.Unmarshal([]byte(command.Result), &stored); err != nil {
+		result.Error = indraframework.InternalServerException(err.Error(), "internal error")
+		return result
+	}
+	logger.WithData(map[string]interface{}{
+		"commandId": command.CommandId,
+	}).Info("Answered a repeated issue cards command with its stored result")
+	return stored
+}
+
+// complete stores the result of the claimed command. a command which issued no card is deleted instead, so it is
+// handled again when it is repeated. the result is stored even if the consumer is stopping meanwhile
+func (c *Consumer) complete(command *dbmodel.Command, result dto.IssueCardsResultDTO) {
+	ctx := context.Background()
+	var err error
+	if result.Error != nil && len(result.Cards) == 0 {
+		err = c.commands.DeleteCommand(ctx, *command)
+	} else {
+		var data []byte
+		if data, err = json.Marshal(result); err == nil {
+			command.Handled(string(data), time.Now())
+			err = c.commands.StoreCommand(ctx, command)
+		}
+	}
+	if err != nil {
+		logger.WithData(map[string]interface{}{
+			"commandId": command.CommandId,
+		}).ErrorException(err, "error while storing the result of an issue cards command")
+	}
+}
+
+func (c *Consumer) reply(ctx context.Context, message Message, result dto.IssueCardsResultDTO) {
+	if message.Reply == "" {
+		if result.Error != nil && result.Error.ErrorCode < 500 {
+			logger.WithData(map[string]interface{}{
+				"commandId": result.CommandId,
+			}).Warn("An issue cards command is refused: " + result.Error.Message)
+		}
+		return
+	}
+	data, err := json.Marshal(result)
+	if err == nil {
+		err = c.broker.Publish(ctx, Message{Subject: message.Reply, Data: data})
+	}
+	if err != nil {
+		logger.WithData(map[string]interface{}{
+			"commandId": result.CommandId,
+		}).ErrorException(err, "error while answering an issue cards command")
+	}
+}
@@ -0,0 +1,192 @@
+package broker_test
+
+import (
+	"context"
+	"encoding/json"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/core/logic"
+	"giftcard-engine/infrastructure/broker"
+	"giftcard-engine/infrastructure/repository/memory"
+	"giftcard-engine/infrastructure/repository/sql"
+	"giftcard-engine/infrastructure/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// createConsumerForTest starts a consumer of a gift card service which keeps the cards and the commands in memory
+func createConsumerForTest(t *testing.T) (*broker.MemoryBroker, *dbmodel.Campaign, core.CommandRepository) {
+	database := memory.NewDatabase()
+	campaign := dbmodel.NewCampaign("yalda")
+	require.Nil(t, memory.NewCampaignRepository(database).Store(context.Background(), campaign))
+	service := logic.NewGiftCardService(memory.NewGiftCardRepository(database), memory.NewBatchRepository(database),
+		search.NewDisabledGiftCardIndex(), sql.NewMapper())
+
+	commands := memory.NewCommandRepository(database)
+	b := broker.NewMemoryBroker()
+	stop, err := broker.NewConsumer(b, service, commands, "giftcard").Start()
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		assert.Empty(t, stop(context.Background()))
+		_ = b.Close()
+	})
+	return b, campaign, commands
+}
+
+// sendCommand sends the command and returns its answer
+func sendCommand(t *testing.T, b broker.Broker, command interface{}) dto.IssueCardsResultDTO {
+	answers := subscribe(t, b, "checkout.answers", "")
+	data, err := json.Marshal(command)
+	require.Nil(t, err)
+	require.Nil(t, b.Publish(context.Background(), broker.Message{
+		Subject: broker.IssueCardsSubject("giftcard"),
+		Reply:   "checkout.answers",
+		Data:    data,
+	}))
+	answers.eventually(t, 1)
+	require.Equal(t, 1, len(answers.subjects()))
+
+	var result dto.IssueCardsResultDTO
+	require.Nil(t, json.Unmarshal(answers.messages[0].Data, &result))
+	return result
+}
+
+func TestConsumerIssueCards(te *testing.T) {
+	te.Parallel()
+	expireDate := time.Now().AddDate(0, 1, 0).Format("2006-01-02")
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		b, campaign, _ := createConsumerForTest(t)
+
+		result := sendCommand(t, b, dto.IssueCardsCommandDTO{
+			CommandId: "order-12",
+			BulkCreateSameGiftCardsDTO: dto.BulkCreateSameGiftCardsDTO{
+				ExpireDate: expireDate,
+				Amount:     5000,
+				Count:      3,
+				CampaignId: uint(campaign.ID),
+				CreatedBy:  "checkout",
+			},
+		})
+
+		assert.Nil(t, result.Error)
+		assert.Equal(t, "order-12", result.CommandId)
+		assert.Equal(t, 3, len(result.Cards))
+		assert.NotZero(t, result.BatchId)
+		assert.Equal(t, int32(5000), result.Cards[0].Amount)
+	})
+
+	te.Run("with invalid command", func(t *testing.T) {
+		t.Parallel()
+		b, campaign, _ := createConsumerForTest(t)
+
+		result := sendCommand(t, b, dto.IssueCardsCommandDTO{
+			CommandId: "order-12",
+			BulkCreateSameGiftCardsDTO: dto.BulkCreateSameGiftCardsDTO{
+				ExpireDate: expireDate,
+				Amount:     5000,
+				CampaignId: uint(campaign.ID),
+			},
+		})
+
+		require.NotNil(t, result.Error)
+		assert.Equal(t, "order-12", result.CommandId)
+		assert.Equal(t, http.StatusBadRequest, result.Error.ErrorCode)
+		assert.Empty(t, result.Cards)
+	})
+
+	te.Run("with malformed command", func(t *testing.T) {
+		t.Parallel()
+		b, _, _ := createConsumerForTest(t)
+
+		result := sendCommand(t, b, "issue three cards")
+
+		require.NotNil(t, result.Error)
+		assert.Equal(t, http.StatusBadRequest, result.Error.ErrorCode)
+	})
+
+	te.Run("with invalid campaign", func(t *testing.T) {
+		t.Parallel()
+		b, _, _ := createConsumerForTest(t)
+
+		result := sendCommand(t, b, dto.IssueCardsCommandDTO{
+			CommandId: "order-12",
+			BulkCreateSameGiftCardsDTO: dto.BulkCreateSameGiftCardsDTO{
+				ExpireDate: expireDate,
+				Amount:     5000,
+				Count:      1,
+				CampaignId: 12,
+			},
+		})
+
+		require.NotNil(t, result.Error)
+		assert.Equal(t, http.StatusBadRequest, result.Error.ErrorCode)
+		assert.Equal(t, "invalid campaign", result.Error.Message)
+	})
+
+	te.Run("with repeated command", func(t *testing.T) {
+		t.Parallel()
+		b, campaign, _ := createConsumerForTest(t)
+		command := dto.IssueCardsCommandDTO{
+			CommandId: "order-12",
+			BulkCreateSameGiftCardsDTO: dto.BulkCreateSameGiftCardsDTO{
+				ExpireDate: expireDate,
+				Amount:     5000,
+				Count:      2,
+				CampaignId: uint(campaign.ID),
+			},
+		}
+
+		first := sendCommand(t, b, command)
+		repeated := sendCommand(t, b, command)
+
+		assert.Nil(t, repeated.Error)
+		assert.Equal(t, first, repeated, "a repeated command should be answered with the first result")
+	})
+
+	te.Run("with repeated failed command", func(t *testing.T) {
+		t.Parallel()
+		b, campaign, _ := createConsumerForTest(t)
+		command := dto.IssueCardsCommandDTO{
+			CommandId: "order-12",
+			BulkCreateSameGiftCardsDTO: dto.BulkCreateSameGiftCardsDTO{
+				ExpireDate: expireDate,
+				Amount:     5000,
+				Count:      1,
+				CampaignId: uint(campaign.ID) + 1,
+			},
+		}
+		require.NotNil(t, sendCommand(t, b, command).Error)
+
+		command.CampaignId = uint(campaign.ID)
+		result := sendCommand(t, b, command)
+
+		assert.Nil(t, result.Error, "a command which issued no card should be handled again")
+		assert.Equal(t, 1, len(result.Cards))
+	})
+
+	te.Run("with command being handled", func(t *testing.T) {
+		t.Parallel()
+		b, campaign, commands := createConsumerForTest(t)
+		_, _, err := commands.ClaimCommand(context.Background(), "order-12", time.Now(), time.Hour)
+		require.Nil(t, err)
+
+		result := sendCommand(t, b, dto.IssueCardsCommandDTO{
+			CommandId: "order-12",
+			BulkCreateSameGiftCardsDTO: dto.BulkCreateSameGiftCardsDTO{
+				ExpireDate: expireDate,
+				Amount:     5000,
+				Count:      1,
+				CampaignId: uint(campaign.ID),
+			},
+		})
+
+		require.NotNil(t, result.Error)
+		assert.Equal(t, http.StatusConflict, result.Error.ErrorCode)
+		assert.Empty(t, result.Cards)
+	})
+}
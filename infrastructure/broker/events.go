@@ -0,0 +1,45 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dto"
+	"time"
+)
+
+type eventPublisher struct {
+	broker Broker
+	prefix string
+}
+
+// NewEventPublisher returns the publisher of the domain events which publishes every event to the subject of its
+// name, so the other services can subscribe to the events they need, like giftcard.events.gift_card.> for the
+// events of the cards
+func NewEventPublisher(broker Broker, prefix string) core.EventPublisher {
+	return &eventPublisher{broker: broker, prefix: prefix}
+}
+
+func (p *eventPublisher) Publish(ctx context.Context, events []core.DomainEvent) error {
+	for _, event := range events {
+		data, err := json.Marshal(dto.BrokerEventDTO{
+			ID:          event.ID,
+			Event:       event.Name,
+			AggregateId: event.AggregateId,
+			OccurredAt:  event.OccurredAt.UTC().Format(time.RFC3339Nano),
+			Data:        event.Payload,
+		})
+		if err != nil {
+			return err
+		}
+		if err := p.broker.Publish(ctx, Message{Subject: EventSubject(p.prefix, event.Name), Data: data}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EventSubject returns the subject the events of the name are published to
+func EventSubject(prefix, event string) string {
+	return prefix + ".events." + event
+}
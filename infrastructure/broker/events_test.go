@@ -0,0 +1,43 @@
+package broker_test
+
+import (
+	"context"
+	"encoding/json"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/broker"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestEventPublisher(t *testing.T) {
+	t.Parallel()
+	b := broker.NewMemoryBroker()
+	defer b.Close()
+	cards := subscribe(t, b, "giftcard.events.gift_card.>", "")
+	campaigns := subscribe(t, b, "giftcard.events.campaign.*", "")
+	publisher := broker.NewEventPublisher(b, "giftcard")
+
+	err := publisher.Publish(context.Background(), []core.DomainEvent{
+		{ID: 4, Name: dbmodel.CardApprovedEvent, AggregateId: 12, OccurredAt: time.Date(2024, 3, 1, 10, 0, 0, 0,
+			time.UTC), Payload: json.RawMessage(`{"id":12,"uun":"milad"}`)},
+		{ID: 5, Name: dbmodel.CampaignCreatedEvent, AggregateId: 3, Payload: json.RawMessage(`{"id":3}`)},
+	})
+	cards.eventually(t, 1)
+	campaigns.eventually(t, 1)
+
+	assert.Empty(t, err)
+	assert.Equal(t, []string{"giftcard.events.gift_card.approved"}, cards.subjects())
+	assert.Equal(t, []string{"giftcard.events.campaign.created"}, campaigns.subjects())
+	var event dto.BrokerEventDTO
+	assert.Empty(t, json.Unmarshal(cards.messages[0].Data, &event))
+	assert.Equal(t, dto.BrokerEventDTO{
+		ID:          4,
+		Event:       dbmodel.CardApprovedEvent,
+		AggregateId: 12,
+		OccurredAt:  "2024-03-01T10:00:00Z",
+		Data:        json.RawMessage(`{"id":12,"uun":"milad"}`),
+	}, event)
+}
@@ -0,0 +1,61 @@
+package broker
+
+import (
+	"context"
+	"giftcard-engine/infrastructure/logger"
+	"sync"
+)
+
+// pendingMessages is how many messages a subscription holds while its handler is busy. the next ones are dropped,
+// like nats drops the messages of a slow consumer, so the messages are delivered at most once. the events are
+// published again from the outbox only if the publish itself fails, and the commands are retried by their senders
+const pendingMessages = 1024
+
+// inbox hands the messages of a subscription to its handler in their order
+type inbox struct {
+	subject  string
+	queue    string
+	handler  Handler
+	messages chan Message
+	done     chan struct{}
+	once     sync.Once
+}
+
+func newInbox(subject, queue string, handler Handler) *inbox {
+	i := &inbox{
+		subject:  subject,
+		queue:    queue,
+		handler:  handler,
+		messages: make(chan Message, pendingMessages),
+		done:     make(chan struct{}),
+	}
+	go i.run()
+	return i
+}
+
+func (i *inbox) run() {
+	for {
+		select {
+		case <-i.done:
+			return
+		case message := <-i.messages:
+			i.handler(context.Background(), message)
+		}
+	}
+}
+
+// deliver queues the message without waiting for the handler. a message which does not fit is lost
+func (i *inbox) deliver(message Message) {
+	select {
+	case <-i.done:
+	case i.messages <- message:
+	default:
+		logger.WithData(map[string]interface{}{
+			"subject": message.Subject,
+		}).Warn("A broker message is dropped because the subscription of " + i.subject + " is too slow")
+	}
+}
+
+func (i *inbox) stop() {
+	i.once.Do(func() { close(i.done) })
+}
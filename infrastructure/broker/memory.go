@@ -0,0 +1,108 @@
+package broker
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryBroker hands the messages to the subscriptions of the same process, so the integration of the broker can be
+// used in development and tested without a running broker
+type MemoryBroker struct {
+	mu      sync.Mutex
+	inboxes map[int]*inbox
+	next    int
+	turns   map[string]int // the next member of every queue
+	closed  bool
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{inboxes: map[int]*inbox{}, turns: map[string]int{}}
+}
+
+// Publish hands the message to every matching subscription which is not in a queue, and to one matching
+// subscription of every queue in turn
+func (b *MemoryBroker) Publish(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := validSubject(message.Subject, false); err != nil {
+		return err
+	}
+	message.Data = append([]byte(nil), message.Data...)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrClosed
+	}
+	ids := make([]int, 0, len(b.inboxes))
+	for id := range b.inboxes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	queues := map[string][]*inbox{}
+	var queueNames []string
+	for _, id := range ids {
+		i := b.inboxes[id]
+		if !matches(i.subject, message.Subject) {
+			continue
+		}
+		if i.queue == "" {
+			i.deliver(message)
+			continue
+		}
+		if _, ok := queues[i.queue]; !ok {
+			queueNames = append(queueNames, i.queue)
+		}
+		queues[i.queue] = append(queues[i.queue], i)
+	}
+	for _, name := range queueNames {
+		members := queues[name]
+		turn := b.turns[name]
+		b.turns[name] = turn + 1
+		members[turn%len(members)].deliver(message)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(subject, queue string, handler Handler) (Subscription, error) {
+	if err := validSubject(subject, true); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	b.next++
+	b.inboxes[b.next] = newInbox(subject, queue, handler)
+	return &memorySubscription{broker: b, id: b.next}, nil
+}
+
+// Close stops every subscription. the messages which are not handled yet are lost
+func (b *MemoryBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for id, i := range b.inboxes {
+		i.stop()
+		delete(b.inboxes, id)
+	}
+	return nil
+}
+
+type memorySubscription struct {
+	broker *MemoryBroker
+	id     int
+}
+
+func (s *memorySubscription) Unsubscribe() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	if i, ok := s.broker.inboxes[s.id]; ok {
+		i.stop()
+		delete(s.broker.inboxes, s.id)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package broker
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/logger"
+	"github.com/nats-io/nats.go"
+	"strings"
+	"time"
+)
+
+// NATSBroker publishes and subscribes through a connection of the nats client. a publish flushes the connection,
+// which waits for the answer of a ping sent after the message, so the server has surely got the message when it
+// returns. the lost connection is made again in the background with the subscriptions, and the publishes fail with
+// ErrNotConnected meanwhile instead of being buffered, so the outbox relays them again. core nats delivers the
+// messages at most once: the messages published while a subscription is disconnected, or dropped because it is too
+// slow, are not delivered to it again
+type NATSBroker struct {
+	conn    *nats.Conn
+	timeout time.Duration
+}
+
+// DialNATS connects to the server of the url of the configuration, with tls when the configuration is secure. the
+// name is shown by the server as the name of the client
+func DialNATS(ctx context.Context, config configuration.BrokerConfiguration, name string) (*NATSBroker, error) {
+	options := []nats.Option{
+		nats.Name(name),
+		nats.Timeout(config.Timeout),
+		nats.ReconnectWait(config.ReconnectWait),
+		nats.MaxReconnects(-1),
+		nats.ReconnectBufSize(-1),
+		nats.NoCallbacksAfterClientClose(),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			logger.WarnException(err, "The connection to the broker is lost")
+		}),
+		nats.ReconnectHandler(func(*nats.Conn) {
+			logger.Info("Connected to the broker again")
+		}),
+		nats.ErrorHandler(func(_ *nats.Conn, subscription *nats.Subscription, err error) {
+			data := map[string]interface{}{}
+			if subscription != nil {
+				data["subject"] = subscription.Subject
+			}
+			logger.WithData(data).WarnException(err, "The broker reported an error")
+		}),
+	}
+	if config.Secure() {
+		options = append(options, nats.Secure(&tls.Config{MinVersion: tls.VersionTLS12}))
+		if config.CaFile != "" {
+			options = append(options, nats.RootCAs(config.CaFile))
+		}
+		if config.CertFile != "" {
+			options = append(options, nats.ClientCert(config.CertFile, config.KeyFile))
+		}
+	}
+
+	type dialed struct {
+		conn *nats.Conn
+		err  error
+	}
+	result := make(chan dialed, 1)
+	go func() {
+		conn, err := nats.Connect(config.Url, options...)
+		result <- dialed{conn: conn, err: err}
+	}()
+	select {
+	case d := <-result:
+		if d.err != nil {
+			return nil, d.err
+		}
+		return &NATSBroker{conn: d.conn, timeout: config.Timeout}, nil
+	case <-ctx.Done():
+		go func() {
+			if d := <-result; d.conn != nil {
+				d.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+func (b *NATSBroker) Publish(ctx context.Context, message Message) error {
+	if err := validSubject(message.Subject, false); err != nil {
+		return err
+	}
+	if message.Reply != "" {
+		if err := validSubject(message.Reply, false); err != nil {
+			return err
+		}
+	}
+	if err := b.connected(); err != nil {
+		return err
+	}
+	if maxPayload := b.conn.MaxPayload(); maxPayload > 0 && int64(len(message.Data)) > maxPayload {
+		return fmt.Errorf("the message of %d bytes is more than the %d bytes the broker accepts", len(message.Data),
+			maxPayload)
+	}
+	err := b.conn.PublishMsg(&nats.Msg{Subject: message.Subject, Reply: message.Reply, Data: message.Data})
+	if err != nil {
+		return b.translate(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	defer cancel()
+	if err := b.conn.FlushWithContext(ctx); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("the broker did not confirm the message in time: %w", err)
+		}
+		return b.translate(err)
+	}
+	return nil
+}
+
+func (b *NATSBroker) Subscribe(subject, queue string, handler Handler) (Subscription, error) {
+	if err := validSubject(subject, true); err != nil {
+		return nil, err
+	}
+	if strings.ContainsAny(queue, " \t\r\n") {
+		return nil, fmt.Errorf("%q is not a valid queue", queue)
+	}
+	if b.conn.IsClosed() {
+		return nil, ErrClosed
+	}
+	subscription, err := b.conn.QueueSubscribe(subject, queue, func(msg *nats.Msg) {
+		handler(context.Background(), Message{Subject: msg.Subject, Reply: msg.Reply, Data: msg.Data})
+	})
+	if err != nil {
+		return nil, b.translate(err)
+	}
+	// the pending messages are limited like the subscriptions of the memory broker. the next ones are dropped and
+	// reported to the error handler
+	if err := subscription.SetPendingLimits(pendingMessages, -1); err != nil {
+		_ = subscription.Unsubscribe()
+		return nil, err
+	}
+	return &natsSubscription{subscription: subscription}, nil
+}
+
+// Close closes the connection and stops every subscription
+func (b *NATSBroker) Close() error {
+	b.conn.Close()
+	return nil
+}
+
+// connected returns the error of a publish while there is no connection
+func (b *NATSBroker) connected() error {
+	switch {
+	case b.conn.IsClosed():
+		return ErrClosed
+	case !b.conn.IsConnected():
+		return ErrNotConnected
+	}
+	return nil
+}
+
+// translate returns the errors of the broker for the errors of the client about its connection
+func (b *NATSBroker) translate(err error) error {
+	switch err {
+	case nats.ErrConnectionClosed:
+		return ErrClosed
+	case nats.ErrReconnectBufExceeded, nats.ErrConnectionReconnecting:
+		return ErrNotConnected
+	}
+	return err
+}
+
+type natsSubscription struct {
+	subscription *nats.Subscription
+}
+
+// Unsubscribe stops the subscription. the subscriptions of a closed broker are already stopped
+func (s *natsSubscription) Unsubscribe() error {
+	err := s.subscription.Unsubscribe()
+	if err == nats.ErrConnectionClosed || err == nats.ErrBadSubscription {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,333 @@
+package broker_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"giftcard-engine/infrastructure/broker"
+	"giftcard-engine/infrastructure/config/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// natsServer is a nats server of the operations the client uses. it hands a message to every subscription of its
+// subject or of a prefix of it ending with >, regardless of the queues
+type natsServer struct {
+	listener net.Listener
+	password string
+	tls      *tls.Config // the connections are upgraded to tls after the greeting when it is set
+
+	mu       sync.Mutex
+	clients  map[net.Conn]*natsClient
+	connects []map[string]interface{}
+}
+
+type natsClient struct {
+	mu   sync.Mutex
+	conn net.Conn
+	subs map[string]string // the subjects by their sids
+}
+
+func (c *natsClient) write(format string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = fmt.Fprintf(c.conn, format, args...)
+}
+
+func newNatsServer(t *testing.T, password string) *natsServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	s := &natsServer{listener: listener, password: password, clients: map[net.Conn]*natsClient{}}
+	t.Cleanup(func() {
+		_ = listener.Close()
+		s.drop()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *natsServer) url(user, password string) string {
+	return fmt.Sprintf("nats://%s:%s@%s", user, password, s.listener.Addr())
+}
+
+// drop closes the connections of the clients
+func (s *natsServer) drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.clients {
+		_ = conn.Close()
+		delete(s.clients, conn)
+	}
+}
+
+func (s *natsServer) connectOptions() []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connects
+}
+
+func (s *natsServer) serve(conn net.Conn) {
+	client := &natsClient{conn: conn, subs: map[string]string{}}
+	s.mu.Lock()
+	s.clients[conn] = client
+	s.mu.Unlock()
+	defer conn.Close()
+	if s.tls == nil {
+		client.write("INFO {\"server_id\":\"test\",\"max_payload\":1024}\r\n")
+	} else {
+		client.write("INFO {\"server_id\":\"test\",\"max_payload\":1024,\"tls_required\":true}\r\n")
+		secure := tls.Server(conn, s.tls)
+		if err := secure.Handshake(); err != nil {
+			return
+		}
+		client.mu.Lock()
+		client.conn = secure
+		client.mu.Unlock()
+	}
+
+	reader := bufio.NewReader(client.conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "CONNECT":
+			var options map[string]interface{}
+			_ = json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")), &options)
+			s.mu.Lock()
+			s.connects = append(s.connects, options)
+			s.mu.Unlock()
+			if options["pass"] != s.password {
+				client.write("-ERR 'Authorization Violation'\r\n")
+				return
+			}
+		case "PING":
+			client.write("PONG\r\n")
+		case "SUB":
+			client.mu.Lock()
+			client.subs[fields[len(fields)-1]] = fields[1]
+			client.mu.Unlock()
+		case "UNSUB":
+			client.mu.Lock()
+			delete(client.subs, fields[1])
+			client.mu.Unlock()
+		case "PUB":
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			reply := ""
+			if len(fields) == 4 {
+				reply = fields[2] + " "
+			}
+			s.route(fields[1], reply, payload[:size])
+		}
+	}
+}
+
+func (s *natsServer) route(subject, reply string, payload []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, client := range s.clients {
+		client.mu.Lock()
+		var sids []string
+		for sid, pattern := range client.subs {
+			if pattern == subject || (strings.HasSuffix(pattern, ">") &&
+				strings.HasPrefix(subject, strings.TrimSuffix(pattern, ">"))) {
+				sids = append(sids, sid)
+			}
+		}
+		client.mu.Unlock()
+		for _, sid := range sids {
+			client.write("MSG %s %s %s%d\r\n%s\r\n", subject, sid, reply, len(payload), payload)
+		}
+	}
+}
+
+// serveTLS makes the server upgrade its connections to tls with a self signed certificate of 127.0.0.1, and returns
+// the file of the certificate which the clients verify the server with
+func (s *natsServer) serveTLS(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "nats"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	require.Nil(t, ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}),
+		0600))
+	s.mu.Lock()
+	s.tls = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{certificate}, PrivateKey: key}}}
+	s.mu.Unlock()
+	return file
+}
+
+func natsConfiguration(url string) configuration.BrokerConfiguration {
+	return configuration.BrokerConfiguration{
+		Driver:        "nats",
+		Url:           url,
+		Timeout:       time.Second,
+		ReconnectWait: 10 * time.Millisecond,
+	}
+}
+
+func dialForTest(t *testing.T, url string) *broker.NATSBroker {
+	b, err := broker.DialNATS(context.Background(), natsConfiguration(url), "giftcard-engine")
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = b.Close() })
+	return b
+}
+
+func TestNATSBroker(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		server := newNatsServer(t, "secret")
+		b := dialForTest(t, server.url("engine", "secret"))
+		c := subscribe(t, b, "giftcard.events.>", "")
+
+		err := b.Publish(context.Background(), broker.Message{Subject: "giftcard.events.gift_card.issued",
+			Reply: "checkout.answers", Data: []byte("issued\r\ncard")})
+		c.eventually(t, 1)
+
+		assert.Empty(t, err)
+		assert.Equal(t, "giftcard.events.gift_card.issued", c.messages[0].Subject)
+		assert.Equal(t, "checkout.answers", c.messages[0].Reply)
+		assert.Equal(t, "issued\r\ncard", string(c.messages[0].Data))
+		options := server.connectOptions()[0]
+		assert.Equal(t, "giftcard-engine", options["name"])
+		assert.Equal(t, "engine", options["user"])
+		assert.Equal(t, false, options["verbose"])
+	})
+
+	te.Run("with tls", func(t *testing.T) {
+		t.Parallel()
+		server := newNatsServer(t, "secret")
+		config := natsConfiguration(strings.Replace(server.url("engine", "secret"), "nats://", "tls://", 1))
+		config.CaFile = server.serveTLS(t)
+		b, err := broker.DialNATS(context.Background(), config, "giftcard-engine")
+		require.Nil(t, err)
+		t.Cleanup(func() { _ = b.Close() })
+		c := subscribe(t, b, "giftcard.events.>", "")
+
+		publish(t, b, "giftcard.events.gift_card.issued")
+		c.eventually(t, 1)
+
+		assert.Equal(t, []string{"giftcard.events.gift_card.issued"}, c.subjects())
+	})
+
+	te.Run("with untrusted tls server", func(t *testing.T) {
+		t.Parallel()
+		server := newNatsServer(t, "secret")
+		server.serveTLS(t)
+
+		_, err := broker.DialNATS(context.Background(),
+			natsConfiguration(strings.Replace(server.url("engine", "secret"), "nats://", "tls://", 1)), "engine")
+
+		require.Error(t, err, "the certificate of the server should be verified")
+	})
+
+	te.Run("with refused connection", func(t *testing.T) {
+		t.Parallel()
+		server := newNatsServer(t, "secret")
+
+		_, err := broker.DialNATS(context.Background(), natsConfiguration(server.url("engine", "wrong")), "engine")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Authorization Violation")
+	})
+
+	te.Run("with lost connection", func(t *testing.T) {
+		t.Parallel()
+		server := newNatsServer(t, "secret")
+		b := dialForTest(t, server.url("engine", "secret"))
+		c := subscribe(t, b, "giftcard.events.>", "")
+
+		server.drop()
+		assert.Eventually(t, func() bool {
+			return b.Publish(context.Background(), broker.Message{Subject: "giftcard.events.campaign.created"}) == nil
+		}, time.Second, 10*time.Millisecond, "the connection should be made again")
+		c.eventually(t, 1)
+
+		assert.Equal(t, []string{"giftcard.events.campaign.created"}, c.subjects(),
+			"the subscriptions should be sent again")
+	})
+
+	te.Run("with unsubscribed subscription", func(t *testing.T) {
+		t.Parallel()
+		server := newNatsServer(t, "secret")
+		b := dialForTest(t, server.url("engine", "secret"))
+		c := &collector{}
+		subscription, err := b.Subscribe("giftcard.events.>", "", c.handle)
+		require.Nil(t, err)
+		other := subscribe(t, b, "giftcard.>", "")
+
+		assert.Empty(t, subscription.Unsubscribe())
+		publish(t, b, "giftcard.events.gift_card.issued")
+		other.eventually(t, 1)
+
+		assert.Empty(t, c.subjects())
+	})
+
+	te.Run("with large message", func(t *testing.T) {
+		t.Parallel()
+		server := newNatsServer(t, "secret")
+		b := dialForTest(t, server.url("engine", "secret"))
+
+		err := b.Publish(context.Background(), broker.Message{Subject: "giftcard.events", Data: make([]byte, 2048)})
+
+		assert.EqualError(t, err, "the message of 2048 bytes is more than the 1024 bytes the broker accepts")
+	})
+
+	te.Run("with closed broker", func(t *testing.T) {
+		t.Parallel()
+		server := newNatsServer(t, "secret")
+		b := dialForTest(t, server.url("engine", "secret"))
+
+		assert.Empty(t, b.Close())
+
+		assert.Equal(t, broker.ErrClosed, b.Publish(context.Background(), broker.Message{Subject: "giftcard.events"}))
+	})
+}
@@ -1,16 +1,27 @@
 package configuration
 
 import (
-	"log"
-	"os"
-	"strconv"
+	"fmt"
+	"giftcard-engine/utils"
+	"github.com/sirupsen/logrus"
+	"net/url"
+	"strings"
+	"time"
 )
 
 type Configurations struct {
 	Server            ServerConfiguration
 	ConnectionStrings DatabaseConfiguration
-	ElasticUrl        string
-	ElasticHost       string
+	Search            SearchConfiguration
+	Tracing           TracingConfiguration
+	Timeout           TimeoutConfiguration
+	Health            HealthConfiguration
+	Logging           LoggingConfiguration
+	Security          SecurityConfiguration
+	Webhooks          WebhookConfiguration
+	Outbox            OutboxConfiguration
+	Broker            BrokerConfiguration
+	Features          FeatureConfiguration
 	ServiceName       string
 	Environment       string
 }
@@ -27,29 +38,217 @@ func (l Configurations) IsDevelopment() bool {
 	return l.Environment == "Development" || l.Environment == "development"
 }
 
-func CreateDefaultConfiguration() Configurations {
-	port, err := strconv.Atoi(os.Getenv("GIFT_CARD_SERVER_PORT"))
-	if err != nil {
-		log.Fatalln("The port number is not valid")
-	}
-
-	outSideOfContainerPort, err := strconv.Atoi(os.Getenv("GIFT_CARD_CONTAINER_PORT"))
-	if err != nil {
-		log.Fatalln("The container port number is not valid")
-	}
-
+// Defaults returns the configurations which the file, the environment and the flags are applied on
+func Defaults() Configurations {
 	return Configurations{
 		Server: ServerConfiguration{
-			Port:                   port,
-			OutSideOfContainerPort: outSideOfContainerPort,
-			OutSideOfContainerHost: os.Getenv("GIFT_CARD_CONTAINER_NAME"),
+			Port:                   8080,
+			OutSideOfContainerPort: 8080,
+			OutSideOfContainerHost: "localhost",
+			GrpcPort:               9090,
+			ReadHeaderTimeout:      10 * time.Second,
+			IdleTimeout:            2 * time.Minute,
+			ShutdownTimeout:        30 * time.Second,
+			ShutdownDelay:          5 * time.Second,
+			StopTimeout:            10 * time.Second,
 		},
 		ConnectionStrings: DatabaseConfiguration{
-			DefaultConnection: os.Getenv("ConnectionStrings__DefaultConnection"),
+			Driver:           "mssql",
+			MigrationTimeout: 5 * time.Minute,
+			Pool: PoolConfiguration{
+				MaxOpen: 10,
+				MaxIdle: 10,
+			},
+		},
+		Search: SearchConfiguration{
+			Index: "giftcards",
+		},
+		Tracing: TracingConfiguration{
+			SampleRatio: 1,
+		},
+		Timeout: TimeoutConfiguration{
+			Default: 30 * time.Second,
+			Routes:  map[string]time.Duration{},
+		},
+		Health: HealthConfiguration{
+			Timeout:        5 * time.Second,
+			MaxGoroutines:  10000,
+			DiskPath:       "/",
+			DiskMinFree:    0.1,
+			PoolSaturation: 0.9,
+		},
+		Security: SecurityConfiguration{
+			SecretCodeLength: utils.GiftCardSecretKeyLength,
+			PublicCodeLength: utils.GiftCardPublicKeyLength,
+		},
+		Webhooks: WebhookConfiguration{
+			PollInterval:  5 * time.Second,
+			Timeout:       10 * time.Second,
+			BatchSize:     20,
+			MaxAttempts:   8,
+			RetryDelay:    30 * time.Second,
+			MaxRetryDelay: time.Hour,
+			ExpirySweep:   15 * time.Minute,
+		},
+		Outbox: OutboxConfiguration{
+			PollInterval:  time.Second,
+			Timeout:       30 * time.Second,
+			BatchSize:     100,
+			MaxAttempts:   50,
+			RetryDelay:    5 * time.Second,
+			MaxRetryDelay: 5 * time.Minute,
+			Retention:     7 * 24 * time.Hour,
+		},
+		Broker: BrokerConfiguration{
+			SubjectPrefix: "giftcard",
+			Timeout:       10 * time.Second,
+			ReconnectWait: 2 * time.Second,
+			Commands:      true,
 		},
-		Environment: os.Getenv("GIFT_CARD_ENVIRONMENT"),
-		ElasticHost: os.Getenv("GIFT_CARD_ELASTIC_HOST"),
-		ElasticUrl:  os.Getenv("GIFT_CARD_ELASTIC_URL"),
-		ServiceName: os.Getenv("APP_NAME"),
+		Features: FeatureConfiguration{
+			Swagger: true,
+		},
+		ServiceName: "giftcard-engine",
+		Environment: "Development",
+	}
+}
+
+// Validate returns every rule which the configurations break as Errors
+func (l Configurations) Validate() error {
+	var errs Errors
+	check := func(valid bool, key, format string, args ...interface{}) {
+		if !valid {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(validPort(l.Server.Port), "server.port", "%d is not a port between 1 and 65535", l.Server.Port)
+	check(validPort(l.Server.OutSideOfContainerPort), "server.container_port",
+		"%d is not a port between 1 and 65535", l.Server.OutSideOfContainerPort)
+	check(l.Server.OutSideOfContainerHost != "", "server.container_host", "is required")
+	check(l.Server.GrpcPort == 0 || validPort(l.Server.GrpcPort), "server.grpc_port",
+		"%d is not a port between 1 and 65535, or zero to disable the grpc server", l.Server.GrpcPort)
+	check(l.Server.GrpcPort != l.Server.Port, "server.grpc_port", "%d is the port of the http server",
+		l.Server.GrpcPort)
+	check(l.Server.StopTimeout > 0, "server.stop_timeout", "should be more than zero")
+
+	database := l.ConnectionStrings
+	check(knownDriver(database.Driver), "database.driver", "%q is not one of %s", database.Driver,
+		strings.Join(drivers, ", "))
+	check(database.Driver == "memory" || database.DefaultConnection != "", "database.connection",
+		"is required for the %s driver", database.Driver)
+	check(database.Pool.MaxOpen >= 0, "database.pool.max_open", "should not be negative")
+	check(database.Pool.MaxIdle >= 0, "database.pool.max_idle", "should not be negative")
+	check(database.Pool.MaxOpen <= 0 || database.Pool.MaxIdle <= database.Pool.MaxOpen, "database.pool.max_idle",
+		"%d is more than the %d open connections", database.Pool.MaxIdle, database.Pool.MaxOpen)
+
+	check(l.Health.Timeout > 0, "health.timeout", "should be more than zero")
+	check(l.Health.MaxGoroutines >= 0, "health.max_goroutines", "should not be negative")
+	check(l.Health.DiskMinFree >= 0 && l.Health.DiskMinFree < 1, "health.disk_min_free",
+		"%v is not a number from 0 to 1", l.Health.DiskMinFree)
+	check(l.Health.PoolSaturation > 0 && l.Health.PoolSaturation <= 1, "health.pool_saturation",
+		"%v is not a number between 0 and 1", l.Health.PoolSaturation)
+	l.Health.validateDependencies(check)
+	check(l.Search.Url == "" || l.Search.Index != "", "search.index", "is required when the search url is set")
+	check(l.Tracing.SampleRatio >= 0 && l.Tracing.SampleRatio <= 1, "tracing.sample_ratio",
+		"%v is not a number between 0 and 1", l.Tracing.SampleRatio)
+
+	_, err := logrus.ParseLevel(l.Logging.Level)
+	check(l.Logging.Level == "" || err == nil, "logging.level",
+		"%q is not one of trace, debug, info, warning, error, fatal or panic", l.Logging.Level)
+
+	check(validKeyLength(l.Security.SecretCodeLength), "security.secret_code_length",
+		"%d is not between %d and %d", l.Security.SecretCodeLength, utils.MinGiftCardKeyLength,
+		utils.MaxGiftCardKeyLength)
+	check(validKeyLength(l.Security.PublicCodeLength), "security.public_code_length",
+		"%d is not between %d and %d", l.Security.PublicCodeLength, utils.MinGiftCardKeyLength,
+		utils.MaxGiftCardKeyLength)
+
+	webhooks := l.Webhooks
+	check(webhooks.PollInterval > 0, "webhooks.poll_interval", "should be more than zero")
+	check(webhooks.Timeout > 0, "webhooks.timeout", "should be more than zero")
+	check(webhooks.BatchSize > 0, "webhooks.batch_size", "should be more than zero")
+	check(webhooks.MaxAttempts > 0, "webhooks.max_attempts", "should be more than zero")
+	check(webhooks.RetryDelay > 0, "webhooks.retry_delay", "should be more than zero")
+	check(webhooks.MaxRetryDelay >= webhooks.RetryDelay, "webhooks.max_retry_delay",
+		"%v is less than the %v retry delay", webhooks.MaxRetryDelay, webhooks.RetryDelay)
+	check(webhooks.ExpirySweep >= 0, "webhooks.expiry_sweep", "should not be negative")
+
+	outbox := l.Outbox
+	check(outbox.PollInterval > 0, "outbox.poll_interval", "should be more than zero")
+	check(outbox.Timeout > 0, "outbox.timeout", "should be more than zero")
+	check(outbox.BatchSize > 0, "outbox.batch_size", "should be more than zero")
+	check(outbox.MaxAttempts > 0, "outbox.max_attempts", "should be more than zero")
+	check(outbox.RetryDelay > 0, "outbox.retry_delay", "should be more than zero")
+	check(outbox.MaxRetryDelay >= outbox.RetryDelay, "outbox.max_retry_delay",
+		"%v is less than the %v retry delay", outbox.MaxRetryDelay, outbox.RetryDelay)
+	check(outbox.Retention >= 0, "outbox.retention", "should not be negative")
+
+	broker := l.Broker
+	check(knownBroker(broker.Driver), "broker.driver", "%q is not one of %s", broker.Driver,
+		strings.Join(brokers[1:], ", "))
+	check(broker.Driver != "nats" || validBrokerUrl(broker.Url), "broker.url",
+		"%q is not a nats url like nats://nats:4222 or tls://nats:4222", redactUrl(broker.Url))
+	check((broker.CertFile == "") == (broker.KeyFile == ""), "broker.cert_file",
+		"the client certificate and its key should be set together")
+	check(validSubjectToken(broker.SubjectPrefix), "broker.subject_prefix",
+		"%q is not a subject token without dots, spaces and wildcards", broker.SubjectPrefix)
+	check(broker.Timeout > 0, "broker.timeout", "should be more than zero")
+	check(broker.ReconnectWait > 0, "broker.reconnect_wait", "should be more than zero")
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Errors lists every problem of the configurations, so they are fixed at once instead of one run at a time
+type Errors []error
+
+func (e Errors) Error() string {
+	problems := make([]string, len(e))
+	for i, err := range e {
+		problems[i] = "\n  - " + err.Error()
+	}
+	return "the configuration is not valid:" + strings.Join(problems, "")
+}
+
+// brokers are the known broker drivers. the empty one disables the broker
+var brokers = []string{"", "nats", "memory"}
+
+func knownBroker(driver string) bool {
+	for _, known := range brokers {
+		if driver == known {
+			return true
+		}
 	}
+	return false
+}
+
+func validBrokerUrl(text string) bool {
+	parsed, err := url.Parse(text)
+	return err == nil && (parsed.Scheme == "nats" || parsed.Scheme == "tls") && parsed.Host != ""
+}
+
+func validSubjectToken(token string) bool {
+	return token != "" && !strings.ContainsAny(token, ".*> \t\r\n")
+}
+
+var drivers = []string{"mssql", "postgres", "mysql", "sqlite3", "memory"}
+
+func knownDriver(driver string) bool {
+	for _, known := range drivers {
+		if driver == known {
+			return true
+		}
+	}
+	return false
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+func validKeyLength(length int) bool {
+	return length >= utils.MinGiftCardKeyLength && length <= utils.MaxGiftCardKeyLength
 }
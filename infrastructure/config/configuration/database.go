@@ -1,5 +1,22 @@
 package configuration
 
+import "time"
+
 type DatabaseConfiguration struct {
+	// Driver is the sql dialect of the connection: mssql, postgres, mysql or sqlite3.
+	// memory keeps the data in the process instead, for development and integration tests
+	Driver            string
 	DefaultConnection string
+	// Migrate applies the pending migrations on startup instead of only checking the schema version
+	Migrate bool
+	// MigrationTimeout bounds the wait for the schema lock and the migrations on startup
+	MigrationTimeout time.Duration
+	Pool             PoolConfiguration
+}
+
+type PoolConfiguration struct {
+	MaxOpen     int           // open connections of the pool. zero is unlimited. default is 10
+	MaxIdle     int           // connections kept open while they are not used. default is 10
+	MaxLifetime time.Duration // connections older than this are closed. zero keeps them open
+	MaxIdleTime time.Duration // idle connections older than this are closed. zero keeps them open
 }
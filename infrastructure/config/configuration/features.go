@@ -0,0 +1,5 @@
+package configuration
+
+type FeatureConfiguration struct {
+	Swagger bool // serves the swagger ui of the api. default is true
+}
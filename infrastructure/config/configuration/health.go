@@ -0,0 +1,105 @@
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type HealthConfiguration struct {
+	Timeout  time.Duration // how long a health checker may take before it is unhealthy. default is 5s
+	CacheFor time.Duration // how long the results of the dependencies are reused. zero checks them on every probe
+
+	MaxGoroutines  int     // the readiness degrades with more goroutines, which may be leaked. zero is no limit
+	DiskPath       string  // path of the disk whose free space is checked. the disk is not checked when it is empty
+	DiskMinFree    float64 // share of the disk which should be free. default is 0.1
+	PoolSaturation float64 // share of the open database connections in use which degrades the health. default is 0.9
+
+	// Dependencies are the http services which are checked by the readiness
+	Dependencies []HealthDependency
+}
+
+// HealthDependency is an http service whose endpoint is checked by the readiness
+type HealthDependency struct {
+	Name           string
+	Url            string
+	ExpectedStatus int           // the status of a healthy response. default is any 2xx status
+	Timeout        time.Duration // default is the timeout of the health
+	// Critical dependencies make the service unhealthy when they fail, the others only degrade it
+	Critical bool
+}
+
+// healthDependencyJson is the form of the dependencies in the files and the environment, like
+// [{"name": "wallet", "url": "http://wallet/health", "expected_status": 200, "timeout": "2s", "critical": true}]
+type healthDependencyJson struct {
+	Name           string `json:"name"`
+	Url            string `json:"url"`
+	ExpectedStatus int    `json:"expected_status,omitempty"`
+	Timeout        string `json:"timeout,omitempty"`
+	Critical       bool   `json:"critical"`
+}
+
+func parseHealthDependencies(value string) ([]HealthDependency, error) {
+	var items []healthDependencyJson
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		return nil, fmt.Errorf("is not a json list of dependencies like "+
+			`[{"name": "wallet", "url": "http://wallet/health", "timeout": "2s", "critical": true}]: %v`, err)
+	}
+	dependencies := make([]HealthDependency, len(items))
+	for i, item := range items {
+		dependencies[i] = HealthDependency{Name: item.Name, Url: item.Url, ExpectedStatus: item.ExpectedStatus,
+			Critical: item.Critical}
+		if item.Timeout == "" {
+			continue
+		}
+		timeout, err := time.ParseDuration(item.Timeout)
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("the timeout of the dependency %q is not a non negative duration like 2s",
+				item.Name)
+		}
+		dependencies[i].Timeout = timeout
+	}
+	return dependencies, nil
+}
+
+func formatHealthDependencies(dependencies []HealthDependency) string {
+	items := make([]healthDependencyJson, len(dependencies))
+	for i, dependency := range dependencies {
+		items[i] = healthDependencyJson{Name: dependency.Name, Url: dependency.Url,
+			ExpectedStatus: dependency.ExpectedStatus, Critical: dependency.Critical}
+		if dependency.Timeout > 0 {
+			items[i].Timeout = dependency.Timeout.String()
+		}
+	}
+	text, _ := json.Marshal(items)
+	return string(text)
+}
+
+// validateDependencies checks the dependencies with the check of Validate
+func (h HealthConfiguration) validateDependencies(check func(valid bool, key, format string, args ...interface{})) {
+	names := map[string]bool{}
+	for i, dependency := range h.Dependencies {
+		key := fmt.Sprintf("health.dependencies[%d]", i)
+		check(dependency.Name != "", key, "the name is required")
+		check(!names[dependency.Name], key, "the name %q is repeated", dependency.Name)
+		names[dependency.Name] = true
+		parsed, err := url.Parse(dependency.Url)
+		check(err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "", key,
+			"%q is not an http url", dependency.Url)
+		check(dependency.ExpectedStatus == 0 || (dependency.ExpectedStatus >= 100 && dependency.ExpectedStatus < 600),
+			key, "%d is not an http status", dependency.ExpectedStatus)
+	}
+}
+
+// maskHealthDependencies masks the passwords of the urls of the dependencies
+func maskHealthDependencies(text string) string {
+	dependencies, err := parseHealthDependencies(text)
+	if err != nil {
+		return masked
+	}
+	for i := range dependencies {
+		dependencies[i].Url = redactUrl(dependencies[i].Url)
+	}
+	return formatHealthDependencies(dependencies)
+}
@@ -0,0 +1,211 @@
+package configuration
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+)
+
+// FileEnv is the environment variable of the configuration file when the -config flag is not given
+const FileEnv = "GIFT_CARD_CONFIG_FILE"
+
+// value is the text of a setting and where it was read from, for the errors
+type value struct {
+	text   string
+	source string
+}
+
+// Loader merges the defaults, the configuration file, the environment and the flags, each one overriding the
+// ones before it. the file is yaml or json, with the keys of the settings nested like
+//
+//	server:
+//	  port: 8080
+//	database:
+//	  pool:
+//	    max_open: 20
+//
+// the empty environment variables are ignored, so an empty line of an env file does not clear a setting
+type Loader struct {
+	flags *flag.FlagSet
+	file  *string
+}
+
+func NewLoader() *Loader {
+	return &Loader{}
+}
+
+// BindFlags adds the -config flag and a flag for every setting, like -server.port, to the flags of the command.
+// the flags should be parsed before Load
+func (l *Loader) BindFlags(flags *flag.FlagSet) {
+	l.flags = flags
+	l.file = flags.String("config", "", "yaml or json configuration file. default is $"+FileEnv)
+	for _, s := range settings {
+		flags.String(s.key, "", fmt.Sprintf("%s ($%s)", s.usage, s.envName()))
+	}
+}
+
+// Load returns the merged configurations, or Errors with every setting which could not be read or is not valid
+func (l *Loader) Load() (Configurations, error) {
+	values := map[string]value{}
+	var errs Errors
+
+	if file := l.File(); file != "" {
+		if err := readFile(file, values); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, s := range settings {
+		if text, ok := os.LookupEnv(s.envName()); ok && text != "" {
+			values[s.key] = value{text: text, source: "$" + s.envName()}
+		}
+	}
+	if l.flags != nil {
+		l.flags.Visit(func(f *flag.Flag) {
+			if f.Name != "config" {
+				values[f.Name] = value{text: f.Value.String(), source: "the -" + f.Name + " flag"}
+			}
+		})
+	}
+
+	configurations := Defaults()
+	for _, s := range settings {
+		v, ok := values[s.key]
+		if !ok {
+			continue
+		}
+		if err := s.apply(&configurations, strings.TrimSpace(v.text)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v, read from %s", s.key, err, v.source))
+		}
+	}
+	if err := configurations.Validate(); err != nil {
+		errs = append(errs, err.(Errors)...)
+	}
+	if len(errs) > 0 {
+		return configurations, errs
+	}
+	return configurations, nil
+}
+
+// File returns the configuration file of the -config flag or the environment. it is empty when there is none
+func (l *Loader) File() string {
+	if l.file != nil && *l.file != "" {
+		return *l.file
+	}
+	return os.Getenv(FileEnv)
+}
+
+// readFile adds the settings of the yaml or json file to the values. json is read as yaml, which it is a subset of
+func readFile(name string, values map[string]value) error {
+	content, err := ioutil.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("reading the configuration file: %w", err)
+	}
+	var document map[string]interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return fmt.Errorf("parsing the configuration file %s: %w", name, err)
+	}
+
+	var unknown []string
+	flatten("", document, func(key, text string) {
+		if !known(key) {
+			unknown = append(unknown, key)
+			return
+		}
+		values[key] = value{text: text, source: "the file " + name}
+	})
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("the configuration file %s has unknown settings: %s", name, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// flatten joins the nested keys of the file with dots. the structured settings are turned into json, the maps of
+// the other settings, like the route timeouts, into the comma separated key=value text of their environment
+// variable and the lists into comma separated items
+func flatten(prefix string, node interface{}, add func(key, text string)) {
+	if s, ok := lookup(prefix); ok && s.structured {
+		text, _ := json.Marshal(jsonNode(node))
+		add(prefix, string(text))
+		return
+	}
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		entries := make(map[interface{}]interface{}, len(typed))
+		for key, item := range typed {
+			entries[key] = item
+		}
+		flatten(prefix, entries, add)
+	case map[interface{}]interface{}:
+		if known(prefix) {
+			items := make([]string, 0, len(typed))
+			for key, item := range typed {
+				items = append(items, fmt.Sprintf("%v=%v", key, item))
+			}
+			sort.Strings(items)
+			add(prefix, strings.Join(items, ","))
+			return
+		}
+		for key, item := range typed {
+			name := fmt.Sprint(key)
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			flatten(name, item, add)
+		}
+	case []interface{}:
+		items := make([]string, len(typed))
+		for i, item := range typed {
+			items[i] = fmt.Sprint(item)
+		}
+		add(prefix, strings.Join(items, ","))
+	case nil:
+	default:
+		add(prefix, fmt.Sprint(typed))
+	}
+}
+
+// jsonNode turns the maps of yaml, whose keys may be of any type, into maps which json can write
+func jsonNode(node interface{}) interface{} {
+	switch typed := node.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			converted[fmt.Sprint(key)] = jsonNode(item)
+		}
+		return converted
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			converted[key] = jsonNode(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(typed))
+		for i, item := range typed {
+			converted[i] = jsonNode(item)
+		}
+		return converted
+	default:
+		return typed
+	}
+}
+
+func lookup(key string) (setting, bool) {
+	for _, s := range settings {
+		if s.key == key {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+func known(key string) bool {
+	_, ok := lookup(key)
+	return ok
+}
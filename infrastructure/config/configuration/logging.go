@@ -0,0 +1,8 @@
+package configuration
+
+type LoggingConfiguration struct {
+	// Level is the lowest level of the written logs, like debug or warning. by default it is info in production
+	// and staging, and debug in the other environments
+	Level      string
+	ElasticUrl string // elasticsearch which the logs of production and staging are sent to
+}
@@ -0,0 +1,14 @@
+package configuration
+
+import "time"
+
+type OutboxConfiguration struct {
+	PollInterval  time.Duration // how often the due events are looked for
+	Timeout       time.Duration // how long a publisher is waited for
+	BatchSize     int           // events relayed at the same time
+	MaxAttempts   int           // relays of an event before it is dead and not relayed anymore
+	RetryDelay    time.Duration // wait after the first failed relay of an event, which doubles after every next one
+	MaxRetryDelay time.Duration // longest wait between two relays of an event
+	Retention     time.Duration // how long the published events are kept. zero keeps them forever
+	LogEvents     bool          // writes the events to the logs too
+}
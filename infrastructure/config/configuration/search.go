@@ -0,0 +1,6 @@
+package configuration
+
+type SearchConfiguration struct {
+	Url   string // elasticsearch url of the gift card index. the search is disabled when it is empty
+	Index string // name of the gift card index. default is giftcards
+}
@@ -0,0 +1,10 @@
+package configuration
+
+type SecurityConfiguration struct {
+	// SecretCodeLength is the length of the secret codes of the new gift cards, which the secrets of the approvals and
+	// the validations should have too. default is 16
+	SecretCodeLength int
+	PublicCodeLength int // length of the public codes of the new gift cards. default is 12
+	// AdminToken is the bearer token of the admin endpoints. they are disabled when it is empty
+	AdminToken string
+}
@@ -1,7 +1,17 @@
 package configuration
 
+import "time"
+
 type ServerConfiguration struct {
 	Port                   int    // this is for server port inside the container
 	OutSideOfContainerPort int    // this is for the port that is observable from outside the container. for swagger gen
 	OutSideOfContainerHost string // this is the hostname outside of the container. for swagger gen. default is localhost
+	GrpcPort               int    // port of the grpc server, which is disabled when it is zero. default is 9090
+
+	ReadHeaderTimeout time.Duration // how long the headers of a request are waited for. default is 10s
+	IdleTimeout       time.Duration // how long the idle keep-alive connections are kept. default is 2m
+
+	ShutdownTimeout time.Duration // how long the in-flight requests are waited for on shutdown. default is 30s
+	ShutdownDelay   time.Duration // how long the service reports unready before it stops listening. default is 5s
+	StopTimeout     time.Duration // how long the resources are waited for after the requests are drained. default is 10s
 }
@@ -0,0 +1,43 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type TimeoutConfiguration struct {
+	Default time.Duration            // deadline of the requests. the requests have no deadline when it is zero
+	Routes  map[string]time.Duration // deadline of single routes keyed like "POST /v1/gift-card/bulk"
+}
+
+// For returns the deadline of the route with the given method and path template
+func (t TimeoutConfiguration) For(method, path string) time.Duration {
+	if timeout, ok := t.Routes[method+" "+path]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// parseRouteTimeouts reads a comma separated list of route timeouts like
+// "POST /v1/gift-card/bulk=2m,GET /v1/report/gift-card=1m"
+func parseRouteTimeouts(value string) (map[string]time.Duration, error) {
+	routes := map[string]time.Duration{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		route := strings.Fields(parts[0])
+		if len(parts) != 2 || len(route) != 2 {
+			return nil, fmt.Errorf("the route timeout %q should be like \"GET /path=30s\"", item)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("the timeout of %q is not a valid duration", item)
+		}
+		routes[strings.ToUpper(route[0])+" "+route[1]] = timeout
+	}
+	return routes, nil
+}
@@ -0,0 +1,7 @@
+package configuration
+
+type TracingConfiguration struct {
+	Endpoint    string  // host:port of the otlp http collector. the tracing is disabled when it is empty
+	Insecure    bool    // sends the spans without tls
+	SampleRatio float64 // share of the new traces which are recorded. default is 1
+}
@@ -0,0 +1,13 @@
+package configuration
+
+import "time"
+
+type WebhookConfiguration struct {
+	PollInterval  time.Duration // how often the due deliveries are looked for
+	Timeout       time.Duration // how long a receiver is waited for
+	BatchSize     int           // deliveries sent at the same time
+	MaxAttempts   int           // attempts of a delivery before it is dead
+	RetryDelay    time.Duration // wait after the first failed attempt, which doubles after every next one
+	MaxRetryDelay time.Duration // longest wait between two attempts
+	ExpirySweep   time.Duration // how often the expired cards are published. zero does not publish them
+}
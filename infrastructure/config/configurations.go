@@ -1,34 +1,78 @@
 package config
 
 import (
+	"flag"
 	"giftcard-engine/infrastructure/config/configuration"
 	"github.com/joho/godotenv"
 	"log"
+	"os"
+	"reflect"
 	"sync"
 )
 
 var (
-	once         sync.Once
-	instance     configuration.Configurations
-	instantiated bool
+	once        sync.Once
+	mutex       sync.RWMutex
+	instance    configuration.Configurations
+	subscribers []func(configurations configuration.Configurations)
+	loader      = configuration.NewLoader()
 )
 
-func readConfig() configuration.Configurations {
-	once.Do(func() {
-		err := godotenv.Load("dev.env")
-		if err != nil {
-			log.Fatal("Error loading .env file")
-		}
-		instance = configuration.CreateDefaultConfiguration()
-		instantiated = true
-	})
+// BindFlags adds the flags of the settings to the flags of the command, which should be parsed before Get
+func BindFlags(flags *flag.FlagSet) {
+	loader.BindFlags(flags)
+}
 
-	return instance
+// readConfig loads the variables of dev.env, when there is one, into the environment and then merges the
+// defaults, the configuration file, the environment and the flags. it exits with every invalid setting listed
+func readConfig() {
+	if err := godotenv.Load("dev.env"); err != nil && !os.IsNotExist(err) {
+		log.Fatalln("Error loading the dev.env file:", err)
+	}
+	configurations, err := loader.Load()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	instance = configurations
 }
 
+// Get returns the running configurations. the reloadable settings may change between the calls, so the
+// components which keep them should Subscribe instead
 func Get() configuration.Configurations {
-	if !instantiated {
-		return readConfig()
-	}
+	once.Do(readConfig)
+	mutex.RLock()
+	defer mutex.RUnlock()
 	return instance
 }
+
+// Subscribe calls the subscriber with the new configurations after every reload which changes them
+func Subscribe(subscriber func(configurations configuration.Configurations)) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	subscribers = append(subscribers, subscriber)
+}
+
+// Reload loads the configurations again and applies their reloadable settings. when they are not valid the
+// running configurations are kept and the error lists the problems. the keys of the changed settings which
+// need a restart are returned
+func Reload() ([]string, error) {
+	Get()
+	loaded, err := loader.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	mutex.Lock()
+	reloaded, restart := configuration.Reload(instance, loaded)
+	changed := !reflect.DeepEqual(instance, reloaded)
+	instance = reloaded
+	notified := append([]func(configuration.Configurations){}, subscribers...)
+	mutex.Unlock()
+
+	if changed {
+		for _, subscriber := range notified {
+			subscriber(reloaded)
+		}
+	}
+	return restart, nil
+}
@@ -0,0 +1,71 @@
+package config_test
+
+import (
+	"giftcard-engine/infrastructure/config"
+	"giftcard-engine/infrastructure/config/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestTheConfigurationIsReloadedWhenTheFileChangesAndOnSighup(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string) {
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+	}
+	write("database: {driver: memory}\nlogging: {level: info}\n")
+	t.Setenv(configuration.FileEnv, file)
+	config.Get()
+	_, err := config.Reload()
+	require.NoError(t, err)
+	require.Equal(t, "info", config.Get().Logging.Level)
+
+	levels := make(chan string, 10)
+	config.Subscribe(func(configurations configuration.Configurations) {
+		levels <- configurations.Logging.Level
+	})
+	stop := config.Watch(10 * time.Millisecond)
+	defer stop()
+
+	write("database: {driver: memory}\nlogging: {level: warning}\nserver: {port: 9000}\n")
+	assert.Equal(t, "warning", receive(t, levels))
+	assert.Equal(t, 8080, config.Get().Server.Port, "the port is only changed by a restart")
+
+	write("database: {driver: memory}\nlogging: {level: loudest}\n")
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, "warning", config.Get().Logging.Level, "the invalid file should be rejected")
+	assert.Empty(t, levels)
+
+	t.Setenv("GIFT_CARD_LOGGING_LEVEL", "error")
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGHUP))
+	assert.Equal(t, "error", receive(t, levels))
+}
+
+func TestReloadReturnsTheSettingsWhichNeedARestart(t *testing.T) {
+	t.Setenv("GIFT_CARD_DATABASE_DRIVER", "memory")
+	config.Get()
+	_, err := config.Reload()
+	require.NoError(t, err)
+	t.Setenv("GIFT_CARD_DATABASE_POOL_MAX_OPEN", "50")
+	t.Setenv("GIFT_CARD_DATABASE_POOL_MAX_IDLE", "50")
+
+	restart, err := config.Reload()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"database.pool.max_open", "database.pool.max_idle"}, restart)
+	assert.Equal(t, 10, config.Get().ConnectionStrings.Pool.MaxOpen)
+}
+
+func receive(t *testing.T, levels chan string) string {
+	select {
+	case level := <-levels:
+		return level
+	case <-time.After(5 * time.Second):
+		t.Fatal("the configuration was not reloaded")
+		return ""
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"giftcard-engine/infrastructure/logger"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Watch reloads the configurations on SIGHUP and when the configuration file changes, which is checked every
+// interval. the failed reloads are logged and change nothing. the returned function stops the watch
+func Watch(interval time.Duration) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	ticker := time.NewTicker(interval)
+	file := loader.File()
+	ticks := ticker.C
+	if file == "" {
+		ticks = nil
+	}
+
+	last := stamp(file)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case <-signals:
+				reload("SIGHUP")
+			case <-ticks:
+				if current := stamp(file); current != last {
+					last = current
+					reload("file change")
+				}
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		ticker.Stop()
+		close(done)
+		<-stopped
+	}
+}
+
+// fileStamp changes when the file is written, or is removed
+type fileStamp struct {
+	modified time.Time
+	size     int64
+}
+
+func stamp(file string) fileStamp {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modified: info.ModTime(), size: info.Size()}
+}
+
+func reload(trigger string) {
+	restart, err := Reload()
+	if err != nil {
+		logger.WithData(map[string]interface{}{"trigger": trigger}).
+			ErrorException(err, "The configuration is not reloaded, the running one is kept")
+		return
+	}
+	if len(restart) > 0 {
+		logger.WithData(map[string]interface{}{"trigger": trigger, "settings": restart}).
+			Warn("Some of the changed settings are only applied after a restart")
+	}
+	logger.WithData(map[string]interface{}{"trigger": trigger}).Info("The configuration is reloaded")
+}
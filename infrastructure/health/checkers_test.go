@@ -0,0 +1,125 @@
+package health_test
+
+import (
+	"context"
+	"database/sql"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/health"
+	"github.com/olivere/elastic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGoroutineHealthChecker(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, health.Healthy, health.NewGoroutineHealthChecker("goroutines", 100000).
+		Check(context.Background()).Status)
+
+	result := health.NewGoroutineHealthChecker("goroutines", 1).Check(context.Background())
+
+	assert.Equal(t, health.UnHealthy, result.Status)
+	assert.Equal(t, "1", result.Data["max"])
+}
+
+func TestDiskHealthChecker(t *testing.T) {
+	t.Parallel()
+	healthy := health.NewDiskHealthChecker("disk", t.TempDir(), 0).Check(context.Background())
+	full := health.NewDiskHealthChecker("disk", t.TempDir(), 0.9999999).Check(context.Background())
+	missing := health.NewDiskHealthChecker("disk", "/missing/disk", 0.1).Check(context.Background())
+
+	assert.Equal(t, health.Healthy, healthy.Status)
+	assert.NotEmpty(t, healthy.Data["totalBytes"])
+	assert.Equal(t, health.UnHealthy, full.Status)
+	assert.Equal(t, health.UnHealthy, missing.Status)
+}
+
+func TestDbPoolHealthCheckerFailsWhenTheConnectionsAreInUse(t *testing.T) {
+	t.Parallel()
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(2)
+	checker := health.NewDbPoolHealthChecker("connectionPool", db, 0.5)
+	assert.Equal(t, health.Healthy, checker.Check(context.Background()).Status)
+
+	connection, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer connection.Close()
+	result := checker.Check(context.Background())
+
+	assert.Equal(t, health.UnHealthy, result.Status)
+	assert.Equal(t, "1 of the 2 connections of the pool are in use", result.Description)
+	assert.Equal(t, "1", result.Data["inUse"])
+}
+
+func TestServiceHealthCheckerChecksTheStatus(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/accepted" {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	assert.Equal(t, health.Healthy, health.NewServiceHealthChecker("wallet", server.URL, 0).
+		Check(context.Background()).Status)
+	assert.Equal(t, health.Healthy, health.NewServiceHealthChecker("wallet", server.URL+"/accepted", 202).
+		Check(context.Background()).Status)
+	result := health.NewServiceHealthChecker("wallet", server.URL, 200).Check(context.Background())
+	assert.Equal(t, health.UnHealthy, result.Status)
+	assert.Contains(t, result.Exception, "204")
+}
+
+func TestElasticHealthCheckerFailsWhenTheClusterIsRed(t *testing.T) {
+	t.Parallel()
+	status := "yellow"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"cluster_name": "logs", "status": "` + status + `", "number_of_nodes": 3}`))
+	}))
+	defer server.Close()
+	client, err := elastic.NewClient(elastic.SetURL(server.URL), elastic.SetSniff(false),
+		elastic.SetHealthcheck(false))
+	require.NoError(t, err)
+	checker := health.NewElasticHealthChecker("logSink", client)
+
+	yellow := checker.Check(context.Background())
+	status = "red"
+	red := checker.Check(context.Background())
+
+	assert.Equal(t, health.Healthy, yellow.Status)
+	assert.Equal(t, "3", yellow.Data["nodes"])
+	assert.Equal(t, health.UnHealthy, red.Status)
+	assert.Equal(t, "the elasticsearch cluster logs is red", red.Description)
+}
+
+func TestConfigureHealthChecksAddsTheDependencies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	config := configuration.Defaults().Health
+	config.Dependencies = []configuration.HealthDependency{
+		{Name: "wallet", Url: server.URL, Critical: true, Timeout: time.Second},
+		{Name: "notification", Url: server.URL},
+	}
+
+	health.ConfigureHealthChecks(nil, config)
+	ready := health.Run(context.Background(), health.Readiness)
+	live := health.Run(context.Background(), health.Liveness)
+
+	assert.Equal(t, health.UnHealthy, ready.Status)
+	assert.Equal(t, health.UnHealthy, ready.Entries["wallet"].Status)
+	assert.Equal(t, health.Degraded, ready.Entries["notification"].Status)
+	assert.Contains(t, ready.Entries, "disk")
+	assert.Contains(t, ready.Entries, "goroutines")
+	assert.NotContains(t, live.Entries, "goroutines")
+	assert.NotContains(t, live.Entries, "wallet")
+}
@@ -1,10 +1,42 @@
 package health
 
 import (
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/logger"
 	"github.com/jinzhu/gorm"
 )
 
-func ConfigureHealthChecks(db *gorm.DB) {
-	NewCheckerService().
-		Add(NewDbHealthChecker("defaultConnection", db))
+// ConfigureHealthChecks adds the checkers of the service and of the dependencies of the configuration. db is nil
+// when the repositories keep the data in memory
+func ConfigureHealthChecks(db *gorm.DB, config configuration.HealthConfiguration) {
+	service := NewCheckerService()
+	dependencyOptions := func(optional bool) Options {
+		return Options{Tags: []Probe{Readiness}, Timeout: config.Timeout, CacheFor: config.CacheFor, Optional: optional}
+	}
+	if db != nil {
+		service.Add(NewDbHealthChecker("defaultConnection", db), Options{
+			Tags:     []Probe{Readiness, Startup},
+			Timeout:  config.Timeout,
+			CacheFor: config.CacheFor,
+		})
+		service.Add(NewDbPoolHealthChecker("connectionPool", db.DB(), config.PoolSaturation), dependencyOptions(true))
+	}
+	if client := logger.ElasticSink(); client != nil {
+		service.Add(NewElasticHealthChecker("logSink", client), dependencyOptions(true))
+	}
+	if config.DiskPath != "" {
+		service.Add(NewDiskHealthChecker("disk", config.DiskPath, config.DiskMinFree), dependencyOptions(true))
+	}
+	if config.MaxGoroutines > 0 {
+		// bulk issuance and the log hooks run many goroutines for a while, so too many only degrade the readiness
+		service.Add(NewGoroutineHealthChecker("goroutines", config.MaxGoroutines), dependencyOptions(true))
+	}
+	for _, dependency := range config.Dependencies {
+		options := dependencyOptions(!dependency.Critical)
+		if dependency.Timeout > 0 {
+			options.Timeout = dependency.Timeout
+		}
+		service.Add(NewServiceHealthChecker(dependency.Name, dependency.Url, dependency.ExpectedStatus), options)
+	}
+	service.Add(NewShutdownHealthChecker("shutdown"), Options{Tags: []Probe{Readiness}})
 }
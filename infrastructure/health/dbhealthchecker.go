@@ -1,40 +1,31 @@
 package health
 
 import (
+	"context"
 	"giftcard-engine/infrastructure/logger"
 	"github.com/jinzhu/gorm"
 )
 
 type dbHealthChecker struct {
-	DB  *gorm.DB
-	tag string
+	DB   *gorm.DB
+	name string
 }
 
-func (c *dbHealthChecker) Check() HealthResultDto {
-	if err := c.DB.Exec("select 1;").Error; err != nil {
+func (c *dbHealthChecker) Check(ctx context.Context) HealthResultDto {
+	if _, err := c.DB.DB().ExecContext(ctx, "select 1;"); err != nil {
 		logger.WithException(err).Error("error while trying to check if the sql connection is okay")
-		return HealthResultDto{
-			Status:      UnHealthy,
-			Duration:    defaultTimeStampFormat,
-			Exception:   err.Error(),
-			Description: err.Error(),
-			Data:        map[string]string{},
-		}
-	}
-	return HealthResultDto{
-		Status:   Healthy,
-		Duration: defaultTimeStampFormat,
-		Data:     map[string]string{},
+		return unhealthyResult(err)
 	}
+	return healthyResult()
 }
 
-func (c *dbHealthChecker) Tag() string {
-	return c.tag
+func (c *dbHealthChecker) Name() string {
+	return c.name
 }
 
-func NewDbHealthChecker(tag string, db *gorm.DB) Checker {
+func NewDbHealthChecker(name string, db *gorm.DB) Checker {
 	return &dbHealthChecker{
-		DB:  db,
-		tag: tag,
+		DB:   db,
+		name: name,
 	}
 }
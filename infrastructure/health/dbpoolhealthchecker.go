@@ -0,0 +1,47 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+)
+
+type dbPoolHealthChecker struct {
+	name       string
+	db         *sql.DB
+	saturation float64
+}
+
+// Check fails when the share of the connections of the pool which are in use reaches the saturation, so the
+// requests are about to wait for a connection
+func (c *dbPoolHealthChecker) Check(context.Context) HealthResultDto {
+	stats := c.db.Stats()
+	data := map[string]string{
+		"maxOpen":   strconv.Itoa(stats.MaxOpenConnections),
+		"open":      strconv.Itoa(stats.OpenConnections),
+		"inUse":     strconv.Itoa(stats.InUse),
+		"idle":      strconv.Itoa(stats.Idle),
+		"waitCount": strconv.FormatInt(stats.WaitCount, 10),
+	}
+	if stats.MaxOpenConnections > 0 &&
+		float64(stats.InUse)/float64(stats.MaxOpenConnections) >= c.saturation {
+		return HealthResultDto{
+			Status: UnHealthy,
+			Description: fmt.Sprintf("%d of the %d connections of the pool are in use", stats.InUse,
+				stats.MaxOpenConnections),
+			Data: data,
+		}
+	}
+	return HealthResultDto{Status: Healthy, Data: data}
+}
+
+func (c *dbPoolHealthChecker) Name() string {
+	return c.name
+}
+
+// NewDbPoolHealthChecker returns a checker of the connection pool which fails when the saturation share of its
+// connections, like 0.9, are in use. a pool without a limit is never saturated
+func NewDbPoolHealthChecker(name string, db *sql.DB, saturation float64) Checker {
+	return &dbPoolHealthChecker{name: name, db: db, saturation: saturation}
+}
@@ -0,0 +1,44 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type diskHealthChecker struct {
+	name    string
+	path    string
+	minFree float64
+}
+
+// Check fails when the share of the free space of the disk is less than the min
+func (c *diskHealthChecker) Check(context.Context) HealthResultDto {
+	free, total, err := diskSpace(c.path)
+	if err != nil {
+		return unhealthyResult(err)
+	}
+	data := map[string]string{
+		"path":       c.path,
+		"freeBytes":  strconv.FormatUint(free, 10),
+		"totalBytes": strconv.FormatUint(total, 10),
+	}
+	if total > 0 && float64(free)/float64(total) < c.minFree {
+		return HealthResultDto{
+			Status:      UnHealthy,
+			Description: fmt.Sprintf("less than %.0f%% of %s is free", c.minFree*100, c.path),
+			Data:        data,
+		}
+	}
+	return HealthResultDto{Status: Healthy, Data: data}
+}
+
+func (c *diskHealthChecker) Name() string {
+	return c.name
+}
+
+// NewDiskHealthChecker returns a checker of the disk of the path, which fails when less than the minFree share of
+// it, like 0.1, is free
+func NewDiskHealthChecker(name, path string, minFree float64) Checker {
+	return &diskHealthChecker{name: name, path: path, minFree: minFree}
+}
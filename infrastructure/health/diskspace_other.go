@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package health
+
+import "errors"
+
+func diskSpace(string) (free, total uint64, err error) {
+	return 0, 0, errors.New("the disk space is not checked on this platform")
+}
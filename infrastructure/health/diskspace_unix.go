@@ -0,0 +1,15 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package health
+
+import "syscall"
+
+// diskSpace returns the bytes of the disk of the path which are free for the service, and its size
+func diskSpace(path string) (free, total uint64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), uint64(stat.Blocks) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,42 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"github.com/olivere/elastic"
+	"strconv"
+)
+
+type elasticHealthChecker struct {
+	name   string
+	client *elastic.Client
+}
+
+// Check fails when the cluster is red or does not respond. a yellow cluster still takes the documents
+func (c *elasticHealthChecker) Check(ctx context.Context) HealthResultDto {
+	cluster, err := c.client.ClusterHealth().Do(ctx)
+	if err != nil {
+		return unhealthyResult(err)
+	}
+	data := map[string]string{
+		"cluster": cluster.ClusterName,
+		"status":  cluster.Status,
+		"nodes":   strconv.Itoa(cluster.NumberOfNodes),
+	}
+	if cluster.Status == "red" {
+		return HealthResultDto{
+			Status:      UnHealthy,
+			Description: fmt.Sprintf("the elasticsearch cluster %s is red", cluster.ClusterName),
+			Data:        data,
+		}
+	}
+	return HealthResultDto{Status: Healthy, Data: data}
+}
+
+func (c *elasticHealthChecker) Name() string {
+	return c.name
+}
+
+func NewElasticHealthChecker(name string, client *elastic.Client) Checker {
+	return &elasticHealthChecker{name: name, client: client}
+}
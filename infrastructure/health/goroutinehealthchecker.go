@@ -0,0 +1,35 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+)
+
+type goroutineHealthChecker struct {
+	name string
+	max  int
+}
+
+// Check fails when there are more goroutines than the max, which are most likely leaked by a hung dependency
+func (c *goroutineHealthChecker) Check(context.Context) HealthResultDto {
+	count := runtime.NumGoroutine()
+	data := map[string]string{"goroutines": strconv.Itoa(count), "max": strconv.Itoa(c.max)}
+	if count > c.max {
+		return HealthResultDto{
+			Status:      UnHealthy,
+			Description: fmt.Sprintf("%d goroutines are running, more than %d", count, c.max),
+			Data:        data,
+		}
+	}
+	return HealthResultDto{Status: Healthy, Data: data}
+}
+
+func (c *goroutineHealthChecker) Name() string {
+	return c.name
+}
+
+func NewGoroutineHealthChecker(name string, max int) Checker {
+	return &goroutineHealthChecker{name: name, max: max}
+}
@@ -0,0 +1,21 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Handler runs the checkers of the probe for every request. it responds 503 when the service is unhealthy and 200
+// when it is healthy or degraded
+func Handler(probe Probe) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := Run(r.Context(), probe)
+		status := http.StatusOK
+		if report.Status == UnHealthy {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
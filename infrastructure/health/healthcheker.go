@@ -1,82 +1,219 @@
 package health
 
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
 type Health string
 
 const Healthy Health = "Healthy"
+const Degraded Health = "Degraded"
 const UnHealthy Health = "Unhealthy"
 
-const defaultTimeStampFormat = "00:00:00.0000000"
+// Probe is a tag of the checkers which decides the probes they run in
+type Probe string
+
+const (
+	// Liveness fails when the process should be restarted, so it should not depend on other services
+	Liveness Probe = "live"
+	// Readiness fails when the service should not receive requests for now
+	Readiness Probe = "ready"
+	// Startup fails until the service has started
+	Startup Probe = "startup"
+)
+
+// DefaultTimeout is the timeout of the checkers whose options do not set one
+const DefaultTimeout = 5 * time.Second
 
-var checker CheckerService
+var checker CheckerService = &checkerService{}
 
 type Checker interface {
-	Check() HealthResultDto
-	Tag() string
+	// Check returns the health of a dependency. it should return once the context is done
+	Check(ctx context.Context) HealthResultDto
+	// Name is the key of the checker in the results
+	Name() string
+}
+
+// Options decide when and how a checker runs
+type Options struct {
+	Tags     []Probe       // probes which run the checker. default is readiness and startup
+	Timeout  time.Duration // the checker is unhealthy when it does not finish in time. default is DefaultTimeout
+	CacheFor time.Duration // how long the result is reused. the checker runs on every probe when it is zero
+	// Optional checkers are dependencies which the service works without, so their failures make the status
+	// degraded instead of unhealthy
+	Optional bool
+}
+
+func (o Options) has(probe Probe) bool {
+	if probe == "" {
+		return true
+	}
+	for _, tag := range o.Tags {
+		if tag == probe {
+			return true
+		}
+	}
+	return false
 }
 
 type CheckerService interface {
-	Add(checker Checker) CheckerService
-	GetHealth() map[string]interface{}
+	Add(checker Checker, options Options) CheckerService
+	GetHealth() Report
+	// Run runs the checkers of the probe at once, or all of them when the probe is empty
+	Run(ctx context.Context, probe Probe) Report
+	// Check runs all of the checkers at once and returns their results by name
+	Check() map[string]HealthResultDto
 }
 
 type checkerService struct {
-	checkers []Checker
+	mutex    sync.RWMutex
+	checkers []*registration
 }
 
-func (s *checkerService) Add(checker Checker) CheckerService {
-	s.checkers = append(s.checkers, checker)
+// registration is an added checker with its options and its cached result
+type registration struct {
+	checker   Checker
+	options   Options
+	mutex     sync.Mutex
+	cached    HealthResultDto
+	checkedAt time.Time
+}
+
+func (s *checkerService) Add(checker Checker, options Options) CheckerService {
+	if len(options.Tags) == 0 {
+		options.Tags = []Probe{Readiness, Startup}
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.checkers = append(s.checkers, &registration{checker: checker, options: options})
 	return s
 }
 
 type healthResult struct {
-	tag    string
+	name   string
 	health HealthResultDto
 }
 
-func newHealthResult(tag string, health HealthResultDto) healthResult {
-	return healthResult{
-		tag:    tag,
-		health: health,
+func (s *checkerService) GetHealth() Report {
+	return s.Run(context.Background(), "")
+}
+
+func (s *checkerService) Run(ctx context.Context, probe Probe) Report {
+	start := time.Now()
+	entries := s.run(ctx, probe)
+	report := Report{
+		Status:        Healthy,
+		TotalDuration: formatDuration(time.Since(start)),
+		Entries:       entries,
+	}
+	for _, entry := range entries {
+		if entry.Status == UnHealthy {
+			report.Status = UnHealthy
+		} else if entry.Status == Degraded && report.Status == Healthy {
+			report.Status = Degraded
+		}
 	}
+	return report
 }
 
-func (s *checkerService) GetHealth() map[string]interface{} {
+func (s *checkerService) Check() map[string]HealthResultDto {
+	return s.run(context.Background(), "")
+}
+
+func (s *checkerService) run(ctx context.Context, probe Probe) map[string]HealthResultDto {
+	s.mutex.RLock()
+	var registrations []*registration
+	for _, added := range s.checkers {
+		if added.options.has(probe) {
+			registrations = append(registrations, added)
+		}
+	}
+	s.mutex.RUnlock()
+
+	resultChan := make(chan healthResult, len(registrations))
+	for _, added := range registrations {
+		go func(added *registration) {
+			resultChan <- healthResult{name: added.checker.Name(), health: added.run(ctx)}
+		}(added)
+	}
 	entries := map[string]HealthResultDto{}
-	resultObject := map[string]interface{}{
-		"status":        Healthy,
-		"totalDuration": defaultTimeStampFormat,
+	for range registrations {
+		result := <-resultChan
+		entries[result.name] = result.health
 	}
-	checkersCount := len(s.checkers)
-	resultChan := make(chan healthResult, checkersCount)
-	for _, checker := range s.checkers {
-		go func(result chan healthResult, checkerUnit Checker) {
-			health := checkerUnit.Check()
-			result <- newHealthResult(checkerUnit.Tag(), health)
-		}(resultChan, checker)
+	return entries
+}
+
+// run returns the cached result while it is fresh, or runs the checker until its timeout and measures it
+func (r *registration) run(ctx context.Context) HealthResultDto {
+	r.mutex.Lock()
+	if r.options.CacheFor > 0 && !r.checkedAt.IsZero() && time.Since(r.checkedAt) < r.options.CacheFor {
+		cached := r.cached
+		r.mutex.Unlock()
+		return cached
 	}
+	r.mutex.Unlock()
 
-	for i := 0; i < checkersCount; i++ {
-		result := <-resultChan
-		if result.health.Status == UnHealthy {
-			resultObject["status"] = UnHealthy
+	checkCtx, cancel := context.WithTimeout(ctx, r.options.Timeout)
+	defer cancel()
+	start := time.Now()
+	// the checker is left behind when it does not return in time, so a hung dependency does not hang the probe
+	done := make(chan HealthResultDto, 1)
+	go func() {
+		done <- r.checker.Check(checkCtx)
+	}()
+	var result HealthResultDto
+	select {
+	case result = <-done:
+	case <-checkCtx.Done():
+		result = HealthResultDto{
+			Status:      UnHealthy,
+			Exception:   checkCtx.Err().Error(),
+			Description: fmt.Sprintf("the check did not finish in %s", r.options.Timeout),
 		}
-		entries[result.tag] = result.health
 	}
-	resultObject["entries"] = entries
-	return resultObject
+	result.Duration = formatDuration(time.Since(start))
+	if result.Data == nil {
+		result.Data = map[string]string{}
+	}
+	if result.Status == UnHealthy && r.options.Optional {
+		result.Status = Degraded
+	}
+	result.Tags = r.options.Tags
+
+	// the results of the canceled probes are not about the dependency, so they are not kept
+	if r.options.CacheFor > 0 && ctx.Err() == nil {
+		r.mutex.Lock()
+		r.cached, r.checkedAt = result, time.Now()
+		r.mutex.Unlock()
+	}
+	return result
 }
 
 func NewCheckerService() CheckerService {
-	checker = &checkerService{
-		checkers: []Checker{},
-	}
+	checker = &checkerService{}
 	return checker
 }
 
-func GetHealth() map[string]interface{} {
+func GetHealth() Report {
 	return checker.GetHealth()
 }
 
-func Add(newChecker Checker) CheckerService {
-	return checker.Add(newChecker)
+// Run runs the checkers of the probe on the checker service of the application
+func Run(ctx context.Context, probe Probe) Report {
+	return checker.Run(ctx, probe)
+}
+
+func Check() map[string]HealthResultDto {
+	return checker.Check()
+}
+
+func Add(newChecker Checker, options Options) CheckerService {
+	return checker.Add(newChecker, options)
 }
@@ -0,0 +1,124 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"giftcard-engine/infrastructure/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeChecker returns its result after its delay and counts its runs
+type fakeChecker struct {
+	name  string
+	err   error
+	delay time.Duration
+	runs  int32
+}
+
+func (c *fakeChecker) Check(ctx context.Context) health.HealthResultDto {
+	atomic.AddInt32(&c.runs, 1)
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		// a checker which ignores its context is left behind by the timeout too
+		time.Sleep(c.delay)
+	}
+	if c.err != nil {
+		return health.HealthResultDto{Status: health.UnHealthy, Exception: c.err.Error()}
+	}
+	return health.HealthResultDto{Status: health.Healthy}
+}
+
+func (c *fakeChecker) Name() string {
+	return c.name
+}
+
+func TestRunRunsTheCheckersOfTheProbe(t *testing.T) {
+	t.Parallel()
+	service := health.NewCheckerService()
+	service.Add(&fakeChecker{name: "database"}, health.Options{})
+	service.Add(&fakeChecker{name: "deadlock"}, health.Options{Tags: []health.Probe{health.Liveness}})
+
+	live := service.Run(context.Background(), health.Liveness)
+	ready := service.Run(context.Background(), health.Readiness)
+	all := service.GetHealth()
+
+	assert.Equal(t, health.Healthy, live.Status)
+	assert.Contains(t, live.Entries, "deadlock")
+	assert.NotContains(t, live.Entries, "database")
+	assert.Contains(t, ready.Entries, "database")
+	assert.Equal(t, []health.Probe{health.Readiness, health.Startup}, ready.Entries["database"].Tags)
+	assert.Len(t, all.Entries, 2)
+}
+
+func TestRunStopsWaitingForTheCheckersAfterTheirTimeout(t *testing.T) {
+	t.Parallel()
+	service := health.NewCheckerService()
+	service.Add(&fakeChecker{name: "hung", delay: time.Hour}, health.Options{Timeout: 20 * time.Millisecond})
+	service.Add(&fakeChecker{name: "slow", delay: 30 * time.Millisecond}, health.Options{})
+
+	start := time.Now()
+	report := service.Run(context.Background(), health.Readiness)
+
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	assert.Equal(t, health.UnHealthy, report.Status)
+	assert.Equal(t, health.UnHealthy, report.Entries["hung"].Status)
+	assert.Equal(t, "the check did not finish in 20ms", report.Entries["hung"].Description)
+	assert.Equal(t, health.Healthy, report.Entries["slow"].Status)
+	assert.Regexp(t, `^00:00:00\.0[3-9]\d{5}$`, report.Entries["slow"].Duration)
+	assert.NotEqual(t, "00:00:00.0000000", report.TotalDuration)
+}
+
+func TestFailingOptionalCheckersDegradeTheHealth(t *testing.T) {
+	t.Parallel()
+	service := health.NewCheckerService()
+	service.Add(&fakeChecker{name: "database"}, health.Options{})
+	service.Add(&fakeChecker{name: "search", err: errors.New("connection refused")}, health.Options{Optional: true})
+
+	report := service.Run(context.Background(), health.Readiness)
+
+	assert.Equal(t, health.Degraded, report.Status)
+	assert.Equal(t, health.Degraded, report.Entries["search"].Status)
+	assert.Equal(t, "connection refused", report.Entries["search"].Exception)
+}
+
+func TestRunReusesTheCachedResults(t *testing.T) {
+	t.Parallel()
+	cached := &fakeChecker{name: "cached"}
+	uncached := &fakeChecker{name: "uncached"}
+	service := health.NewCheckerService()
+	service.Add(cached, health.Options{CacheFor: time.Hour})
+	service.Add(uncached, health.Options{})
+
+	for i := 0; i < 3; i++ {
+		service.Run(context.Background(), health.Readiness)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&cached.runs))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&uncached.runs))
+}
+
+func TestHandlerRespondsUnavailableWhenUnhealthy(t *testing.T) {
+	health.NewCheckerService()
+	health.Add(&fakeChecker{name: "database", err: errors.New("login failed")}, health.Options{})
+	health.Add(&fakeChecker{name: "process"}, health.Options{Tags: []health.Probe{health.Liveness}})
+
+	ready := httptest.NewRecorder()
+	health.Handler(health.Readiness).ServeHTTP(ready, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	live := httptest.NewRecorder()
+	health.Handler(health.Liveness).ServeHTTP(live, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, ready.Code)
+	assert.Equal(t, http.StatusOK, live.Code)
+	var report health.Report
+	require.NoError(t, json.Unmarshal(ready.Body.Bytes(), &report))
+	assert.Equal(t, health.UnHealthy, report.Status)
+	assert.Equal(t, "login failed", report.Entries["database"].Exception)
+}
@@ -1,9 +1,48 @@
 package health
 
+import (
+	"fmt"
+	"time"
+)
+
 type HealthResultDto struct {
 	Status      Health            `json:"status"`
 	Duration    string            `json:"duration"`
 	Exception   string            `json:"exception,omitempty"`
 	Description string            `json:"description,omitempty"`
 	Data        map[string]string `json:"data"`
+	Tags        []Probe           `json:"tags"`
+}
+
+// Report is the health of the service, which is unhealthy when a checker is unhealthy and degraded when an
+// optional checker fails
+type Report struct {
+	Status        Health                     `json:"status" swaggertype:"string"`
+	TotalDuration string                     `json:"totalDuration"`
+	Entries       map[string]HealthResultDto `json:"entries"`
+}
+
+// formatDuration writes the duration like 00:00:00.0012345, the format of the health checks of the dotnet services
+func formatDuration(duration time.Duration) string {
+	hours := duration / time.Hour
+	minutes := duration % time.Hour / time.Minute
+	seconds := duration % time.Minute / time.Second
+	ticks := duration % time.Second / 100
+	return fmt.Sprintf("%02d:%02d:%02d.%07d", hours, minutes, seconds, ticks)
+}
+
+func healthyResult() HealthResultDto {
+	return HealthResultDto{
+		Status: Healthy,
+		Data:   map[string]string{},
+	}
+}
+
+func unhealthyResult(err error) HealthResultDto {
+	return HealthResultDto{
+		Status:      UnHealthy,
+		Exception:   err.Error(),
+		Description: err.Error(),
+		Data:        map[string]string{},
+	}
 }
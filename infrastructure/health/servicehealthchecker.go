@@ -1,57 +1,60 @@
 package health
 
 import (
+	"context"
+	"fmt"
 	"giftcard-engine/infrastructure/logger"
 	"net/http"
 )
 
 type serviceHealthChecker struct {
-	client   *http.Client
-	endpoint string
-	tag      string
+	client         *http.Client
+	endpoint       string
+	expectedStatus int
+	name           string
 }
 
-func (c *serviceHealthChecker) Check() HealthResultDto {
-	re, err := c.client.Get(c.endpoint)
+func (c *serviceHealthChecker) Check(ctx context.Context) HealthResultDto {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint, nil)
+	if err != nil {
+		return unhealthyResult(err)
+	}
+	re, err := c.client.Do(request)
 	if err != nil {
 		logger.WithException(err).WithDevMessage("error while checking service endpoint : " + c.endpoint).
 			Error("service is not responding properly")
-		return HealthResultDto{
-			Status:      UnHealthy,
-			Duration:    defaultTimeStampFormat,
-			Exception:   err.Error(),
-			Description: err.Error(),
-			Data:        map[string]string{},
-		}
+		return unhealthyResult(err)
 	}
-	if re.StatusCode < 200 || re.StatusCode > 260 {
+	defer re.Body.Close()
+	if !c.expected(re.StatusCode) {
 		logger.WithData(map[string]interface{}{
 			"statusCode": re.StatusCode,
 			"endpoint":   c.endpoint,
 		}).Warn("calling service endpoint resulted with invalid status code")
-		return HealthResultDto{
-			Status:      UnHealthy,
-			Duration:    defaultTimeStampFormat,
-			Exception:   "calling service endpoint resulted with invalid status code",
-			Description: "calling service endpoint resulted with invalid status code",
-			Data:        map[string]string{},
-		}
+		return unhealthyResult(fmt.Errorf("calling service endpoint resulted with invalid status code %d",
+			re.StatusCode))
 	}
-	return HealthResultDto{
-		Status:   Healthy,
-		Duration: defaultTimeStampFormat,
-		Data:     map[string]string{},
+	return healthyResult()
+}
+
+func (c *serviceHealthChecker) expected(status int) bool {
+	if c.expectedStatus == 0 {
+		return status >= 200 && status < 300
 	}
+	return status == c.expectedStatus
 }
 
-func (c *serviceHealthChecker) Tag() string {
-	return c.tag
+func (c *serviceHealthChecker) Name() string {
+	return c.name
 }
 
-func NewServiceHealthChecker(tag, endpoint string) Checker {
+// NewServiceHealthChecker returns a checker of the endpoint of a service, which is healthy when it responds with
+// the expected status, or any 2xx status when it is zero
+func NewServiceHealthChecker(name, endpoint string, expectedStatus int) Checker {
 	return &serviceHealthChecker{
-		client:   &http.Client{},
-		endpoint: endpoint,
-		tag:      tag,
+		client:         &http.Client{},
+		endpoint:       endpoint,
+		expectedStatus: expectedStatus,
+		name:           name,
 	}
 }
@@ -0,0 +1,41 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+var shuttingDown int32
+
+// BeginShutdown turns the health unhealthy so the load balancers stop sending requests before the server stops
+func BeginShutdown() {
+	atomic.StoreInt32(&shuttingDown, 1)
+}
+
+// IsShuttingDown reports whether the service has started to shut down
+func IsShuttingDown() bool {
+	return atomic.LoadInt32(&shuttingDown) == 1
+}
+
+type shutdownHealthChecker struct {
+	name string
+}
+
+func (c *shutdownHealthChecker) Check(context.Context) HealthResultDto {
+	if IsShuttingDown() {
+		return HealthResultDto{
+			Status:      UnHealthy,
+			Description: "the service is shutting down",
+			Data:        map[string]string{},
+		}
+	}
+	return healthyResult()
+}
+
+func (c *shutdownHealthChecker) Name() string {
+	return c.name
+}
+
+func NewShutdownHealthChecker(name string) Checker {
+	return &shutdownHealthChecker{name: name}
+}
@@ -0,0 +1,45 @@
+package lifecycle
+
+import (
+	"context"
+	"giftcard-engine/infrastructure/logger"
+	"sync"
+)
+
+// StopFunc stops a component. it should return once the component is stopped or the context is done
+type StopFunc func(ctx context.Context) error
+
+type component struct {
+	name string
+	stop StopFunc
+}
+
+var (
+	mutex      sync.Mutex
+	components []component
+)
+
+// OnStop registers a component, like a background job, to be stopped on shutdown. the components are stopped in
+// the reverse order of their registration so a component is stopped before the ones it was built on
+func OnStop(name string, stop StopFunc) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	components = append(components, component{name: name, stop: stop})
+}
+
+// Stop stops the registered components one by one until the context is done. the errors are logged and the
+// remaining components are still stopped
+func Stop(ctx context.Context) {
+	mutex.Lock()
+	stopping := components
+	components = nil
+	mutex.Unlock()
+
+	for i := len(stopping) - 1; i >= 0; i-- {
+		if err := stopping[i].stop(ctx); err != nil {
+			logger.WithData(map[string]interface{}{
+				"component": stopping[i].name,
+			}).ErrorException(err, "error while stopping a component")
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"giftcard-engine/infrastructure/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStopRunsTheComponentsInReverseOrder(t *testing.T) {
+	var stopped []string
+	for _, name := range []string{"database", "index", "worker"} {
+		component := name
+		lifecycle.OnStop(component, func(ctx context.Context) error {
+			stopped = append(stopped, component)
+			if component == "worker" {
+				return errors.New("worker is stuck")
+			}
+			return nil
+		})
+	}
+
+	lifecycle.Stop(context.Background())
+	lifecycle.Stop(context.Background())
+
+	assert.Equal(t, []string{"worker", "index", "database"}, stopped)
+}
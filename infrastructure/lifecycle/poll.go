@@ -0,0 +1,35 @@
+package lifecycle
+
+import (
+	"context"
+	"time"
+)
+
+// Poll calls poll every interval, with the time of the tick, until the returned function is called, which cancels
+// the context of poll and waits for the running call to return
+func Poll(interval time.Duration, poll func(ctx context.Context, now time.Time)) StopFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				poll(ctx, now)
+			}
+		}
+	}()
+	return func(stopCtx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-stopCtx.Done():
+			return stopCtx.Err()
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package lifecycle_test
+
+import (
+	"context"
+	"giftcard-engine/infrastructure/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPollCallsEveryIntervalUntilStopped(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	stop := lifecycle.Poll(5*time.Millisecond, func(ctx context.Context, now time.Time) {
+		atomic.AddInt32(&calls, 1)
+	})
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) >= 2 }, time.Second, time.Millisecond)
+	assert.NoError(t, stop(context.Background()))
+	stopped := atomic.LoadInt32(&calls)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(&calls), "poll is not called after the stop")
+}
+
+func TestPollStopCancelsTheRunningCall(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{}, 1)
+	stop := lifecycle.Poll(time.Millisecond, func(ctx context.Context, now time.Time) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-ctx.Done()
+	})
+	<-started
+
+	assert.NoError(t, stop(context.Background()))
+}
+
+func TestPollStopGivesUpWhenTheContextIsDone(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{}, 1)
+	stop := lifecycle.Poll(time.Millisecond, func(ctx context.Context, now time.Time) {
+		select {
+		case started <- struct{}{}:
+			<-release
+		default:
+		}
+	})
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, stop(ctx))
+}
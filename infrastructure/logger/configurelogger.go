@@ -2,6 +2,7 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/olivere/elastic"
 	log "github.com/sirupsen/logrus"
@@ -25,18 +26,19 @@ func (splitter *outputSplitter) Write(p []byte) (n int, err error) {
 var (
 	logConfig     LoggerConfiguration
 	elasticClient *elastic.Client
+	elasticHook   *asyncElasticHook
 )
 
 type LoggerConfiguration struct {
 	ServiceName string
 	Environment string
 	ElasticUrl  string
+	Level       string // overrides the level of the environment when it is set
 }
 
 func ConfigureLogger(config LoggerConfiguration) {
 	logConfig = config
 	if logConfig.Environment == "Production" || logConfig.Environment == "Staging" {
-		log.SetLevel(log.InfoLevel)
 		log.SetFormatter(&log.JSONFormatter{
 			TimestampFormat: "2006-01-02T15:04:05-0700",
 		})
@@ -48,23 +50,54 @@ func ConfigureLogger(config LoggerConfiguration) {
 			log.Panic(err)
 		}
 		elasticClient = client
-		hook, err := elogrus.NewAsyncElasticHookWithFunc(elasticClient, logConfig.ServiceName,
+		hook, err := elogrus.NewElasticHookWithFunc(elasticClient, logConfig.ServiceName,
 			log.InfoLevel, getTodayElasticIndexName)
 
 		if err != nil {
 			log.Panic(err)
 		}
-		log.AddHook(hook)
+		elasticHook = &asyncElasticHook{hook: hook}
+		log.AddHook(elasticHook)
 	} else {
-		log.SetLevel(log.DebugLevel)
 		log.SetFormatter(&log.JSONFormatter{
 			TimestampFormat: "2006-01-02T15:04:05-0700",
 			PrettyPrint:     true,
 		})
 		log.SetOutput(os.Stdout)
 	}
+	SetLevel(logConfig.Level)
+}
+
+// SetLevel changes the lowest level of the written logs. an empty or unknown level is the level of the
+// environment, info in production and staging and debug in the others
+func SetLevel(level string) {
+	parsed, err := log.ParseLevel(level)
+	if err != nil {
+		parsed = log.DebugLevel
+		if logConfig.Environment == "Production" || logConfig.Environment == "Staging" {
+			parsed = log.InfoLevel
+		}
+	}
+	log.SetLevel(parsed)
 }
 
 func getTodayElasticIndexName() string {
 	return fmt.Sprintf("jabama_%s-log-%s", strings.ToLower(logConfig.ServiceName), time.Now().Format("2006-01-02"))
 }
+
+// ElasticSink returns the client of the elasticsearch which the logs are sent to. it is nil when the logs are only
+// written to the output
+func ElasticSink() *elastic.Client {
+	return elasticClient
+}
+
+// Flush waits until the entries in flight are sent to elasticsearch, or the context is done, and stops the client
+func Flush(ctx context.Context) error {
+	if elasticHook == nil {
+		return nil
+	}
+	err := elasticHook.flush(ctx)
+	elasticHook.hook.Cancel()
+	elasticClient.Stop()
+	return err
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/sohlich/elogrus.v3"
+	"sync"
+)
+
+// asyncElasticHook sends the entries to elasticsearch in the background like the async hook of elogrus, but keeps
+// track of the entries in flight so they can be flushed before exiting
+type asyncElasticHook struct {
+	hook     *elogrus.ElasticHook
+	inFlight sync.WaitGroup
+}
+
+func (h *asyncElasticHook) Levels() []log.Level {
+	return h.hook.Levels()
+}
+
+func (h *asyncElasticHook) Fire(entry *log.Entry) error {
+	// logrus reuses the entry after the hooks so the goroutine gets a copy
+	data := make(log.Fields, len(entry.Data))
+	for key, value := range entry.Data {
+		data[key] = value
+	}
+	copied := &log.Entry{Logger: entry.Logger, Data: data, Time: entry.Time, Level: entry.Level,
+		Caller: entry.Caller, Message: entry.Message}
+	h.inFlight.Add(1)
+	go func() {
+		defer h.inFlight.Done()
+		_ = h.hook.Fire(copied)
+	}()
+	return nil
+}
+
+// flush waits for the entries in flight until the context is done
+func (h *asyncElasticHook) flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		h.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
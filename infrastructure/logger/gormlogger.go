@@ -1,14 +1,31 @@
 package logger
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
-type GormLogger struct{}
+type GormLogger struct {
+	ctx context.Context
+}
+
+// NewGormLogger returns a gorm logger adding the request id and the trace of the context to its lines
+func NewGormLogger(ctx context.Context) *GormLogger {
+	return &GormLogger{ctx: ctx}
+}
+
+func (l *GormLogger) entry() *EntryLog {
+	if l.ctx == nil {
+		return newUnitLogger()
+	}
+	return newUnitLogger().WithContext(l.ctx)
+}
 
-func (*GormLogger) Print(v ...interface{}) {
+func (l *GormLogger) Print(v ...interface{}) {
 	switch v[0] {
 
 	case "sql":
-		WithData(
+		l.entry().WithData(
 			map[string]interface{}{
 				"module":  "gorm",
 				"type":    "sql",
@@ -18,8 +35,8 @@ func (*GormLogger) Print(v ...interface{}) {
 			},
 		).Debug(fmt.Sprintf("%v", v[3]))
 	case "log":
-		WithData(map[string]interface{}{"module": "gorm", "type": "log"}).Debug(fmt.Sprintf("%v", v[2]))
+		l.entry().WithData(map[string]interface{}{"module": "gorm", "type": "log"}).Debug(fmt.Sprintf("%v", v[2]))
 	case "error":
-		WithData(map[string]interface{}{"module": "gorm", "type": "error"}).Error(fmt.Sprintf("%v", v[2]))
+		l.entry().WithData(map[string]interface{}{"module": "gorm", "type": "error"}).Error(fmt.Sprintf("%v", v[2]))
 	}
 }
@@ -5,4 +5,7 @@ const (
 	exceptionKey  = "exception"
 	dataKey       = "data"
 	devMessageKey = "devMessage"
+	requestIdKey  = "requestId"
+	traceIdKey    = "traceId"
+	spanIdKey     = "spanId"
 )
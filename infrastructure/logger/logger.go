@@ -1,5 +1,7 @@
 package logger
 
+import "context"
+
 func Trace(message string) {
 	newUnitLogger().Trace(message)
 }
@@ -53,3 +55,9 @@ func WithDevMessage(devMessage string) *EntryLog {
 func WithData(data interface{}) *EntryLog {
 	return newUnitLogger().WithData(data)
 }
+func WithContext(ctx context.Context) *EntryLog {
+	return newUnitLogger().WithContext(ctx)
+}
+func WithRequestId(requestId string) *EntryLog {
+	return newUnitLogger().WithRequestId(requestId)
+}
@@ -1,11 +1,18 @@
 package logger
 
-import log "github.com/sirupsen/logrus"
+import (
+	"context"
+	"giftcard-engine/infrastructure/requestid"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
 
 type EntryLog struct {
 	devMessage *string
 	exception  error
 	data       interface{}
+	ctx        context.Context
+	requestId  string
 }
 
 func newUnitLogger() *EntryLog {
@@ -27,6 +34,14 @@ func (u *EntryLog) log(level log.Level, message string) {
 	if u.data != nil {
 		entry = entry.WithField(dataKey, u.data)
 	}
+	if u.requestId != "" {
+		entry = entry.WithField(requestIdKey, u.requestId)
+	}
+	if u.ctx != nil {
+		if span := trace.SpanContextFromContext(u.ctx); span.IsValid() {
+			entry = entry.WithField(traceIdKey, span.TraceID().String()).WithField(spanIdKey, span.SpanID().String())
+		}
+	}
 	entry.Log(level, message)
 }
 
@@ -72,3 +87,16 @@ func (u *EntryLog) WithData(data interface{}) *EntryLog {
 	u.data = data
 	return u
 }
+
+// WithContext adds the request id and the trace and span ids of the context to the entry
+func (u *EntryLog) WithContext(ctx context.Context) *EntryLog {
+	u.ctx = ctx
+	if id := requestid.FromContext(ctx); id != "" {
+		u.requestId = id
+	}
+	return u
+}
+func (u *EntryLog) WithRequestId(requestId string) *EntryLog {
+	u.requestId = requestId
+	return u
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"giftcard-engine/core/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	cardsIssued = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cards_issued_total",
+		Help:      "Number of the created gift cards.",
+	})
+
+	cardsValidated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cards_validated_total",
+		Help:      "Number of the validated gift card secrets by their validity.",
+	}, []string{"valid"})
+
+	cardsApproved = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cards_approved_total",
+		Help:      "Number of the gift cards approved for a user and not rolled back.",
+	})
+
+	approveFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "approve_failures_total",
+		Help:      "Number of the failed gift card approvals by their reason.",
+	}, []string{"reason"})
+
+	webhookAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_attempts_total",
+		Help:      "Number of the webhook delivery attempts by their event and outcome.",
+	}, []string{"event", "outcome"})
+
+	outboxEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_events_total",
+		Help:      "Number of the outbox events relayed to the publishers by the publisher and outcome.",
+	}, []string{"publisher", "outcome"})
+)
+
+// approveFailureReasons are the labels of the known approve errors. any other error is internal
+var approveFailureReasons = map[error]string{
+	common.GiftCardNotFound: "not_found",
+	common.GiftCardIsTaken:  "taken",
+	common.GiftCardIsVoided: "voided",
+}
+
+const internalFailure = "internal"
+
+func CardsIssued(count int) {
+	cardsIssued.Add(float64(count))
+}
+
+func CardValidated(valid bool) {
+	label := "false"
+	if valid {
+		label = "true"
+	}
+	cardsValidated.WithLabelValues(label).Inc()
+}
+
+func CardsApproved(count int) {
+	cardsApproved.Add(float64(count))
+}
+
+func ApproveFailed(err error) {
+	reason, ok := approveFailureReasons[err]
+	if !ok {
+		reason = internalFailure
+	}
+	approveFailures.WithLabelValues(reason).Inc()
+}
+
+// the outcomes of the webhook delivery attempts
+const (
+	WebhookDelivered = "delivered"
+	WebhookRetried   = "retried"
+	WebhookDead      = "dead"
+)
+
+func WebhookAttempted(event, outcome string) {
+	webhookAttempts.WithLabelValues(event, outcome).Inc()
+}
+
+// the outcomes of relaying the outbox events to a publisher
+const (
+	EventPublished = "published"
+	EventRetried   = "retried"
+	EventDead      = "dead"
+)
+
+func EventsRelayed(publisher, outcome string, count int) {
+	outboxEvents.WithLabelValues(publisher, outcome).Add(float64(count))
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"giftcard-engine/infrastructure/health"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var healthStatusDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "health_check_status"),
+	"Result of the health checker, 1 when healthy and 0 otherwise.",
+	[]string{"check"}, nil,
+)
+
+// healthCollector runs the health checkers on every scrape
+type healthCollector struct{}
+
+func (c *healthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- healthStatusDesc
+}
+
+func (c *healthCollector) Collect(ch chan<- prometheus.Metric) {
+	for tag, result := range health.Check() {
+		value := 0.0
+		if result.Status == health.Healthy {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(healthStatusDesc, prometheus.GaugeValue, value, tag)
+	}
+}
+
+func newHealthCollector() prometheus.Collector {
+	return &healthCollector{}
+}
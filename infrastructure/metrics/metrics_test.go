@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"errors"
+	"giftcard-engine/core/common"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	route := gin.New()
+	route.Use(Middleware())
+	route.GET("/v1/gift-card/find/:id", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+	route.GET("/metrics", gin.WrapH(Handler()))
+
+	for _, path := range []string{"/v1/gift-card/find/1", "/v1/gift-card/find/2", "/random"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		route.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(
+		requestsTotal.WithLabelValues("GET", "/v1/gift-card/find/:id", "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(requestsTotal.WithLabelValues("GET", unmatchedRoute, "404")))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	route.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.True(t, strings.Contains(w.Body.String(),
+		`giftcard_http_request_duration_seconds_count{method="GET",route="/v1/gift-card/find/:id"} 2`))
+}
+
+func TestApproveFailed(t *testing.T) {
+	ApproveFailed(common.GiftCardIsTaken)
+	ApproveFailed(common.GiftCardIsTaken)
+	ApproveFailed(errors.New("connection lost"))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(approveFailures.WithLabelValues("taken")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(approveFailures.WithLabelValues(internalFailure)))
+}
+
+func TestCardValidated(t *testing.T) {
+	CardValidated(true)
+	CardValidated(false)
+	CardValidated(false)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(cardsValidated.WithLabelValues("true")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(cardsValidated.WithLabelValues("false")))
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+const namespace = "giftcard"
+
+// registry holds every metric of the service. a registry of our own keeps the tests independent of
+// the collectors other libraries put on the default one
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestsTotal,
+		requestDuration,
+		cardsIssued,
+		cardsValidated,
+		cardsApproved,
+		approveFailures,
+		webhookAttempts,
+		outboxEvents,
+	)
+}
+
+// ConfigureMetrics adds the pool stats of the database and the results of the health checkers to the metrics.
+// db is nil when the repositories keep the data in memory
+func ConfigureMetrics(db *gorm.DB) {
+	if db != nil {
+		registry.MustRegister(collectors.NewDBStatsCollector(db.DB(), "defaultConnection"))
+	}
+	registry.MustRegister(newHealthCollector())
+}
+
+// Handler serves the metrics in the prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
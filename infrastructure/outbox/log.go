@@ -0,0 +1,27 @@
+package outbox
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/infrastructure/logger"
+)
+
+type logPublisher struct{}
+
+// NewLogPublisher returns the publisher which writes every event to the logs, so the events can be followed where
+// there is no other receiver of them
+func NewLogPublisher() core.EventPublisher {
+	return logPublisher{}
+}
+
+func (logPublisher) Publish(ctx context.Context, events []core.DomainEvent) error {
+	for _, event := range events {
+		logger.WithData(map[string]interface{}{
+			"eventId":     event.ID,
+			"aggregateId": event.AggregateId,
+			"occurredAt":  event.OccurredAt,
+			"payload":     string(event.Payload),
+		}).Info("Domain event " + event.Name)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Package outbox relays the domain events which are stored in the outbox to the publishers of the event bus
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/lifecycle"
+	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/infrastructure/metrics"
+	"giftcard-engine/infrastructure/retry"
+	"strconv"
+	"time"
+)
+
+// purgeInterval is how often the published events which are older than the retention are deleted
+const purgeInterval = time.Hour
+
+// Relay hands the due events of the outbox to every publisher of the bus which has not got them yet. an event is
+// published once every publisher gets it, and is retried with an exponential backoff until then, so the events
+// are delivered at least once and a publisher may get an event again when the relay stops in the middle of it.
+// an event which fails the max attempts is dead, so a poison event is not claimed again on every poll
+type Relay struct {
+	repository core.OutboxRepository
+	bus        *core.EventBus
+	config     configuration.OutboxConfiguration
+}
+
+func NewRelay(repository core.OutboxRepository, bus *core.EventBus, config configuration.OutboxConfiguration) *Relay {
+	return &Relay{repository: repository, bus: bus, config: config}
+}
+
+// Start relays the due events every poll interval and purges the old published ones every hour until the returned
+// function is called, which waits for the events being relayed
+func (r *Relay) Start() func(ctx context.Context) error {
+	purged := time.Now()
+	return lifecycle.Poll(r.config.PollInterval, func(ctx context.Context, now time.Time) {
+		if _, err := r.Relay(ctx); err != nil && ctx.Err() == nil {
+			logger.ErrorException(err, "error while relaying the outbox events")
+		}
+		if now.Sub(purged) >= purgeInterval {
+			purged = now
+			r.purge(ctx, now)
+		}
+	})
+}
+
+// Relay hands the due events to the publishers once, up to the batch size of them, and returns the number of the
+// published ones
+func (r *Relay) Relay(ctx context.Context) (int, error) {
+	// a claimed event is due again only after every publisher has surely timed out
+	lease := time.Duration(len(r.bus.Publishers()))*r.config.Timeout + time.Minute
+	due, err := r.repository.ClaimDueEvents(ctx, time.Now(), lease, r.config.BatchSize)
+	if err != nil || len(due) == 0 {
+		return 0, err
+	}
+
+	// the publishers which failed the events last, whose errors are the last errors of the events
+	failures := map[int]string{}
+	for _, publisher := range r.bus.Publishers() {
+		var events []core.DomainEvent
+		var pending []*dbmodel.OutboxEvent
+		for i := range due {
+			if due[i].IsPublishedTo(publisher) {
+				continue
+			}
+			pending = append(pending, &due[i])
+			events = append(events, domainEvent(due[i]))
+		}
+		if len(events) == 0 {
+			continue
+		}
+		if err := r.publish(ctx, publisher, events); err != nil {
+			if ctx.Err() != nil {
+				// the events are retried after the lease and the attempt is not counted, but the publishers
+				// which got them are kept so they are not sent them again
+				r.store(due)
+				return 0, nil
+			}
+			for _, event := range pending {
+				failures[event.ID] = publisher
+				event.LastError = publisher + ": " + err.Error()
+			}
+			metrics.EventsRelayed(publisher, metrics.EventRetried, len(events))
+			logger.WithData(map[string]interface{}{
+				"publisher": publisher,
+				"events":    len(events),
+			}).Warn("The outbox events are not published and are retried: " + err.Error())
+			continue
+		}
+		for _, event := range pending {
+			event.PublishTo(publisher)
+		}
+		metrics.EventsRelayed(publisher, metrics.EventPublished, len(events))
+	}
+
+	var published int
+	now := time.Now()
+	for i := range due {
+		event := &due[i]
+		publisher, failed := failures[event.ID]
+		switch {
+		case failed && event.Attempts+1 >= r.config.MaxAttempts:
+			event.Die(event.LastError, now)
+			metrics.EventsRelayed(publisher, metrics.EventDead, 1)
+			logger.WithData(map[string]interface{}{
+				"eventId": event.ID,
+				"event":   event.Event,
+			}).Warn("An outbox event is dead after " + strconv.Itoa(event.Attempts) + " attempts: " + event.LastError)
+		case failed:
+			event.Failed(event.LastError,
+				now.Add(retry.Backoff(event.Attempts+1, r.config.RetryDelay, r.config.MaxRetryDelay)))
+		default:
+			event.Published(now)
+			published++
+		}
+	}
+	r.store(due)
+	return published, nil
+}
+
+// store saves the outcome of relaying the events, even after the context of the relay is done
+func (r *Relay) store(events []dbmodel.OutboxEvent) {
+	for i := range events {
+		if err := r.repository.StoreEvent(context.Background(), &events[i]); err != nil {
+			logger.WithData(map[string]interface{}{
+				"eventId": events[i].ID,
+			}).ErrorException(err, "error while storing an outbox event")
+		}
+	}
+}
+
+// publish hands the events to the publisher within the timeout
+func (r *Relay) publish(ctx context.Context, publisher string, events []core.DomainEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+	defer cancel()
+	return r.bus.Publish(ctx, publisher, events)
+}
+
+// purge deletes the published events which are older than the retention
+func (r *Relay) purge(ctx context.Context, now time.Time) {
+	if r.config.Retention <= 0 {
+		return
+	}
+	deleted, err := r.repository.DeletePublished(ctx, now.Add(-r.config.Retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.ErrorException(err, "error while purging the outbox")
+		}
+		return
+	}
+	if deleted > 0 {
+		logger.Print("Purged " + strconv.Itoa(deleted) + " published events of the outbox\n")
+	}
+}
+
+func domainEvent(event dbmodel.OutboxEvent) core.DomainEvent {
+	return core.DomainEvent{
+		ID:          event.ID,
+		Name:        event.Event,
+		AggregateId: event.AggregateId,
+		OccurredAt:  event.CreatedAt,
+		Payload:     json.RawMessage(event.Payload),
+	}
+}
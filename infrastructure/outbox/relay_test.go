@@ -0,0 +1,191 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/outbox"
+	"giftcard-engine/infrastructure/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+// publisher records the events it gets and fails the given number of times first
+type publisher struct {
+	mu       sync.Mutex
+	failures int
+	events   []core.DomainEvent
+}
+
+func (p *publisher) Publish(ctx context.Context, events []core.DomainEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("unavailable")
+	}
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func (p *publisher) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.events)
+}
+
+// cancelling cancels the relay while it publishes
+type cancelling struct {
+	cancel context.CancelFunc
+}
+
+func (c *cancelling) Publish(ctx context.Context, events []core.DomainEvent) error {
+	c.cancel()
+	return ctx.Err()
+}
+
+func testConfiguration() configuration.OutboxConfiguration {
+	return configuration.OutboxConfiguration{
+		PollInterval:  10 * time.Millisecond,
+		Timeout:       time.Second,
+		BatchSize:     10,
+		MaxAttempts:   3,
+		RetryDelay:    time.Millisecond,
+		MaxRetryDelay: time.Millisecond,
+		Retention:     time.Hour,
+	}
+}
+
+// createRelayForTest stores an issued card, which raises the only event, and returns a relay of the publishers
+func createRelayForTest(t *testing.T, publishers map[string]core.EventPublisher) (*outbox.Relay,
+	core.OutboxRepository, *dbmodel.GiftCard) {
+	database := memory.NewDatabase()
+	campaign := dbmodel.NewCampaign("yalda")
+	campaign.ClearEvents()
+	require.Nil(t, memory.NewCampaignRepository(database).Store(context.Background(), campaign))
+	card := dbmodel.NewGiftCard(1000, time.Now().AddDate(0, 1, 0).UTC())
+	card.SetCampaign(uint(campaign.ID))
+	require.Nil(t, memory.NewGiftCardRepository(database).Store(context.Background(), card))
+
+	bus := core.NewEventBus()
+	for _, name := range []string{"webhooks", "broker"} {
+		if publisher, ok := publishers[name]; ok {
+			bus.Subscribe(name, publisher)
+		}
+	}
+	repo := memory.NewOutboxRepository(database)
+	return outbox.NewRelay(repo, bus, testConfiguration()), repo, card
+}
+
+// relayUntilDue waits for the backoff of the last attempt and relays again
+func relayUntilDue(t *testing.T, relay *outbox.Relay) int {
+	time.Sleep(5 * time.Millisecond)
+	published, err := relay.Relay(context.Background())
+	assert.Empty(t, err)
+	return published
+}
+
+func TestRelay(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		webhooks := &publisher{}
+		relay, _, card := createRelayForTest(t, map[string]core.EventPublisher{"webhooks": webhooks})
+
+		assert.Equal(t, 1, relayUntilDue(t, relay))
+		require.Equal(t, 1, webhooks.count())
+		assert.Equal(t, dbmodel.CardIssuedEvent, webhooks.events[0].Name)
+		assert.Equal(t, card.ID, webhooks.events[0].AggregateId)
+		assert.Contains(t, string(webhooks.events[0].Payload), card.PublicCode)
+
+		assert.Equal(t, 0, relayUntilDue(t, relay))
+		assert.Equal(t, 1, webhooks.count(), "a published event should not be relayed again")
+	})
+
+	te.Run("with failed publisher", func(t *testing.T) {
+		t.Parallel()
+		webhooks := &publisher{}
+		broker := &publisher{failures: 1}
+		relay, repo, _ := createRelayForTest(t, map[string]core.EventPublisher{"webhooks": webhooks,
+			"broker": broker})
+
+		assert.Equal(t, 0, relayUntilDue(t, relay))
+		time.Sleep(5 * time.Millisecond)
+		failed, err := repo.ClaimDueEvents(context.Background(), time.Now(), 0, 10)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(failed))
+		assert.Equal(t, 1, failed[0].Attempts)
+		assert.Equal(t, "broker: unavailable", failed[0].LastError)
+		assert.True(t, failed[0].IsPublishedTo("webhooks"))
+
+		assert.Equal(t, 1, relayUntilDue(t, relay))
+		assert.Equal(t, 1, webhooks.count(), "the publishers which got the event should not get it again")
+		assert.Equal(t, 1, broker.count())
+	})
+
+	te.Run("with dead event", func(t *testing.T) {
+		t.Parallel()
+		webhooks := &publisher{}
+		broker := &publisher{failures: 3}
+		relay, repo, _ := createRelayForTest(t, map[string]core.EventPublisher{"webhooks": webhooks,
+			"broker": broker})
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, 0, relayUntilDue(t, relay))
+		}
+		assert.Equal(t, 0, relayUntilDue(t, relay))
+		due, err := repo.ClaimDueEvents(context.Background(), time.Now().Add(time.Hour), 0, 10)
+
+		require.Nil(t, err)
+		assert.Empty(t, due, "a dead event should not be claimed again")
+		assert.Equal(t, 0, broker.count())
+		assert.Equal(t, 1, webhooks.count())
+	})
+
+	te.Run("with cancelled context", func(t *testing.T) {
+		t.Parallel()
+		webhooks := &publisher{}
+		relay, _, _ := createRelayForTest(t, map[string]core.EventPublisher{"webhooks": webhooks})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := relay.Relay(ctx)
+
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, 0, webhooks.count())
+	})
+
+	te.Run("with context cancelled while publishing", func(t *testing.T) {
+		t.Parallel()
+		webhooks := &publisher{}
+		ctx, cancel := context.WithCancel(context.Background())
+		relay, repo, _ := createRelayForTest(t, map[string]core.EventPublisher{"webhooks": webhooks,
+			"broker": &cancelling{cancel: cancel}})
+
+		published, err := relay.Relay(ctx)
+		due, claimErr := repo.ClaimDueEvents(context.Background(), time.Now().Add(time.Hour), 0, 10)
+
+		assert.Empty(t, err)
+		assert.Equal(t, 0, published)
+		require.Nil(t, claimErr)
+		require.Equal(t, 1, len(due), "the event should be retried after the lease")
+		assert.True(t, due[0].IsPublishedTo("webhooks"), "the progress of the relay should be kept")
+		assert.Equal(t, 0, due[0].Attempts, "the cancelled attempt should not be counted")
+	})
+}
+
+func TestRelayStart(t *testing.T) {
+	t.Parallel()
+	webhooks := &publisher{}
+	relay, _, _ := createRelayForTest(t, map[string]core.EventPublisher{"webhooks": webhooks})
+	stop := relay.Start()
+
+	assert.Eventually(t, func() bool { return webhooks.count() == 1 }, time.Second, 10*time.Millisecond)
+	assert.Empty(t, stop(context.Background()))
+	assert.Equal(t, 1, webhooks.count())
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"sort"
+	"time"
+)
+
+const batchTable = "Batch"
+
+type batchRepository struct {
+	db *Database
+}
+
+func (r *batchRepository) FindByID(ctx context.Context, id uint) (*dbmodel.Batch, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	batch, ok := r.db.batches[int(id)]
+	if !ok || !live(batch.DeletedAt) {
+		return nil, common.BatchNotFound
+	}
+	batch = cloneBatch(batch)
+	return &batch, nil
+}
+
+func (r *batchRepository) Store(ctx context.Context, batch *dbmodel.Batch) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	stored, ok := r.db.batches[batch.ID]
+	switch {
+	case batch.ID == 0:
+		batch.ID = r.db.nextId(batchTable)
+	case ok && !live(stored.DeletedAt):
+		return fmt.Errorf("%w: batch %d", ErrDuplicateKey, batch.ID)
+	default:
+		r.db.claimId(batchTable, batch.ID)
+	}
+	now := time.Now()
+	if batch.CreatedAt.IsZero() {
+		batch.CreatedAt = now
+	}
+	batch.UpdatedAt = now
+	r.db.batches[batch.ID] = cloneBatch(*batch)
+	return nil
+}
+
+func (r *batchRepository) Delete(ctx context.Context, batch dbmodel.Batch) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	stored, ok := r.db.batches[batch.ID]
+	if ok && live(stored.DeletedAt) {
+		now := time.Now()
+		stored.DeletedAt = &now
+		r.db.batches[batch.ID] = stored
+	}
+	return nil
+}
+
+func (r *batchRepository) FindPage(ctx context.Context, size, number uint, createdBy string) ([]dbmodel.Batch, int) {
+	batches := []dbmodel.Batch{}
+	if ctx.Err() != nil {
+		return batches, 0
+	}
+	r.db.mu.RLock()
+	for _, batch := range r.db.batches {
+		if live(batch.DeletedAt) && (createdBy == "" || batch.CreatedBy == createdBy) {
+			batches = append(batches, cloneBatch(batch))
+		}
+	}
+	r.db.mu.RUnlock()
+	sort.Slice(batches, func(i, j int) bool { return batches[i].ID > batches[j].ID })
+	start, end := page(len(batches), size, number)
+	return batches[start:end], len(batches)
+}
+
+func NewBatchRepository(db *Database) core.BatchRepository {
+	return &batchRepository{db: db}
+}
@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"sort"
+	"strings"
+	"time"
+)
+
+const campaignTable = "Campaign"
+
+type campaignRepository struct {
+	db *Database
+}
+
+func (r *campaignRepository) FindByID(ctx context.Context, id uint) (dbmodel.Campaign, error) {
+	if err := ctx.Err(); err != nil {
+		return dbmodel.EmptyCampaign(), err
+	}
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	campaign, ok := r.db.campaigns[int(id)]
+	if !ok || !live(campaign.DeletedAt) {
+		return dbmodel.EmptyCampaign(), common.CampaignNotFound
+	}
+	return cloneCampaign(campaign), nil
+}
+
+func (r *campaignRepository) Store(ctx context.Context, campaign *dbmodel.Campaign) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	for _, stored := range r.db.campaigns {
+		if stored.Title != campaign.Title {
+			continue
+		}
+		if live(stored.DeletedAt) {
+			return common.DuplicatedCampaignTitle
+		}
+		if stored.ID != campaign.ID {
+			return fmt.Errorf("%w: campaign title %s", ErrDuplicateKey, campaign.Title)
+		}
+	}
+
+	now := time.Now()
+	stored, ok := r.db.campaigns[campaign.ID]
+	switch {
+	case campaign.ID == 0:
+		campaign.ID = r.db.nextId(campaignTable)
+	case ok && !live(stored.DeletedAt):
+		return fmt.Errorf("%w: campaign %d", ErrDuplicateKey, campaign.ID)
+	default:
+		r.db.claimId(campaignTable, campaign.ID)
+	}
+	if campaign.CreatedAt.IsZero() {
+		campaign.CreatedAt = now
+	}
+	campaign.UpdatedAt = now
+	events, err := r.db.campaignEvents(*campaign, campaign.Events())
+	if err != nil {
+		return err
+	}
+	campaign.ClearEvents()
+	r.db.campaigns[campaign.ID] = cloneCampaign(*campaign)
+	r.db.addEvents(events)
+	return nil
+}
+
+func (r *campaignRepository) Delete(ctx context.Context, campaign dbmodel.Campaign) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	stored, ok := r.db.campaigns[campaign.ID]
+	if ok && live(stored.DeletedAt) {
+		events, err := r.db.campaignEvents(stored, []string{dbmodel.CampaignDeletedEvent})
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		stored.DeletedAt = &now
+		r.db.campaigns[campaign.ID] = stored
+		r.db.addEvents(events)
+	}
+	return nil
+}
+
+func (r *campaignRepository) FindPage(ctx context.Context, size, number uint, search string) ([]dbmodel.Campaign, int) {
+	campaigns := r.filter(ctx, search)
+	start, end := page(len(campaigns), size, number)
+	return campaigns[start:end], len(campaigns)
+}
+
+func (r *campaignRepository) FindCursorPage(ctx context.Context, size uint, after *int, search string,
+	withCount bool) ([]dbmodel.Campaign, int) {
+	campaigns := r.filter(ctx, search)
+	total := -1
+	if withCount {
+		total = len(campaigns)
+	}
+	start := 0
+	if after != nil {
+		start = sort.Search(len(campaigns), func(i int) bool { return campaigns[i].ID < *after })
+	}
+	_, end := page(len(campaigns)-start, size, 0)
+	return campaigns[start : start+end], total
+}
+
+// filter returns the live campaigns whose title contains the search, ordered by id desc
+func (r *campaignRepository) filter(ctx context.Context, search string) []dbmodel.Campaign {
+	campaigns := []dbmodel.Campaign{}
+	if ctx.Err() != nil {
+		return campaigns
+	}
+	search = strings.ToLower(search)
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	for _, campaign := range r.db.campaigns {
+		if live(campaign.DeletedAt) && strings.Contains(strings.ToLower(campaign.Title), search) {
+			campaigns = append(campaigns, cloneCampaign(campaign))
+		}
+	}
+	sort.Slice(campaigns, func(i, j int) bool { return campaigns[i].ID > campaigns[j].ID })
+	return campaigns
+}
+
+func NewCampaignRepository(db *Database) core.CampaignRepository {
+	return &campaignRepository{db: db}
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"time"
+)
+
+const commandTable = "Command"
+
+type commandRepository struct {
+	db *Database
+}
+
+// ClaimCommand finds and claims the command under the write lock, so a command is never claimed twice
+func (r *commandRepository) ClaimCommand(ctx context.Context, commandId string, now time.Time,
+	lease time.Duration) (*dbmodel.Command, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	stored, ok := r.db.commands[commandId]
+	switch {
+	case !ok:
+		command := dbmodel.NewCommand(commandId, now)
+		command.ID = r.db.nextId(commandTable)
+		command.CreatedAt = now
+		command.UpdatedAt = now
+		r.db.commands[commandId] = cloneCommand(*command)
+		return command, true, nil
+	case stored.IsHandled() || stored.ClaimedAt.After(now.Add(-lease)):
+		stored = cloneCommand(stored)
+		return &stored, false, nil
+	default:
+		stored.ClaimedAt = now.UTC()
+		r.db.commands[commandId] = stored
+		stored = cloneCommand(stored)
+		return &stored, true, nil
+	}
+}
+
+func (r *commandRepository) StoreCommand(ctx context.Context, command *dbmodel.Command) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	command.UpdatedAt = time.Now()
+	r.db.commands[command.CommandId] = cloneCommand(*command)
+	return nil
+}
+
+func (r *commandRepository) DeleteCommand(ctx context.Context, command dbmodel.Command) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	delete(r.db.commands, command.CommandId)
+	return nil
+}
+
+func NewCommandRepository(db *Database) core.CommandRepository {
+	return &commandRepository{db: db}
+}
@@ -0,0 +1,21 @@
+package memory_test
+
+import (
+	"giftcard-engine/infrastructure/repository/memory"
+	"giftcard-engine/infrastructure/repository/repositorytest"
+	"testing"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		database := memory.NewDatabase()
+		return repositorytest.Repositories{
+			GiftCards: memory.NewGiftCardRepository(database),
+			Campaigns: memory.NewCampaignRepository(database),
+			Batches:   memory.NewBatchRepository(database),
+			Webhooks:  memory.NewWebhookRepository(database),
+			Outbox:    memory.NewOutboxRepository(database),
+			Commands:  memory.NewCommandRepository(database),
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"errors"
+	"giftcard-engine/core/dbmodel"
+	"sync"
+	"time"
+)
+
+// Driver is the database driver of the configuration which selects the in-memory repositories
+const Driver = "memory"
+
+// ErrDuplicateKey is returned when a row breaks a unique index, like the sql databases do. the message contains
+// duplicate so the gift card service generates new codes for the card
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// Database keeps the rows of the in-memory repositories in the process. the repositories of a database share
+// its lock so the guards of the gift cards see the campaigns, and every row is copied in and out so the callers
+// never share the stored values
+type Database struct {
+	mu        sync.RWMutex
+	giftCards map[int]dbmodel.GiftCard
+	// the ids of the gift cards by their unique codes, of the deleted cards too like the unique indexes
+	publicCodes map[string]int
+	secretCodes map[string]int
+	campaigns   map[int]dbmodel.Campaign
+	batches     map[int]dbmodel.Batch
+	revisions   []dbmodel.GiftCardRevision
+	// the webhook subscriptions and their deliveries
+	webhooks   map[int]dbmodel.WebhookSubscription
+	deliveries map[int]dbmodel.WebhookDelivery
+	outbox     map[int]dbmodel.OutboxEvent
+	// the commands of the other services by their command ids, like the unique index
+	commands map[string]dbmodel.Command
+	lastIds  map[string]int
+}
+
+func NewDatabase() *Database {
+	return &Database{
+		giftCards:   map[int]dbmodel.GiftCard{},
+		publicCodes: map[string]int{},
+		secretCodes: map[string]int{},
+		campaigns:   map[int]dbmodel.Campaign{},
+		batches:     map[int]dbmodel.Batch{},
+		webhooks:    map[int]dbmodel.WebhookSubscription{},
+		deliveries:  map[int]dbmodel.WebhookDelivery{},
+		outbox:      map[int]dbmodel.OutboxEvent{},
+		commands:    map[string]dbmodel.Command{},
+		lastIds:     map[string]int{},
+	}
+}
+
+// indexCodes points the codes of the stored card to it, instead of the codes of its previous row
+func (d *Database) indexCodes(previous *dbmodel.GiftCard, card dbmodel.GiftCard) {
+	if previous != nil {
+		delete(d.publicCodes, previous.PublicCode)
+		delete(d.secretCodes, previous.SecretCode)
+	}
+	d.publicCodes[card.PublicCode] = card.ID
+	d.secretCodes[card.SecretCode] = card.ID
+}
+
+// nextId returns the next identity of the table
+func (d *Database) nextId(table string) int {
+	d.lastIds[table]++
+	return d.lastIds[table]
+}
+
+// claimId keeps the next identities of the table after a row stored with an explicit one
+func (d *Database) claimId(table string, id int) {
+	if id > d.lastIds[table] {
+		d.lastIds[table] = id
+	}
+}
+
+// live reports whether the row is not soft deleted
+func live(deletedAt *time.Time) bool {
+	return deletedAt == nil
+}
+
+// cloneTime copies the time a nullable column points to
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := *t
+	return &value
+}
+
+// cloneGiftCard copies the card without its associations
+func cloneGiftCard(card dbmodel.GiftCard) dbmodel.GiftCard {
+	card.DeletedAt = cloneTime(card.DeletedAt)
+	card.RedeemedAt = cloneTime(card.RedeemedAt)
+	card.VoidedAt = cloneTime(card.VoidedAt)
+	if card.BatchId != nil {
+		batchId := *card.BatchId
+		card.BatchId = &batchId
+	}
+	card.Campaign = nil
+	card.Revisions = nil
+	return card
+}
+
+func cloneCampaign(campaign dbmodel.Campaign) dbmodel.Campaign {
+	campaign.DeletedAt = cloneTime(campaign.DeletedAt)
+	return campaign
+}
+
+func cloneBatch(batch dbmodel.Batch) dbmodel.Batch {
+	batch.DeletedAt = cloneTime(batch.DeletedAt)
+	return batch
+}
+
+func cloneWebhookSubscription(subscription dbmodel.WebhookSubscription) dbmodel.WebhookSubscription {
+	subscription.DeletedAt = cloneTime(subscription.DeletedAt)
+	return subscription
+}
+
+func cloneWebhookDelivery(delivery dbmodel.WebhookDelivery) dbmodel.WebhookDelivery {
+	delivery.DeliveredAt = cloneTime(delivery.DeliveredAt)
+	return delivery
+}
+
+func cloneOutboxEvent(event dbmodel.OutboxEvent) dbmodel.OutboxEvent {
+	event.PublishedAt = cloneTime(event.PublishedAt)
+	event.DeadAt = cloneTime(event.DeadAt)
+	return event
+}
+
+func cloneCommand(command dbmodel.Command) dbmodel.Command {
+	command.HandledAt = cloneTime(command.HandledAt)
+	return command
+}
+
+// withCampaign preloads the campaign of the card like the sql repositories, which skip the deleted campaigns
+func (d *Database) withCampaign(card dbmodel.GiftCard) dbmodel.GiftCard {
+	card = cloneGiftCard(card)
+	if campaign, ok := d.campaigns[int(card.CampaignId)]; ok && live(campaign.DeletedAt) {
+		campaign = cloneCampaign(campaign)
+		card.Campaign = &campaign
+	}
+	return card
+}
+
+// page returns the part of the rows of the given page
+func page(length int, size, number uint) (int, int) {
+	start := int(size * number)
+	if start > length {
+		start = length
+	}
+	end := start + int(size)
+	if end > length {
+		end = length
+	}
+	return start, end
+}
@@ -0,0 +1,600 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	giftCardTable         = "GiftCard"
+	giftCardRevisionTable = "GiftCardRevision"
+)
+
+type gCardRepository struct {
+	db *Database
+}
+
+func (r *gCardRepository) FindByUUN(ctx context.Context, uun string) []dbmodel.GiftCard {
+	return r.find(ctx, true, func(card dbmodel.GiftCard) bool { return card.UUN == uun })
+}
+
+func (r *gCardRepository) FindByID(ctx context.Context, id uint) (*dbmodel.GiftCard, error) {
+	return r.first(ctx, true, func(card dbmodel.GiftCard) bool { return card.ID == int(id) })
+}
+
+func (r *gCardRepository) FindByIDs(ctx context.Context, ids []int) []dbmodel.GiftCard {
+	wanted := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	return r.find(ctx, true, func(card dbmodel.GiftCard) bool { return wanted[card.ID] })
+}
+
+func (r *gCardRepository) Store(ctx context.Context, card *dbmodel.GiftCard) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	return r.store(card)
+}
+
+// store saves the card and its new revisions. the caller holds the write lock
+func (r *gCardRepository) store(card *dbmodel.GiftCard) error {
+	campaign, ok := r.db.campaigns[int(card.CampaignId)]
+	if !ok || !live(campaign.DeletedAt) {
+		return common.InvalidCampaign
+	}
+	if id, ok := r.db.publicCodes[card.PublicCode]; ok && id != card.ID {
+		return fmt.Errorf("%w: public code %s", ErrDuplicateKey, card.PublicCode)
+	}
+	if id, ok := r.db.secretCodes[card.SecretCode]; ok && id != card.ID {
+		return fmt.Errorf("%w: secret code", ErrDuplicateKey)
+	}
+
+	stored, ok := r.db.giftCards[card.ID]
+	switch {
+	case card.ID == 0:
+		card.ID = r.db.nextId(giftCardTable)
+	case ok && !live(stored.DeletedAt):
+		return fmt.Errorf("%w: gift card %d", ErrDuplicateKey, card.ID)
+	default:
+		r.db.claimId(giftCardTable, card.ID)
+	}
+	events, err := r.db.cardEvents(*card, card.Events())
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if card.CreatedAt.IsZero() {
+		card.CreatedAt = now
+	}
+	card.UpdatedAt = now
+	for i := range card.Revisions {
+		revision := &card.Revisions[i]
+		if revision.ID != 0 {
+			continue
+		}
+		revision.ID = r.db.nextId(giftCardRevisionTable)
+		revision.GiftCardId = card.ID
+		r.db.revisions = append(r.db.revisions, *revision)
+	}
+	card.ClearEvents()
+	var previous *dbmodel.GiftCard
+	if ok {
+		previous = &stored
+	}
+	r.db.indexCodes(previous, *card)
+	r.db.giftCards[card.ID] = cloneGiftCard(*card)
+	r.db.addEvents(events)
+	return nil
+}
+
+func (r *gCardRepository) Delete(ctx context.Context, card dbmodel.GiftCard) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	stored, ok := r.db.giftCards[card.ID]
+	if !ok || !live(stored.DeletedAt) {
+		return nil
+	}
+	events, err := r.db.cardEvents(stored, []string{dbmodel.CardDeletedEvent})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	stored.DeletedAt = &now
+	r.db.giftCards[card.ID] = stored
+	r.db.addEvents(events)
+	return nil
+}
+
+func (r *gCardRepository) FindByPublicKey(ctx context.Context, key string) (*dbmodel.GiftCard, error) {
+	return r.byCode(ctx, r.db.publicCodes, key)
+}
+
+func (r *gCardRepository) FindPage(ctx context.Context, size, number uint,
+	filter core.GiftCardFilter) ([]dbmodel.GiftCard, int) {
+	giftCards := r.filter(ctx, filter)
+	sortGiftCards(giftCards, filter.Sort)
+	start, end := page(len(giftCards), size, number)
+	return giftCards[start:end], len(giftCards)
+}
+
+func (r *gCardRepository) FindCursorPage(ctx context.Context, size uint, after *int, filter core.GiftCardFilter,
+	withCount bool) ([]dbmodel.GiftCard, int) {
+	giftCards := r.filter(ctx, filter)
+	sortGiftCards(giftCards, nil)
+	total := -1
+	if withCount {
+		total = len(giftCards)
+	}
+	start := 0
+	if after != nil {
+		start = sort.Search(len(giftCards), func(i int) bool { return giftCards[i].ID < *after })
+	}
+	_, end := page(len(giftCards)-start, size, 0)
+	return giftCards[start : start+end], total
+}
+
+// filter returns the live cards matching every criterion of the filter, without their campaigns
+func (r *gCardRepository) filter(ctx context.Context, filter core.GiftCardFilter) []dbmodel.GiftCard {
+	giftCards := []dbmodel.GiftCard{}
+	if ctx.Err() != nil {
+		return giftCards
+	}
+	rule := dbmodel.CurrentValidity()
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	campaignIds := r.campaignIds(filter)
+	for _, card := range r.db.giftCards {
+		if live(card.DeletedAt) && matches(card, filter, rule, campaignIds) {
+			giftCards = append(giftCards, cloneGiftCard(card))
+		}
+	}
+	return giftCards
+}
+
+// campaignIds returns the campaigns the cards of the filter may belong to, or nil when any campaign matches
+func (r *gCardRepository) campaignIds(filter core.GiftCardFilter) []map[uint]bool {
+	var sets []map[uint]bool
+	if len(filter.CampaignIds) > 0 {
+		set := map[uint]bool{}
+		for _, id := range filter.CampaignIds {
+			set[id] = true
+		}
+		sets = append(sets, set)
+	}
+	if filter.CampaignTitle != "" {
+		set := map[uint]bool{}
+		title := strings.ToLower(filter.CampaignTitle)
+		for _, campaign := range r.db.campaigns {
+			if live(campaign.DeletedAt) && strings.Contains(strings.ToLower(campaign.Title), title) {
+				set[uint(campaign.ID)] = true
+			}
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+// matches translates the criteria of the filter like the sql repositories do
+func matches(card dbmodel.GiftCard, filter core.GiftCardFilter, rule dbmodel.GiftCardValidity,
+	campaignIds []map[uint]bool) bool {
+	for _, set := range campaignIds {
+		if !set[card.CampaignId] {
+			return false
+		}
+	}
+	switch {
+	case filter.Search != "" &&
+		!strings.Contains(strings.ToLower(card.PublicCode), strings.ToLower(filter.Search)),
+		filter.UUN != "" && card.UUN != filter.UUN,
+		filter.Status != nil && card.Status != *filter.Status,
+		filter.AmountFrom != nil && card.Amount < *filter.AmountFrom,
+		filter.AmountTo != nil && card.Amount > *filter.AmountTo,
+		filter.IsValid != nil && rule.Matches(card) != *filter.IsValid,
+		filter.ExpireDateFrom != nil && !card.ExpireDate.After(*filter.ExpireDateFrom),
+		filter.ExpireDateTo != nil && !card.ExpireDate.Before(*filter.ExpireDateTo),
+		filter.CreatedFrom != nil && card.CreatedAt.Before(*filter.CreatedFrom),
+		filter.CreatedTo != nil && !card.CreatedAt.Before(*filter.CreatedTo),
+		filter.RedeemedFrom != nil && (card.RedeemedAt == nil || card.RedeemedAt.Before(*filter.RedeemedFrom)),
+		filter.RedeemedTo != nil && (card.RedeemedAt == nil || !card.RedeemedAt.Before(*filter.RedeemedTo)):
+		return false
+	}
+	return true
+}
+
+// giftCardComparers compare two cards by the api sort names. the cards without a redeem time come first
+var giftCardComparers = map[string]func(a, b dbmodel.GiftCard) int{
+	"id":     func(a, b dbmodel.GiftCard) int { return compareInts(int64(a.ID), int64(b.ID)) },
+	"amount": func(a, b dbmodel.GiftCard) int { return compareInts(int64(a.Amount), int64(b.Amount)) },
+	"status": func(a, b dbmodel.GiftCard) int { return compareInts(int64(a.Status), int64(b.Status)) },
+	"expire_date": func(a, b dbmodel.GiftCard) int {
+		return compareTimes(&a.ExpireDate, &b.ExpireDate)
+	},
+	"created_at": func(a, b dbmodel.GiftCard) int {
+		return compareTimes(&a.CreatedAt, &b.CreatedAt)
+	},
+	"redeemed_at": func(a, b dbmodel.GiftCard) int { return compareTimes(a.RedeemedAt, b.RedeemedAt) },
+}
+
+// sortGiftCards orders the cards by the given fields and then by id desc to keep the paging stable
+func sortGiftCards(giftCards []dbmodel.GiftCard, fields []core.SortField) {
+	sort.SliceStable(giftCards, func(i, j int) bool {
+		for _, field := range fields {
+			compare, ok := giftCardComparers[field.Column]
+			if !ok {
+				continue
+			}
+			result := compare(giftCards[i], giftCards[j])
+			if field.Descending {
+				result = -result
+			}
+			if result != 0 {
+				return result < 0
+			}
+		}
+		return giftCards[i].ID > giftCards[j].ID
+	})
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	case a.Before(*b):
+		return -1
+	case a.After(*b):
+		return 1
+	}
+	return 0
+}
+
+func (r *gCardRepository) FindBySecretKey(ctx context.Context, secret string) (*dbmodel.GiftCard, error) {
+	return r.byCode(ctx, r.db.secretCodes, secret)
+}
+
+func (r *gCardRepository) RollBackApprove(ctx context.Context, secret string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	card, ok := r.byCodeLocked(r.db.secretCodes, secret)
+	if !ok {
+		return common.GiftCardNotFound
+	}
+	card.RollBack()
+	return r.store(&card)
+}
+
+func (r *gCardRepository) FindByBatch(ctx context.Context, batchId uint) []dbmodel.GiftCard {
+	return r.find(ctx, true, func(card dbmodel.GiftCard) bool {
+		return card.BatchId != nil && *card.BatchId == batchId
+	})
+}
+
+// ExtendBatchExpiry keeps the previous terms of the cards as revisions before changing their expire date
+func (r *gCardRepository) ExtendBatchExpiry(ctx context.Context, batchId uint, expireDate time.Time) (int, error) {
+	revisedAt := time.Now().UTC()
+	return r.updateUnusedOfBatch(ctx, batchId, dbmodel.CardUpdatedEvent, func(card *dbmodel.GiftCard) {
+		r.db.revisions = append(r.db.revisions, dbmodel.GiftCardRevision{
+			ID:         r.db.nextId(giftCardRevisionTable),
+			GiftCardId: card.ID,
+			Amount:     card.Amount,
+			ExpireDate: card.ExpireDate,
+			RevisedAt:  revisedAt,
+		})
+		card.ExpireDate = expireDate
+	})
+}
+
+func (r *gCardRepository) VoidBatch(ctx context.Context, batchId uint) (int, error) {
+	voidedAt := time.Now().UTC()
+	return r.updateUnusedOfBatch(ctx, batchId, dbmodel.CardVoidedEvent, func(card *dbmodel.GiftCard) {
+		card.Status = dbmodel.Voided
+		card.VoidedAt = cloneTime(&voidedAt)
+	})
+}
+
+// updateUnusedOfBatch changes the live cards of the batch which are neither taken nor voided, and adds the event of
+// the change of every card to the outbox
+func (r *gCardRepository) updateUnusedOfBatch(ctx context.Context, batchId uint, event string,
+	update func(card *dbmodel.GiftCard)) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	var ids []int
+	for id, card := range r.db.giftCards {
+		if live(card.DeletedAt) && card.BatchId != nil && *card.BatchId == batchId && card.IsUnused() {
+			ids = append(ids, id)
+		}
+	}
+	// the events are added in the order of the cards like the sql repository does
+	sort.Ints(ids)
+
+	updated := make([]dbmodel.GiftCard, 0, len(ids))
+	var events []dbmodel.OutboxEvent
+	now := time.Now()
+	for _, id := range ids {
+		card := r.db.giftCards[id]
+		update(&card)
+		card.UpdatedAt = now
+		cardEvents, err := r.db.cardEvents(card, []string{event})
+		if err != nil {
+			return 0, err
+		}
+		updated = append(updated, card)
+		events = append(events, cardEvents...)
+	}
+	for _, card := range updated {
+		r.db.giftCards[card.ID] = card
+	}
+	r.db.addEvents(events)
+	return len(updated), nil
+}
+
+// Stats aggregates the cards of a campaign, or all of the cards when campaignId is nil
+func (r *gCardRepository) Stats(ctx context.Context, campaignId *uint) (core.GiftCardStats, error) {
+	if err := ctx.Err(); err != nil {
+		return core.GiftCardStats{}, err
+	}
+	rule := dbmodel.CurrentValidity()
+	var stats core.GiftCardStats
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	for _, card := range r.db.giftCards {
+		if campaignId != nil && card.CampaignId != *campaignId {
+			continue
+		}
+		amount := int64(card.Amount)
+		add(&stats.Issued, amount)
+		switch {
+		case !live(card.DeletedAt):
+			add(&stats.Deleted, amount)
+		case card.UUN != "":
+			add(&stats.Redeemed, amount)
+			if card.RedeemedAt != nil {
+				stats.TimedRedeems++
+				stats.RedeemSeconds += int64(card.RedeemedAt.Sub(card.CreatedAt) / time.Second)
+			}
+		case card.Status == dbmodel.Voided:
+			add(&stats.Voided, amount)
+		case card.Status == rule.Status && rule.IsDateValid(card.ExpireDate):
+			add(&stats.Outstanding, amount)
+		case card.Status == rule.Status:
+			add(&stats.Expired, amount)
+		}
+	}
+	return stats, nil
+}
+
+func add(aggregate *core.CardsAggregate, amount int64) {
+	aggregate.Count++
+	aggregate.Amount += amount
+}
+
+// reportTimes returns the times of the report events of the cards
+var reportTimes = map[core.ReportEvent]func(card dbmodel.GiftCard) *time.Time{
+	core.IssuedEvent:   func(card dbmodel.GiftCard) *time.Time { return &card.CreatedAt },
+	core.RedeemedEvent: func(card dbmodel.GiftCard) *time.Time { return card.RedeemedAt },
+}
+
+// HourlyTotals groups the cards by the utc hour of the event, shifted by the minutes of the location offset like
+// the sql repositories. deleted cards are included because the reports show what has happened
+func (r *gCardRepository) HourlyTotals(ctx context.Context, event core.ReportEvent, campaignId *uint,
+	from, to time.Time,
+	location *time.Location) ([]core.HourlyTotal, error) {
+	eventTime, ok := reportTimes[event]
+	if !ok {
+		return nil, fmt.Errorf("unknown report event %d", event)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	_, offset := from.In(location).Zone()
+	shift := time.Duration(offset/60%60) * time.Minute
+
+	hours := map[time.Time]*core.HourlyTotal{}
+	r.db.mu.RLock()
+	for _, card := range r.db.giftCards {
+		at := eventTime(card)
+		if at == nil || at.Before(from) || !at.Before(to) || campaignId != nil && card.CampaignId != *campaignId {
+			continue
+		}
+		hour := at.UTC().Add(shift).Truncate(time.Hour).Add(-shift)
+		total, ok := hours[hour]
+		if !ok {
+			total = &core.HourlyTotal{Hour: hour}
+			hours[hour] = total
+		}
+		total.Count++
+		total.Amount += int64(card.Amount)
+	}
+	r.db.mu.RUnlock()
+
+	totals := make([]core.HourlyTotal, 0, len(hours))
+	for _, total := range hours {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Hour.Before(totals[j].Hour) })
+	return totals, nil
+}
+
+// OutstandingAt finds the cards which were created, not deleted, not redeemed, not voided and not expired
+// at the moment, with the terms of the first revision after the moment like the sql repositories
+func (r *gCardRepository) OutstandingAt(ctx context.Context, at time.Time,
+	campaignId *uint) ([]core.CampaignLiability, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	at = at.UTC()
+	rule := dbmodel.ValidityAt(at)
+	after := func(t *time.Time) bool { return t != nil && t.After(at) }
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	revisions := map[int]dbmodel.GiftCardRevision{}
+	for _, revision := range r.db.revisions {
+		first, ok := revisions[revision.GiftCardId]
+		if revision.RevisedAt.After(at) && (!ok || revision.RevisedAt.Before(first.RevisedAt)) {
+			revisions[revision.GiftCardId] = revision
+		}
+	}
+	liabilities := map[uint]*core.CampaignLiability{}
+	for _, card := range r.db.giftCards {
+		campaign, ok := r.db.campaigns[int(card.CampaignId)]
+		if !ok || campaignId != nil && card.CampaignId != *campaignId || card.CreatedAt.After(at) ||
+			!live(card.DeletedAt) && !after(card.DeletedAt) ||
+			card.UUN != "" && !after(card.RedeemedAt) ||
+			card.Status == dbmodel.Voided && !after(card.VoidedAt) {
+			continue
+		}
+		amount, expireDate := card.Amount, card.ExpireDate
+		if revision, ok := revisions[card.ID]; ok {
+			amount, expireDate = revision.Amount, revision.ExpireDate
+		}
+		if !rule.IsDateValid(expireDate) {
+			continue
+		}
+		liability, ok := liabilities[card.CampaignId]
+		if !ok {
+			liability = &core.CampaignLiability{CampaignId: card.CampaignId, CampaignTitle: campaign.Title}
+			liabilities[card.CampaignId] = liability
+		}
+		liability.Count++
+		liability.Amount += int64(amount)
+	}
+
+	result := make([]core.CampaignLiability, 0, len(liabilities))
+	for _, liability := range liabilities {
+		result = append(result, *liability)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CampaignId < result[j].CampaignId })
+	return result, nil
+}
+
+// FindExpired finds the unused cards whose expire date left the validity rule between the two moments
+func (r *gCardRepository) FindExpired(ctx context.Context, from, to time.Time) ([]dbmodel.GiftCard, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	after, until := dbmodel.ValidityAt(from).ExpireAfter, dbmodel.ValidityAt(to).ExpireAfter
+	return r.find(ctx, true, func(card dbmodel.GiftCard) bool {
+		return card.IsUnused() && card.ExpireDate.After(after) && !card.ExpireDate.After(until)
+	}), nil
+}
+
+// find returns the live cards matching the predicate ordered by id
+func (r *gCardRepository) find(ctx context.Context, preload bool,
+	predicate func(card dbmodel.GiftCard) bool) []dbmodel.GiftCard {
+	giftCards := []dbmodel.GiftCard{}
+	if ctx.Err() != nil {
+		return giftCards
+	}
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	for _, card := range r.db.giftCards {
+		if !live(card.DeletedAt) || !predicate(card) {
+			continue
+		}
+		if preload {
+			card = r.db.withCampaign(card)
+		} else {
+			card = cloneGiftCard(card)
+		}
+		giftCards = append(giftCards, card)
+	}
+	sort.Slice(giftCards, func(i, j int) bool { return giftCards[i].ID < giftCards[j].ID })
+	return giftCards
+}
+
+// first returns the live card with the lowest id matching the predicate
+func (r *gCardRepository) first(ctx context.Context, preload bool,
+	predicate func(card dbmodel.GiftCard) bool) (*dbmodel.GiftCard, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	card, ok := r.firstLocked(preload, predicate)
+	if !ok {
+		return nil, common.GiftCardNotFound
+	}
+	return &card, nil
+}
+
+// firstLocked is first for the callers holding the lock
+func (r *gCardRepository) firstLocked(preload bool,
+	predicate func(card dbmodel.GiftCard) bool) (dbmodel.GiftCard, bool) {
+	var found *dbmodel.GiftCard
+	for id := range r.db.giftCards {
+		card := r.db.giftCards[id]
+		if live(card.DeletedAt) && predicate(card) && (found == nil || card.ID < found.ID) {
+			found = &card
+		}
+	}
+	if found == nil {
+		return dbmodel.GiftCard{}, false
+	}
+	if preload {
+		return r.db.withCampaign(*found), true
+	}
+	return cloneGiftCard(*found), true
+}
+
+// byCode returns the live card which the code index points to
+func (r *gCardRepository) byCode(ctx context.Context, codes map[string]int, code string) (*dbmodel.GiftCard, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	card, ok := r.byCodeLocked(codes, code)
+	if !ok {
+		return nil, common.GiftCardNotFound
+	}
+	return &card, nil
+}
+
+// byCodeLocked is byCode for the callers holding the lock
+func (r *gCardRepository) byCodeLocked(codes map[string]int, code string) (dbmodel.GiftCard, bool) {
+	id, ok := codes[code]
+	if !ok {
+		return dbmodel.GiftCard{}, false
+	}
+	card, ok := r.db.giftCards[id]
+	if !ok || !live(card.DeletedAt) {
+		return dbmodel.GiftCard{}, false
+	}
+	return cloneGiftCard(card), true
+}
+
+func NewGiftCardRepository(db *Database) core.GiftCardRepository {
+	return &gCardRepository{db: db}
+}
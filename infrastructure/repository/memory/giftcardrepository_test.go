@@ -0,0 +1,133 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/infrastructure/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newRepositories(t *testing.T) (core.GiftCardRepository, core.CampaignRepository, dbmodel.Campaign) {
+	database := memory.NewDatabase()
+	campaigns := memory.NewCampaignRepository(database)
+	campaign := dbmodel.NewCampaign("yalda")
+	if err := campaigns.Store(context.Background(), campaign); err != nil {
+		t.Fatal(err)
+	}
+	return memory.NewGiftCardRepository(database), campaigns, *campaign
+}
+
+func newCard(campaign dbmodel.Campaign) *dbmodel.GiftCard {
+	card := dbmodel.NewGiftCard(1000, time.Now().AddDate(0, 1, 0))
+	card.SetCampaign(uint(campaign.ID))
+	return card
+}
+
+func TestStoreRejectsDuplicatedCodes(t *testing.T) {
+	repository, _, campaign := newRepositories(t)
+	stored := newCard(campaign)
+	assert.Nil(t, repository.Store(context.Background(), stored))
+
+	card := newCard(campaign)
+	card.PublicCode = stored.PublicCode
+	err := repository.Store(context.Background(), card)
+
+	assert.True(t, errors.Is(err, memory.ErrDuplicateKey))
+	// the gift card service retries with new codes on these errors
+	assert.True(t, strings.Contains(err.Error(), "duplicate"))
+	assert.Zero(t, card.ID)
+}
+
+func TestStoreKeepsTheCodesOfDeletedCardsUnique(t *testing.T) {
+	repository, _, campaign := newRepositories(t)
+	stored := newCard(campaign)
+	assert.Nil(t, repository.Store(context.Background(), stored))
+	assert.Nil(t, repository.Delete(context.Background(), *stored))
+
+	card := newCard(campaign)
+	card.SecretCode = stored.SecretCode
+
+	assert.True(t, errors.Is(repository.Store(context.Background(), card), memory.ErrDuplicateKey))
+	_, err := repository.FindByID(context.Background(), uint(stored.ID))
+	assert.Equal(t, common.GiftCardNotFound, err)
+}
+
+func TestStoreFreesTheCodesTheCardNoLongerHas(t *testing.T) {
+	repository, _, campaign := newRepositories(t)
+	stored := newCard(campaign)
+	assert.Nil(t, repository.Store(context.Background(), stored))
+	publicCode := stored.PublicCode
+	stored.GenerateKey()
+	assert.Nil(t, repository.Store(context.Background(), stored))
+
+	card := newCard(campaign)
+	card.PublicCode = publicCode
+	assert.Nil(t, repository.Store(context.Background(), card))
+	card = newCard(campaign)
+	card.SecretCode = stored.SecretCode
+	assert.True(t, errors.Is(repository.Store(context.Background(), card), memory.ErrDuplicateKey))
+}
+
+func TestTheCallersDoNotShareTheStoredCards(t *testing.T) {
+	repository, _, campaign := newRepositories(t)
+	card := newCard(campaign)
+	assert.Nil(t, repository.Store(context.Background(), card))
+
+	card.Amount = 1
+	found, _ := repository.FindByID(context.Background(), uint(card.ID))
+	found.UUN = "milad"
+	again, _ := repository.FindByID(context.Background(), uint(card.ID))
+
+	assert.Equal(t, int32(1000), again.Amount)
+	assert.Equal(t, "", again.UUN)
+	assert.Equal(t, "yalda", again.Campaign.Title)
+}
+
+func TestConcurrentStoresGetDistinctIds(t *testing.T) {
+	repository, _, campaign := newRepositories(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			card := newCard(campaign)
+			assert.Nil(t, repository.Store(context.Background(), card))
+			_, _ = repository.FindPage(context.Background(), 10, 0, core.GiftCardFilter{})
+		}()
+	}
+	wg.Wait()
+
+	cards, total := repository.FindPage(context.Background(), 100, 0, core.GiftCardFilter{})
+	assert.Equal(t, 50, total)
+	ids := map[int]bool{}
+	for _, card := range cards {
+		ids[card.ID] = true
+	}
+	assert.Len(t, ids, 50)
+}
+
+func TestStoreAddsTheNewRevisionsOfTheCard(t *testing.T) {
+	repository, _, campaign := newRepositories(t)
+	card := newCard(campaign)
+	card.CreatedAt = time.Now().Add(-time.Hour)
+	assert.Nil(t, repository.Store(context.Background(), card))
+	createdAt := card.CreatedAt
+
+	assert.Nil(t, card.Update(2000, card.ExpireDate))
+	assert.Nil(t, repository.Store(context.Background(), card))
+	liabilities, err := repository.OutstandingAt(context.Background(), time.Now().Add(-time.Minute), nil)
+
+	assert.Nil(t, err)
+	assert.NotZero(t, card.Revisions[0].ID)
+	assert.Equal(t, createdAt, card.CreatedAt)
+	if assert.Len(t, liabilities, 1) {
+		assert.Equal(t, int64(1000), liabilities[0].Amount)
+	}
+}
@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"sort"
+	"time"
+)
+
+const outboxEventTable = "OutboxEvent"
+
+type outboxRepository struct {
+	db *Database
+}
+
+// cardEvents returns the outbox events of the card, which are added with the change of the card
+func (d *Database) cardEvents(card dbmodel.GiftCard, names []string) ([]dbmodel.OutboxEvent, error) {
+	events := make([]dbmodel.OutboxEvent, 0, len(names))
+	for _, name := range names {
+		event, err := dbmodel.NewGiftCardOutboxEvent(name, card)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+	return events, nil
+}
+
+// campaignEvents returns the outbox events of the campaign like cardEvents
+func (d *Database) campaignEvents(campaign dbmodel.Campaign, names []string) ([]dbmodel.OutboxEvent, error) {
+	events := make([]dbmodel.OutboxEvent, 0, len(names))
+	for _, name := range names {
+		event, err := dbmodel.NewCampaignOutboxEvent(name, campaign)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+	return events, nil
+}
+
+// addEvents stores the new events in the outbox. the caller holds the write lock
+func (d *Database) addEvents(events []dbmodel.OutboxEvent) {
+	now := time.Now()
+	for _, event := range events {
+		event.ID = d.nextId(outboxEventTable)
+		event.CreatedAt = now
+		event.UpdatedAt = now
+		d.outbox[event.ID] = event
+	}
+}
+
+// ClaimDueEvents finds and postpones the due events under the write lock, so an event is never claimed twice
+func (r *outboxRepository) ClaimDueEvents(ctx context.Context, now time.Time, lease time.Duration,
+	limit int) ([]dbmodel.OutboxEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	due := []dbmodel.OutboxEvent{}
+	for _, event := range r.db.outbox {
+		if event.PublishedAt == nil && event.DeadAt == nil && !event.NextAttemptAt.After(now) {
+			due = append(due, event)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	leased := now.Add(lease).UTC()
+	claimed := make([]dbmodel.OutboxEvent, 0, len(due))
+	for _, event := range due {
+		event.NextAttemptAt = leased
+		r.db.outbox[event.ID] = event
+		claimed = append(claimed, cloneOutboxEvent(event))
+	}
+	return claimed, nil
+}
+
+func (r *outboxRepository) StoreEvent(ctx context.Context, event *dbmodel.OutboxEvent) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	if event.ID == 0 {
+		event.ID = r.db.nextId(outboxEventTable)
+	} else {
+		r.db.claimId(outboxEventTable, event.ID)
+	}
+	now := time.Now()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = now
+	}
+	event.UpdatedAt = now
+	r.db.outbox[event.ID] = cloneOutboxEvent(*event)
+	return nil
+}
+
+func (r *outboxRepository) DeletePublished(ctx context.Context, before time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	deleted := 0
+	for id, event := range r.db.outbox {
+		if event.PublishedAt != nil && event.PublishedAt.Before(before) {
+			delete(r.db.outbox, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func NewOutboxRepository(db *Database) core.OutboxRepository {
+	return &outboxRepository{db: db}
+}
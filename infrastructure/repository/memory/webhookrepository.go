@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"sort"
+	"time"
+)
+
+const (
+	webhookSubscriptionTable = "WebhookSubscription"
+	webhookDeliveryTable     = "WebhookDelivery"
+)
+
+type webhookRepository struct {
+	db *Database
+}
+
+func (r *webhookRepository) FindSubscription(ctx context.Context, id uint) (*dbmodel.WebhookSubscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	subscription, ok := r.db.webhooks[int(id)]
+	if !ok || !live(subscription.DeletedAt) {
+		return nil, common.WebhookNotFound
+	}
+	subscription = cloneWebhookSubscription(subscription)
+	return &subscription, nil
+}
+
+func (r *webhookRepository) FindSubscriptions(ctx context.Context) ([]dbmodel.WebhookSubscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	subscriptions := []dbmodel.WebhookSubscription{}
+	r.db.mu.RLock()
+	for _, subscription := range r.db.webhooks {
+		if live(subscription.DeletedAt) {
+			subscriptions = append(subscriptions, cloneWebhookSubscription(subscription))
+		}
+	}
+	r.db.mu.RUnlock()
+	sort.Slice(subscriptions, func(i, j int) bool { return subscriptions[i].ID < subscriptions[j].ID })
+	return subscriptions, nil
+}
+
+func (r *webhookRepository) StoreSubscription(ctx context.Context, subscription *dbmodel.WebhookSubscription) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	stored, ok := r.db.webhooks[subscription.ID]
+	switch {
+	case subscription.ID == 0:
+		subscription.ID = r.db.nextId(webhookSubscriptionTable)
+	case ok && !live(stored.DeletedAt):
+		return fmt.Errorf("%w: webhook subscription %d", ErrDuplicateKey, subscription.ID)
+	default:
+		r.db.claimId(webhookSubscriptionTable, subscription.ID)
+	}
+	now := time.Now()
+	if subscription.CreatedAt.IsZero() {
+		subscription.CreatedAt = now
+	}
+	subscription.UpdatedAt = now
+	r.db.webhooks[subscription.ID] = cloneWebhookSubscription(*subscription)
+	return nil
+}
+
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, subscription dbmodel.WebhookSubscription) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	stored, ok := r.db.webhooks[subscription.ID]
+	if ok && live(stored.DeletedAt) {
+		now := time.Now()
+		stored.DeletedAt = &now
+		r.db.webhooks[subscription.ID] = stored
+	}
+	return nil
+}
+
+func (r *webhookRepository) FindDelivery(ctx context.Context, id uint) (*dbmodel.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+	delivery, ok := r.db.deliveries[int(id)]
+	if !ok {
+		return nil, common.WebhookDeliveryNotFound
+	}
+	delivery = cloneWebhookDelivery(delivery)
+	return &delivery, nil
+}
+
+func (r *webhookRepository) StoreDelivery(ctx context.Context, delivery *dbmodel.WebhookDelivery) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	if delivery.ID == 0 {
+		delivery.ID = r.db.nextId(webhookDeliveryTable)
+	} else {
+		r.db.claimId(webhookDeliveryTable, delivery.ID)
+	}
+	now := time.Now()
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = now
+	}
+	delivery.UpdatedAt = now
+	r.db.deliveries[delivery.ID] = cloneWebhookDelivery(*delivery)
+	return nil
+}
+
+// ClaimDueDeliveries finds and postpones the due deliveries under the write lock, so a delivery is never claimed
+// twice
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration,
+	limit int) ([]dbmodel.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	due := []dbmodel.WebhookDelivery{}
+	for _, delivery := range r.db.deliveries {
+		if delivery.Status == dbmodel.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	leased := now.Add(lease).UTC()
+	claimed := make([]dbmodel.WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		delivery.NextAttemptAt = leased
+		r.db.deliveries[delivery.ID] = delivery
+		claimed = append(claimed, cloneWebhookDelivery(delivery))
+	}
+	return claimed, nil
+}
+
+func (r *webhookRepository) FindDeadDeliveries(ctx context.Context, size, number uint) ([]dbmodel.WebhookDelivery,
+	int) {
+	deliveries := []dbmodel.WebhookDelivery{}
+	if ctx.Err() != nil {
+		return deliveries, 0
+	}
+	r.db.mu.RLock()
+	for _, delivery := range r.db.deliveries {
+		if delivery.Status == dbmodel.DeliveryDead {
+			deliveries = append(deliveries, cloneWebhookDelivery(delivery))
+		}
+	}
+	r.db.mu.RUnlock()
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID > deliveries[j].ID })
+	start, end := page(len(deliveries), size, number)
+	return deliveries[start:end], len(deliveries)
+}
+
+func NewWebhookRepository(db *Database) core.WebhookRepository {
+	return &webhookRepository{db: db}
+}
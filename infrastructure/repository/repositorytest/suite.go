@@ -0,0 +1,740 @@
+// Package repositorytest verifies that an implementation of the core repositories keeps their contract, so every
+// backend behaves like the sql one the services were written against
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Repositories are the repositories of a single empty storage
+type Repositories struct {
+	GiftCards core.GiftCardRepository
+	Campaigns core.CampaignRepository
+	Batches   core.BatchRepository
+	Webhooks  core.WebhookRepository
+	Outbox    core.OutboxRepository
+	Commands  core.CommandRepository
+}
+
+// Factory returns the repositories of a new empty storage for every test
+type Factory func(t *testing.T) Repositories
+
+// Run runs the conformance tests of the repositories as subtests of t
+func Run(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, r Repositories)
+	}{
+		{"CampaignNotFound", testCampaignNotFound},
+		{"DuplicatedCampaignTitle", testDuplicatedCampaignTitle},
+		{"CampaignPaging", testCampaignPaging},
+		{"CampaignSoftDelete", testCampaignSoftDelete},
+		{"GiftCardNotFound", testGiftCardNotFound},
+		{"CampaignGuard", testCampaignGuard},
+		{"GiftCardFinders", testGiftCardFinders},
+		{"GiftCardPaging", testGiftCardPaging},
+		{"GiftCardCursorPaging", testGiftCardCursorPaging},
+		{"GiftCardFilters", testGiftCardFilters},
+		{"GiftCardSort", testGiftCardSort},
+		{"GiftCardSoftDelete", testGiftCardSoftDelete},
+		{"RollBackApprove", testRollBackApprove},
+		{"BatchUpdates", testBatchUpdates},
+		{"BatchSoftDelete", testBatchSoftDelete},
+		{"Stats", testStats},
+		{"ExpiredGiftCards", testExpiredGiftCards},
+		{"WebhookSubscriptions", testWebhookSubscriptions},
+		{"WebhookDeliveryClaims", testWebhookDeliveryClaims},
+		{"DeadWebhookDeliveries", testDeadWebhookDeliveries},
+		{"OutboxEvents", testOutboxEvents},
+		{"CampaignOutboxEvents", testCampaignOutboxEvents},
+		{"OutboxEventClaims", testOutboxEventClaims},
+		{"PublishedOutboxEvents", testPublishedOutboxEvents},
+		{"CommandClaims", testCommandClaims},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.test(t, factory(t))
+		})
+	}
+}
+
+var ctx = context.Background()
+
+func storeCampaign(t *testing.T, r Repositories, title string) dbmodel.Campaign {
+	campaign := dbmodel.NewCampaign(title)
+	require.Nil(t, r.Campaigns.Store(ctx, campaign))
+	require.NotZero(t, campaign.ID)
+	return *campaign
+}
+
+// storeCard stores a card of the campaign after letting change set its fields
+func storeCard(t *testing.T, r Repositories, campaign dbmodel.Campaign,
+	change func(card *dbmodel.GiftCard)) *dbmodel.GiftCard {
+	card := dbmodel.NewGiftCard(1000, time.Now().AddDate(0, 1, 0).UTC())
+	card.SetCampaign(uint(campaign.ID))
+	if change != nil {
+		change(card)
+	}
+	require.Nil(t, r.GiftCards.Store(ctx, card))
+	require.NotZero(t, card.ID)
+	return card
+}
+
+func ids(cards []dbmodel.GiftCard) []int {
+	result := make([]int, 0, len(cards))
+	for _, card := range cards {
+		result = append(result, card.ID)
+	}
+	return result
+}
+
+func testCampaignNotFound(t *testing.T, r Repositories) {
+	_, err := r.Campaigns.FindByID(ctx, 404)
+
+	assert.Equal(t, common.CampaignNotFound, err)
+}
+
+func testDuplicatedCampaignTitle(t *testing.T, r Repositories) {
+	storeCampaign(t, r, "yalda")
+
+	err := r.Campaigns.Store(ctx, dbmodel.NewCampaign("yalda"))
+
+	assert.Equal(t, common.DuplicatedCampaignTitle, err)
+}
+
+func testCampaignPaging(t *testing.T, r Repositories) {
+	for _, title := range []string{"yalda", "nowruz", "Yalda night", "mehregan"} {
+		storeCampaign(t, r, title)
+	}
+
+	campaigns, total := r.Campaigns.FindPage(ctx, 2, 0, "")
+	assert.Equal(t, 4, total)
+	assert.Equal(t, []string{"mehregan", "Yalda night"}, []string{campaigns[0].Title, campaigns[1].Title})
+
+	campaigns, total = r.Campaigns.FindPage(ctx, 2, 1, "")
+	assert.Equal(t, 4, total)
+	assert.Equal(t, []string{"nowruz", "yalda"}, []string{campaigns[0].Title, campaigns[1].Title})
+
+	campaigns, total = r.Campaigns.FindPage(ctx, 10, 0, "YALDA")
+	assert.Equal(t, 2, total, "the search should ignore the case")
+	assert.Len(t, campaigns, 2)
+
+	after := campaigns[0].ID
+	campaigns, total = r.Campaigns.FindCursorPage(ctx, 10, &after, "", false)
+	assert.Equal(t, -1, total)
+	assert.Len(t, campaigns, 2)
+	campaigns, total = r.Campaigns.FindCursorPage(ctx, 1, nil, "", true)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, "mehregan", campaigns[0].Title)
+}
+
+func testCampaignSoftDelete(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	storeCampaign(t, r, "nowruz")
+
+	require.Nil(t, r.Campaigns.Delete(ctx, campaign))
+
+	_, err := r.Campaigns.FindByID(ctx, uint(campaign.ID))
+	assert.Equal(t, common.CampaignNotFound, err)
+	campaigns, total := r.Campaigns.FindPage(ctx, 10, 0, "")
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "nowruz", campaigns[0].Title)
+}
+
+func testGiftCardNotFound(t *testing.T, r Repositories) {
+	_, err := r.GiftCards.FindByID(ctx, 404)
+	assert.Equal(t, common.GiftCardNotFound, err)
+	_, err = r.GiftCards.FindByPublicKey(ctx, "404")
+	assert.Equal(t, common.GiftCardNotFound, err)
+	_, err = r.GiftCards.FindBySecretKey(ctx, "404")
+	assert.Equal(t, common.GiftCardNotFound, err)
+	assert.Equal(t, common.GiftCardNotFound, r.GiftCards.RollBackApprove(ctx, "404"))
+	assert.Empty(t, r.GiftCards.FindByUUN(ctx, "milad"))
+	assert.Empty(t, r.GiftCards.FindByIDs(ctx, []int{404}))
+	assert.Empty(t, r.GiftCards.FindByBatch(ctx, 404))
+}
+
+func testCampaignGuard(t *testing.T, r Repositories) {
+	deleted := storeCampaign(t, r, "yalda")
+	require.Nil(t, r.Campaigns.Delete(ctx, deleted))
+
+	for _, campaignId := range []uint{404, uint(deleted.ID)} {
+		card := dbmodel.NewGiftCard(1000, time.Now().AddDate(0, 1, 0).UTC())
+		card.SetCampaign(campaignId)
+
+		assert.Equal(t, common.InvalidCampaign, r.GiftCards.Store(ctx, card), "campaign %d", campaignId)
+	}
+	_, total := r.GiftCards.FindPage(ctx, 10, 0, core.GiftCardFilter{})
+	assert.Zero(t, total)
+}
+
+func testGiftCardFinders(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	first := storeCard(t, r, campaign, func(card *dbmodel.GiftCard) {
+		card.SetBatch(7)
+		require.Nil(t, card.SetUUN("milad"))
+	})
+	second := storeCard(t, r, campaign, func(card *dbmodel.GiftCard) { card.SetBatch(7) })
+	storeCard(t, r, campaign, nil)
+
+	found, err := r.GiftCards.FindByID(ctx, uint(first.ID))
+	require.Nil(t, err)
+	assert.Equal(t, first.PublicCode, found.PublicCode)
+	require.NotNil(t, found.Campaign)
+	assert.Equal(t, "yalda", found.Campaign.Title)
+
+	found, err = r.GiftCards.FindByPublicKey(ctx, second.PublicCode)
+	require.Nil(t, err)
+	assert.Equal(t, second.ID, found.ID)
+	found, err = r.GiftCards.FindBySecretKey(ctx, second.SecretCode)
+	require.Nil(t, err)
+	assert.Equal(t, second.ID, found.ID)
+
+	assert.Equal(t, []int{first.ID}, ids(r.GiftCards.FindByUUN(ctx, "milad")))
+	assert.Equal(t, []int{first.ID, second.ID}, ids(r.GiftCards.FindByBatch(ctx, 7)))
+	assert.ElementsMatch(t, []int{first.ID, second.ID}, ids(r.GiftCards.FindByIDs(ctx, []int{first.ID, second.ID,
+		404})))
+}
+
+func testGiftCardPaging(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	var stored []int
+	for i := 0; i < 5; i++ {
+		stored = append(stored, storeCard(t, r, campaign, nil).ID)
+	}
+
+	cards, total := r.GiftCards.FindPage(ctx, 2, 0, core.GiftCardFilter{})
+	assert.Equal(t, 5, total)
+	assert.Equal(t, []int{stored[4], stored[3]}, ids(cards))
+
+	cards, total = r.GiftCards.FindPage(ctx, 2, 2, core.GiftCardFilter{})
+	assert.Equal(t, 5, total)
+	assert.Equal(t, []int{stored[0]}, ids(cards))
+
+	cards, total = r.GiftCards.FindPage(ctx, 2, 3, core.GiftCardFilter{})
+	assert.Equal(t, 5, total)
+	assert.Empty(t, cards)
+}
+
+func testGiftCardCursorPaging(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	var stored []int
+	for i := 0; i < 5; i++ {
+		stored = append(stored, storeCard(t, r, campaign, nil).ID)
+	}
+
+	cards, total := r.GiftCards.FindCursorPage(ctx, 2, nil, core.GiftCardFilter{}, true)
+	assert.Equal(t, 5, total)
+	assert.Equal(t, []int{stored[4], stored[3]}, ids(cards))
+
+	after := stored[3]
+	cards, total = r.GiftCards.FindCursorPage(ctx, 2, &after, core.GiftCardFilter{}, false)
+	assert.Equal(t, -1, total)
+	assert.Equal(t, []int{stored[2], stored[1]}, ids(cards))
+
+	after = stored[0]
+	cards, _ = r.GiftCards.FindCursorPage(ctx, 2, &after, core.GiftCardFilter{}, false)
+	assert.Empty(t, cards)
+}
+
+func testGiftCardFilters(t *testing.T, r Repositories) {
+	yalda := storeCampaign(t, r, "yalda")
+	nowruz := storeCampaign(t, r, "Nowruz")
+	now := time.Now().UTC().Truncate(time.Second)
+	redeemedAt := now.Add(-time.Hour)
+
+	valid := storeCard(t, r, yalda, func(card *dbmodel.GiftCard) {
+		card.Amount = 100
+		card.CreatedAt = now.AddDate(0, 0, -3)
+	})
+	redeemed := storeCard(t, r, yalda, func(card *dbmodel.GiftCard) {
+		card.Amount = 200
+		card.CreatedAt = now.AddDate(0, 0, -2)
+		card.UUN = "milad"
+		card.Status = dbmodel.Approved
+		card.RedeemedAt = &redeemedAt
+	})
+	expired := storeCard(t, r, nowruz, func(card *dbmodel.GiftCard) {
+		card.Amount = 300
+		card.CreatedAt = now.AddDate(0, 0, -1)
+		card.ExpireDate = now.AddDate(0, 0, -10)
+	})
+	voided := storeCard(t, r, nowruz, func(card *dbmodel.GiftCard) {
+		card.Amount = 400
+		card.CreatedAt = now
+		card.ExpireDate = now.AddDate(0, 2, 0)
+		require.Nil(t, card.Void())
+	})
+
+	isValid, isInvalid := true, false
+	empty, approved := dbmodel.Empty, dbmodel.Approved
+	amountFrom, amountTo := int32(200), int32(300)
+	expireFrom, expireTo := now.AddDate(0, 0, -11), now.AddDate(0, 1, 15)
+	createdFrom, createdTo := now.AddDate(0, 0, -2), now
+	redeemedFrom, redeemedTo := now.Add(-2*time.Hour), now
+	tests := []struct {
+		name   string
+		filter core.GiftCardFilter
+		want   []*dbmodel.GiftCard
+	}{
+		{"nothing", core.GiftCardFilter{}, []*dbmodel.GiftCard{valid, redeemed, expired, voided}},
+		{"search", core.GiftCardFilter{Search: valid.PublicCode[2:8]}, []*dbmodel.GiftCard{valid}},
+		{"uun", core.GiftCardFilter{UUN: "milad"}, []*dbmodel.GiftCard{redeemed}},
+		{"status", core.GiftCardFilter{Status: &approved}, []*dbmodel.GiftCard{redeemed}},
+		{"empty status", core.GiftCardFilter{Status: &empty}, []*dbmodel.GiftCard{valid, expired}},
+		{"amount from", core.GiftCardFilter{AmountFrom: &amountFrom}, []*dbmodel.GiftCard{redeemed, expired, voided}},
+		{"amount to", core.GiftCardFilter{AmountTo: &amountTo}, []*dbmodel.GiftCard{valid, redeemed, expired}},
+		{"campaign ids", core.GiftCardFilter{CampaignIds: []uint{uint(nowruz.ID), 404}},
+			[]*dbmodel.GiftCard{expired, voided}},
+		{"campaign title", core.GiftCardFilter{CampaignTitle: "nowr"}, []*dbmodel.GiftCard{expired, voided}},
+		{"campaign ids and title", core.GiftCardFilter{CampaignIds: []uint{uint(yalda.ID)}, CampaignTitle: "nowr"},
+			nil},
+		{"valid", core.GiftCardFilter{IsValid: &isValid}, []*dbmodel.GiftCard{valid}},
+		{"invalid", core.GiftCardFilter{IsValid: &isInvalid}, []*dbmodel.GiftCard{redeemed, expired, voided}},
+		{"expire date from", core.GiftCardFilter{ExpireDateFrom: &expireFrom},
+			[]*dbmodel.GiftCard{valid, redeemed, expired, voided}},
+		{"expire date to", core.GiftCardFilter{ExpireDateTo: &expireTo},
+			[]*dbmodel.GiftCard{valid, redeemed, expired}},
+		{"created from", core.GiftCardFilter{CreatedFrom: &createdFrom},
+			[]*dbmodel.GiftCard{redeemed, expired, voided}},
+		{"created to", core.GiftCardFilter{CreatedTo: &createdTo}, []*dbmodel.GiftCard{valid, redeemed, expired}},
+		{"redeemed from", core.GiftCardFilter{RedeemedFrom: &redeemedFrom}, []*dbmodel.GiftCard{redeemed}},
+		{"redeemed to", core.GiftCardFilter{RedeemedTo: &redeemedFrom}, nil},
+		{"redeemed to later", core.GiftCardFilter{RedeemedTo: &redeemedTo}, []*dbmodel.GiftCard{redeemed}},
+		{"several", core.GiftCardFilter{CampaignIds: []uint{uint(yalda.ID)}, AmountFrom: &amountFrom},
+			[]*dbmodel.GiftCard{redeemed}},
+	}
+	for _, test := range tests {
+		want := make([]int, 0, len(test.want))
+		for i := len(test.want) - 1; i >= 0; i-- {
+			want = append(want, test.want[i].ID)
+		}
+
+		cards, total := r.GiftCards.FindPage(ctx, 10, 0, test.filter)
+		assert.Equal(t, len(want), total, test.name)
+		assert.Equal(t, want, ids(cards), test.name)
+		cards, total = r.GiftCards.FindCursorPage(ctx, 10, nil, test.filter, true)
+		assert.Equal(t, len(want), total, test.name)
+		assert.Equal(t, want, ids(cards), test.name)
+	}
+}
+
+func testGiftCardSort(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	now := time.Now().UTC().Truncate(time.Second)
+	var stored []int
+	for i, amount := range []int32{300, 100, 300, 200} {
+		expireDate := now.AddDate(0, 1, -i)
+		stored = append(stored, storeCard(t, r, campaign, func(card *dbmodel.GiftCard) {
+			card.Amount = amount
+			card.ExpireDate = expireDate
+		}).ID)
+	}
+
+	tests := []struct {
+		sort string
+		want []int
+	}{
+		{"amount", []int{stored[1], stored[3], stored[2], stored[0]}},
+		{"-amount", []int{stored[2], stored[0], stored[3], stored[1]}},
+		{"-amount,expire_date", []int{stored[2], stored[0], stored[3], stored[1]}},
+		{"-amount,-expire_date", []int{stored[0], stored[2], stored[3], stored[1]}},
+		{"expire_date", []int{stored[3], stored[2], stored[1], stored[0]}},
+		{"id", []int{stored[0], stored[1], stored[2], stored[3]}},
+	}
+	for _, test := range tests {
+		fields, err := core.ParseGiftCardSort(test.sort)
+		require.Nil(t, err)
+
+		cards, _ := r.GiftCards.FindPage(ctx, 10, 0, core.GiftCardFilter{Sort: fields})
+
+		assert.Equal(t, test.want, ids(cards), test.sort)
+	}
+}
+
+func testGiftCardSoftDelete(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	deleted := storeCard(t, r, campaign, func(card *dbmodel.GiftCard) { card.SetBatch(7) })
+	kept := storeCard(t, r, campaign, nil)
+
+	require.Nil(t, r.GiftCards.Delete(ctx, *deleted))
+
+	_, err := r.GiftCards.FindByID(ctx, uint(deleted.ID))
+	assert.Equal(t, common.GiftCardNotFound, err)
+	_, err = r.GiftCards.FindByPublicKey(ctx, deleted.PublicCode)
+	assert.Equal(t, common.GiftCardNotFound, err)
+	cards, total := r.GiftCards.FindPage(ctx, 10, 0, core.GiftCardFilter{})
+	assert.Equal(t, 1, total)
+	assert.Equal(t, []int{kept.ID}, ids(cards))
+	assert.Empty(t, r.GiftCards.FindByBatch(ctx, 7))
+	assert.Empty(t, r.GiftCards.FindByIDs(ctx, []int{deleted.ID}))
+	stats, err := r.GiftCards.Stats(ctx, nil)
+	require.Nil(t, err)
+	assert.Equal(t, core.CardsAggregate{Count: 1, Amount: 1000}, stats.Deleted)
+	assert.Equal(t, core.CardsAggregate{Count: 2, Amount: 2000}, stats.Issued)
+}
+
+func testRollBackApprove(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	card := storeCard(t, r, campaign, func(card *dbmodel.GiftCard) { require.Nil(t, card.SetUUN("milad")) })
+
+	require.Nil(t, r.GiftCards.RollBackApprove(ctx, card.SecretCode))
+
+	found, err := r.GiftCards.FindByID(ctx, uint(card.ID))
+	require.Nil(t, err)
+	assert.Equal(t, "", found.UUN)
+	assert.Equal(t, dbmodel.Empty, found.Status)
+	assert.Nil(t, found.RedeemedAt)
+	assert.True(t, found.IsValid())
+	assert.Empty(t, r.GiftCards.FindByUUN(ctx, "milad"))
+}
+
+func testBatchUpdates(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	unused := storeCard(t, r, campaign, func(card *dbmodel.GiftCard) { card.SetBatch(7) })
+	taken := storeCard(t, r, campaign, func(card *dbmodel.GiftCard) {
+		card.SetBatch(7)
+		require.Nil(t, card.SetUUN("milad"))
+	})
+	other := storeCard(t, r, campaign, func(card *dbmodel.GiftCard) { card.SetBatch(8) })
+	expireDate := time.Now().AddDate(1, 0, 0).UTC().Truncate(time.Second)
+
+	extended, err := r.GiftCards.ExtendBatchExpiry(ctx, 7, expireDate)
+	require.Nil(t, err)
+	assert.Equal(t, 1, extended)
+	voided, err := r.GiftCards.VoidBatch(ctx, 7)
+	require.Nil(t, err)
+	assert.Equal(t, 1, voided)
+
+	found, _ := r.GiftCards.FindByID(ctx, uint(unused.ID))
+	assert.True(t, expireDate.Equal(found.ExpireDate), fmt.Sprintf("%v is not extended", found.ExpireDate))
+	assert.Equal(t, dbmodel.Voided, found.Status)
+	assert.NotNil(t, found.VoidedAt)
+	found, _ = r.GiftCards.FindByID(ctx, uint(taken.ID))
+	assert.Equal(t, dbmodel.Approved, found.Status)
+	found, _ = r.GiftCards.FindByID(ctx, uint(other.ID))
+	assert.Equal(t, dbmodel.Empty, found.Status)
+}
+
+func testBatchSoftDelete(t *testing.T, r Repositories) {
+	deleted := dbmodel.NewBatch("milawd", "{}")
+	require.Nil(t, r.Batches.Store(ctx, deleted))
+	require.Nil(t, r.Batches.Store(ctx, dbmodel.NewBatch("milawd", "{}")))
+
+	require.Nil(t, r.Batches.Delete(ctx, *deleted))
+
+	_, err := r.Batches.FindByID(ctx, uint(deleted.ID))
+	assert.Equal(t, common.BatchNotFound, err)
+	_, total := r.Batches.FindPage(ctx, 10, 0, "milawd")
+	assert.Equal(t, 1, total)
+}
+
+func testStats(t *testing.T, r Repositories) {
+	yalda := storeCampaign(t, r, "yalda")
+	nowruz := storeCampaign(t, r, "nowruz")
+	storeCard(t, r, yalda, nil)
+	storeCard(t, r, yalda, func(card *dbmodel.GiftCard) { require.Nil(t, card.SetUUN("milad")) })
+	storeCard(t, r, yalda, func(card *dbmodel.GiftCard) { card.ExpireDate = time.Now().AddDate(0, 0, -10).UTC() })
+	storeCard(t, r, yalda, func(card *dbmodel.GiftCard) { require.Nil(t, card.Void()) })
+	storeCard(t, r, nowruz, func(card *dbmodel.GiftCard) { card.Amount = 500 })
+
+	stats, err := r.GiftCards.Stats(ctx, nil)
+	require.Nil(t, err)
+	assert.Equal(t, core.CardsAggregate{Count: 5, Amount: 4500}, stats.Issued)
+	assert.Equal(t, core.CardsAggregate{Count: 2, Amount: 1500}, stats.Outstanding)
+
+	id := uint(yalda.ID)
+	stats, err = r.GiftCards.Stats(ctx, &id)
+	require.Nil(t, err)
+	assert.Equal(t, core.CardsAggregate{Count: 4, Amount: 4000}, stats.Issued)
+	assert.Equal(t, core.CardsAggregate{Count: 1, Amount: 1000}, stats.Redeemed)
+	assert.Equal(t, core.CardsAggregate{Count: 1, Amount: 1000}, stats.Expired)
+	assert.Equal(t, core.CardsAggregate{Count: 1, Amount: 1000}, stats.Voided)
+	assert.Equal(t, core.CardsAggregate{Count: 1, Amount: 1000}, stats.Outstanding)
+	assert.Equal(t, int64(1), stats.TimedRedeems)
+}
+
+func testExpiredGiftCards(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	from := time.Now().UTC().Truncate(time.Second)
+	to := from.Add(time.Hour)
+	expireIn := func(d time.Duration) func(card *dbmodel.GiftCard) {
+		return func(card *dbmodel.GiftCard) { card.ExpireDate = from.AddDate(0, 0, -1).Add(d) }
+	}
+	first := storeCard(t, r, campaign, expireIn(time.Minute))
+	last := storeCard(t, r, campaign, expireIn(time.Hour))
+	storeCard(t, r, campaign, expireIn(0))
+	storeCard(t, r, campaign, expireIn(2*time.Hour))
+	storeCard(t, r, campaign, func(card *dbmodel.GiftCard) {
+		expireIn(time.Minute)(card)
+		require.Nil(t, card.SetUUN("milad"))
+	})
+	deleted := storeCard(t, r, campaign, expireIn(time.Minute))
+	require.Nil(t, r.GiftCards.Delete(ctx, *deleted))
+
+	expired, err := r.GiftCards.FindExpired(ctx, from, to)
+
+	require.Nil(t, err)
+	assert.Equal(t, []int{first.ID, last.ID}, ids(expired))
+	require.NotNil(t, expired[0].Campaign)
+	assert.Equal(t, "yalda", expired[0].Campaign.Title)
+}
+
+func testWebhookSubscriptions(t *testing.T, r Repositories) {
+	_, err := r.Webhooks.FindSubscription(ctx, 12)
+	assert.Equal(t, common.WebhookNotFound, err)
+
+	subscription := dbmodel.NewWebhookSubscription("http://crm/hooks", []string{dbmodel.CardIssuedEvent},
+		"a-long-enough-secret")
+	require.Nil(t, r.Webhooks.StoreSubscription(ctx, subscription))
+	require.NotZero(t, subscription.ID)
+	subscription.SetEvents([]string{dbmodel.CardApprovedEvent, dbmodel.CardExpiredEvent})
+	require.Nil(t, r.Webhooks.StoreSubscription(ctx, subscription))
+
+	found, err := r.Webhooks.FindSubscription(ctx, uint(subscription.ID))
+	require.Nil(t, err)
+	assert.Equal(t, []string{dbmodel.CardApprovedEvent, dbmodel.CardExpiredEvent}, found.EventList())
+	assert.Equal(t, "a-long-enough-secret", found.Secret)
+	all, err := r.Webhooks.FindSubscriptions(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(all))
+
+	require.Nil(t, r.Webhooks.DeleteSubscription(ctx, *found))
+	_, err = r.Webhooks.FindSubscription(ctx, uint(subscription.ID))
+	assert.Equal(t, common.WebhookNotFound, err)
+	all, err = r.Webhooks.FindSubscriptions(ctx)
+	require.Nil(t, err)
+	assert.Empty(t, all)
+}
+
+// storeDelivery stores a pending delivery after letting change set its fields
+func storeDelivery(t *testing.T, r Repositories,
+	change func(delivery *dbmodel.WebhookDelivery)) *dbmodel.WebhookDelivery {
+	delivery := dbmodel.NewWebhookDelivery(1, dbmodel.CardIssuedEvent, `{"event":"gift_card.issued"}`)
+	if change != nil {
+		change(delivery)
+	}
+	require.Nil(t, r.Webhooks.StoreDelivery(ctx, delivery))
+	require.NotZero(t, delivery.ID)
+	return delivery
+}
+
+func testWebhookDeliveryClaims(t *testing.T, r Repositories) {
+	_, err := r.Webhooks.FindDelivery(ctx, 12)
+	assert.Equal(t, common.WebhookDeliveryNotFound, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	later := storeDelivery(t, r, func(delivery *dbmodel.WebhookDelivery) {
+		delivery.NextAttemptAt = now.Add(-time.Minute)
+	})
+	sooner := storeDelivery(t, r, func(delivery *dbmodel.WebhookDelivery) {
+		delivery.NextAttemptAt = now.Add(-time.Hour)
+	})
+	storeDelivery(t, r, func(delivery *dbmodel.WebhookDelivery) { delivery.NextAttemptAt = now.Add(time.Hour) })
+	storeDelivery(t, r, func(delivery *dbmodel.WebhookDelivery) { delivery.Delivered(now) })
+	storeDelivery(t, r, func(delivery *dbmodel.WebhookDelivery) { delivery.Die("the receiver answered 500") })
+
+	claimed, err := r.Webhooks.ClaimDueDeliveries(ctx, now, time.Minute, 10)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(claimed))
+	assert.Equal(t, sooner.ID, claimed[0].ID, "the deliveries should be claimed by their next attempt")
+	assert.Equal(t, later.ID, claimed[1].ID)
+	assert.Equal(t, `{"event":"gift_card.issued"}`, claimed[0].Payload)
+
+	claimed, err = r.Webhooks.ClaimDueDeliveries(ctx, now, time.Minute, 10)
+	require.Nil(t, err)
+	assert.Empty(t, claimed, "the claimed deliveries should not be due until the lease is over")
+
+	claimed, err = r.Webhooks.ClaimDueDeliveries(ctx, now.Add(2*time.Minute), time.Minute, 1)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(claimed))
+
+	found, err := r.Webhooks.FindDelivery(ctx, uint(claimed[0].ID))
+	require.Nil(t, err)
+	found.Failed("the receiver answered 500", now.Add(time.Hour))
+	require.Nil(t, r.Webhooks.StoreDelivery(ctx, found))
+	found, _ = r.Webhooks.FindDelivery(ctx, uint(found.ID))
+	assert.Equal(t, 1, found.Attempts)
+	assert.Equal(t, "the receiver answered 500", found.LastError)
+	assert.True(t, now.Add(time.Hour).Equal(found.NextAttemptAt), fmt.Sprintf("%v is not retried", found.NextAttemptAt))
+}
+
+func testDeadWebhookDeliveries(t *testing.T, r Repositories) {
+	var dead []int
+	for i := 0; i < 3; i++ {
+		dead = append(dead, storeDelivery(t, r, func(delivery *dbmodel.WebhookDelivery) {
+			delivery.Die("the receiver answered 500")
+		}).ID)
+	}
+	storeDelivery(t, r, nil)
+
+	page, total := r.Webhooks.FindDeadDeliveries(ctx, 2, 0)
+	assert.Equal(t, 3, total)
+	require.Equal(t, 2, len(page))
+	assert.Equal(t, dead[2], page[0].ID, "the newest dead deliveries should come first")
+	assert.Equal(t, dead[1], page[1].ID)
+	page, _ = r.Webhooks.FindDeadDeliveries(ctx, 2, 1)
+	require.Equal(t, 1, len(page))
+	assert.Equal(t, dead[0], page[0].ID)
+}
+
+// outboxEvents claims every stored event which is not published yet, as the names and the aggregate ids in their
+// order. the claimed events are not due again in the test
+func outboxEvents(t *testing.T, r Repositories) ([]string, []int) {
+	claimed, err := r.Outbox.ClaimDueEvents(ctx, time.Now().Add(24*time.Hour), time.Hour, 1000)
+	require.Nil(t, err)
+	var names []string
+	var cards []int
+	for _, event := range claimed {
+		names = append(names, event.Event)
+		cards = append(cards, event.AggregateId)
+	}
+	return names, cards
+}
+
+func testOutboxEvents(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	outboxEvents(t, r)
+	card := storeCard(t, r, campaign, func(card *dbmodel.GiftCard) { card.SetBatch(7) })
+	assert.Empty(t, card.Events(), "the events should be cleared once they are stored")
+	require.Nil(t, card.SetUUN("milad"))
+	require.Nil(t, r.GiftCards.Store(ctx, card))
+	require.Nil(t, r.GiftCards.Store(ctx, card))
+	require.Nil(t, r.GiftCards.RollBackApprove(ctx, card.SecretCode))
+	_, err := r.GiftCards.ExtendBatchExpiry(ctx, 7, time.Now().AddDate(1, 0, 0).UTC())
+	require.Nil(t, err)
+	_, err = r.GiftCards.VoidBatch(ctx, 7)
+	require.Nil(t, err)
+	_, err = r.GiftCards.VoidBatch(ctx, 7)
+	require.Nil(t, err)
+	require.Nil(t, r.GiftCards.Delete(ctx, *card))
+
+	names, cards := outboxEvents(t, r)
+	assert.Equal(t, []string{dbmodel.CardIssuedEvent, dbmodel.CardApprovedEvent, dbmodel.CardRolledBackEvent,
+		dbmodel.CardUpdatedEvent, dbmodel.CardVoidedEvent, dbmodel.CardDeletedEvent}, names,
+		"only the changes should raise events")
+	for _, id := range cards {
+		assert.Equal(t, card.ID, id)
+	}
+}
+
+func testCampaignOutboxEvents(t *testing.T, r Repositories) {
+	campaign := dbmodel.NewCampaign("yalda")
+	require.Nil(t, r.Campaigns.Store(ctx, campaign))
+	assert.Empty(t, campaign.Events(), "the events should be cleared once they are stored")
+	campaign.Update("nowruz")
+	campaign.Update("nowruz")
+	require.Nil(t, r.Campaigns.Store(ctx, campaign))
+	require.Nil(t, r.Campaigns.Delete(ctx, *campaign))
+
+	names, campaigns := outboxEvents(t, r)
+	assert.Equal(t, []string{dbmodel.CampaignCreatedEvent, dbmodel.CampaignUpdatedEvent,
+		dbmodel.CampaignDeletedEvent}, names, "only the changes should raise events")
+	assert.Equal(t, []int{campaign.ID, campaign.ID, campaign.ID}, campaigns)
+}
+
+func testOutboxEventClaims(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	outboxEvents(t, r)
+	first := storeCard(t, r, campaign, nil)
+	second := storeCard(t, r, campaign, nil)
+	now := time.Now().Add(time.Second)
+
+	claimed, err := r.Outbox.ClaimDueEvents(ctx, now, time.Minute, 1)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(claimed))
+	assert.Equal(t, first.ID, claimed[0].AggregateId, "the events should be claimed in their order")
+	assert.Contains(t, claimed[0].Payload, first.PublicCode)
+	assert.NotContains(t, claimed[0].Payload, first.SecretCode)
+
+	claimed, err = r.Outbox.ClaimDueEvents(ctx, now, time.Minute, 10)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(claimed), "the claimed events should not be due until the lease is over")
+	assert.Equal(t, second.ID, claimed[0].AggregateId)
+
+	claimed[0].PublishTo("webhooks")
+	claimed[0].Failed("log: unavailable", now.Add(time.Hour))
+	require.Nil(t, r.Outbox.StoreEvent(ctx, &claimed[0]))
+	claimed, err = r.Outbox.ClaimDueEvents(ctx, now.Add(2*time.Minute), time.Minute, 10)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(claimed), "a failed event should be due at its retry")
+	assert.Equal(t, first.ID, claimed[0].AggregateId)
+
+	claimed, err = r.Outbox.ClaimDueEvents(ctx, now.Add(2*time.Hour), time.Minute, 10)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(claimed))
+	failed := claimed[1]
+	assert.Equal(t, second.ID, failed.AggregateId)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, "log: unavailable", failed.LastError)
+	assert.True(t, failed.IsPublishedTo("webhooks"))
+
+	failed.Die("log: unavailable", now)
+	require.Nil(t, r.Outbox.StoreEvent(ctx, &failed))
+	claimed, err = r.Outbox.ClaimDueEvents(ctx, now.Add(3*time.Hour), time.Minute, 10)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(claimed), "a dead event should not be claimed")
+	assert.Equal(t, first.ID, claimed[0].AggregateId)
+}
+
+func testPublishedOutboxEvents(t *testing.T, r Repositories) {
+	campaign := storeCampaign(t, r, "yalda")
+	outboxEvents(t, r)
+	storeCard(t, r, campaign, nil)
+	storeCard(t, r, campaign, nil)
+	now := time.Now().UTC()
+	claimed, err := r.Outbox.ClaimDueEvents(ctx, now.Add(time.Second), time.Minute, 1)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(claimed))
+	claimed[0].Published(now.Add(-time.Hour))
+	require.Nil(t, r.Outbox.StoreEvent(ctx, &claimed[0]))
+
+	names, _ := outboxEvents(t, r)
+	assert.Equal(t, 1, len(names), "a published event should not be claimed")
+
+	deleted, err := r.Outbox.DeletePublished(ctx, now.Add(-2*time.Hour))
+	require.Nil(t, err)
+	assert.Equal(t, 0, deleted)
+	deleted, err = r.Outbox.DeletePublished(ctx, now)
+	require.Nil(t, err)
+	assert.Equal(t, 1, deleted)
+}
+
+func testCommandClaims(t *testing.T, r Repositories) {
+	now := time.Now()
+	command, claimed, err := r.Commands.ClaimCommand(ctx, "order-12", now, time.Minute)
+	require.Nil(t, err)
+	require.True(t, claimed)
+	assert.NotZero(t, command.ID)
+
+	repeated, claimed, err := r.Commands.ClaimCommand(ctx, "order-12", now.Add(time.Second), time.Minute)
+	require.Nil(t, err)
+	assert.False(t, claimed, "a command should be claimed once")
+	assert.False(t, repeated.IsHandled())
+
+	repeated, claimed, err = r.Commands.ClaimCommand(ctx, "order-12", now.Add(2*time.Minute), time.Minute)
+	require.Nil(t, err)
+	assert.True(t, claimed, "a command which is not handled within the lease should be claimed again")
+
+	repeated.Handled(`{"command_id":"order-12"}`, now)
+	require.Nil(t, r.Commands.StoreCommand(ctx, repeated))
+	handled, claimed, err := r.Commands.ClaimCommand(ctx, "order-12", now.Add(time.Hour), time.Minute)
+	require.Nil(t, err)
+	assert.False(t, claimed, "a handled command should not be claimed again")
+	assert.True(t, handled.IsHandled())
+	assert.Equal(t, `{"command_id":"order-12"}`, handled.Result)
+
+	require.Nil(t, r.Commands.DeleteCommand(ctx, *handled))
+	_, claimed, err = r.Commands.ClaimCommand(ctx, "order-12", now, time.Minute)
+	require.Nil(t, err)
+	assert.True(t, claimed, "a deleted command should be claimed again")
+}
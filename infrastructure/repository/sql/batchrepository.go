@@ -0,0 +1,61 @@
+package sql
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"github.com/jinzhu/gorm"
+)
+
+type batchRepository struct {
+	DB *gorm.DB
+}
+
+func (r *batchRepository) FindByID(ctx context.Context, id uint) (*dbmodel.Batch, error) {
+	var batch dbmodel.Batch
+
+	db := r.db(ctx).Find(&batch, id)
+	if db.RecordNotFound() {
+		return nil, common.BatchNotFound
+	} else if db.Error != nil {
+		return nil, db.Error
+	}
+	return &batch, nil
+}
+
+func (r *batchRepository) Store(ctx context.Context, batch *dbmodel.Batch) error {
+	return r.db(ctx).Save(batch).Error
+}
+
+func (r *batchRepository) Delete(ctx context.Context, batch dbmodel.Batch) error {
+	return r.db(ctx).Delete(&batch).Error
+}
+
+func (r *batchRepository) FindPage(ctx context.Context, size, number uint, createdBy string) ([]dbmodel.Batch, int) {
+	data := make(chan []dbmodel.Batch)
+
+	query := r.db(ctx).Model(&dbmodel.Batch{})
+	if createdBy != "" {
+		query = query.Where(quoted(r.DB, `"CreatedBy" = ?`), createdBy)
+	}
+
+	go func(channel chan<- []dbmodel.Batch) {
+		var batches []dbmodel.Batch
+		query.Order("id desc").Limit(size).Offset(size * number).Find(&batches)
+		channel <- batches
+	}(data)
+
+	var total int
+	query.Count(&total)
+	return <-data, total
+}
+
+// db binds the context to the queries so their spans join the trace of the request
+func (r *batchRepository) db(ctx context.Context) *gorm.DB {
+	return withContext(r.DB, ctx)
+}
+
+func NewBatchRepository(DB *gorm.DB) core.BatchRepository {
+	return &batchRepository{DB: DB}
+}
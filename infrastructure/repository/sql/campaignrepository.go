@@ -1,73 +1,117 @@
 package sql
 
 import (
+	"context"
 	"giftcard-engine/core"
 	"giftcard-engine/core/common"
 	"giftcard-engine/core/dbmodel"
 	"github.com/jinzhu/gorm"
-	_ "github.com/jinzhu/gorm/dialects/mssql"
 )
 
 type campaignRepository struct {
 	DB *gorm.DB
 }
 
-func (r *campaignRepository) FindByID(id uint) (dbmodel.Campaign, error) {
+func (r *campaignRepository) FindByID(ctx context.Context, id uint) (dbmodel.Campaign, error) {
 	var campaign dbmodel.Campaign
 
-	if r.DB.Find(&campaign, id).RecordNotFound() {
+	db := r.db(ctx).Find(&campaign, id)
+	if db.RecordNotFound() {
 		return dbmodel.EmptyCampaign(), common.CampaignNotFound
+	} else if db.Error != nil {
+		return dbmodel.EmptyCampaign(), db.Error
 	}
 	return campaign, nil
 }
 
-func (r *campaignRepository) Store(campaign *dbmodel.Campaign) error {
-	err := r.titleGuard(campaign.Title)
+// Store saves the campaign with its events in a transaction
+func (r *campaignRepository) Store(ctx context.Context, campaign *dbmodel.Campaign) error {
+	err := r.titleGuard(ctx, campaign.Title)
 	if err != nil {
 		return err
 	}
-	return r.DB.Save(campaign).Error
+	err = transaction(ctx, r.db(ctx), func(tx *gorm.DB) error {
+		if err := tx.Save(campaign).Error; err != nil {
+			return err
+		}
+		return addCampaignEvents(tx, *campaign, campaign.Events())
+	})
+	if err == nil {
+		campaign.ClearEvents()
+	}
+	return err
 }
 
-func (r *campaignRepository) Delete(campaign dbmodel.Campaign) error {
-	db := r.DB.Delete(&campaign)
-	if db.RecordNotFound() {
-		return common.CampaignNotFound
-	}
-	return db.Error
+func (r *campaignRepository) Delete(ctx context.Context, campaign dbmodel.Campaign) error {
+	return transaction(ctx, r.db(ctx), func(tx *gorm.DB) error {
+		db := tx.Delete(&campaign)
+		if db.RecordNotFound() {
+			return common.CampaignNotFound
+		}
+		if db.Error != nil || db.RowsAffected == 0 {
+			return db.Error
+		}
+		return addCampaignEvents(tx, campaign, []string{dbmodel.CampaignDeletedEvent})
+	})
 }
 
-func (r *campaignRepository) titleGuard(title string) error {
+func (r *campaignRepository) titleGuard(ctx context.Context, title string) error {
 	var total int
-	r.DB.Model(&dbmodel.Campaign{}).Where("Title = ?", title).Count(&total)
+	r.db(ctx).Model(&dbmodel.Campaign{}).Where(quoted(r.DB, `"Title" = ?`), title).Count(&total)
 	if total > 0 {
 		return common.DuplicatedCampaignTitle
 	}
 	return nil
 }
 
-func (r *campaignRepository) FindPage(size, number uint, search string) ([]dbmodel.Campaign, int) {
+func (r *campaignRepository) FindPage(ctx context.Context, size, number uint, search string) ([]dbmodel.Campaign, int) {
 	data := make(chan []dbmodel.Campaign)
+	query := r.filter(ctx, search)
 	go func(channel chan<- []dbmodel.Campaign) {
 		var campaigns []dbmodel.Campaign
-		if search == "" {
-			r.DB.Order("id desc").Limit(size).Offset(size * number).Find(&campaigns)
-		} else {
-			r.DB.Order("id desc").Where("Title like ?", "%"+search+"%").
-				Limit(size).Offset(size * number).Find(&campaigns)
-		}
+		query.Order("id desc").Limit(size).Offset(size * number).Find(&campaigns)
 		channel <- campaigns
 	}(data)
 
 	var total int
-	if search == "" {
-		r.DB.Model(&dbmodel.Campaign{}).Count(&total)
-	} else {
-		r.DB.Model(&dbmodel.Campaign{}).Where("Title like ?", "%"+search+"%").Count(&total)
+	query.Count(&total)
+	return <-data, total
+}
+
+func (r *campaignRepository) FindCursorPage(ctx context.Context, size uint, after *int, search string,
+	withCount bool) ([]dbmodel.Campaign, int) {
+	data := make(chan []dbmodel.Campaign)
+	query := r.filter(ctx, search)
+	go func(channel chan<- []dbmodel.Campaign) {
+		var campaigns []dbmodel.Campaign
+		page := query
+		if after != nil {
+			page = page.Where("id < ?", *after)
+		}
+		page.Order("id desc").Limit(size).Find(&campaigns)
+		channel <- campaigns
+	}(data)
+
+	total := -1
+	if withCount {
+		query.Count(&total)
 	}
 	return <-data, total
 }
 
+func (r *campaignRepository) filter(ctx context.Context, search string) *gorm.DB {
+	query := r.db(ctx).Model(&dbmodel.Campaign{})
+	if search != "" {
+		query = query.Where(containing(r.DB, "Title"), pattern(search))
+	}
+	return query
+}
+
+// db binds the context to the queries so their spans join the trace of the request
+func (r *campaignRepository) db(ctx context.Context) *gorm.DB {
+	return withContext(r.DB, ctx)
+}
+
 func NewCampaignRepository(DB *gorm.DB) core.CampaignRepository {
 	return &campaignRepository{DB: DB}
 }
@@ -0,0 +1,65 @@
+package sql
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"github.com/jinzhu/gorm"
+	"time"
+)
+
+type commandRepository struct {
+	DB *gorm.DB
+}
+
+// ClaimCommand relies on the unique index of the command id, so only one of the instances inserts a command. an
+// expired claim is taken over only if it is not taken over by another instance since it was read, which is known
+// by its claim time being unchanged
+func (r *commandRepository) ClaimCommand(ctx context.Context, commandId string, now time.Time,
+	lease time.Duration) (*dbmodel.Command, bool, error) {
+	command := dbmodel.NewCommand(commandId, now)
+	createErr := r.db(ctx).Create(command).Error
+	if createErr == nil {
+		return command, true, nil
+	}
+
+	var stored dbmodel.Command
+	db := r.db(ctx).Where(quoted(r.DB, `"CommandId" = ?`), commandId).First(&stored)
+	if db.RecordNotFound() {
+		return nil, false, createErr
+	} else if db.Error != nil {
+		return nil, false, db.Error
+	}
+	if stored.IsHandled() || stored.ClaimedAt.After(now.Add(-lease)) {
+		return &stored, false, nil
+	}
+	claimedAt := now.UTC()
+	db = r.db(ctx).Model(&dbmodel.Command{}).
+		Where(quoted(r.DB, `id = ? and "HandledAt" is null and "ClaimedAt" = ?`), stored.ID, stored.ClaimedAt).
+		UpdateColumn("ClaimedAt", claimedAt)
+	if db.Error != nil {
+		return nil, false, db.Error
+	}
+	if db.RowsAffected == 1 {
+		stored.ClaimedAt = claimedAt
+		return &stored, true, nil
+	}
+	return &stored, false, nil
+}
+
+func (r *commandRepository) StoreCommand(ctx context.Context, command *dbmodel.Command) error {
+	return r.db(ctx).Save(command).Error
+}
+
+func (r *commandRepository) DeleteCommand(ctx context.Context, command dbmodel.Command) error {
+	return r.db(ctx).Delete(&command).Error
+}
+
+// db binds the context to the queries so their spans join the trace of the request
+func (r *commandRepository) db(ctx context.Context) *gorm.DB {
+	return withContext(r.DB, ctx)
+}
+
+func NewCommandRepository(DB *gorm.DB) core.CommandRepository {
+	return &commandRepository{DB: DB}
+}
@@ -0,0 +1,21 @@
+package sql_test
+
+import (
+	"giftcard-engine/infrastructure/repository/repositorytest"
+	"giftcard-engine/infrastructure/repository/sql"
+	"testing"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		db := newTestDB(t)
+		return repositorytest.Repositories{
+			GiftCards: sql.NewGiftCardRepository(db),
+			Campaigns: sql.NewCampaignRepository(db),
+			Batches:   sql.NewBatchRepository(db),
+			Webhooks:  sql.NewWebhookRepository(db),
+			Outbox:    sql.NewOutboxRepository(db),
+			Commands:  sql.NewCommandRepository(db),
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"giftcard-engine/infrastructure/logger"
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+// withContext binds the context of the request to the queries of the handle so their spans join its trace, their
+// log lines carry its request id and they are skipped once it is canceled
+func withContext(db *gorm.DB, ctx context.Context) *gorm.DB {
+	scoped := db.Set(contextKey, ctx)
+	scoped.SetLogger(logger.NewGormLogger(ctx))
+	return scoped
+}
+
+// RegisterCancellation stops a handle bound to a context from running new statements after the context is done.
+// gorm does not pass the context to the driver so a running statement is not interrupted, so the long statements
+// run through queryContext or in a transaction
+func RegisterCancellation(db *gorm.DB) {
+	callbacks := db.Callback()
+	for operation, processor := range map[string]func() *gorm.CallbackProcessor{
+		"create": callbacks.Create,
+		"query":  callbacks.Query,
+		"update": callbacks.Update,
+		"delete": callbacks.Delete,
+	} {
+		processor().Before("gorm:"+operation).Register("context:cancel_"+operation, cancelWhenDone)
+	}
+}
+
+func cancelWhenDone(scope *gorm.Scope) {
+	value, ok := scope.Get(contextKey)
+	if !ok {
+		return
+	}
+	ctx, ok := value.(context.Context)
+	if !ok || ctx.Err() == nil {
+		return
+	}
+	scope.Err(ctx.Err())
+	// the query callbacks do not check the errors of the scope before running
+	scope.InstanceSet("gorm:skip_query_callback", true)
+}
+
+// transaction works like gorm.DB.Transaction but the transaction is rolled back as soon as the context is done
+func transaction(ctx context.Context, db *gorm.DB, fc func(tx *gorm.DB) error) (err error) {
+	panicked := true
+	tx := db.BeginTx(ctx, nil)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer func() {
+		if panicked || err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	err = fc(tx)
+	if err == nil {
+		err = tx.Commit().Error
+	}
+	panicked = false
+	return
+}
+
+// queryContext runs a raw statement through the driver with the context, so the database cancels it as soon as the
+// context is done, and calls scan for every row. the ? placeholders are replaced with the bind variables of the
+// dialect. it is used by the reports, which scan the whole table
+func queryContext(ctx context.Context, db *gorm.DB, scan func(rows *dbsql.Rows) error, statement string,
+	args ...interface{}) (err error) {
+	ctx, span := tracer.Start(ctx, "gorm.row_query", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(db.Dialect().GetName()),
+			semconv.DBStatementKey.String(statement),
+		))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	rows, err := db.DB().QueryContext(ctx, bindVars(db, statement), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// bindVars replaces the ? placeholders of the statement with the bind variables of the dialect, like $1 on postgres
+func bindVars(db *gorm.DB, statement string) string {
+	parts := strings.Split(statement, "?")
+	var builder strings.Builder
+	builder.WriteString(parts[0])
+	for i, part := range parts[1:] {
+		builder.WriteString(db.Dialect().BindVar(i + 1))
+		builder.WriteString(part)
+	}
+	// the dialects which bind with ? return a marker like the scopes of gorm do
+	return strings.Replace(builder.String(), "$$$", "?", -1)
+}
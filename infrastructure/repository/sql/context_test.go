@@ -0,0 +1,35 @@
+package sql_test
+
+import (
+	"context"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/infrastructure/repository/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCanceledContextSkipsTheStatements(t *testing.T) {
+	db := newTestDB(t)
+	campaign := dbmodel.Campaign{Title: "yalda"}
+	db.Create(&campaign)
+	repository := sql.NewGiftCardRepository(db)
+	stored := dbmodel.NewGiftCard(1000, time.Now().AddDate(0, 1, 0))
+	stored.SetCampaign(uint(campaign.ID))
+	assert.Nil(t, repository.Store(context.Background(), stored))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	card := dbmodel.NewGiftCard(1000, time.Now().AddDate(0, 1, 0))
+	card.SetCampaign(uint(campaign.ID))
+	err := repository.Store(ctx, card)
+	_, findErr := repository.FindByID(ctx, uint(stored.ID))
+	_, extendErr := repository.ExtendBatchExpiry(ctx, 1, time.Now().AddDate(0, 2, 0))
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, context.Canceled, findErr)
+	assert.Equal(t, context.Canceled, extendErr)
+	total := 0
+	db.Model(&dbmodel.GiftCard{}).Count(&total)
+	assert.Equal(t, 1, total)
+}
@@ -0,0 +1,75 @@
+package sql
+
+import (
+	"fmt"
+	"github.com/jinzhu/gorm"
+	"regexp"
+	"strings"
+)
+
+// identifier matches the double quoted identifiers of a raw statement
+var identifier = regexp.MustCompile(`"\w+"`)
+
+// quoted replaces the double quotes around the identifiers of a raw statement with the quotes of the dialect.
+// the columns are mixed case so they have to be quoted on postgres, which folds unquoted names to lower case
+func quoted(db *gorm.DB, statement string) string {
+	return identifier.ReplaceAllStringFunc(statement, func(name string) string {
+		return db.Dialect().Quote(strings.Trim(name, `"`))
+	})
+}
+
+// containing returns a case insensitive condition matching the values of the column which contain the argument
+// returned by pattern, since like is case sensitive on postgres
+func containing(db *gorm.DB, column string) string {
+	return quoted(db, fmt.Sprintf(`LOWER("%s") LIKE ?`, column))
+}
+
+// pattern returns the argument of containing for the text
+func pattern(text string) string {
+	return "%" + strings.ToLower(text) + "%"
+}
+
+// bigint casts the expression to a 64 bit integer so the sums of the int columns do not overflow
+func bigint(db *gorm.DB, expression string) string {
+	switch db.Dialect().GetName() {
+	case "mysql":
+		return fmt.Sprintf("CAST(%s AS SIGNED)", expression)
+	case "sqlite3":
+		return fmt.Sprintf("CAST(%s AS INTEGER)", expression)
+	default:
+		return fmt.Sprintf("CAST(%s AS BIGINT)", expression)
+	}
+}
+
+// secondsBetween returns the number of seconds from one datetime column to another
+func secondsBetween(db *gorm.DB, from, to string) string {
+	switch db.Dialect().GetName() {
+	case "mysql":
+		return fmt.Sprintf("TIMESTAMPDIFF(SECOND, %s, %s)", from, to)
+	case "postgres":
+		return fmt.Sprintf("EXTRACT(EPOCH FROM (%s - %s))", to, from)
+	case "sqlite3":
+		return fmt.Sprintf("(julianday(%s) - julianday(%s)) * 86400", to, from)
+	default:
+		return fmt.Sprintf("DATEDIFF(second, %s, %s)", from, to)
+	}
+}
+
+// hourFormat is the layout of the values returned by hourOf
+const hourFormat = "2006-01-02 15"
+
+// hourOf returns the utc hour of a datetime column shifted by the given minutes, formatted like hourFormat
+func hourOf(db *gorm.DB, column string, shiftMinutes int) string {
+	switch db.Dialect().GetName() {
+	case "mysql":
+		return fmt.Sprintf("DATE_FORMAT(DATE_ADD(%s, INTERVAL %d MINUTE), '%%Y-%%m-%%d %%H')", column, shiftMinutes)
+	case "postgres":
+		return fmt.Sprintf("to_char((%s AT TIME ZONE 'UTC') + interval '%d minutes', 'YYYY-MM-DD HH24')",
+			column, shiftMinutes)
+	case "sqlite3":
+		return fmt.Sprintf("strftime('%%Y-%%m-%%d %%H', %s, '%+d minutes')", column, shiftMinutes)
+	default:
+		return fmt.Sprintf("CONVERT(varchar(13), DATEADD(minute, %d, SWITCHOFFSET(%s, '+00:00')), 120)",
+			shiftMinutes, column)
+	}
+}
@@ -1,11 +1,14 @@
 package sql
 
 import (
+	"context"
+	dbsql "database/sql"
+	"fmt"
 	"giftcard-engine/core"
 	"giftcard-engine/core/common"
 	"giftcard-engine/core/dbmodel"
 	"github.com/jinzhu/gorm"
-	_ "github.com/jinzhu/gorm/dialects/mssql"
+	"strings"
 	"time"
 )
 
@@ -13,82 +16,93 @@ type gCardRepository struct {
 	DB *gorm.DB
 }
 
-func (r *gCardRepository) FindByUUN(uun string) []dbmodel.GiftCard {
+func (r *gCardRepository) FindByUUN(ctx context.Context, uun string) []dbmodel.GiftCard {
 	var giftCards []dbmodel.GiftCard
-	r.DB.Preload("Campaign").Find(&giftCards, "UUN=?", uun)
+	r.db(ctx).Preload("Campaign").Find(&giftCards, quoted(r.DB, `"UUN" = ?`), uun)
 	return giftCards
 }
 
-func (r *gCardRepository) FindByID(id uint) (*dbmodel.GiftCard, error) {
+func (r *gCardRepository) FindByID(ctx context.Context, id uint) (*dbmodel.GiftCard, error) {
 	var giftCard dbmodel.GiftCard
 
-	if r.DB.Preload("Campaign").Find(&giftCard, id).RecordNotFound() {
+	db := r.db(ctx).Preload("Campaign").Find(&giftCard, id)
+	if db.RecordNotFound() {
 		return nil, common.GiftCardNotFound
+	} else if db.Error != nil {
+		return nil, db.Error
 	}
 	return &giftCard, nil
 }
 
-func (r *gCardRepository) Store(card *dbmodel.GiftCard) error {
-	err := r.campaignGuard(card.CampaignId)
-	if err != nil {
-		return err
+func (r *gCardRepository) FindByIDs(ctx context.Context, ids []int) []dbmodel.GiftCard {
+	var giftCards []dbmodel.GiftCard
+	if len(ids) == 0 {
+		return giftCards
 	}
-	return r.DB.Save(&card).Error
+	r.db(ctx).Preload("Campaign").Where("id in (?)", ids).Find(&giftCards)
+	return giftCards
 }
 
-func (r *gCardRepository) campaignGuard(cid uint) error {
-	if r.DB.Find(&dbmodel.Campaign{}, cid).RecordNotFound() {
-		return common.InvalidCampaign
+// Store saves the card with its new revisions and events in a transaction
+func (r *gCardRepository) Store(ctx context.Context, card *dbmodel.GiftCard) error {
+	err := r.campaignGuard(ctx, card.CampaignId)
+	if err != nil {
+		return err
 	}
-	return nil
+	err = transaction(ctx, r.db(ctx), func(tx *gorm.DB) error {
+		if err := tx.Save(&card).Error; err != nil {
+			return err
+		}
+		return addCardEvents(tx, *card, card.Events())
+	})
+	if err == nil {
+		card.ClearEvents()
+	}
+	return err
 }
 
-func (r *gCardRepository) Delete(card dbmodel.GiftCard) error {
-	db := r.DB.Delete(&card)
+func (r *gCardRepository) campaignGuard(ctx context.Context, cid uint) error {
+	db := r.db(ctx).Find(&dbmodel.Campaign{}, cid)
 	if db.RecordNotFound() {
-		return common.GiftCardNotFound
+		return common.InvalidCampaign
 	}
 	return db.Error
 }
 
-func (r *gCardRepository) FindByPublicKey(key string) (*dbmodel.GiftCard, error) {
+func (r *gCardRepository) Delete(ctx context.Context, card dbmodel.GiftCard) error {
+	return transaction(ctx, r.db(ctx), func(tx *gorm.DB) error {
+		db := tx.Delete(&card)
+		if db.RecordNotFound() {
+			return common.GiftCardNotFound
+		}
+		if db.Error != nil || db.RowsAffected == 0 {
+			return db.Error
+		}
+		return addCardEvents(tx, card, []string{dbmodel.CardDeletedEvent})
+	})
+}
+
+func (r *gCardRepository) FindByPublicKey(ctx context.Context, key string) (*dbmodel.GiftCard, error) {
 	var giftCard dbmodel.GiftCard
 
-	if r.DB.Where("PublicCode = ?", key).First(&giftCard).RecordNotFound() {
+	db := r.db(ctx).Where(quoted(r.DB, `"PublicCode" = ?`), key).First(&giftCard)
+	if db.RecordNotFound() {
 		return nil, common.GiftCardNotFound
+	} else if db.Error != nil {
+		return nil, db.Error
 	}
 	return &giftCard, nil
 }
 
-func (r *gCardRepository) FindPage(size, number uint, search string, campaignId *int,
-	isValid *bool, expireDateFrom *time.Time, expireDateTo *time.Time) ([]dbmodel.GiftCard, int) {
+func (r *gCardRepository) FindPage(ctx context.Context, size, number uint,
+	filter core.GiftCardFilter) ([]dbmodel.GiftCard, int) {
 	data := make(chan []dbmodel.GiftCard)
 
-	query := r.DB.Model(&dbmodel.GiftCard{})
-	if search != "" {
-		query = query.Where("PublicCode like ?", "%"+search+"%")
-	}
-	if campaignId != nil {
-		query = query.Where("CampaignId = ?", *campaignId)
-	}
-	if isValid != nil && *isValid == true {
-		query = query.Where("(UUN is null or UUN = '') and ExpireDate > GETDATE()")
-	}
-	if isValid != nil && *isValid == false {
-		query = query.Where("UUN is not null and ExpireDate < GETDATE()")
-	}
-
-	if expireDateFrom != nil {
-		query = query.Where("ExpireDate > ?", *expireDateFrom)
-	}
-
-	if expireDateTo != nil {
-		query = query.Where("ExpireDate < ?", *expireDateTo)
-	}
+	query := r.filter(ctx, filter)
 
 	go func(channel chan<- []dbmodel.GiftCard) {
 		var giftCards []dbmodel.GiftCard
-		query.Order("id desc").Limit(size).Offset(size * number).Find(&giftCards)
+		r.sort(query, filter.Sort).Limit(size).Offset(size * number).Find(&giftCards)
 		channel <- giftCards
 	}(data)
 
@@ -97,23 +111,425 @@ func (r *gCardRepository) FindPage(size, number uint, search string, campaignId
 	return <-data, total
 }
 
-func (r *gCardRepository) FindBySecretKey(secret string) (*dbmodel.GiftCard, error) {
+func (r *gCardRepository) FindCursorPage(ctx context.Context, size uint, after *int, filter core.GiftCardFilter,
+	withCount bool) ([]dbmodel.GiftCard, int) {
+	data := make(chan []dbmodel.GiftCard)
+
+	query := r.filter(ctx, filter)
+
+	go func(channel chan<- []dbmodel.GiftCard) {
+		var giftCards []dbmodel.GiftCard
+		page := query
+		if after != nil {
+			page = page.Where("id < ?", *after)
+		}
+		page.Order("id desc").Limit(size).Find(&giftCards)
+		channel <- giftCards
+	}(data)
+
+	total := -1
+	if withCount {
+		query.Count(&total)
+	}
+	return <-data, total
+}
+
+func (r *gCardRepository) filter(ctx context.Context, filter core.GiftCardFilter) *gorm.DB {
+	query := r.db(ctx).Model(&dbmodel.GiftCard{})
+	if filter.Search != "" {
+		query = query.Where(containing(r.DB, "PublicCode"), pattern(filter.Search))
+	}
+	if filter.UUN != "" {
+		query = query.Where(quoted(r.DB, `"UUN" = ?`), filter.UUN)
+	}
+	if filter.Status != nil {
+		query = query.Where(quoted(r.DB, `"Status" = ?`), *filter.Status)
+	}
+	if filter.AmountFrom != nil {
+		query = query.Where(quoted(r.DB, `"Amount" >= ?`), *filter.AmountFrom)
+	}
+	if filter.AmountTo != nil {
+		query = query.Where(quoted(r.DB, `"Amount" <= ?`), *filter.AmountTo)
+	}
+	if len(filter.CampaignIds) > 0 {
+		query = query.Where(quoted(r.DB, `"CampaignId" in (?)`), filter.CampaignIds)
+	}
+	if filter.CampaignTitle != "" {
+		query = query.Where(quoted(r.DB, `"CampaignId" in (?)`), r.db(ctx).Model(&dbmodel.Campaign{}).
+			Select("id").Where(containing(r.DB, "Title"), pattern(filter.CampaignTitle)).QueryExpr())
+	}
+	if filter.IsValid != nil {
+		query = validity(r.DB, query, dbmodel.CurrentValidity(), *filter.IsValid)
+	}
+	if filter.ExpireDateFrom != nil {
+		query = query.Where(quoted(r.DB, `"ExpireDate" > ?`), *filter.ExpireDateFrom)
+	}
+	if filter.ExpireDateTo != nil {
+		query = query.Where(quoted(r.DB, `"ExpireDate" < ?`), *filter.ExpireDateTo)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.RedeemedFrom != nil {
+		query = query.Where(quoted(r.DB, `"RedeemedAt" >= ?`), *filter.RedeemedFrom)
+	}
+	if filter.RedeemedTo != nil {
+		query = query.Where(quoted(r.DB, `"RedeemedAt" < ?`), *filter.RedeemedTo)
+	}
+	return query
+}
+
+// validity translates the domain validity rule into criteria. the moment is passed as a parameter
+// instead of a database function to keep the query portable between the dialects
+func validity(db, query *gorm.DB, rule dbmodel.GiftCardValidity, valid bool) *gorm.DB {
+	if valid {
+		return query.Where(quoted(db, `("UUN" is null or "UUN" = '') and "Status" = ? and "ExpireDate" > ?`),
+			rule.Status, rule.ExpireAfter)
+	}
+	return query.Where(quoted(db, `(("UUN" is not null and "UUN" <> '') or "Status" <> ? or "ExpireDate" <= ?)`),
+		rule.Status, rule.ExpireAfter)
+}
+
+// giftCardSortColumns maps the api sort names to the sql columns
+var giftCardSortColumns = map[string]string{
+	"id":          "id",
+	"amount":      "Amount",
+	"status":      "Status",
+	"expire_date": "ExpireDate",
+	"created_at":  "created_at",
+	"redeemed_at": "RedeemedAt",
+}
+
+// sort orders the query by the given fields and then by id to keep the paging stable
+func (r *gCardRepository) sort(query *gorm.DB, fields []core.SortField) *gorm.DB {
+	for _, field := range fields {
+		column, ok := giftCardSortColumns[field.Column]
+		if !ok {
+			continue
+		}
+		column = r.DB.Dialect().Quote(column)
+		if field.Descending {
+			column += " desc"
+		}
+		query = query.Order(column)
+	}
+	return query.Order("id desc")
+}
+
+func (r *gCardRepository) FindBySecretKey(ctx context.Context, secret string) (*dbmodel.GiftCard, error) {
 	var giftCard dbmodel.GiftCard
 
-	if r.DB.Where("SecretCode = ?", secret).First(&giftCard).RecordNotFound() {
+	db := r.db(ctx).Where(quoted(r.DB, `"SecretCode" = ?`), secret).First(&giftCard)
+	if db.RecordNotFound() {
 		return nil, common.GiftCardNotFound
+	} else if db.Error != nil {
+		return nil, db.Error
 	}
 	return &giftCard, nil
 }
 
-func (r *gCardRepository) RollBackApprove(secret string) error {
-	var giftCard dbmodel.GiftCard
+func (r *gCardRepository) RollBackApprove(ctx context.Context, secret string) error {
+	return transaction(ctx, r.db(ctx), func(tx *gorm.DB) error {
+		var giftCard dbmodel.GiftCard
+
+		db := tx.Where(quoted(tx, `"SecretCode" = ?`), secret).First(&giftCard)
+		if db.RecordNotFound() {
+			return common.GiftCardNotFound
+		} else if db.Error != nil {
+			return db.Error
+		}
+		giftCard.RollBack()
+		if err := tx.Save(giftCard).Error; err != nil {
+			return err
+		}
+		return addCardEvents(tx, giftCard, giftCard.Events())
+	})
+}
+
+// FindByBatch reads the cards in a transaction of the context so the driver cancels the export of a large batch
+// when the request is gone
+func (r *gCardRepository) FindByBatch(ctx context.Context, batchId uint) []dbmodel.GiftCard {
+	var giftCards []dbmodel.GiftCard
+	err := transaction(ctx, r.db(ctx), func(tx *gorm.DB) error {
+		return tx.Preload("Campaign").Order("id").Find(&giftCards, quoted(tx, `"BatchId" = ?`), batchId).Error
+	})
+	if err != nil {
+		return nil
+	}
+	return giftCards
+}
+
+// ExtendBatchExpiry keeps the previous terms of the cards as revisions before changing their expire date
+func (r *gCardRepository) ExtendBatchExpiry(ctx context.Context, batchId uint, expireDate time.Time) (int, error) {
+	affected := 0
+	err := transaction(ctx, r.db(ctx), func(tx *gorm.DB) error {
+		var cards []dbmodel.GiftCard
+		if err := unusedCardsOfBatch(tx, batchId).Order("id").Find(&cards).Error; err != nil {
+			return err
+		}
+		err := tx.Exec(quoted(tx, `INSERT INTO "GiftCardRevision" ("GiftCardId", "Amount", "ExpireDate", "RevisedAt") `+
+			`SELECT id, "Amount", "ExpireDate", ? FROM "GiftCard" WHERE deleted_at IS NULL AND `+unusedOfBatch),
+			time.Now().UTC(), batchId, dbmodel.Empty).Error
+		if err != nil {
+			return err
+		}
+		db := unusedCardsOfBatch(tx, batchId).Updates(map[string]interface{}{"ExpireDate": expireDate})
+		if db.Error != nil {
+			return db.Error
+		}
+		affected = int(db.RowsAffected)
+		for _, card := range cards {
+			card.ExpireDate = expireDate
+			if err = addCardEvents(tx, card, []string{dbmodel.CardUpdatedEvent}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return affected, err
+}
+
+func (r *gCardRepository) VoidBatch(ctx context.Context, batchId uint) (int, error) {
+	affected := 0
+	err := transaction(ctx, r.db(ctx), func(tx *gorm.DB) error {
+		var cards []dbmodel.GiftCard
+		if err := unusedCardsOfBatch(tx, batchId).Order("id").Find(&cards).Error; err != nil {
+			return err
+		}
+		voidedAt := time.Now().UTC()
+		db := unusedCardsOfBatch(tx, batchId).
+			Updates(map[string]interface{}{"Status": dbmodel.Voided, "VoidedAt": voidedAt})
+		if db.Error != nil {
+			return db.Error
+		}
+		affected = int(db.RowsAffected)
+		for _, card := range cards {
+			card.Status = dbmodel.Voided
+			card.VoidedAt = &voidedAt
+			if err := addCardEvents(tx, card, []string{dbmodel.CardVoidedEvent}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return affected, err
+}
+
+const unusedOfBatch = `"BatchId" = ? and ("UUN" is null or "UUN" = '') and "Status" = ?`
+
+func unusedCardsOfBatch(db *gorm.DB, batchId uint) *gorm.DB {
+	return db.Model(&dbmodel.GiftCard{}).Where(quoted(db, unusedOfBatch), batchId, dbmodel.Empty)
+}
+
+// giftCardStatsRow is the result of the stats query
+type giftCardStatsRow struct {
+	IssuedCount       int64
+	IssuedAmount      int64
+	RedeemedCount     int64
+	RedeemedAmount    int64
+	ExpiredCount      int64
+	ExpiredAmount     int64
+	VoidedCount       int64
+	VoidedAmount      int64
+	DeletedCount      int64
+	DeletedAmount     int64
+	OutstandingCount  int64
+	OutstandingAmount int64
+	TimedRedeems      int64
+	RedeemSeconds     int64
+}
+
+// Stats aggregates the cards of a campaign, or all of the cards when campaignId is nil, in a single query
+func (r *gCardRepository) Stats(ctx context.Context, campaignId *uint) (core.GiftCardStats, error) {
+	rule := dbmodel.CurrentValidity()
+	amount := bigint(r.DB, `"Amount"`)
+	unused := `deleted_at IS NULL AND ("UUN" IS NULL OR "UUN" = '')`
+	redeemed := `deleted_at IS NULL AND "UUN" IS NOT NULL AND "UUN" <> ''`
+	groups := []struct {
+		name      string
+		condition string
+		args      []interface{}
+	}{
+		{"redeemed", redeemed, nil},
+		{"expired", unused + ` AND "Status" = ? AND "ExpireDate" <= ?`, []interface{}{rule.Status, rule.ExpireAfter}},
+		{"voided", unused + ` AND "Status" = ?`, []interface{}{dbmodel.Voided}},
+		{"deleted", "deleted_at IS NOT NULL", nil},
+		{"outstanding", unused + ` AND "Status" = ? AND "ExpireDate" > ?`,
+			[]interface{}{rule.Status, rule.ExpireAfter}},
+	}
+
+	columns := []string{"COUNT(*) AS issued_count", fmt.Sprintf("COALESCE(SUM(%s), 0) AS issued_amount", amount)}
+	var args []interface{}
+	for _, group := range groups {
+		columns = append(columns,
+			fmt.Sprintf("COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0) AS %s_count", group.condition, group.name),
+			fmt.Sprintf("COALESCE(SUM(CASE WHEN %s THEN %s ELSE 0 END), 0) AS %s_amount",
+				group.condition, amount, group.name))
+		args = append(args, group.args...)
+		args = append(args, group.args...)
+	}
+	timed := redeemed + ` AND "RedeemedAt" IS NOT NULL`
+	columns = append(columns,
+		fmt.Sprintf("COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0) AS timed_redeems", timed),
+		fmt.Sprintf("COALESCE(SUM(CASE WHEN %s THEN %s ELSE 0 END), 0) AS redeem_seconds",
+			timed, bigint(r.DB, secondsBetween(r.DB, "created_at", `"RedeemedAt"`))))
+
+	statement := fmt.Sprintf(`SELECT %s FROM "GiftCard"`, strings.Join(columns, ", "))
+	if campaignId != nil {
+		statement += ` WHERE "CampaignId" = ?`
+		args = append(args, *campaignId)
+	}
+	var row giftCardStatsRow
+	err := queryContext(ctx, r.DB, func(rows *dbsql.Rows) error {
+		return r.DB.ScanRows(rows, &row)
+	}, quoted(r.DB, statement), args...)
+	if err != nil {
+		return core.GiftCardStats{}, err
+	}
+	return core.GiftCardStats{
+		Issued:        core.CardsAggregate{Count: row.IssuedCount, Amount: row.IssuedAmount},
+		Redeemed:      core.CardsAggregate{Count: row.RedeemedCount, Amount: row.RedeemedAmount},
+		Expired:       core.CardsAggregate{Count: row.ExpiredCount, Amount: row.ExpiredAmount},
+		Voided:        core.CardsAggregate{Count: row.VoidedCount, Amount: row.VoidedAmount},
+		Deleted:       core.CardsAggregate{Count: row.DeletedCount, Amount: row.DeletedAmount},
+		Outstanding:   core.CardsAggregate{Count: row.OutstandingCount, Amount: row.OutstandingAmount},
+		TimedRedeems:  row.TimedRedeems,
+		RedeemSeconds: row.RedeemSeconds,
+	}, nil
+}
+
+// reportColumns maps the report events to their datetime columns
+var reportColumns = map[core.ReportEvent]string{
+	core.IssuedEvent:   "created_at",
+	core.RedeemedEvent: `"RedeemedAt"`,
+}
+
+// hourlyTotalRow is a single row of the hourly totals query
+type hourlyTotalRow struct {
+	Hour   string
+	Count  int64
+	Amount int64
+}
+
+// HourlyTotals groups the cards by the utc hour of the event. the times are shifted by the minutes of the
+// location offset first so half hour time zones get their own hour boundaries. deleted cards are included
+// because the reports show what has happened
+func (r *gCardRepository) HourlyTotals(ctx context.Context, event core.ReportEvent, campaignId *uint,
+	from, to time.Time,
+	location *time.Location) ([]core.HourlyTotal, error) {
+	column, ok := reportColumns[event]
+	if !ok {
+		return nil, fmt.Errorf("unknown report event %d", event)
+	}
+	_, offset := from.In(location).Zone()
+	shift := offset / 60 % 60
+	hour := hourOf(r.DB, column, shift)
+
+	statement := fmt.Sprintf(`SELECT %s AS hour, COUNT(*) AS count, COALESCE(SUM(%s), 0) AS amount `+
+		`FROM "GiftCard" WHERE %s >= ? AND %s < ?`, hour, bigint(r.DB, `"Amount"`), column, column)
+	args := []interface{}{from.UTC(), to.UTC()}
+	if campaignId != nil {
+		statement += ` AND "CampaignId" = ?`
+		args = append(args, *campaignId)
+	}
+	statement += fmt.Sprintf(" GROUP BY %s ORDER BY %s", hour, hour)
+	var rows []hourlyTotalRow
+	err := queryContext(ctx, r.DB, func(result *dbsql.Rows) error {
+		var row hourlyTotalRow
+		if err := r.DB.ScanRows(result, &row); err != nil {
+			return err
+		}
+		rows = append(rows, row)
+		return nil
+	}, quoted(r.DB, statement), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make([]core.HourlyTotal, 0, len(rows))
+	for _, row := range rows {
+		start, err := time.Parse(hourFormat, row.Hour)
+		if err != nil {
+			return nil, err
+		}
+		totals = append(totals, core.HourlyTotal{
+			Hour:   start.Add(-time.Duration(shift) * time.Minute),
+			Count:  row.Count,
+			Amount: row.Amount,
+		})
+	}
+	return totals, nil
+}
+
+// campaignLiabilityRow is a single row of the outstanding query
+type campaignLiabilityRow struct {
+	CampaignId    uint
+	CampaignTitle string
+	Count         int64
+	Amount        int64
+}
+
+// OutstandingAt finds the cards which were created, not deleted, not redeemed, not voided and not expired
+// at the moment. the amount and the expire date come from the first revision after the moment, if any.
+// cards redeemed or voided before their times were recorded are treated as redeemed or voided all along
+func (r *gCardRepository) OutstandingAt(ctx context.Context, at time.Time,
+	campaignId *uint) ([]core.CampaignLiability, error) {
+	at = at.UTC()
+	rule := dbmodel.ValidityAt(at)
+	amount := `COALESCE(rev."Amount", "GiftCard"."Amount")`
+	statement := fmt.Sprintf(`SELECT "GiftCard"."CampaignId" AS campaign_id, `+
+		`"Campaign"."Title" AS campaign_title, COUNT(*) AS count, COALESCE(SUM(%s), 0) AS amount `+
+		`FROM "GiftCard" JOIN "Campaign" ON "Campaign".id = "GiftCard"."CampaignId" `+
+		`LEFT JOIN "GiftCardRevision" rev ON rev."GiftCardId" = "GiftCard".id AND rev."RevisedAt" = `+
+		`(SELECT MIN(later."RevisedAt") FROM "GiftCardRevision" later `+
+		`WHERE later."GiftCardId" = "GiftCard".id AND later."RevisedAt" > ?) `+
+		`WHERE "GiftCard".created_at <= ? `+
+		`AND ("GiftCard".deleted_at IS NULL OR "GiftCard".deleted_at > ?) `+
+		`AND ("GiftCard"."UUN" IS NULL OR "GiftCard"."UUN" = '' OR "GiftCard"."RedeemedAt" > ?) `+
+		`AND ("GiftCard"."Status" <> ? OR "GiftCard"."VoidedAt" > ?) `+
+		`AND COALESCE(rev."ExpireDate", "GiftCard"."ExpireDate") > ?`, bigint(r.DB, amount))
+	args := []interface{}{at, at, at, at, dbmodel.Voided, at, rule.ExpireAfter}
+	if campaignId != nil {
+		statement += ` AND "GiftCard"."CampaignId" = ?`
+		args = append(args, *campaignId)
+	}
+	statement += ` GROUP BY "GiftCard"."CampaignId", "Campaign"."Title" ORDER BY "GiftCard"."CampaignId"`
 
-	if r.DB.Where("SecretCode = ?", secret).First(&giftCard).RecordNotFound() {
-		return common.GiftCardNotFound
+	var liabilities []core.CampaignLiability
+	err := queryContext(ctx, r.DB, func(rows *dbsql.Rows) error {
+		var row campaignLiabilityRow
+		if err := r.DB.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		liabilities = append(liabilities, core.CampaignLiability(row))
+		return nil
+	}, quoted(r.DB, statement), args...)
+	if err != nil {
+		return nil, err
+	}
+	if liabilities == nil {
+		liabilities = []core.CampaignLiability{}
 	}
-	giftCard.RollBack()
-	return r.DB.Save(giftCard).Error
+	return liabilities, nil
+}
+
+// FindExpired finds the unused cards whose expire date left the validity rule between the two moments
+func (r *gCardRepository) FindExpired(ctx context.Context, from, to time.Time) ([]dbmodel.GiftCard, error) {
+	var giftCards []dbmodel.GiftCard
+	err := r.db(ctx).Preload("Campaign").
+		Where(quoted(r.DB, `("UUN" is null or "UUN" = '') and "Status" = ?`), dbmodel.Empty).
+		Where(quoted(r.DB, `"ExpireDate" > ? and "ExpireDate" <= ?`), dbmodel.ValidityAt(from).ExpireAfter,
+			dbmodel.ValidityAt(to).ExpireAfter).
+		Order("id").Find(&giftCards).Error
+	return giftCards, err
+}
+
+// db binds the context to the queries so their spans join the trace of the request
+func (r *gCardRepository) db(ctx context.Context) *gorm.DB {
+	return withContext(r.DB, ctx)
 }
 
 func NewGiftCardRepository(DB *gorm.DB) core.GiftCardRepository {
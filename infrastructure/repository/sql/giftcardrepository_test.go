@@ -0,0 +1,388 @@
+package sql_test
+
+import (
+	"context"
+	"fmt"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/infrastructure/repository/sql"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// newTestDB opens an isolated in-memory sqlite database with the gift card schema
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.DB().SetMaxOpenConns(1)
+	if _, err := sql.NewMigrator(db).Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	return db
+}
+
+func TestOpenRejectsUnsupportedDrivers(t *testing.T) {
+	_, err := sql.Open("oracle", "")
+
+	assert.EqualError(t, err, `unsupported database driver "oracle"`)
+}
+
+// storeValidityCards stores one card for every combination that affects the validity
+func storeValidityCards(t *testing.T, repository core.GiftCardRepository, campaignId uint) {
+	now := time.Now().UTC()
+	expireDates := []time.Time{now.AddDate(0, 0, -10), now.Add(-2 * time.Hour), now.AddDate(0, 1, 0)}
+	for _, expireDate := range expireDates {
+		for _, status := range []int{dbmodel.Empty, dbmodel.Approved, dbmodel.Voided} {
+			for _, uun := range []string{"", "milad"} {
+				card := dbmodel.NewGiftCard(1000, expireDate)
+				card.SetCampaign(campaignId)
+				card.Status = status
+				card.UUN = uun
+				if err := repository.Store(context.Background(), card); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+}
+
+func TestFindPageValidityAgreesWithIsValid(t *testing.T) {
+	db := newTestDB(t)
+	campaign := dbmodel.Campaign{Title: "yalda"}
+	db.Create(&campaign)
+	repository := sql.NewGiftCardRepository(db)
+	storeValidityCards(t, repository, uint(campaign.ID))
+
+	all, total := repository.FindPage(context.Background(), 100, 0, core.GiftCardFilter{})
+	assert.Equal(t, 18, total)
+	assert.Equal(t, 18, len(all))
+
+	valid, validTotal := true, 0
+	invalid, invalidTotal := false, 0
+	var validCards, invalidCards []dbmodel.GiftCard
+	validCards, validTotal = repository.FindPage(context.Background(), 100, 0, core.GiftCardFilter{IsValid: &valid})
+	invalidCards, invalidTotal = repository.FindPage(context.Background(), 100, 0,
+		core.GiftCardFilter{IsValid: &invalid})
+
+	assert.Equal(t, 2, validTotal)
+	assert.Equal(t, total, validTotal+invalidTotal, "every card should be either valid or invalid")
+	for _, card := range validCards {
+		assert.True(t, card.IsValid(), "card %d is listed as valid", card.ID)
+	}
+	for _, card := range invalidCards {
+		assert.False(t, card.IsValid(), "card %d is listed as invalid", card.ID)
+	}
+}
+
+func TestFindCursorPageValidityAgreesWithIsValid(t *testing.T) {
+	db := newTestDB(t)
+	campaign := dbmodel.Campaign{Title: "yalda"}
+	db.Create(&campaign)
+	repository := sql.NewGiftCardRepository(db)
+	storeValidityCards(t, repository, uint(campaign.ID))
+
+	for _, isValid := range []bool{true, false} {
+		valid := isValid
+		cards, total := repository.FindCursorPage(context.Background(), 100, nil, core.GiftCardFilter{IsValid: &valid},
+			true)
+
+		assert.Equal(t, len(cards), total)
+		for _, card := range cards {
+			assert.Equal(t, valid, card.IsValid(), "card %d", card.ID)
+		}
+	}
+}
+
+func TestFindPageFilterAndSort(t *testing.T) {
+	db := newTestDB(t)
+	yalda := dbmodel.Campaign{Title: "yalda"}
+	nowruz := dbmodel.Campaign{Title: "nowruz"}
+	db.Create(&yalda)
+	db.Create(&nowruz)
+	repository := sql.NewGiftCardRepository(db)
+	for i, amount := range []int32{300, 100, 200} {
+		card := dbmodel.NewGiftCard(amount, time.Now().AddDate(0, 1, 0).UTC())
+		card.SetCampaign(uint(yalda.ID))
+		if i == 2 {
+			card.SetCampaign(uint(nowruz.ID))
+			_ = card.SetUUN("milad")
+		}
+		if err := repository.Store(context.Background(), card); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cards, total := repository.FindPage(context.Background(), 10, 0, core.GiftCardFilter{
+		Sort: []core.SortField{{Column: "amount", Descending: true}},
+	})
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []int32{300, 200, 100}, []int32{cards[0].Amount, cards[1].Amount, cards[2].Amount})
+
+	from := int32(150)
+	_, total = repository.FindPage(context.Background(), 10, 0, core.GiftCardFilter{AmountFrom: &from})
+	assert.Equal(t, 2, total)
+
+	cards, total = repository.FindPage(context.Background(), 10, 0, core.GiftCardFilter{CampaignTitle: "nowr"})
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "milad", cards[0].UUN)
+	_, total = repository.FindPage(context.Background(), 10, 0, core.GiftCardFilter{CampaignTitle: "NOWR"})
+	assert.Equal(t, 1, total)
+
+	_, total = repository.FindPage(context.Background(), 10, 0, core.GiftCardFilter{CampaignIds: []uint{uint(yalda.ID),
+		uint(nowruz.ID)}})
+	assert.Equal(t, 3, total)
+
+	redeemedFrom := time.Now().Add(-time.Hour).UTC()
+	_, total = repository.FindPage(context.Background(), 10, 0, core.GiftCardFilter{RedeemedFrom: &redeemedFrom})
+	assert.Equal(t, 1, total)
+}
+
+func TestFindByIDs(t *testing.T) {
+	db := newTestDB(t)
+	campaign := dbmodel.Campaign{Title: "yalda"}
+	db.Create(&campaign)
+	repository := sql.NewGiftCardRepository(db)
+	storeValidityCards(t, repository, uint(campaign.ID))
+
+	cards := repository.FindByIDs(context.Background(), []int{1, 3, 404})
+
+	assert.Equal(t, 2, len(cards))
+	assert.Equal(t, "yalda", cards[0].Campaign.Title)
+	assert.Empty(t, repository.FindByIDs(context.Background(), []int{}))
+}
+
+func TestStats(t *testing.T) {
+	db := newTestDB(t)
+	yalda := dbmodel.Campaign{Title: "yalda"}
+	nowruz := dbmodel.Campaign{Title: "nowruz"}
+	db.Create(&yalda)
+	db.Create(&nowruz)
+	repository := sql.NewGiftCardRepository(db)
+	now := time.Now().UTC()
+	store := func(campaign dbmodel.Campaign, amount int32, expireDate time.Time) *dbmodel.GiftCard {
+		card := dbmodel.NewGiftCard(amount, expireDate)
+		card.SetCampaign(uint(campaign.ID))
+		if err := repository.Store(context.Background(), card); err != nil {
+			t.Fatal(err)
+		}
+		return card
+	}
+	store(yalda, 100, now.AddDate(0, 1, 0))
+	store(yalda, 200, now.AddDate(0, 0, -5))
+	redeemed := store(yalda, 300, now.AddDate(0, 1, 0))
+	_ = redeemed.SetUUN("milad")
+	redeemedAt := redeemed.CreatedAt.Add(2 * time.Hour)
+	redeemed.RedeemedAt = &redeemedAt
+	_ = repository.Store(context.Background(), redeemed)
+	voided := store(yalda, 400, now.AddDate(0, 1, 0))
+	_ = voided.Void()
+	_ = repository.Store(context.Background(), voided)
+	_ = repository.Delete(context.Background(), *store(yalda, 500, now.AddDate(0, 1, 0)))
+	store(nowruz, 1000, now.AddDate(0, 1, 0))
+
+	campaignId := uint(yalda.ID)
+	stats, err := repository.Stats(context.Background(), &campaignId)
+
+	assert.Empty(t, err)
+	assert.Equal(t, core.CardsAggregate{Count: 5, Amount: 1500}, stats.Issued)
+	assert.Equal(t, core.CardsAggregate{Count: 1, Amount: 100}, stats.Outstanding)
+	assert.Equal(t, core.CardsAggregate{Count: 1, Amount: 200}, stats.Expired)
+	assert.Equal(t, core.CardsAggregate{Count: 1, Amount: 300}, stats.Redeemed)
+	assert.Equal(t, core.CardsAggregate{Count: 1, Amount: 400}, stats.Voided)
+	assert.Equal(t, core.CardsAggregate{Count: 1, Amount: 500}, stats.Deleted)
+	assert.Equal(t, int64(1), stats.TimedRedeems)
+	assert.InDelta(t, 7200, stats.RedeemSeconds, 1)
+
+	summary, err := repository.Stats(context.Background(), nil)
+
+	assert.Empty(t, err)
+	assert.Equal(t, core.CardsAggregate{Count: 6, Amount: 2500}, summary.Issued)
+	assert.Equal(t, core.CardsAggregate{Count: 2, Amount: 1100}, summary.Outstanding)
+}
+
+func TestStatsWithoutCards(t *testing.T) {
+	db := newTestDB(t)
+	repository := sql.NewGiftCardRepository(db)
+	campaignId := uint(7)
+
+	stats, err := repository.Stats(context.Background(), &campaignId)
+
+	assert.Empty(t, err)
+	assert.Equal(t, core.GiftCardStats{}, stats)
+}
+
+func TestStatsWithCancelledContext(t *testing.T) {
+	db := newTestDB(t)
+	repository := sql.NewGiftCardRepository(db)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repository.Stats(ctx, nil)
+
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestHourlyTotals(t *testing.T) {
+	db := newTestDB(t)
+	campaign := dbmodel.Campaign{Title: "yalda"}
+	db.Create(&campaign)
+	repository := sql.NewGiftCardRepository(db)
+	tehran := time.FixedZone("Tehran", 3*3600+1800)
+	store := func(amount int32, createdAt time.Time, redeemedAt *time.Time) {
+		card := dbmodel.NewGiftCard(amount, createdAt.AddDate(1, 0, 0))
+		card.SetCampaign(uint(campaign.ID))
+		card.CreatedAt = createdAt
+		if redeemedAt != nil {
+			_ = card.SetUUN("milad")
+			card.RedeemedAt = redeemedAt
+		}
+		if err := repository.Store(context.Background(), card); err != nil {
+			t.Fatal(err)
+		}
+	}
+	day := time.Date(2020, 3, 10, 0, 0, 0, 0, time.UTC)
+	redeemedAt := day.Add(30 * time.Hour)
+	store(100, day.Add(20*time.Hour+10*time.Minute), nil)
+	store(200, day.Add(20*time.Hour+40*time.Minute), &redeemedAt)
+	store(400, day.Add(20*time.Hour+50*time.Minute), nil)
+	store(800, day.AddDate(0, 0, 5), nil)
+
+	issued, err := repository.HourlyTotals(context.Background(), core.IssuedEvent, nil, day, day.AddDate(0, 0, 2),
+		tehran)
+
+	assert.Empty(t, err)
+	assert.Equal(t, 2, len(issued), "20:10 utc is 23:40 in tehran and 20:40 is the next day")
+	assert.Equal(t, day.Add(19*time.Hour+30*time.Minute), issued[0].Hour.UTC())
+	assert.Equal(t, core.CardsAggregate{Count: 1, Amount: 100}, core.CardsAggregate{Count: issued[0].Count,
+		Amount: issued[0].Amount})
+	assert.Equal(t, day.Add(20*time.Hour+30*time.Minute), issued[1].Hour.UTC())
+	assert.Equal(t, int64(600), issued[1].Amount)
+
+	campaignId := uint(campaign.ID)
+	redeemed, err := repository.HourlyTotals(context.Background(), core.RedeemedEvent, &campaignId, day, day.AddDate(0,
+		0, 2), time.UTC)
+
+	assert.Empty(t, err)
+	assert.Equal(t, 1, len(redeemed))
+	assert.Equal(t, redeemedAt, redeemed[0].Hour.UTC())
+	assert.Equal(t, int64(200), redeemed[0].Amount)
+
+	_, err = repository.HourlyTotals(context.Background(), core.ReportEvent(0), nil, day, day, time.UTC)
+	assert.NotNil(t, err)
+}
+
+func TestOutstandingAt(t *testing.T) {
+	db := newTestDB(t)
+	yalda := dbmodel.Campaign{Title: "yalda"}
+	nowruz := dbmodel.Campaign{Title: "nowruz"}
+	db.Create(&yalda)
+	db.Create(&nowruz)
+	repository := sql.NewGiftCardRepository(db)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2020, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	store := func(campaign dbmodel.Campaign, amount int32, expireDate time.Time,
+		change func(card *dbmodel.GiftCard)) *dbmodel.GiftCard {
+		card := dbmodel.NewGiftCard(amount, expireDate)
+		card.SetCampaign(uint(campaign.ID))
+		card.CreatedAt = date(1, 10)
+		if change != nil {
+			change(card)
+		}
+		if err := repository.Store(context.Background(), card); err != nil {
+			t.Fatal(err)
+		}
+		return card
+	}
+	redeemAt := func(at time.Time) func(card *dbmodel.GiftCard) {
+		return func(card *dbmodel.GiftCard) {
+			_ = card.SetUUN("milad")
+			card.RedeemedAt = &at
+		}
+	}
+	voidAt := func(at *time.Time) func(card *dbmodel.GiftCard) {
+		return func(card *dbmodel.GiftCard) {
+			card.Status = dbmodel.Voided
+			card.VoidedAt = at
+		}
+	}
+	revise := func(card *dbmodel.GiftCard, amount int32, expireDate, revisedAt time.Time) {
+		db.Create(&dbmodel.GiftCardRevision{GiftCardId: card.ID, Amount: amount, ExpireDate: expireDate,
+			RevisedAt: revisedAt})
+	}
+	voidedLate := date(3, 1)
+	voidedEarly := date(1, 25)
+
+	store(yalda, 100, date(6, 1), nil)
+	store(yalda, 200, date(1, 15), nil)
+	store(yalda, 300, date(6, 1), redeemAt(date(2, 10)))
+	store(yalda, 350, date(6, 1), redeemAt(date(1, 20)))
+	store(yalda, 400, date(6, 1), voidAt(&voidedEarly))
+	store(yalda, 450, date(6, 1), voidAt(&voidedLate))
+	store(yalda, 475, date(6, 1), voidAt(nil))
+	_ = repository.Delete(context.Background(), *store(yalda, 500, date(6, 1), nil))
+	store(yalda, 550, date(6, 1), func(card *dbmodel.GiftCard) { card.CreatedAt = date(2, 5) })
+	extended := store(yalda, 900, date(6, 1), nil)
+	revise(extended, 700, date(1, 20), date(2, 3))
+	revised := store(yalda, 1000, date(6, 1), nil)
+	revise(revised, 50, date(6, 1), date(1, 15))
+	revise(revised, 600, date(6, 1), date(2, 2))
+	revise(revised, 800, date(6, 1), date(3, 1))
+	store(nowruz, 1000, date(6, 1), nil)
+
+	liabilities, err := repository.OutstandingAt(context.Background(), date(2, 1), nil)
+
+	assert.Empty(t, err)
+	assert.Equal(t, []core.CampaignLiability{
+		{CampaignId: uint(yalda.ID), CampaignTitle: "yalda", Count: 5, Amount: 1950},
+		{CampaignId: uint(nowruz.ID), CampaignTitle: "nowruz", Count: 1, Amount: 1000},
+	}, liabilities)
+
+	campaignId := uint(nowruz.ID)
+	liabilities, err = repository.OutstandingAt(context.Background(), date(2, 1), &campaignId)
+
+	assert.Empty(t, err)
+	assert.Equal(t, 1, len(liabilities))
+	assert.Equal(t, int64(1000), liabilities[0].Amount)
+
+	liabilities, err = repository.OutstandingAt(context.Background(), date(1, 1), nil)
+
+	assert.Empty(t, err)
+	assert.Empty(t, liabilities)
+}
+
+func TestStoreKeepsRevisions(t *testing.T) {
+	db := newTestDB(t)
+	campaign := dbmodel.Campaign{Title: "yalda"}
+	db.Create(&campaign)
+	repository := sql.NewGiftCardRepository(db)
+	expireDate := time.Now().UTC().AddDate(0, 1, 0).Truncate(time.Second)
+	card := dbmodel.NewGiftCard(100, expireDate)
+	card.SetCampaign(uint(campaign.ID))
+	batchId := uint(3)
+	card.BatchId = &batchId
+	if err := repository.Store(context.Background(), card); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = card.Update(200, expireDate)
+	err := repository.Store(context.Background(), card)
+	assert.Empty(t, err)
+	affected, err := repository.ExtendBatchExpiry(context.Background(), batchId, expireDate.AddDate(0, 1, 0))
+	assert.Empty(t, err)
+	assert.Equal(t, 1, affected)
+
+	var revisions []dbmodel.GiftCardRevision
+	db.Where("GiftCardId = ?", card.ID).Order("id").Find(&revisions)
+	assert.Equal(t, 2, len(revisions))
+	assert.Equal(t, int32(100), revisions[0].Amount)
+	assert.Equal(t, int32(200), revisions[1].Amount)
+	assert.Equal(t, expireDate, revisions[1].ExpireDate.UTC())
+}
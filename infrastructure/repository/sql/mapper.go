@@ -22,6 +22,14 @@ func (m *mapper) ToGiftCardDTO(card *dbmodel.GiftCard) dto.GiftCardDTO {
 		c := dbmodel.EmptyCampaign()
 		card.Campaign = &c
 	}
+	var batchId uint
+	if card.BatchId != nil {
+		batchId = *card.BatchId
+	}
+	var redeemedAt string
+	if card.RedeemedAt != nil {
+		redeemedAt = card.RedeemedAt.Local().String()
+	}
 	return dto.GiftCardDTO{
 		ID:            card.ID,
 		PublicCode:    card.PublicCode,
@@ -32,6 +40,8 @@ func (m *mapper) ToGiftCardDTO(card *dbmodel.GiftCard) dto.GiftCardDTO {
 		IsValid:       card.IsValid(),
 		CampaignId:    card.CampaignId,
 		CampaignTitle: card.Campaign.Title,
+		BatchId:       batchId,
+		RedeemedAt:    redeemedAt,
 	}
 }
 
@@ -71,9 +81,7 @@ func (m *mapper) ApprovedToGiftCardStatusDTO(card dbmodel.GiftCard) dto.GiftCard
 }
 
 func (m *mapper) ToCampaign(dto dto.CreateCampaignDTO) dbmodel.Campaign {
-	return dbmodel.Campaign{
-		Title: dto.Title,
-	}
+	return *dbmodel.NewCampaign(dto.Title)
 }
 
 func (m *mapper) ToCampaignDTO(campaign dbmodel.Campaign) dto.CampaignDTO {
@@ -92,6 +100,25 @@ func (m *mapper) ToListOfCampaigns(campaigns []dbmodel.Campaign) []dto.CampaignD
 	return newList
 }
 
+func (m *mapper) ToBatchDTO(batch dbmodel.Batch) dto.BatchDTO {
+	return dto.BatchDTO{
+		ID:          batch.ID,
+		CreatedBy:   batch.CreatedBy,
+		CreatedAt:   batch.CreatedAt.Local().String(),
+		Parameters:  batch.Parameters,
+		Count:       batch.Count,
+		TotalAmount: batch.TotalAmount,
+	}
+}
+
+func (m *mapper) ToListOfBatches(batches []dbmodel.Batch) []dto.BatchDTO {
+	var newList []dto.BatchDTO
+	for _, batch := range batches {
+		newList = append(newList, m.ToBatchDTO(batch))
+	}
+	return newList
+}
+
 func NewMapper() core.Mapper {
 	return &mapper{}
 }
@@ -129,6 +129,7 @@ func TestToCampaign(t *testing.T) {
 
 	assert.NotEmpty(t, model)
 	assert.Equal(t, camp.Title, model.Title)
+	assert.Equal(t, []string{dbmodel.CampaignCreatedEvent}, model.Events())
 }
 
 func TestToCampaignDTO(t *testing.T) {
@@ -161,3 +162,39 @@ func TestToListOfCampaigns(t *testing.T) {
 	assert.Equal(t, camps[0].ID, campsDto[0].ID)
 	assert.Equal(t, camps[0].Title, campsDto[0].Title)
 }
+
+func TestToBatchDTO(t *testing.T) {
+	t.Parallel()
+	batch := dbmodel.NewBatch("milawd", `{"count":2}`)
+	batch.ID = 3
+	batch.AddCard(2000)
+	batch.AddCard(2000)
+
+	batchDto := mapper.ToBatchDTO(*batch)
+
+	assert.Equal(t, batch.ID, batchDto.ID)
+	assert.Equal(t, batch.CreatedBy, batchDto.CreatedBy)
+	assert.Equal(t, batch.Parameters, batchDto.Parameters)
+	assert.Equal(t, 2, batchDto.Count)
+	assert.Equal(t, int64(4000), batchDto.TotalAmount)
+}
+
+func TestToGiftCardDTOWithBatch(t *testing.T) {
+	t.Parallel()
+	card := dbmodel.NewGiftCard(2000, date.DefaultToTimeOrDefault("2222-01-01"))
+	card.SetBatch(3)
+
+	cardDto := mapper.ToGiftCardDTO(card)
+
+	assert.Equal(t, uint(3), cardDto.BatchId)
+}
+
+func TestToGiftCardDTOWithRedeemedAt(t *testing.T) {
+	t.Parallel()
+	card := dbmodel.NewGiftCard(2000, date.DefaultToTimeOrDefault("2222-01-01"))
+	assert.Empty(t, mapper.ToGiftCardDTO(card).RedeemedAt)
+
+	_ = card.SetUUN("milad")
+
+	assert.NotEmpty(t, mapper.ToGiftCardDTO(card).RedeemedAt)
+}
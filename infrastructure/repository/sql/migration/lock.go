@@ -0,0 +1,114 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"github.com/jinzhu/gorm"
+	"time"
+)
+
+const (
+	// lockStaleAfter is the age after which a lock is assumed to be left behind by a crashed migrator
+	lockStaleAfter = 15 * time.Minute
+	// lockRefreshEvery is the interval of the holder refreshing the lock, well below the stale age so a long
+	// migration is never taken over
+	lockRefreshEvery = lockStaleAfter / 5
+	// lockRetryEvery is the wait between the attempts to take a held lock
+	lockRetryEvery = time.Second
+)
+
+// schemaLock is the single row of the lock table. the primary key makes the insert of the row succeed for only
+// one of the migrators on every database, which the advisory locks of the dialects do not
+type schemaLock struct {
+	ID       int       `gorm:"column:id;primary_key;auto_increment:false"`
+	LockedBy string    `gorm:"column:LockedBy;not null"`
+	LockedAt time.Time `gorm:"column:LockedAt;not null"`
+}
+
+// TableName returns the sql table name for changing the default naming system
+func (*schemaLock) TableName() string {
+	return "SchemaLock"
+}
+
+type lock struct {
+	db           *gorm.DB
+	owner        string
+	staleAfter   time.Duration
+	retryEvery   time.Duration
+	refreshEvery time.Duration
+	stop         chan struct{}
+	stopped      chan struct{}
+}
+
+func newLock(db *gorm.DB, owner string) *lock {
+	return &lock{db: db, owner: owner, staleAfter: lockStaleAfter, retryEvery: lockRetryEvery,
+		refreshEvery: lockRefreshEvery}
+}
+
+// acquire waits until the lock is taken or the context is done. the taken lock is refreshed until it is released
+func (l *lock) acquire(ctx context.Context) error {
+	if err := l.createTable(); err != nil {
+		return err
+	}
+	for {
+		l.db.Where(l.column("LockedAt")+" < ?", time.Now().UTC().Add(-l.staleAfter)).Delete(&schemaLock{})
+		err := l.db.Create(&schemaLock{ID: 1, LockedBy: l.owner, LockedAt: time.Now().UTC()}).Error
+		if err == nil {
+			l.stop, l.stopped = make(chan struct{}), make(chan struct{})
+			go l.refresh(l.stop, l.stopped)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			var holder schemaLock
+			if l.db.First(&holder, 1).Error == nil {
+				return fmt.Errorf("the schema is locked by %s since %s: %w", holder.LockedBy,
+					holder.LockedAt.Format(time.RFC3339), ctx.Err())
+			}
+			return fmt.Errorf("the schema lock is not acquired: %v: %w", err, ctx.Err())
+		case <-time.After(l.retryEvery):
+		}
+	}
+}
+
+// refresh moves the time of the held lock forward so the other migrators do not see it as stale
+func (l *lock) refresh(stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(l.refreshEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			l.db.Model(&schemaLock{}).Where("id = ? AND "+l.column("LockedBy")+" = ?", 1, l.owner).
+				Update("LockedAt", time.Now().UTC())
+		}
+	}
+}
+
+func (l *lock) release() error {
+	if l.stop != nil {
+		close(l.stop)
+		<-l.stopped
+		l.stop, l.stopped = nil, nil
+	}
+	return l.db.Where("id = ? AND "+l.column("LockedBy")+" = ?", 1, l.owner).Delete(&schemaLock{}).Error
+}
+
+// column quotes the mixed case column for the dialect
+func (l *lock) column(name string) string {
+	return l.db.Dialect().Quote(name)
+}
+
+// createTable creates the lock table unless another migrator has just created it
+func (l *lock) createTable() error {
+	if l.db.HasTable(&schemaLock{}) {
+		return nil
+	}
+	if err := l.db.CreateTable(&schemaLock{}).Error; err != nil && !l.db.HasTable(&schemaLock{}) {
+		return err
+	}
+	return nil
+}
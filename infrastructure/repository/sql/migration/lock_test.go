@@ -0,0 +1,44 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+	"testing"
+	"time"
+)
+
+func newTestLock(db *gorm.DB, owner string) *lock {
+	l := newLock(db, owner)
+	l.staleAfter = 100 * time.Millisecond
+	l.retryEvery = 10 * time.Millisecond
+	l.refreshEvery = 20 * time.Millisecond
+	return l
+}
+
+func TestHeldLockIsNotTakenOverWhenRefreshed(t *testing.T) {
+	db, err := gorm.Open("sqlite3", "sqlite", fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name()))
+	require.Nil(t, err)
+	db.DB().SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	holder := newTestLock(db, "holder:1")
+	require.Nil(t, holder.acquire(context.Background()))
+
+	time.Sleep(250 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = newTestLock(db, "other:1").acquire(ctx)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "holder:1")
+	assert.Nil(t, holder.release())
+	other := newTestLock(db, "other:1")
+	assert.Nil(t, other.acquire(context.Background()))
+	assert.Nil(t, other.release())
+}
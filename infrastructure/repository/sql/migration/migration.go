@@ -0,0 +1,244 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jinzhu/gorm"
+	"os"
+	"time"
+)
+
+// ErrUnknownVersion is returned when the database has a schema version this build does not know, which means
+// a newer release migrated it or a migration was removed
+var ErrUnknownVersion = errors.New("unknown schema version")
+
+// ErrPendingMigrations is returned when the database is behind the migrations of this build
+var ErrPendingMigrations = errors.New("pending schema migrations")
+
+// Migration is a single versioned change of the schema. Up and Down run in a transaction together with the
+// bookkeeping of the version, except on the databases which commit the ddl statements implicitly
+type Migration struct {
+	Version     uint
+	Description string
+	Up          func(tx *gorm.DB) error
+	Down        func(tx *gorm.DB) error
+}
+
+// Status is the state of a migration in the database
+type Status struct {
+	Version     uint
+	Description string
+	AppliedAt   *time.Time
+}
+
+// Applied reports whether the migration has been run
+func (s Status) Applied() bool {
+	return s.AppliedAt != nil
+}
+
+// schemaVersion is a row of the applied migrations
+type schemaVersion struct {
+	Version     uint      `gorm:"column:Version;primary_key;auto_increment:false"`
+	Description string    `gorm:"column:Description;not null"`
+	AppliedAt   time.Time `gorm:"column:AppliedAt;not null"`
+}
+
+// TableName returns the sql table name for changing the default naming system
+func (*schemaVersion) TableName() string {
+	return "SchemaVersion"
+}
+
+// Migrator applies and reverts the migrations of a database. the migrations are ordered by their versions and
+// only one migrator of all of the instances sharing the database runs at a time
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	lock       *lock
+}
+
+// NewMigrator returns a migrator of the given migrations, which should be sorted by their versions
+func NewMigrator(db *gorm.DB, migrations []Migration) *Migrator {
+	host, _ := os.Hostname()
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		lock:       newLock(db, fmt.Sprintf("%s:%d", host, os.Getpid())),
+	}
+}
+
+// Up applies the pending migrations in order and returns the applied ones
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.locked(ctx, func(versions map[uint]schemaVersion) error {
+		if err := m.checkKnown(versions); err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+			if err := m.run(ctx, migration, migration.Up, func(tx *gorm.DB) error {
+				return tx.Create(&schemaVersion{
+					Version:     migration.Version,
+					Description: migration.Description,
+					AppliedAt:   time.Now().UTC(),
+				}).Error
+			}); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the given number of the last applied migrations and returns the reverted ones
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.locked(ctx, func(versions map[uint]schemaVersion) error {
+		if err := m.checkKnown(versions); err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+			if err := m.run(ctx, migration, migration.Down, func(tx *gorm.DB) error {
+				return tx.Delete(&schemaVersion{Version: migration.Version}).Error
+			}); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status returns the state of every migration
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	versions, err := m.versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Description: migration.Description}
+		if version, ok := versions[migration.Version]; ok {
+			appliedAt := version.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Check returns an error unless every migration, and nothing else, has been applied to the database
+func (m *Migrator) Check(ctx context.Context) error {
+	versions, err := m.versions(ctx)
+	if err != nil {
+		return err
+	}
+	if err := m.checkKnown(versions); err != nil {
+		return err
+	}
+	for _, migration := range m.migrations {
+		if _, ok := versions[migration.Version]; !ok {
+			return fmt.Errorf("%w: version %d is not applied", ErrPendingMigrations, migration.Version)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) checkKnown(versions map[uint]schemaVersion) error {
+	for i, migration := range m.migrations {
+		if i > 0 && migration.Version <= m.migrations[i-1].Version {
+			return fmt.Errorf("migration %d is out of order", migration.Version)
+		}
+	}
+	for version := range versions {
+		if !m.known(version) {
+			return fmt.Errorf("%w: version %d is applied to the database", ErrUnknownVersion, version)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) known(version uint) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// locked runs fc with the applied versions while holding the lock of the schema
+func (m *Migrator) locked(ctx context.Context, fc func(versions map[uint]schemaVersion) error) (err error) {
+	if err := m.lock.acquire(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if releaseErr := m.lock.release(); err == nil {
+			err = releaseErr
+		}
+	}()
+
+	if !m.db.HasTable(&schemaVersion{}) {
+		if err := m.db.CreateTable(&schemaVersion{}).Error; err != nil {
+			return err
+		}
+	}
+	versions, err := m.versions(ctx)
+	if err != nil {
+		return err
+	}
+	return fc(versions)
+}
+
+// versions returns the applied migrations by their versions
+func (m *Migrator) versions(ctx context.Context) (map[uint]schemaVersion, error) {
+	versions := map[uint]schemaVersion{}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !m.db.HasTable(&schemaVersion{}) {
+		return versions, nil
+	}
+	var rows []schemaVersion
+	if err := m.db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		versions[row.Version] = row
+	}
+	return versions, nil
+}
+
+// run runs the change of the migration and the bookkeeping of its version in a transaction
+func (m *Migrator) run(ctx context.Context, migration Migration, change, record func(tx *gorm.DB) error) (err error) {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	tx := m.db.BeginTx(ctx, nil)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			err = fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+	}()
+
+	if err = change(tx); err != nil {
+		return err
+	}
+	if err = record(tx); err != nil {
+		return err
+	}
+	return tx.Commit().Error
+}
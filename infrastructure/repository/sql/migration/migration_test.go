@@ -0,0 +1,158 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"giftcard-engine/infrastructure/repository/sql/migration"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+	"testing"
+	"time"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "sqlite", fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.DB().SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	return db
+}
+
+// tableMigration creates a table named after the version
+func tableMigration(version uint, runs *[]string) migration.Migration {
+	table := fmt.Sprintf("table_%d", version)
+	return migration.Migration{
+		Version:     version,
+		Description: "create " + table,
+		Up: func(tx *gorm.DB) error {
+			*runs = append(*runs, fmt.Sprintf("up %d", version))
+			return tx.Exec(fmt.Sprintf("CREATE TABLE %s (id integer)", table)).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			*runs = append(*runs, fmt.Sprintf("down %d", version))
+			return tx.Exec(fmt.Sprintf("DROP TABLE %s", table)).Error
+		},
+	}
+}
+
+func TestUpAppliesThePendingMigrationsInOrder(t *testing.T) {
+	db := newTestDB(t)
+	var runs []string
+	first := migration.NewMigrator(db, []migration.Migration{tableMigration(1, &runs)})
+	_, err := first.Up(context.Background())
+	assert.Nil(t, err)
+
+	migrator := migration.NewMigrator(db, []migration.Migration{tableMigration(1, &runs), tableMigration(2, &runs),
+		tableMigration(5, &runs)})
+	applied, err := migrator.Up(context.Background())
+
+	assert.Nil(t, err)
+	assert.Len(t, applied, 2)
+	assert.Equal(t, []string{"up 1", "up 2", "up 5"}, runs)
+	assert.True(t, db.HasTable("table_5"))
+	statuses, err := migrator.Status(context.Background())
+	assert.Nil(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Applied(), "version %d", status.Version)
+	}
+	assert.Nil(t, migrator.Check(context.Background()))
+}
+
+func TestDownRevertsTheLastMigrations(t *testing.T) {
+	db := newTestDB(t)
+	var runs []string
+	migrator := migration.NewMigrator(db, []migration.Migration{tableMigration(1, &runs), tableMigration(2, &runs),
+		tableMigration(3, &runs)})
+	_, _ = migrator.Up(context.Background())
+
+	reverted, err := migrator.Down(context.Background(), 2)
+
+	assert.Nil(t, err)
+	assert.Len(t, reverted, 2)
+	assert.Equal(t, []string{"up 1", "up 2", "up 3", "down 3", "down 2"}, runs)
+	assert.True(t, db.HasTable("table_1"))
+	assert.False(t, db.HasTable("table_2"))
+	statuses, _ := migrator.Status(context.Background())
+	assert.True(t, statuses[0].Applied())
+	assert.False(t, statuses[1].Applied())
+	assert.True(t, errors.Is(migrator.Check(context.Background()), migration.ErrPendingMigrations))
+}
+
+func TestFailedMigrationIsNotRecorded(t *testing.T) {
+	db := newTestDB(t)
+	var runs []string
+	failing := migration.Migration{
+		Version:     2,
+		Description: "fail",
+		Up: func(tx *gorm.DB) error {
+			return errors.New("broken")
+		},
+	}
+	migrator := migration.NewMigrator(db, []migration.Migration{tableMigration(1, &runs), failing,
+		tableMigration(3, &runs)})
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.EqualError(t, err, "migration 2 (fail): broken")
+	assert.Len(t, applied, 1)
+	assert.False(t, db.HasTable("table_3"))
+	statuses, _ := migrator.Status(context.Background())
+	assert.False(t, statuses[1].Applied())
+}
+
+func TestCheckRejectsUnknownVersions(t *testing.T) {
+	db := newTestDB(t)
+	var runs []string
+	newer := migration.NewMigrator(db, []migration.Migration{tableMigration(1, &runs), tableMigration(2, &runs)})
+	_, _ = newer.Up(context.Background())
+
+	older := migration.NewMigrator(db, []migration.Migration{tableMigration(1, &runs)})
+	_, upErr := older.Up(context.Background())
+
+	assert.True(t, errors.Is(older.Check(context.Background()), migration.ErrUnknownVersion))
+	assert.True(t, errors.Is(upErr, migration.ErrUnknownVersion))
+}
+
+func TestCheckRejectsAnUnmigratedDatabase(t *testing.T) {
+	var runs []string
+	migrator := migration.NewMigrator(newTestDB(t), []migration.Migration{tableMigration(1, &runs)})
+
+	assert.True(t, errors.Is(migrator.Check(context.Background()), migration.ErrPendingMigrations))
+}
+
+func TestUpWaitsForTheLockOfAnotherMigrator(t *testing.T) {
+	db := newTestDB(t)
+	var runs []string
+	migrator := migration.NewMigrator(db, []migration.Migration{tableMigration(1, &runs)})
+	_, _ = migrator.Up(context.Background())
+	db.Exec(`INSERT INTO "SchemaLock" (id, "LockedBy", "LockedAt") VALUES (1, 'other:1', ?)`, time.Now().UTC())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := migration.NewMigrator(db, []migration.Migration{tableMigration(1, &runs), tableMigration(2, &runs)}).
+		Up(ctx)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "other:1")
+	assert.Equal(t, []string{"up 1"}, runs)
+}
+
+func TestUpTakesOverAStaleLock(t *testing.T) {
+	db := newTestDB(t)
+	var runs []string
+	migrator := migration.NewMigrator(db, []migration.Migration{tableMigration(1, &runs)})
+	_, _ = migrator.Down(context.Background(), 1)
+	db.Exec(`INSERT INTO "SchemaLock" (id, "LockedBy", "LockedAt") VALUES (1, 'crashed:1', ?)`,
+		time.Now().UTC().Add(-time.Hour))
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.Nil(t, err)
+	assert.Len(t, applied, 1)
+}
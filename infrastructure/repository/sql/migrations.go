@@ -0,0 +1,224 @@
+package sql
+
+import (
+	"giftcard-engine/infrastructure/repository/sql/migration"
+	"github.com/jinzhu/gorm"
+	"time"
+)
+
+// migrations are the versions of the schema. a released migration should never be changed, the models of the
+// migrations are copies of the dbmodel ones at the time so later changes of dbmodel do not rewrite history
+var migrations = []migration.Migration{
+	{
+		Version:     1,
+		Description: "initial schema",
+		// the tables of the databases created by AutoMigrate before the migrations are completed and kept
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&v1GiftCard{}, &v1Campaign{}, &v1Batch{}, &v1GiftCardRevision{}).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.DropTableIfExists(&v1GiftCardRevision{}, &v1GiftCard{}, &v1Batch{}, &v1Campaign{}).Error
+		},
+	},
+	{
+		Version:     2,
+		Description: "index the campaign of the gift cards",
+		Up: func(tx *gorm.DB) error {
+			return tx.Model(&v1GiftCard{}).AddIndex("idx_GiftCard_CampaignId", "CampaignId").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Model(&v1GiftCard{}).RemoveIndex("idx_GiftCard_CampaignId").Error
+		},
+	},
+	{
+		Version:     3,
+		Description: "webhook subscriptions and deliveries",
+		Up: func(tx *gorm.DB) error {
+			return tx.CreateTable(&v3WebhookSubscription{}, &v3WebhookDelivery{}).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.DropTableIfExists(&v3WebhookDelivery{}, &v3WebhookSubscription{}).Error
+		},
+	},
+	{
+		Version:     4,
+		Description: "outbox of the domain events",
+		Up: func(tx *gorm.DB) error {
+			return tx.CreateTable(&v4OutboxEvent{}).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.DropTableIfExists(&v4OutboxEvent{}).Error
+		},
+	},
+	{
+		Version:     5,
+		Description: "dead outbox events",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&v5OutboxEvent{}).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Model(&v5OutboxEvent{}).RemoveIndex("idx_OutboxEvent_DeadAt").Error; err != nil {
+				return err
+			}
+			return tx.Model(&v5OutboxEvent{}).DropColumn("DeadAt").Error
+		},
+	},
+	{
+		Version:     6,
+		Description: "handled broker commands",
+		Up: func(tx *gorm.DB) error {
+			return tx.CreateTable(&v6Command{}).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.DropTableIfExists(&v6Command{}).Error
+		},
+	},
+}
+
+// NewMigrator returns the migrator of the gift card schema
+func NewMigrator(db *gorm.DB) *migration.Migrator {
+	return migration.NewMigrator(db, migrations)
+}
+
+type v1GiftCard struct {
+	ID         int `gorm:"primary_key"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  *time.Time `sql:"index"`
+	Amount     int32      `gorm:"column:Amount;not null"`
+	PublicCode string     `gorm:"column:PublicCode;unique_index;not null"`
+	SecretCode string     `gorm:"column:SecretCode;unique_index;not null"`
+	UUN        string     `gorm:"column:UUN"`
+	ExpireDate time.Time  `gorm:"column:ExpireDate;not null"`
+	Status     int        `gorm:"column:Status;not null;default:1"`
+	CampaignId uint       `gorm:"column:CampaignId;not null;"`
+	BatchId    *uint      `gorm:"column:BatchId;index"`
+	RedeemedAt *time.Time `gorm:"column:RedeemedAt"`
+	VoidedAt   *time.Time `gorm:"column:VoidedAt"`
+}
+
+func (*v1GiftCard) TableName() string {
+	return "GiftCard"
+}
+
+type v1Campaign struct {
+	ID        int `gorm:"primary_key"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time `sql:"index"`
+	Title     string     `gorm:"column:Title;unique_index;not null"`
+}
+
+func (*v1Campaign) TableName() string {
+	return "Campaign"
+}
+
+type v1Batch struct {
+	ID          int `gorm:"primary_key"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   *time.Time `sql:"index"`
+	CreatedBy   string     `gorm:"column:CreatedBy"`
+	Parameters  string     `gorm:"column:Parameters;type:text"`
+	Count       int        `gorm:"column:Count;not null"`
+	TotalAmount int64      `gorm:"column:TotalAmount;not null"`
+}
+
+func (*v1Batch) TableName() string {
+	return "Batch"
+}
+
+type v1GiftCardRevision struct {
+	ID         int       `gorm:"primary_key"`
+	GiftCardId int       `gorm:"column:GiftCardId;index;not null"`
+	Amount     int32     `gorm:"column:Amount;not null"`
+	ExpireDate time.Time `gorm:"column:ExpireDate;not null"`
+	RevisedAt  time.Time `gorm:"column:RevisedAt;not null"`
+}
+
+func (*v1GiftCardRevision) TableName() string {
+	return "GiftCardRevision"
+}
+
+type v3WebhookSubscription struct {
+	ID        int `gorm:"primary_key"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time `sql:"index"`
+	Url       string     `gorm:"column:Url;not null"`
+	Events    string     `gorm:"column:Events;not null"`
+	Secret    string     `gorm:"column:Secret;not null"`
+}
+
+func (*v3WebhookSubscription) TableName() string {
+	return "WebhookSubscription"
+}
+
+type v3WebhookDelivery struct {
+	ID             int `gorm:"primary_key"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SubscriptionId uint       `gorm:"column:SubscriptionId;index;not null"`
+	Event          string     `gorm:"column:Event;not null"`
+	Payload        string     `gorm:"column:Payload;type:text;not null"`
+	Status         int        `gorm:"column:Status;not null;default:1"`
+	Attempts       int        `gorm:"column:Attempts;not null"`
+	NextAttemptAt  time.Time  `gorm:"column:NextAttemptAt;index;not null"`
+	LastError      string     `gorm:"column:LastError;type:text"`
+	DeliveredAt    *time.Time `gorm:"column:DeliveredAt"`
+}
+
+func (*v3WebhookDelivery) TableName() string {
+	return "WebhookDelivery"
+}
+
+type v4OutboxEvent struct {
+	ID            int `gorm:"primary_key"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Event         string     `gorm:"column:Event;not null"`
+	AggregateId   int        `gorm:"column:AggregateId;index;not null"`
+	Payload       string     `gorm:"column:Payload;type:text;not null"`
+	PublishedTo   string     `gorm:"column:PublishedTo"`
+	Attempts      int        `gorm:"column:Attempts;not null"`
+	NextAttemptAt time.Time  `gorm:"column:NextAttemptAt;index;not null"`
+	LastError     string     `gorm:"column:LastError;type:text"`
+	PublishedAt   *time.Time `gorm:"column:PublishedAt;index"`
+}
+
+func (*v4OutboxEvent) TableName() string {
+	return "OutboxEvent"
+}
+
+type v5OutboxEvent struct {
+	ID            int `gorm:"primary_key"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Event         string     `gorm:"column:Event;not null"`
+	AggregateId   int        `gorm:"column:AggregateId;index;not null"`
+	Payload       string     `gorm:"column:Payload;type:text;not null"`
+	PublishedTo   string     `gorm:"column:PublishedTo"`
+	Attempts      int        `gorm:"column:Attempts;not null"`
+	NextAttemptAt time.Time  `gorm:"column:NextAttemptAt;index;not null"`
+	LastError     string     `gorm:"column:LastError;type:text"`
+	PublishedAt   *time.Time `gorm:"column:PublishedAt;index"`
+	DeadAt        *time.Time `gorm:"column:DeadAt;index"`
+}
+
+func (*v5OutboxEvent) TableName() string {
+	return "OutboxEvent"
+}
+
+type v6Command struct {
+	ID        int `gorm:"primary_key"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CommandId string     `gorm:"column:CommandId;unique_index;not null"`
+	ClaimedAt time.Time  `gorm:"column:ClaimedAt;not null"`
+	Result    string     `gorm:"column:Result;type:text"`
+	HandledAt *time.Time `gorm:"column:HandledAt"`
+}
+
+func (*v6Command) TableName() string {
+	return "Command"
+}
@@ -0,0 +1,41 @@
+package sql_test
+
+import (
+	"context"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/infrastructure/repository/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMigrationsCanBeRevertedAndReapplied(t *testing.T) {
+	db := newTestDB(t)
+	migrator := sql.NewMigrator(db)
+
+	reverted, err := migrator.Down(context.Background(), 100)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, reverted)
+	assert.False(t, db.HasTable(&dbmodel.GiftCard{}))
+
+	_, err = migrator.Up(context.Background())
+	assert.Nil(t, err)
+	assert.Nil(t, migrator.Check(context.Background()))
+	assert.True(t, db.HasTable(&dbmodel.GiftCard{}))
+	assert.True(t, db.Dialect().HasIndex("GiftCard", "idx_GiftCard_CampaignId"))
+}
+
+func TestMigrationsMatchTheModels(t *testing.T) {
+	db := newTestDB(t)
+	scope := db.NewScope(nil)
+	for _, model := range []interface{}{&dbmodel.GiftCard{}, &dbmodel.Campaign{}, &dbmodel.Batch{},
+		&dbmodel.GiftCardRevision{}, &dbmodel.WebhookSubscription{}, &dbmodel.WebhookDelivery{},
+		&dbmodel.OutboxEvent{}, &dbmodel.Command{}} {
+		modelScope := db.NewScope(model)
+		for _, field := range modelScope.GetModelStruct().StructFields {
+			if field.IsNormal {
+				assert.True(t, scope.Dialect().HasColumn(modelScope.TableName(), field.DBName),
+					"%s.%s is not migrated", modelScope.TableName(), field.DBName)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package sql
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"github.com/jinzhu/gorm"
+	"time"
+)
+
+type outboxRepository struct {
+	DB *gorm.DB
+}
+
+// addCardEvents adds the events of the card to the outbox in the transaction of its change. the card is stored
+// before, so its id is known
+func addCardEvents(tx *gorm.DB, card dbmodel.GiftCard, names []string) error {
+	for _, name := range names {
+		event, err := dbmodel.NewGiftCardOutboxEvent(name, card)
+		if err != nil {
+			return err
+		}
+		if err = tx.Create(event).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addCampaignEvents adds the events of the campaign to the outbox like addCardEvents
+func addCampaignEvents(tx *gorm.DB, campaign dbmodel.Campaign, names []string) error {
+	for _, name := range names {
+		event, err := dbmodel.NewCampaignOutboxEvent(name, campaign)
+		if err != nil {
+			return err
+		}
+		if err = tx.Create(event).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClaimDueEvents postpones every due event only if it is not claimed by another instance since it was read, which
+// is known by its next attempt being unchanged
+func (r *outboxRepository) ClaimDueEvents(ctx context.Context, now time.Time, lease time.Duration,
+	limit int) ([]dbmodel.OutboxEvent, error) {
+	var due []dbmodel.OutboxEvent
+	err := r.db(ctx).Where(quoted(r.DB, `"PublishedAt" is null and "DeadAt" is null and "NextAttemptAt" <= ?`),
+		now.UTC()).
+		Order(quoted(r.DB, `"NextAttemptAt", id`)).Limit(limit).Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+
+	leased := now.Add(lease).UTC()
+	claimed := make([]dbmodel.OutboxEvent, 0, len(due))
+	for _, event := range due {
+		db := r.db(ctx).Model(&dbmodel.OutboxEvent{}).
+			Where(quoted(r.DB, `id = ? and "PublishedAt" is null and "NextAttemptAt" = ?`), event.ID,
+				event.NextAttemptAt).
+			UpdateColumn("NextAttemptAt", leased)
+		if db.Error != nil {
+			return claimed, db.Error
+		}
+		if db.RowsAffected == 1 {
+			event.NextAttemptAt = leased
+			claimed = append(claimed, event)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *outboxRepository) StoreEvent(ctx context.Context, event *dbmodel.OutboxEvent) error {
+	return r.db(ctx).Save(event).Error
+}
+
+func (r *outboxRepository) DeletePublished(ctx context.Context, before time.Time) (int, error) {
+	db := r.db(ctx).Where(quoted(r.DB, `"PublishedAt" < ?`), before.UTC()).Delete(&dbmodel.OutboxEvent{})
+	return int(db.RowsAffected), db.Error
+}
+
+// db binds the context to the queries so their spans join the trace of the request
+func (r *outboxRepository) db(ctx context.Context) *gorm.DB {
+	return withContext(r.DB, ctx)
+}
+
+func NewOutboxRepository(DB *gorm.DB) core.OutboxRepository {
+	return &outboxRepository{DB: DB}
+}
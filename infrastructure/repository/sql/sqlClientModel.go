@@ -1,27 +1,83 @@
 package sql
 
 import (
-	"giftcard-engine/core/dbmodel"
+	"context"
+	"fmt"
+	"giftcard-engine/infrastructure/config/configuration"
 	"giftcard-engine/infrastructure/logger"
 	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/mssql"
+	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	_ "modernc.org/sqlite"
+	"strings"
 )
 
-func newSqlClient(connectionString string) *gorm.DB {
-	db, err := gorm.Open("mssql", connectionString)
+// drivers maps the dialects the repositories are written for to their database/sql driver.
+// sqlite uses a pure go driver so the backend also runs in the CGO_ENABLED=0 builds
+var drivers = map[string]string{"mssql": "mssql", "postgres": "postgres", "mysql": "mysql", "sqlite3": "sqlite"}
+
+// Open connects to a database of one of the supported drivers and registers the callbacks of the repositories.
+// the schema is managed by the migrations of NewMigrator
+func Open(driver, connectionString string) (*gorm.DB, error) {
+	sqlDriver, ok := drivers[driver]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+	if driver == "sqlite3" {
+		connectionString = sqliteConnection(connectionString)
+	}
+	db, err := gorm.Open(driver, sqlDriver, connectionString)
 	if err != nil {
-		logger.FatalException(err, "Error creating connection pool")
+		return nil, err
 	}
 
 	db.SetLogger(&logger.GormLogger{})
+	RegisterTracing(db)
+	RegisterCancellation(db)
+	return db, nil
+}
+
+// sqliteConnection makes the driver write the times in the sqlite format, which the date functions of the
+// dialect can read, unless the connection string already chooses a format
+func sqliteConnection(connectionString string) string {
+	if strings.Contains(connectionString, "_time_format=") {
+		return connectionString
+	}
+	if strings.Contains(connectionString, "?") {
+		return connectionString + "&_time_format=sqlite"
+	}
+	return connectionString + "?_time_format=sqlite"
+}
+
+func newSqlClient(config configuration.DatabaseConfiguration) *gorm.DB {
+	db, err := Open(config.Driver, config.DefaultConnection)
+	if err != nil {
+		logger.FatalException(err, "Error creating connection pool")
+	}
+
 	logger.Print("Connected!\n")
-	db.DB().SetMaxIdleConns(10)
-	db.DB().SetMaxOpenConns(10)
-	db.AutoMigrate(&dbmodel.GiftCard{}, &dbmodel.Campaign{})
+	db.DB().SetMaxIdleConns(config.Pool.MaxIdle)
+	db.DB().SetMaxOpenConns(config.Pool.MaxOpen)
+	db.DB().SetConnMaxLifetime(config.Pool.MaxLifetime)
+	db.DB().SetConnMaxIdleTime(config.Pool.MaxIdleTime)
+
+	migrator := NewMigrator(db)
+	ctx, cancel := context.WithTimeout(context.Background(), config.MigrationTimeout)
+	defer cancel()
+	if config.Migrate {
+		if _, err := migrator.Up(ctx); err != nil {
+			logger.FatalException(err, "Error migrating the database")
+		}
+	}
+	if err := migrator.Check(ctx); err != nil {
+		logger.FatalException(err, "The database schema does not match this version, run the migrate command")
+	}
 	return db
 }
 
-// InitDatabase returns an implementation of the sql database orm with given connection string.
-func InitDatabase(connectionString string) *gorm.DB {
-	db := newSqlClient(connectionString)
+// InitDatabase returns an implementation of the sql database orm with given driver and connection string.
+func InitDatabase(config configuration.DatabaseConfiguration) *gorm.DB {
+	db := newSqlClient(config)
 	return db
 }
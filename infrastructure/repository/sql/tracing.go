@@ -0,0 +1,67 @@
+package sql
+
+import (
+	"context"
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	contextKey = "otel:context"
+	spanKey    = "otel:span"
+)
+
+var tracer = otel.Tracer("giftcard-engine/infrastructure/repository/sql")
+
+// RegisterTracing starts a span for every query ran by a handle bound to a context, as a child of its span
+func RegisterTracing(db *gorm.DB) {
+	callbacks := db.Callback()
+	for operation, processor := range map[string]func() *gorm.CallbackProcessor{
+		"create":    callbacks.Create,
+		"query":     callbacks.Query,
+		"update":    callbacks.Update,
+		"delete":    callbacks.Delete,
+		"row_query": callbacks.RowQuery,
+	} {
+		processor().Before("gorm:"+operation).Register("otel:before_"+operation, startSpan(operation))
+		processor().After("gorm:"+operation).Register("otel:after_"+operation, endSpan)
+	}
+}
+
+func startSpan(operation string) func(scope *gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		value, ok := scope.Get(contextKey)
+		if !ok {
+			return
+		}
+		ctx, ok := value.(context.Context)
+		if !ok {
+			return
+		}
+		_, span := tracer.Start(ctx, "gorm."+operation, trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemKey.String(scope.Dialect().GetName()),
+				semconv.DBSQLTableKey.String(scope.TableName()),
+			))
+		scope.InstanceSet(spanKey, span)
+	}
+}
+
+func endSpan(scope *gorm.Scope) {
+	value, ok := scope.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	span.SetAttributes(semconv.DBStatementKey.String(scope.SQL), attribute.Int64("db.rows_affected",
+		scope.DB().RowsAffected))
+	if err := scope.DB().Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,47 @@
+package sql_test
+
+import (
+	"context"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/infrastructure/repository/sql"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+	"time"
+)
+
+func TestQueriesAreTracedAsChildrenOfTheRequest(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	db := newTestDB(t)
+	campaign := dbmodel.Campaign{Title: "yalda"}
+	db.Create(&campaign)
+	repository := sql.NewGiftCardRepository(db)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	card := dbmodel.NewGiftCard(1000, time.Now().AddDate(0, 1, 0))
+	card.SetCampaign(uint(campaign.ID))
+	assert.Nil(t, repository.Store(ctx, card))
+	_, err := repository.FindByID(ctx, uint(card.ID))
+	assert.Nil(t, err)
+	parent.End()
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			continue
+		}
+		names = append(names, span.Name())
+		assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+		for _, attribute := range span.Attributes() {
+			if attribute.Key == "db.statement" {
+				assert.NotEmpty(t, attribute.Value.AsString())
+			}
+		}
+	}
+	assert.Contains(t, names, "gorm.create")
+	assert.Contains(t, names, "gorm.query")
+}
@@ -0,0 +1,115 @@
+package sql
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"github.com/jinzhu/gorm"
+	"time"
+)
+
+type webhookRepository struct {
+	DB *gorm.DB
+}
+
+func (r *webhookRepository) FindSubscription(ctx context.Context, id uint) (*dbmodel.WebhookSubscription, error) {
+	var subscription dbmodel.WebhookSubscription
+
+	db := r.db(ctx).Find(&subscription, id)
+	if db.RecordNotFound() {
+		return nil, common.WebhookNotFound
+	} else if db.Error != nil {
+		return nil, db.Error
+	}
+	return &subscription, nil
+}
+
+func (r *webhookRepository) FindSubscriptions(ctx context.Context) ([]dbmodel.WebhookSubscription, error) {
+	var subscriptions []dbmodel.WebhookSubscription
+	err := r.db(ctx).Order("id").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *webhookRepository) StoreSubscription(ctx context.Context, subscription *dbmodel.WebhookSubscription) error {
+	return r.db(ctx).Save(subscription).Error
+}
+
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, subscription dbmodel.WebhookSubscription) error {
+	db := r.db(ctx).Delete(&subscription)
+	if db.RecordNotFound() {
+		return common.WebhookNotFound
+	}
+	return db.Error
+}
+
+func (r *webhookRepository) FindDelivery(ctx context.Context, id uint) (*dbmodel.WebhookDelivery, error) {
+	var delivery dbmodel.WebhookDelivery
+
+	db := r.db(ctx).Find(&delivery, id)
+	if db.RecordNotFound() {
+		return nil, common.WebhookDeliveryNotFound
+	} else if db.Error != nil {
+		return nil, db.Error
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) StoreDelivery(ctx context.Context, delivery *dbmodel.WebhookDelivery) error {
+	return r.db(ctx).Save(delivery).Error
+}
+
+// ClaimDueDeliveries postpones every due delivery only if it is not claimed by another instance since it was read,
+// which is known by its next attempt being unchanged
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration,
+	limit int) ([]dbmodel.WebhookDelivery, error) {
+	var due []dbmodel.WebhookDelivery
+	err := r.db(ctx).Where(quoted(r.DB, `"Status" = ? and "NextAttemptAt" <= ?`), dbmodel.DeliveryPending, now.UTC()).
+		Order(quoted(r.DB, `"NextAttemptAt", id`)).Limit(limit).Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+
+	leased := now.Add(lease).UTC()
+	claimed := make([]dbmodel.WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		db := r.db(ctx).Model(&dbmodel.WebhookDelivery{}).
+			Where(quoted(r.DB, `id = ? and "Status" = ? and "NextAttemptAt" = ?`), delivery.ID,
+				dbmodel.DeliveryPending, delivery.NextAttemptAt).
+			UpdateColumn("NextAttemptAt", leased)
+		if db.Error != nil {
+			return claimed, db.Error
+		}
+		if db.RowsAffected == 1 {
+			delivery.NextAttemptAt = leased
+			claimed = append(claimed, delivery)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *webhookRepository) FindDeadDeliveries(ctx context.Context, size, number uint) ([]dbmodel.WebhookDelivery,
+	int) {
+	data := make(chan []dbmodel.WebhookDelivery)
+
+	query := r.db(ctx).Model(&dbmodel.WebhookDelivery{}).Where(quoted(r.DB, `"Status" = ?`), dbmodel.DeliveryDead)
+
+	go func(channel chan<- []dbmodel.WebhookDelivery) {
+		var deliveries []dbmodel.WebhookDelivery
+		query.Order("id desc").Limit(size).Offset(size * number).Find(&deliveries)
+		channel <- deliveries
+	}(data)
+
+	var total int
+	query.Count(&total)
+	return <-data, total
+}
+
+// db binds the context to the queries so their spans join the trace of the request
+func (r *webhookRepository) db(ctx context.Context) *gorm.DB {
+	return withContext(r.DB, ctx)
+}
+
+func NewWebhookRepository(DB *gorm.DB) core.WebhookRepository {
+	return &webhookRepository{DB: DB}
+}
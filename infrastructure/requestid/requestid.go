@@ -0,0 +1,61 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"regexp"
+)
+
+// Header is the http header the request id is read from and written to
+const Header = "X-Request-ID"
+
+// Key is the key of the request id in the gin context
+const Key = "requestId"
+
+type contextKey struct{}
+
+// validId limits the accepted ids of the clients so they can not inject anything into the logs
+var validId = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// NewContext returns a copy of the context carrying the request id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request id of the context or an empty string when it has none
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Generate returns a new random request id
+func Generate() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// Accept returns the request id of the client, or a new one when it is missing or invalid
+func Accept(id string) string {
+	if !validId.MatchString(id) {
+		return Generate()
+	}
+	return id
+}
+
+// Middleware keeps the X-Request-ID of the request, or generates one when it is missing or invalid, puts it in
+// the context of the request and returns it in the response headers
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := Accept(c.GetHeader(Header))
+		c.Set(Key, id)
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), id))
+		c.Header(Header, id)
+		c.Next()
+	}
+}
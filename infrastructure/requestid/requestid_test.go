@@ -0,0 +1,47 @@
+package requestid_test
+
+import (
+	"giftcard-engine/infrastructure/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serve(header string) (*httptest.ResponseRecorder, string) {
+	gin.SetMode(gin.TestMode)
+	route := gin.New()
+	route.Use(requestid.Middleware())
+	var fromContext string
+	route.GET("/", func(c *gin.Context) {
+		fromContext = requestid.FromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		request.Header.Set(requestid.Header, header)
+	}
+	recorder := httptest.NewRecorder()
+	route.ServeHTTP(recorder, request)
+	return recorder, fromContext
+}
+
+func TestMiddlewareKeepsTheRequestId(t *testing.T) {
+	t.Parallel()
+	recorder, fromContext := serve("order-42")
+
+	assert.Equal(t, "order-42", recorder.Header().Get(requestid.Header))
+	assert.Equal(t, "order-42", fromContext)
+}
+
+func TestMiddlewareGeneratesMissingOrInvalidIds(t *testing.T) {
+	t.Parallel()
+	for _, header := range []string{"", "bad id\nwith new line"} {
+		recorder, fromContext := serve(header)
+
+		assert.Len(t, fromContext, 32)
+		assert.NotEqual(t, header, fromContext)
+		assert.Equal(t, fromContext, recorder.Header().Get(requestid.Header))
+	}
+}
@@ -0,0 +1,19 @@
+// Package retry holds the retry policy shared by the background jobs which retry their failed work later
+package retry
+
+import "time"
+
+// Backoff returns how long the work waits after its failed attempt, which doubles from the delay with every
+// attempt up to the max delay
+func Backoff(attempt int, delay, max time.Duration) time.Duration {
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
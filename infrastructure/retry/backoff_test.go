@@ -0,0 +1,18 @@
+package retry_test
+
+import (
+	"giftcard-engine/infrastructure/retry"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 30*time.Second, retry.Backoff(1, 30*time.Second, 5*time.Minute))
+	assert.Equal(t, time.Minute, retry.Backoff(2, 30*time.Second, 5*time.Minute))
+	assert.Equal(t, 4*time.Minute, retry.Backoff(4, 30*time.Second, 5*time.Minute))
+	assert.Equal(t, 5*time.Minute, retry.Backoff(5, 30*time.Second, 5*time.Minute))
+	assert.Equal(t, 5*time.Minute, retry.Backoff(60, 30*time.Second, 5*time.Minute))
+	assert.Equal(t, time.Minute, retry.Backoff(1, 2*time.Minute, time.Minute), "the delay is capped too")
+}
@@ -0,0 +1,32 @@
+package search
+
+import (
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"time"
+)
+
+// disabledGiftCardIndex is used when no search server is configured. writes are ignored
+// and searching reports that the search is not available
+type disabledGiftCardIndex struct{}
+
+func (disabledGiftCardIndex) Index(cards []dbmodel.GiftCard) error {
+	return nil
+}
+
+func (disabledGiftCardIndex) Delete(id int) error {
+	return nil
+}
+
+func (disabledGiftCardIndex) Search(query string, size, from uint) ([]int, int, error) {
+	return nil, 0, common.SearchIsNotAvailable
+}
+
+func (disabledGiftCardIndex) Prune(before time.Time) error {
+	return nil
+}
+
+func NewDisabledGiftCardIndex() core.GiftCardIndex {
+	return disabledGiftCardIndex{}
+}
@@ -0,0 +1,197 @@
+package search
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/infrastructure/logger"
+	"github.com/olivere/elastic"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const documentType = "_doc"
+
+// giftCardMapping stores the codes as lowercase keywords so they can be searched by their prefixes
+const giftCardMapping = `{
+	"settings": {
+		"analysis": {
+			"normalizer": {
+				"lowercase_normalizer": {"type": "custom", "filter": ["lowercase"]}
+			}
+		}
+	},
+	"mappings": {
+		"_doc": {
+			"properties": {
+				"public_code":    {"type": "keyword", "normalizer": "lowercase_normalizer"},
+				"uun":            {"type": "keyword", "normalizer": "lowercase_normalizer"},
+				"campaign_id":    {"type": "long"},
+				"campaign_title": {"type": "text"},
+				"batch_id":       {"type": "long"},
+				"amount":         {"type": "long"},
+				"status":         {"type": "integer"},
+				"expire_date":    {"type": "date"},
+				"indexed_at":     {"type": "date"}
+			}
+		}
+	}
+}`
+
+// giftCardDocument is the indexed form of a gift card. the secret code is never indexed
+type giftCardDocument struct {
+	PublicCode    string    `json:"public_code"`
+	UUN           string    `json:"uun"`
+	CampaignId    uint      `json:"campaign_id"`
+	CampaignTitle string    `json:"campaign_title"`
+	BatchId       *uint     `json:"batch_id"`
+	Amount        int32     `json:"amount"`
+	Status        int       `json:"status"`
+	ExpireDate    time.Time `json:"expire_date"`
+	IndexedAt     time.Time `json:"indexed_at"`
+}
+
+type elasticGiftCardIndex struct {
+	client *elastic.Client
+	name   string
+}
+
+func newGiftCardDocument(card dbmodel.GiftCard) giftCardDocument {
+	document := giftCardDocument{
+		PublicCode: card.PublicCode,
+		UUN:        card.UUN,
+		CampaignId: card.CampaignId,
+		BatchId:    card.BatchId,
+		Amount:     card.Amount,
+		Status:     card.Status,
+		ExpireDate: card.ExpireDate,
+		IndexedAt:  time.Now().UTC(),
+	}
+	if card.Campaign != nil {
+		document.CampaignTitle = card.Campaign.Title
+	}
+	return document
+}
+
+// ensureIndex creates the index with the gift card mapping when it does not exist yet
+func (i *elasticGiftCardIndex) ensureIndex() error {
+	ctx := context.Background()
+	exists, err := i.client.IndexExists(i.name).Do(ctx)
+	if err != nil || exists {
+		return err
+	}
+	_, err = i.client.CreateIndex(i.name).BodyString(giftCardMapping).Do(ctx)
+	return err
+}
+
+func (i *elasticGiftCardIndex) Index(cards []dbmodel.GiftCard) error {
+	if len(cards) == 0 {
+		return nil
+	}
+	bulk := i.client.Bulk().Index(i.name).Type(documentType)
+	for _, card := range cards {
+		bulk.Add(elastic.NewBulkIndexRequest().Id(strconv.Itoa(card.ID)).Doc(newGiftCardDocument(card)))
+	}
+	response, err := bulk.Do(context.Background())
+	if err != nil {
+		return err
+	}
+	if failed := response.Failed(); len(failed) > 0 {
+		return &elastic.Error{Status: failed[0].Status, Details: failed[0].Error}
+	}
+	return nil
+}
+
+func (i *elasticGiftCardIndex) Delete(id int) error {
+	_, err := i.client.Delete().Index(i.name).Type(documentType).Id(strconv.Itoa(id)).
+		Do(context.Background())
+	if elastic.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (i *elasticGiftCardIndex) Search(query string, size, from uint) ([]int, int, error) {
+	response, err := i.client.Search(i.name).
+		Query(newGiftCardQuery(query)).
+		From(int(from)).
+		Size(int(size)).
+		FetchSource(false).
+		Do(context.Background())
+	if err != nil {
+		return nil, 0, err
+	}
+	ids := make([]int, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		id, err := strconv.Atoi(hit.Id)
+		if err != nil {
+			return nil, 0, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, int(response.TotalHits()), nil
+}
+
+// Prune deletes the documents whose last write is older than the moment, or which were written before the documents
+// had a write time. the index is refreshed first so the documents written just before are seen with their new time
+func (i *elasticGiftCardIndex) Prune(before time.Time) error {
+	ctx := context.Background()
+	if _, err := i.client.Refresh(i.name).Do(ctx); err != nil {
+		return err
+	}
+	// a conflict means the card has been written again while pruning, so it is kept
+	_, err := i.client.DeleteByQuery(i.name).Type(documentType).
+		Query(elastic.NewBoolQuery().MustNot(elastic.NewRangeQuery("indexed_at").Gte(before.UTC()))).
+		ProceedOnVersionConflict().
+		Do(ctx)
+	return err
+}
+
+// newGiftCardQuery matches the start of the codes, a fuzzy campaign title and an exact amount. a prefix query takes
+// the query literally, so a * or ? typed by the user is not a wildcard, and it uses the terms index instead of
+// scanning every code like a leading wildcard does
+func newGiftCardQuery(query string) elastic.Query {
+	prefix := strings.ToLower(query)
+	match := elastic.NewBoolQuery().Should(
+		elastic.NewPrefixQuery("public_code", prefix).Boost(2),
+		elastic.NewPrefixQuery("uun", prefix).Boost(2),
+		elastic.NewMatchQuery("campaign_title", query).Fuzziness("AUTO"),
+	)
+	if amount, err := strconv.ParseInt(query, 10, 32); err == nil {
+		match.Should(elastic.NewTermQuery("amount", amount))
+	}
+	return match.MinimumNumberShouldMatch(1)
+}
+
+// Close stops the background health checks of the elasticsearch client
+func (i *elasticGiftCardIndex) Close() error {
+	i.client.Stop()
+	return nil
+}
+
+// NewElasticGiftCardIndex returns a gift card index stored in elasticsearch and creates the index if needed
+func NewElasticGiftCardIndex(url, name string) (core.GiftCardIndex, error) {
+	client, err := elastic.NewClient(elastic.SetURL(url), elastic.SetSniff(false))
+	if err != nil {
+		return nil, err
+	}
+	index := &elasticGiftCardIndex{client: client, name: name}
+	if err = index.ensureIndex(); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// InitGiftCardIndex returns the elasticsearch index of the given url or a disabled index when no url is configured
+func InitGiftCardIndex(url, name string) core.GiftCardIndex {
+	if url == "" {
+		logger.Warn("search url is not configured, gift card search is disabled")
+		return NewDisabledGiftCardIndex()
+	}
+	index, err := NewElasticGiftCardIndex(url, name)
+	if err != nil {
+		logger.FatalException(err, "Error creating the gift card search index")
+	}
+	return index
+}
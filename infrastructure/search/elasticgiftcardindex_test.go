@@ -0,0 +1,40 @@
+package search
+
+import (
+	"encoding/json"
+	"giftcard-engine/core/dbmodel"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewGiftCardDocument(t *testing.T) {
+	t.Parallel()
+	card := dbmodel.NewGiftCard(2000, time.Now())
+	card.Campaign = &dbmodel.Campaign{Title: "yalda"}
+
+	document, err := json.Marshal(newGiftCardDocument(*card))
+
+	assert.Empty(t, err)
+	assert.Contains(t, string(document), card.PublicCode)
+	assert.Contains(t, string(document), "yalda")
+	assert.Contains(t, string(document), "indexed_at", "the write time should be kept for pruning")
+	assert.NotContains(t, string(document), card.SecretCode, "the secret code should never be indexed")
+}
+
+func TestNewGiftCardQuery(t *testing.T) {
+	t.Parallel()
+	source, err := newGiftCardQuery("AbC").Source()
+	assert.Empty(t, err)
+	query, _ := json.Marshal(source)
+	assert.Contains(t, string(query), `"prefix":{"public_code":{"boost":2,"value":"abc"}}`)
+	assert.NotContains(t, string(query), "amount")
+
+	source, _ = newGiftCardQuery("a*?").Source()
+	query, _ = json.Marshal(source)
+	assert.NotContains(t, string(query), "wildcard", "the wildcards of the query should be taken literally")
+
+	source, _ = newGiftCardQuery("2000").Source()
+	query, _ = json.Marshal(source)
+	assert.Contains(t, string(query), "amount")
+}
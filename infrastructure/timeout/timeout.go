@@ -0,0 +1,46 @@
+package timeout
+
+import (
+	"context"
+	"giftcard-engine/infrastructure/config/configuration"
+	"github.com/gin-gonic/gin"
+	"sync/atomic"
+	"time"
+)
+
+// Deadlines are the timeouts of the routes, which can be replaced while the requests are served
+type Deadlines struct {
+	config atomic.Value
+}
+
+func NewDeadlines(config configuration.TimeoutConfiguration) *Deadlines {
+	deadlines := &Deadlines{}
+	deadlines.Set(config)
+	return deadlines
+}
+
+// Set replaces the timeouts of the requests which begin from now on
+func (d *Deadlines) Set(config configuration.TimeoutConfiguration) {
+	d.config.Store(config)
+}
+
+// For returns the deadline of the route with the given method and path template
+func (d *Deadlines) For(method, path string) time.Duration {
+	return d.config.Load().(configuration.TimeoutConfiguration).For(method, path)
+}
+
+// Middleware sets the deadline of the route on the context of the request so the services and the repositories
+// stop their work when it passes
+func Middleware(deadlines *Deadlines) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := deadlines.For(c.Request.Method, c.FullPath())
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,53 @@
+package timeout_test
+
+import (
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/timeout"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareUsesTheTimeoutOfTheRoute(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	route := gin.New()
+	route.Use(timeout.Middleware(timeout.NewDeadlines(configuration.TimeoutConfiguration{
+		Default: time.Minute,
+		Routes:  map[string]time.Duration{"GET /slow/:id": time.Hour, "GET /unbounded": 0},
+	})))
+	deadlines := map[string]time.Duration{}
+	handler := func(c *gin.Context) {
+		if deadline, ok := c.Request.Context().Deadline(); ok {
+			deadlines[c.FullPath()] = time.Until(deadline)
+		}
+		c.Status(http.StatusOK)
+	}
+	route.GET("/fast", handler)
+	route.GET("/slow/:id", handler)
+	route.GET("/unbounded", handler)
+
+	for _, path := range []string{"/fast", "/slow/1", "/unbounded"} {
+		route.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.InDelta(t, time.Minute, deadlines["/fast"], float64(time.Second))
+	assert.InDelta(t, time.Hour, deadlines["/slow/:id"], float64(time.Second))
+	assert.NotContains(t, deadlines, "/unbounded")
+}
+
+func TestDeadlinesCanBeReplaced(t *testing.T) {
+	t.Parallel()
+	deadlines := timeout.NewDeadlines(configuration.TimeoutConfiguration{Default: time.Minute})
+
+	deadlines.Set(configuration.TimeoutConfiguration{
+		Default: time.Second,
+		Routes:  map[string]time.Duration{"GET /slow": time.Hour},
+	})
+
+	assert.Equal(t, time.Second, deadlines.For(http.MethodGet, "/fast"))
+	assert.Equal(t, time.Hour, deadlines.For(http.MethodGet, "/slow"))
+}
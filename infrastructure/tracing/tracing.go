@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"giftcard-engine/infrastructure/config/configuration"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// serverName is the name of the service in the spans of the http requests
+var serverName = "giftcard-engine"
+
+// ConfigureTracing installs the w3c trace context propagator so the incoming trace ids are always kept, and
+// exports the spans to the otlp collector when an endpoint is configured. the returned function flushes
+// the remaining spans and should be called before exiting
+func ConfigureTracing(serviceName, environment string,
+	config configuration.TracingConfiguration) (func(context.Context) error, error) {
+	if serviceName != "" {
+		serverName = serviceName
+	}
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+	if config.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serverName),
+			semconv.DeploymentEnvironmentKey.String(environment),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Middleware starts a span for every request as a child of the trace context of its headers
+func Middleware() gin.HandlerFunc {
+	return otelgin.Middleware(serverName)
+}
@@ -0,0 +1,41 @@
+package tracing_test
+
+import (
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddlewareContinuesTheTraceOfTheRequest(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	_, err := tracing.ConfigureTracing("giftcard-engine", "test", configuration.TracingConfiguration{})
+	require.NoError(t, err)
+	gin.SetMode(gin.TestMode)
+	route := gin.New()
+	route.Use(tracing.Middleware())
+	var traceId trace.TraceID
+	route.GET("/ping", func(c *gin.Context) {
+		traceId = trace.SpanContextFromContext(c.Request.Context()).TraceID()
+		c.Status(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	route.ServeHTTP(httptest.NewRecorder(), request)
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceId.String())
+	spans := recorder.Ended()
+	require.Equal(t, 1, len(spans))
+	assert.Equal(t, "/ping", spans[0].Name())
+	assert.Equal(t, traceId, spans[0].SpanContext().TraceID())
+}
@@ -0,0 +1,170 @@
+// Package webhook sends the queued webhook deliveries to their subscriptions
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"giftcard-engine/core"
+	"giftcard-engine/core/common"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/lifecycle"
+	"giftcard-engine/infrastructure/logger"
+	"giftcard-engine/infrastructure/metrics"
+	"giftcard-engine/infrastructure/retry"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxErrorBody is how much of the body of a failed response is kept as the error of the delivery
+const maxErrorBody = 256
+
+// Dispatcher sends the due deliveries, retries the failed ones with an exponential backoff and lets the ones which
+// run out of attempts die, so they are listed as dead letters until they are redelivered
+type Dispatcher struct {
+	repository core.WebhookRepository
+	config     configuration.WebhookConfiguration
+	client     *http.Client
+}
+
+func NewDispatcher(repository core.WebhookRepository, config configuration.WebhookConfiguration) *Dispatcher {
+	return &Dispatcher{
+		repository: repository,
+		config:     config,
+		client:     &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Start dispatches the due deliveries every poll interval until the returned function is called, which waits for
+// the deliveries being sent
+func (d *Dispatcher) Start() func(ctx context.Context) error {
+	return lifecycle.Poll(d.config.PollInterval, func(ctx context.Context, _ time.Time) {
+		if _, err := d.Dispatch(ctx); err != nil && ctx.Err() == nil {
+			logger.ErrorException(err, "error while dispatching the webhooks")
+		}
+	})
+}
+
+// Dispatch sends the due deliveries once, up to the batch size of them at the same time, and returns the number of
+// the delivered ones
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	// a claimed delivery is due again only after every attempt of the batch has surely timed out
+	lease := 2*d.config.Timeout + time.Minute
+	due, err := d.repository.ClaimDueDeliveries(ctx, time.Now(), lease, d.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	subscriptions := map[uint]*dbmodel.WebhookSubscription{}
+	var delivered int
+	var mutex sync.Mutex
+	wg := &sync.WaitGroup{}
+	for i := range due {
+		delivery := &due[i]
+		subscription, err := d.subscription(ctx, subscriptions, delivery.SubscriptionId)
+		if err != nil {
+			return delivered, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if d.deliver(ctx, subscription, delivery) {
+				mutex.Lock()
+				delivered++
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return delivered, nil
+}
+
+// subscription finds the subscription of a delivery once for every dispatch. it is nil when it is deleted
+func (d *Dispatcher) subscription(ctx context.Context, found map[uint]*dbmodel.WebhookSubscription,
+	id uint) (*dbmodel.WebhookSubscription, error) {
+	if subscription, ok := found[id]; ok {
+		return subscription, nil
+	}
+	subscription, err := d.repository.FindSubscription(ctx, id)
+	if err == common.WebhookNotFound {
+		subscription, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	found[id] = subscription
+	return subscription, nil
+}
+
+// deliver makes an attempt and stores its result. it reports whether the delivery is delivered
+func (d *Dispatcher) deliver(ctx context.Context, subscription *dbmodel.WebhookSubscription,
+	delivery *dbmodel.WebhookDelivery) bool {
+	var err error
+	deleted := subscription == nil
+	if deleted {
+		err = fmt.Errorf("the subscription %d is deleted", delivery.SubscriptionId)
+	} else {
+		err = d.send(ctx, subscription, delivery)
+	}
+
+	outcome := metrics.WebhookDelivered
+	switch {
+	case err == nil:
+		delivery.Delivered(time.Now())
+	case ctx.Err() != nil:
+		// the attempt is cut by the shutdown, so it is not counted and the delivery is retried after the lease
+		return false
+	case deleted || delivery.Attempts+1 >= d.config.MaxAttempts:
+		outcome = metrics.WebhookDead
+		delivery.Die(err.Error())
+	default:
+		outcome = metrics.WebhookRetried
+		delivery.Failed(err.Error(), time.Now().Add(retry.Backoff(delivery.Attempts+1, d.config.RetryDelay, d.config.MaxRetryDelay)))
+	}
+	metrics.WebhookAttempted(delivery.Event, outcome)
+
+	if err := d.repository.StoreDelivery(context.Background(), delivery); err != nil {
+		logger.WithData(map[string]interface{}{
+			"deliveryId": delivery.ID,
+		}).ErrorException(err, "error while storing a webhook delivery")
+	}
+	if outcome == metrics.WebhookDead {
+		logger.WithData(map[string]interface{}{
+			"deliveryId":     delivery.ID,
+			"subscriptionId": delivery.SubscriptionId,
+			"event":          delivery.Event,
+		}).Warn("A webhook delivery is dead after " + strconv.Itoa(delivery.Attempts) + " attempts: " +
+			delivery.LastError)
+	}
+	return outcome == metrics.WebhookDelivered
+}
+
+// send posts the signed payload and fails on any answer but 2xx
+func (d *Dispatcher) send(ctx context.Context, subscription *dbmodel.WebhookSubscription,
+	delivery *dbmodel.WebhookDelivery) error {
+	body := []byte(delivery.Payload)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, delivery.Event)
+	request.Header.Set(DeliveryHeader, strconv.Itoa(delivery.ID))
+	request.Header.Set(SignatureHeader, Sign(subscription.Secret, time.Now(), body))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	answer, _ := ioutil.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("the receiver answered %d: %s", response.StatusCode, bytes.TrimSpace(answer))
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package webhook_test
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/infrastructure/config/configuration"
+	"giftcard-engine/infrastructure/repository/memory"
+	"giftcard-engine/infrastructure/webhook"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testSecret = "a-long-enough-secret"
+
+// receiver is a webhook endpoint which answers the statuses in order and then 200
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+	verified []error
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	r.verified = append(r.verified,
+		webhook.Verify(testSecret, req.Header.Get(webhook.SignatureHeader), body, time.Minute, time.Now()))
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte("answered " + strconv.Itoa(status)))
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func testConfiguration() configuration.WebhookConfiguration {
+	return configuration.WebhookConfiguration{
+		PollInterval:  10 * time.Millisecond,
+		Timeout:       time.Second,
+		BatchSize:     10,
+		MaxAttempts:   3,
+		RetryDelay:    time.Millisecond,
+		MaxRetryDelay: time.Millisecond,
+	}
+}
+
+func createDispatcherForTest(t *testing.T, statuses ...int) (*webhook.Dispatcher, core.WebhookRepository,
+	*receiver, *dbmodel.WebhookDelivery) {
+	r := &receiver{statuses: statuses}
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	repo := memory.NewWebhookRepository(memory.NewDatabase())
+	subscription := dbmodel.NewWebhookSubscription(server.URL, []string{dbmodel.CardIssuedEvent}, testSecret)
+	assert.Empty(t, repo.StoreSubscription(context.Background(), subscription))
+	delivery := dbmodel.NewWebhookDelivery(uint(subscription.ID), dbmodel.CardIssuedEvent,
+		`{"id":"1","event":"gift_card.issued"}`)
+	assert.Empty(t, repo.StoreDelivery(context.Background(), delivery))
+	return webhook.NewDispatcher(repo, testConfiguration()), repo, r, delivery
+}
+
+// dispatchUntilDue waits for the backoff of the last attempt and dispatches again
+func dispatchUntilDue(t *testing.T, dispatcher *webhook.Dispatcher) int {
+	time.Sleep(5 * time.Millisecond)
+	delivered, err := dispatcher.Dispatch(context.Background())
+	assert.Empty(t, err)
+	return delivered
+}
+
+func TestDispatch(te *testing.T) {
+	te.Parallel()
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		dispatcher, repo, r, delivery := createDispatcherForTest(t)
+
+		delivered := dispatchUntilDue(t, dispatcher)
+		stored, _ := repo.FindDelivery(context.Background(), uint(delivery.ID))
+
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, 1, r.count())
+		assert.Empty(t, r.verified[0], "the request should be signed with the secret")
+		assert.Equal(t, `{"id":"1","event":"gift_card.issued"}`, string(r.bodies[0]))
+		assert.Equal(t, dbmodel.CardIssuedEvent, r.requests[0].Header.Get(webhook.EventHeader))
+		assert.Equal(t, strconv.Itoa(delivery.ID), r.requests[0].Header.Get(webhook.DeliveryHeader))
+		assert.Equal(t, dbmodel.DeliveryDelivered, stored.Status)
+		assert.Equal(t, 1, stored.Attempts)
+
+		assert.Equal(t, 0, dispatchUntilDue(t, dispatcher))
+		assert.Equal(t, 1, r.count(), "a delivered delivery should not be sent again")
+	})
+
+	te.Run("with failed attempt", func(t *testing.T) {
+		t.Parallel()
+		dispatcher, repo, r, delivery := createDispatcherForTest(t, http.StatusInternalServerError)
+
+		assert.Equal(t, 0, dispatchUntilDue(t, dispatcher))
+		failed, _ := repo.FindDelivery(context.Background(), uint(delivery.ID))
+		assert.Equal(t, dbmodel.DeliveryPending, failed.Status)
+		assert.Equal(t, 1, failed.Attempts)
+		assert.Equal(t, "the receiver answered 500: answered 500", failed.LastError)
+
+		assert.Equal(t, 1, dispatchUntilDue(t, dispatcher))
+		retried, _ := repo.FindDelivery(context.Background(), uint(delivery.ID))
+		assert.Equal(t, dbmodel.DeliveryDelivered, retried.Status)
+		assert.Equal(t, 2, retried.Attempts)
+		assert.Equal(t, 2, r.count())
+	})
+
+	te.Run("with dead delivery", func(t *testing.T) {
+		t.Parallel()
+		dispatcher, repo, r, delivery := createDispatcherForTest(t, http.StatusBadGateway, http.StatusBadGateway,
+			http.StatusBadGateway)
+
+		for i := 0; i < 5; i++ {
+			dispatchUntilDue(t, dispatcher)
+		}
+		dead, _ := repo.FindDelivery(context.Background(), uint(delivery.ID))
+		deadLetters, total := repo.FindDeadDeliveries(context.Background(), 10, 0)
+
+		assert.Equal(t, 3, r.count(), "a delivery should not be sent after its max attempts")
+		assert.Equal(t, dbmodel.DeliveryDead, dead.Status)
+		assert.Equal(t, 3, dead.Attempts)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, delivery.ID, deadLetters[0].ID)
+
+		assert.Empty(t, dead.Redeliver())
+		assert.Empty(t, repo.StoreDelivery(context.Background(), dead))
+		assert.Equal(t, 1, dispatchUntilDue(t, dispatcher), "a redelivered delivery should be sent again")
+		assert.Equal(t, 4, r.count())
+	})
+
+	te.Run("with deleted subscription", func(t *testing.T) {
+		t.Parallel()
+		dispatcher, repo, r, delivery := createDispatcherForTest(t)
+		subscription, _ := repo.FindSubscription(context.Background(), delivery.SubscriptionId)
+		assert.Empty(t, repo.DeleteSubscription(context.Background(), *subscription))
+
+		assert.Equal(t, 0, dispatchUntilDue(t, dispatcher))
+		dead, _ := repo.FindDelivery(context.Background(), uint(delivery.ID))
+
+		assert.Equal(t, 0, r.count())
+		assert.Equal(t, dbmodel.DeliveryDead, dead.Status)
+	})
+}
+
+func TestDispatcherStart(t *testing.T) {
+	t.Parallel()
+	_, repo, r, delivery := createDispatcherForTest(t)
+	stop := webhook.NewDispatcher(repo, testConfiguration()).Start()
+
+	assert.Eventually(t, func() bool {
+		stored, _ := repo.FindDelivery(context.Background(), uint(delivery.ID))
+		return stored.Status == dbmodel.DeliveryDelivered
+	}, time.Second, 10*time.Millisecond)
+	assert.Empty(t, stop(context.Background()))
+	assert.Equal(t, 1, r.count())
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/core/dto"
+	"time"
+)
+
+type eventPublisher struct {
+	webhooks core.WebhookPublisher
+	mapper   core.Mapper
+}
+
+// NewEventPublisher returns the publisher of the domain events which queues them as webhooks. the following events
+// of the same name are queued as a single webhook of their cards, whose id is made of the ids of the first and the
+// last of them. the events which are not webhook events, like the ones of the campaigns, are skipped
+func NewEventPublisher(webhooks core.WebhookPublisher, mapper core.Mapper) core.EventPublisher {
+	return &eventPublisher{webhooks: webhooks, mapper: mapper}
+}
+
+func (p *eventPublisher) Publish(ctx context.Context, events []core.DomainEvent) error {
+	events = webhookEvents(events)
+	for start := 0; start < len(events); {
+		end := start + 1
+		for end < len(events) && events[end].Name == events[start].Name {
+			end++
+		}
+		event, err := p.webhookEvent(events[start:end])
+		if err != nil {
+			return err
+		}
+		if err := p.webhooks.Publish(ctx, event); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+// webhookEvents returns the events which the webhooks can subscribe to
+func webhookEvents(events []core.DomainEvent) []core.DomainEvent {
+	known := make(map[string]bool, len(dbmodel.WebhookEvents))
+	for _, name := range dbmodel.WebhookEvents {
+		known[name] = true
+	}
+	result := make([]core.DomainEvent, 0, len(events))
+	for _, event := range events {
+		if known[event.Name] {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// webhookEvent returns the webhook of the events, which are of the same name
+func (p *eventPublisher) webhookEvent(events []core.DomainEvent) (dto.WebhookEventDTO, error) {
+	var occurredAt time.Time
+	cards := make([]dto.WebhookCardDTO, 0, len(events))
+	for _, event := range events {
+		var snapshot dbmodel.GiftCardSnapshot
+		if err := json.Unmarshal(event.Payload, &snapshot); err != nil {
+			return dto.WebhookEventDTO{}, err
+		}
+		card := snapshot.GiftCard()
+		cards = append(cards, dto.NewWebhookCardDTO(p.mapper.ToGiftCardDTO(&card)))
+		if event.OccurredAt.After(occurredAt) {
+			occurredAt = event.OccurredAt
+		}
+	}
+	return dto.WebhookEventDTO{
+		ID:         fmt.Sprintf("outbox-%d-%d", events[0].ID, events[len(events)-1].ID),
+		Event:      events[0].Name,
+		OccurredAt: occurredAt.UTC().Format(time.RFC3339Nano),
+		Cards:      cards,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"giftcard-engine/core"
+	"giftcard-engine/core/dbmodel"
+	"giftcard-engine/core/dto"
+	"giftcard-engine/infrastructure/repository/sql"
+	"giftcard-engine/infrastructure/webhook"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type webhookPublisher struct {
+	events []dto.WebhookEventDTO
+	err    error
+}
+
+func (p *webhookPublisher) Publish(ctx context.Context, event dto.WebhookEventDTO) error {
+	p.events = append(p.events, event)
+	return p.err
+}
+
+func cardEvent(t *testing.T, id int, name string, card dbmodel.GiftCard) core.DomainEvent {
+	payload, err := json.Marshal(dbmodel.NewGiftCardSnapshot(card))
+	assert.Empty(t, err)
+	return core.DomainEvent{
+		ID:          id,
+		Name:        name,
+		AggregateId: card.ID,
+		OccurredAt:  time.Date(2024, 3, 1, 10, 0, id, 0, time.UTC),
+		Payload:     payload,
+	}
+}
+
+func TestEventPublisher(te *testing.T) {
+	te.Parallel()
+	first := dbmodel.GiftCard{AbstractModel: dbmodel.AbstractModel{ID: 1}, PublicCode: "first", Amount: 1000}
+	second := dbmodel.GiftCard{AbstractModel: dbmodel.AbstractModel{ID: 2}, PublicCode: "second", Amount: 2000}
+	te.Run("default behavior", func(t *testing.T) {
+		t.Parallel()
+		webhooks := &webhookPublisher{}
+		publisher := webhook.NewEventPublisher(webhooks, sql.NewMapper())
+
+		err := publisher.Publish(context.Background(), []core.DomainEvent{
+			cardEvent(t, 4, dbmodel.CardIssuedEvent, first),
+			cardEvent(t, 5, dbmodel.CardIssuedEvent, second),
+			{ID: 6, Name: dbmodel.CampaignCreatedEvent, Payload: []byte(`{"id":1,"title":"yalda"}`)},
+			cardEvent(t, 7, dbmodel.CardApprovedEvent, first),
+		})
+
+		assert.Empty(t, err)
+		assert.Equal(t, 2, len(webhooks.events), "the following events of the same name should be a webhook")
+		assert.Equal(t, "outbox-4-5", webhooks.events[0].ID)
+		assert.Equal(t, dbmodel.CardIssuedEvent, webhooks.events[0].Event)
+		assert.Equal(t, "2024-03-01T10:00:05Z", webhooks.events[0].OccurredAt)
+		assert.Equal(t, []string{"first", "second"},
+			[]string{webhooks.events[0].Cards[0].PublicCode, webhooks.events[0].Cards[1].PublicCode})
+		assert.Equal(t, "outbox-7-7", webhooks.events[1].ID, "the campaign events should be skipped")
+		assert.Equal(t, dbmodel.CardApprovedEvent, webhooks.events[1].Event)
+	})
+
+	te.Run("with failed webhooks", func(t *testing.T) {
+		t.Parallel()
+		webhooks := &webhookPublisher{err: errors.New("unavailable")}
+		publisher := webhook.NewEventPublisher(webhooks, sql.NewMapper())
+
+		err := publisher.Publish(context.Background(), []core.DomainEvent{
+			cardEvent(t, 4, dbmodel.CardIssuedEvent, first),
+			cardEvent(t, 6, dbmodel.CardApprovedEvent, first),
+		})
+
+		assert.Equal(t, webhooks.err, err)
+		assert.Equal(t, 1, len(webhooks.events), "the next events should not be published after a failure")
+	})
+}
@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"context"
+	"giftcard-engine/core"
+	"giftcard-engine/infrastructure/lifecycle"
+	"giftcard-engine/infrastructure/logger"
+	"time"
+)
+
+// StartExpirySweep publishes the expired event of the cards which expire in every interval, once the interval
+// is over, until the returned function is called. the intervals are aligned to the clock, so the instances which
+// sweep at the same time publish events of the same ids and the receivers can ignore the repeated ones. the
+// cards which expire while no instance is running are not published
+func StartExpirySweep(service core.WebhookService, interval time.Duration) func(ctx context.Context) error {
+	from := time.Now().Truncate(interval)
+	return lifecycle.Poll(interval, func(ctx context.Context, now time.Time) {
+		to := now.Truncate(interval)
+		if !to.After(from) {
+			return
+		}
+		count, err := service.PublishExpired(ctx, from, to)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.ErrorException(err, "error while publishing the expired gift cards")
+			}
+			return
+		}
+		if count > 0 {
+			logger.WithData(map[string]interface{}{
+				"from":  from,
+				"to":    to,
+				"count": count,
+			}).Info("Published the expired gift cards")
+		}
+		from = to
+	})
+}
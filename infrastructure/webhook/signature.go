@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the headers of the webhook requests
+const (
+	SignatureHeader = "X-Gift-Card-Signature"
+	EventHeader     = "X-Gift-Card-Event"
+	DeliveryHeader  = "X-Gift-Card-Delivery"
+)
+
+var (
+	ErrInvalidSignature = errors.New("the webhook signature is not valid")
+	ErrExpiredSignature = errors.New("the webhook signature is too old")
+)
+
+// Sign returns the signature header of the body sent at the given moment, like
+//
+//	t=1700000000,v1=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd
+//
+// where v1 is the hex hmac sha256 of the unix time, a dot and the body with the secret of the subscription. the
+// time is signed too, so a receiver which checks it cannot be sent an old request again
+func Sign(secret string, at time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, hex.EncodeToString(mac(secret, timestamp, body)))
+}
+
+// Verify checks the signature header of a received body and that it is signed in the tolerance before now.
+// a zero tolerance does not check the time
+func Verify(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value := part, ""
+		if i := strings.IndexByte(part, '='); i >= 0 {
+			key, value = part[:i], part[i+1:]
+		}
+		switch strings.TrimSpace(key) {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, mac(secret, timestamp, body)) {
+		return ErrInvalidSignature
+	}
+	if tolerance > 0 && now.Sub(time.Unix(signedAt, 0)) > tolerance {
+		return ErrExpiredSignature
+	}
+	return nil
+}
+
+func mac(secret, timestamp string, body []byte) []byte {
+	hash := hmac.New(sha256.New, []byte(secret))
+	hash.Write([]byte(timestamp))
+	hash.Write([]byte("."))
+	hash.Write(body)
+	return hash.Sum(nil)
+}
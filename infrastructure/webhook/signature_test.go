@@ -0,0 +1,60 @@
+package webhook_test
+
+import (
+	"giftcard-engine/infrastructure/webhook"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSignature(te *testing.T) {
+	te.Parallel()
+	at := time.Unix(1700000000, 0)
+	body := []byte(`{"event":"gift_card.issued"}`)
+	header := webhook.Sign("a-long-enough-secret", at, body)
+
+	te.Run("valid signature", func(t *testing.T) {
+		t.Parallel()
+		assert.Regexp(t, `^t=1700000000,v1=[0-9a-f]{64}$`, header)
+		assert.Empty(t, webhook.Verify("a-long-enough-secret", header, body, time.Minute, at.Add(time.Second)))
+		assert.Empty(t, webhook.Verify("a-long-enough-secret", header, body, 0, at.Add(time.Hour)))
+	})
+
+	te.Run("with other secret", func(t *testing.T) {
+		t.Parallel()
+		err := webhook.Verify("another-long-secret", header, body, 0, at)
+
+		assert.Equal(t, webhook.ErrInvalidSignature, err)
+	})
+
+	te.Run("with changed body", func(t *testing.T) {
+		t.Parallel()
+		err := webhook.Verify("a-long-enough-secret", header, []byte(`{"event":"gift_card.deleted"}`), 0, at)
+
+		assert.Equal(t, webhook.ErrInvalidSignature, err)
+	})
+
+	te.Run("with changed time", func(t *testing.T) {
+		t.Parallel()
+		forged := "t=1700000060" + header[len("t=1700000000"):]
+
+		err := webhook.Verify("a-long-enough-secret", forged, body, 0, at)
+
+		assert.Equal(t, webhook.ErrInvalidSignature, err)
+	})
+
+	te.Run("with malformed header", func(t *testing.T) {
+		t.Parallel()
+		for _, header := range []string{"", "v1=00", "t=abc,v1=00", "t=1700000000,v1=zz"} {
+			assert.Equal(t, webhook.ErrInvalidSignature,
+				webhook.Verify("a-long-enough-secret", header, body, 0, at), header)
+		}
+	})
+
+	te.Run("with old signature", func(t *testing.T) {
+		t.Parallel()
+		err := webhook.Verify("a-long-enough-secret", header, body, time.Minute, at.Add(2*time.Minute))
+
+		assert.Equal(t, webhook.ErrExpiredSignature, err)
+	})
+}
@@ -5,4 +5,8 @@ const (
 	GiftCardPublicKeyLength = 12
 	Numbers                 = "0123456789"
 	EnglishCharacters       = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	// the configured lengths of the codes are kept between these
+	MinGiftCardKeyLength = 8
+	MaxGiftCardKeyLength = 64
 )
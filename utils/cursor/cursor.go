@@ -0,0 +1,31 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"giftcard-engine/core/common"
+	"strconv"
+	"strings"
+)
+
+const prefix = "id:"
+
+// Encode returns an opaque token pointing after the row with the given id
+func Encode(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(prefix + strconv.Itoa(id)))
+}
+
+// Decode returns the id which the token points after, or common.InvalidCursor. an empty token means the first page
+func Decode(token string) (*int, error) {
+	if token == "" {
+		return nil, nil
+	}
+	value, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(value), prefix) {
+		return nil, common.InvalidCursor
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(string(value), prefix))
+	if err != nil || id <= 0 {
+		return nil, common.InvalidCursor
+	}
+	return &id, nil
+}
@@ -0,0 +1,40 @@
+package cursor_test
+
+import (
+	"giftcard-engine/core/common"
+	"giftcard-engine/utils/cursor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+	cases := []int{1, 42, 987654321}
+	for _, item := range cases {
+		id, err := cursor.Decode(cursor.Encode(item))
+		assert.Empty(t, err)
+		assert.Equal(t, item, *id)
+	}
+}
+
+func TestDecodeEmptyToken(t *testing.T) {
+	t.Parallel()
+	id, err := cursor.Decode("")
+	assert.Empty(t, err)
+	assert.Nil(t, id)
+}
+
+func TestDecodeInvalidToken(t *testing.T) {
+	t.Parallel()
+	cases := []string{
+		"not base64 !",
+		"MTIz",
+		cursor.Encode(0),
+		cursor.Encode(-5),
+	}
+	for _, item := range cases {
+		id, err := cursor.Decode(item)
+		assert.Nil(t, id)
+		assert.Equal(t, common.InvalidCursor, err)
+	}
+}
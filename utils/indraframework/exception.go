@@ -6,6 +6,7 @@ type IndraException struct {
 	ErrorCode        int    `json:"errorCode"`
 	TechnicalMessage string `json:"technicalMessage"`
 	Severity         int    `json:"severity"`
+	RequestId        string `json:"requestId,omitempty"`
 }
 
 func (e *IndraException) Error() string {
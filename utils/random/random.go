@@ -4,19 +4,34 @@ import (
 	"giftcard-engine/utils"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 var mu sync.Mutex
 
+// lengths of the new keys, which are configurable
+var secretKeyLength, publicKeyLength int32 = utils.GiftCardSecretKeyLength, utils.GiftCardPublicKeyLength
+
 var seededRand = rand.New(rand.NewSource(time.Now().UnixNano()))
 
 func GiftCardSecretKey() string {
-	return complexString(utils.GiftCardSecretKeyLength)
+	return complexString(int(atomic.LoadInt32(&secretKeyLength)))
 }
 
 func GiftCardPublicKey() string {
-	return stringWithCharset(utils.GiftCardPublicKeyLength, utils.Numbers)
+	return stringWithCharset(int(atomic.LoadInt32(&publicKeyLength)), utils.Numbers)
+}
+
+// SecretKeyLength returns the length of the secret keys generated now, which the secrets of the requests should have
+func SecretKeyLength() int {
+	return int(atomic.LoadInt32(&secretKeyLength))
+}
+
+// SetKeyLengths changes the lengths of the keys generated from now on. the stored keys keep their lengths
+func SetKeyLengths(secret, public int) {
+	atomic.StoreInt32(&secretKeyLength, int32(secret))
+	atomic.StoreInt32(&publicKeyLength, int32(public))
 }
 
 func stringWithCharset(length int, charset string) string {
@@ -31,3 +31,12 @@ func BenchmarkGiftCardPublicKey(b *testing.B) {
 		random.GiftCardPublicKey()
 	}
 }
+
+func TestSetKeyLengths(t *testing.T) {
+	random.SetKeyLengths(20, 10)
+	defer random.SetKeyLengths(utils.GiftCardSecretKeyLength, utils.GiftCardPublicKeyLength)
+
+	assert.Len(t, random.GiftCardSecretKey(), 20)
+	assert.Equal(t, 20, random.SecretKeyLength())
+	assert.Len(t, random.GiftCardPublicKey(), 10)
+}